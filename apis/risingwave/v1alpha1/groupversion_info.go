@@ -37,6 +37,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RisingWaveList{},
 		&RisingWaveScaleView{},
 		&RisingWaveScaleViewList{},
+		&RisingWaveNotification{},
+		&RisingWaveNotificationList{},
+		&RisingWaveUpgrade{},
+		&RisingWaveUpgradeList{},
+		&RisingWaveMetaStoreMigration{},
+		&RisingWaveMetaStoreMigrationList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
@@ -90,6 +90,66 @@ type RisingWaveDBCredentials struct {
 	PasswordKeyRef string `json:"passwordKeyRef,omitempty"`
 }
 
+// RisingWaveDBSSLMode is the SSL mode when connecting to the DB. The modes follow the ones of PostgreSQL, and are
+// translated into the equivalents for MySQL, e.g., verify-full into VERIFY_IDENTITY.
+// +kubebuilder:validation:Enum=disable;prefer;require;verify-ca;verify-full
+type RisingWaveDBSSLMode string
+
+// All valid SSL modes.
+const (
+	RisingWaveDBSSLModeDisable    RisingWaveDBSSLMode = "disable"
+	RisingWaveDBSSLModePrefer     RisingWaveDBSSLMode = "prefer"
+	RisingWaveDBSSLModeRequire    RisingWaveDBSSLMode = "require"
+	RisingWaveDBSSLModeVerifyCA   RisingWaveDBSSLMode = "verify-ca"
+	RisingWaveDBSSLModeVerifyFull RisingWaveDBSSLMode = "verify-full"
+)
+
+// RisingWaveDBCABundle is the reference to the CA bundle stored in a local secret or config map. One and only one
+// of SecretName and ConfigMapName could be set.
+type RisingWaveDBCABundle struct {
+	// The name of the secret in the pod's namespace to select from.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// The name of the config map in the pod's namespace to select from.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Key of the CA bundle in the secret or config map. Defaults to "ca.crt".
+	// +kubebuilder:default=ca.crt
+	Key string `json:"key,omitempty"`
+}
+
+// RisingWaveDBClientCertificate is the reference and keys selector to the client certificate stored in a local
+// secret.
+type RisingWaveDBClientCertificate struct {
+	// The name of the secret in the pod's namespace to select from.
+	SecretName string `json:"secretName"`
+
+	// CertificateKeyRef is the key of the secret to be the PEM encoded certificate. Defaults to "tls.crt".
+	// +kubebuilder:default=tls.crt
+	CertificateKeyRef string `json:"certificateKeyRef,omitempty"`
+
+	// PrivateKeyKeyRef is the key of the secret to be the PEM encoded private key. Defaults to "tls.key".
+	// +kubebuilder:default=tls.key
+	PrivateKeyKeyRef string `json:"privateKeyKeyRef,omitempty"`
+}
+
+// RisingWaveDBTLS describes the TLS settings when connecting to the DB. The CA bundle and the client certificate
+// are mounted into the Pods and referenced in the connection options.
+type RisingWaveDBTLS struct {
+	// SSLMode when connecting to the DB. Both verify-ca and verify-full require the CA bundle.
+	SSLMode RisingWaveDBSSLMode `json:"sslMode"`
+
+	// CABundle to verify the certificate of the DB server.
+	// +optional
+	CABundle *RisingWaveDBCABundle `json:"caBundle,omitempty"`
+
+	// ClientCertificate to authenticate with the DB server.
+	// +optional
+	ClientCertificate *RisingWaveDBClientCertificate `json:"clientCertificate,omitempty"`
+}
+
 // RisingWaveMetaStoreBackendMySQL describes the options of MySQL DB backend.
 type RisingWaveMetaStoreBackendMySQL struct {
 	// RisingWaveDBCredentials is the reference credentials. User must provide a secret contains
@@ -109,6 +169,11 @@ type RisingWaveMetaStoreBackendMySQL struct {
 	// Options when connecting to the MySQL DB. Optional.
 	// +optional
 	Options map[string]string `json:"options,omitempty"`
+
+	// TLS settings when connecting to the MySQL DB. The SSL related options must not be set in Options
+	// if it's set.
+	// +optional
+	TLS *RisingWaveDBTLS `json:"tls,omitempty"`
 }
 
 // RisingWaveMetaStoreBackendPostgreSQL describes the options of PostgreSQL DB backend.
@@ -130,11 +195,22 @@ type RisingWaveMetaStoreBackendPostgreSQL struct {
 	// Options when connecting to the PostgreSQL DB. Optional.
 	// +optional
 	Options map[string]string `json:"options,omitempty"`
+
+	// TLS settings when connecting to the PostgreSQL DB. The SSL related options must not be set in Options
+	// if it's set.
+	// +optional
+	TLS *RisingWaveDBTLS `json:"tls,omitempty"`
 }
 
 // RisingWaveMetaStoreBackend is the collection of parameters for the meta store that RisingWave uses. Note that one
 // and only one of the first-level fields could be set.
 type RisingWaveMetaStoreBackend struct {
+	// DeletionPolicy determines what happens to the meta database when the RisingWave is deleted. Defaults to Retain.
+	// Delete drops the database and is only supported by MySQL and PostgreSQL.
+	// +optional
+	// +kubebuilder:default=Retain
+	DeletionPolicy RisingWaveDeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// Memory indicates to store the metadata in memory. It is only for test usage and strongly
 	// discouraged to be set in production. If one is using the memory storage for meta,
 	// replicas will not work because they are not going to share the same metadata and any kinds
@@ -157,4 +233,8 @@ type RisingWaveMetaStoreBackend struct {
 	// PostgreSQL stores metadata in a PostgreSQL DB.
 	// +optional
 	PostgreSQL *RisingWaveMetaStoreBackendPostgreSQL `json:"postgresql,omitempty"`
+
+	// ManagedPostgreSQL provisions a PostgreSQL to store the metadata. It's only for development and testing.
+	// +optional
+	ManagedPostgreSQL *RisingWaveMetaStoreBackendManagedPostgreSQL `json:"managedPostgreSQL,omitempty"`
 }
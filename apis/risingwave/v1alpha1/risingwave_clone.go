@@ -0,0 +1,113 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RisingWaveCloneFrom is the source RisingWave to clone from.
+type RisingWaveCloneFrom struct {
+	// Name of the source RisingWave.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name string `json:"name"`
+
+	// Namespace of the source RisingWave. Defaults to the namespace of the clone.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="namespace is immutable"
+	Namespace string `json:"namespace,omitempty"`
+
+	// AllowSinks lets the streaming jobs of the clone run after it's bootstrapped. By default, the streaming jobs
+	// are paused on every bootstrap and kept paused by the operator, so that the sinks don't write the external
+	// systems of the source. It's required in the standalone mode, where the streaming jobs can't be paused before
+	// the first bootstrap.
+	// +optional
+	AllowSinks bool `json:"allowSinks,omitempty"`
+
+	// CopyImage is the image of the Job copying the data of the source, which must provide the AWS CLI. Defaults to
+	// amazon/aws-cli.
+	// +optional
+	CopyImage string `json:"copyImage,omitempty"`
+}
+
+// RisingWaveClonePhase is the phase of cloning a RisingWave from the source.
+type RisingWaveClonePhase string
+
+// All phases of the clone.
+const (
+	RisingWaveClonePhaseSnapshotting RisingWaveClonePhase = "Snapshotting"
+	RisingWaveClonePhaseCopying      RisingWaveClonePhase = "Copying"
+	RisingWaveClonePhaseRestoring    RisingWaveClonePhase = "Restoring"
+	RisingWaveClonePhasePausing      RisingWaveClonePhase = "Pausing"
+	RisingWaveClonePhaseCompleted    RisingWaveClonePhase = "Completed"
+	RisingWaveClonePhaseFailed       RisingWaveClonePhase = "Failed"
+)
+
+// RisingWaveCloneLineage is a RisingWave in the lineage of a clone.
+type RisingWaveCloneLineage struct {
+	// Namespace of the RisingWave.
+	Namespace string `json:"namespace"`
+
+	// Name of the RisingWave.
+	Name string `json:"name"`
+
+	// UID of the RisingWave.
+	UID types.UID `json:"uid"`
+
+	// SnapshotID is the ID of the meta snapshot taken from the RisingWave.
+	// +optional
+	SnapshotID *int64 `json:"snapshotID,omitempty"`
+
+	// SnapshotTime is when the meta snapshot is taken.
+	// +optional
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+}
+
+// RisingWaveCloneStatus is the status of cloning a RisingWave from the source.
+type RisingWaveCloneStatus struct {
+	// Phase of the clone.
+	Phase RisingWaveClonePhase `json:"phase,omitempty"`
+
+	// Message is a human-readable message about the phase.
+	Message string `json:"message,omitempty"`
+
+	// Lineage of the RisingWave, the source first, followed by the ones that the source is cloned from.
+	// +listType=atomic
+	Lineage []RisingWaveCloneLineage `json:"lineage,omitempty"`
+
+	// BackupJobID is the ID of the job taking the meta snapshot from the source.
+	BackupJobID *int64 `json:"backupJobID,omitempty"`
+
+	// SourceBackupDirectory is the directory of the meta snapshots of the source in the state store.
+	SourceBackupDirectory string `json:"sourceBackupDirectory,omitempty"`
+
+	// CopyJob is the name of the Job copying the objects in the data directory of the source.
+	CopyJob string `json:"copyJob,omitempty"`
+
+	// DataCopied indicates whether all the objects in the data directory of the source have been copied.
+	DataCopied bool `json:"dataCopied,omitempty"`
+
+	// Job is the name of the Job restoring the meta snapshot into the meta store.
+	Job string `json:"job,omitempty"`
+
+	// PauseRequested indicates whether the streaming jobs are requested to pause on the next bootstrap, and the meta
+	// service is restarted to bootstrap with it.
+	PauseRequested bool `json:"pauseRequested,omitempty"`
+
+	// CompletionTime is when the clone is completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// RisingWaveDeletionPolicy is the policy of the data in a store when the RisingWave is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type RisingWaveDeletionPolicy string
+
+// All valid deletion policies.
+const (
+	RisingWaveDeletionPolicyRetain RisingWaveDeletionPolicy = "Retain"
+	RisingWaveDeletionPolicyDelete RisingWaveDeletionPolicy = "Delete"
+)
+
+// RisingWaveDataCleanupPhase is the phase of cleaning up the data of a deleted RisingWave.
+type RisingWaveDataCleanupPhase string
+
+// All phases of the data cleanup.
+const (
+	RisingWaveDataCleanupPhaseInProgress RisingWaveDataCleanupPhase = "InProgress"
+	RisingWaveDataCleanupPhaseCompleted  RisingWaveDataCleanupPhase = "Completed"
+	RisingWaveDataCleanupPhaseRefused    RisingWaveDataCleanupPhase = "Refused"
+	RisingWaveDataCleanupPhaseFailed     RisingWaveDataCleanupPhase = "Failed"
+)
+
+// RisingWaveDataCleanupStatus is the status of cleaning up the data of a deleted RisingWave.
+type RisingWaveDataCleanupStatus struct {
+	// Phase of the data cleanup.
+	Phase RisingWaveDataCleanupPhase `json:"phase,omitempty"`
+
+	// DeletedObjects is the number of objects deleted from the state store.
+	DeletedObjects int64 `json:"deletedObjects,omitempty"`
+
+	// StateStoreDeleted indicates whether the data in the state store has been deleted.
+	StateStoreDeleted bool `json:"stateStoreDeleted,omitempty"`
+
+	// MetaDatabaseDropped indicates whether the database of the meta store has been dropped.
+	MetaDatabaseDropped bool `json:"metaDatabaseDropped,omitempty"`
+
+	// Message is a human-readable message about the phase.
+	Message string `json:"message,omitempty"`
+}
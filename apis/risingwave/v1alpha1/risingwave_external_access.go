@@ -0,0 +1,115 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// RisingWaveExternalAccess determines the endpoints of the RisingWave exposed outside the cluster.
+type RisingWaveExternalAccess struct {
+	// Dashboard exposes the dashboard of the meta service.
+	// +optional
+	Dashboard *RisingWaveExternalEndpoint `json:"dashboard,omitempty"`
+
+	// Webhook exposes the webhook listener of the frontend. It requires the webhook listener to be enabled.
+	// +optional
+	Webhook *RisingWaveExternalEndpoint `json:"webhook,omitempty"`
+}
+
+// RisingWaveExternalEndpoint determines how an endpoint is exposed. An Ingress, an HTTPRoute of the Gateway API or
+// both can be generated for the endpoint.
+type RisingWaveExternalEndpoint struct {
+	// Ingress generates an Ingress for the endpoint.
+	// +optional
+	Ingress *RisingWaveIngress `json:"ingress,omitempty"`
+
+	// HTTPRoute generates an HTTPRoute for the endpoint. It requires the CRDs of the Gateway API to be installed.
+	// +optional
+	HTTPRoute *RisingWaveHTTPRoute `json:"httpRoute,omitempty"`
+}
+
+// RisingWaveIngress is the configuration of a generated Ingress.
+type RisingWaveIngress struct {
+	// IngressClassName is the name of the IngressClass. The default IngressClass of the cluster is used if it's empty.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Host of the Ingress rule. All hosts are matched if it's empty.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Path prefix of the Ingress rule. Defaults to /.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// TLSSecretName is the name of the Secret that contains the TLS certificate and key for the host. TLS is
+	// terminated at the Ingress if it's set.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations of the Ingress, e.g., the ones consumed by the Ingress controllers.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RisingWaveHTTPRoute is the configuration of a generated HTTPRoute.
+type RisingWaveHTTPRoute struct {
+	// ParentRefs are the Gateways that the HTTPRoute attaches to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []RisingWaveGatewayReference `json:"parentRefs"`
+
+	// Hostname of the HTTPRoute. The hostnames of the Gateway listeners are matched if it's empty.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Path prefix of the HTTPRoute. Defaults to /.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Scheme of the Gateway listeners, which is used to report the external URL. TLS of the HTTPRoute is terminated
+	// by the Gateway listeners with their certificate references. Defaults to https.
+	// +optional
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+
+	// Annotations of the HTTPRoute.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RisingWaveGatewayReference is the reference to a Gateway.
+type RisingWaveGatewayReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the namespace of the RisingWave.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener of the Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// RisingWaveExternalEndpointStatus is the status of an exposed endpoint.
+type RisingWaveExternalEndpointStatus struct {
+	// Name of the endpoint, either dashboard or webhook.
+	Name string `json:"name"`
+
+	// Kind of the object that exposes the endpoint, either Ingress or HTTPRoute.
+	Kind string `json:"kind"`
+
+	// URL of the endpoint. It's empty until the endpoint is admitted by the Ingress controller or the Gateways,
+	// or when the host is unknown.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// RisingWaveHealthReason is the reason of a health issue found on the Pods or the workloads of a node group.
+// +kubebuilder:validation:Enum=CrashLoopBackOff;OOMKilled;ImagePullBackOff;Unschedulable;ProgressDeadlineExceeded
+type RisingWaveHealthReason string
+
+// All health reasons.
+const (
+	// RisingWaveHealthReasonCrashLoopBackOff means containers are restarting in back-off after crashing.
+	RisingWaveHealthReasonCrashLoopBackOff RisingWaveHealthReason = "CrashLoopBackOff"
+
+	// RisingWaveHealthReasonOOMKilled means containers were terminated recently for running out of memory.
+	RisingWaveHealthReasonOOMKilled RisingWaveHealthReason = "OOMKilled"
+
+	// RisingWaveHealthReasonImagePullBackOff means the images of the containers can't be pulled.
+	RisingWaveHealthReasonImagePullBackOff RisingWaveHealthReason = "ImagePullBackOff"
+
+	// RisingWaveHealthReasonUnschedulable means Pods are pending because no node fits them.
+	RisingWaveHealthReasonUnschedulable RisingWaveHealthReason = "Unschedulable"
+
+	// RisingWaveHealthReasonProgressDeadlineExceeded means the rollout of a Deployment exceeds its
+	// progressDeadlineSeconds.
+	RisingWaveHealthReasonProgressDeadlineExceeded RisingWaveHealthReason = "ProgressDeadlineExceeded"
+)
+
+// RisingWaveHealthIssue is a health issue found on the Pods or the workloads of a node group.
+type RisingWaveHealthIssue struct {
+	// Component of the node group, e.g., compute.
+	Component string `json:"component"`
+
+	// Group is the name of the node group.
+	Group string `json:"group"`
+
+	// Reason of the issue.
+	Reason RisingWaveHealthReason `json:"reason"`
+
+	// Count of the Pods with the issue. It's zero for the issues of the workloads.
+	// +optional
+	Count int32 `json:"count,omitempty"`
+
+	// Pods with the issue, at most 5 of them.
+	// +optional
+	// +listType=atomic
+	Pods []string `json:"pods,omitempty"`
+
+	// Message of the issue, e.g., the message of the container state or the condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveMaintenanceWindow is a recurring window in which the disruptive changes are applied.
+type RisingWaveMaintenanceWindow struct {
+	// Schedule of the window opening in the cron format, e.g., "0 2 * * 6" for 2 AM every Saturday.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration of the window.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone of the schedule, e.g., "Asia/Shanghai". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
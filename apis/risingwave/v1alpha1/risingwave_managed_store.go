@@ -0,0 +1,76 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// RisingWaveManagedStoreStorage is the persistent storage of a store provisioned by the operator.
+type RisingWaveManagedStoreStorage struct {
+	// Size of the persistent volume. Defaults to 10Gi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName of the persistent volume. The default storage class is used if not set.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// RisingWaveStateStoreBackendManagedMinIO provisions a single-replica MinIO with generated credentials as the
+// state store. It's only for development and testing. Everything including the data is deleted with the RisingWave.
+type RisingWaveStateStoreBackendManagedMinIO struct {
+	// Image of the MinIO. Defaults to quay.io/minio/minio:latest.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Bucket to store the data. Defaults to hummock.
+	// +optional
+	// +kubebuilder:default=hummock
+	// +kubebuilder:validation:Pattern="^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$"
+	Bucket string `json:"bucket,omitempty"`
+
+	// Storage of the MinIO.
+	// +optional
+	Storage RisingWaveManagedStoreStorage `json:"storage,omitempty"`
+
+	// Resources of the MinIO container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// RisingWaveMetaStoreBackendManagedPostgreSQL provisions a single-replica PostgreSQL with generated credentials as
+// the meta store. It's only for development and testing. Everything including the data is deleted with the
+// RisingWave.
+type RisingWaveMetaStoreBackendManagedPostgreSQL struct {
+	// Image of the PostgreSQL. Defaults to postgres:17.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Database to store the metadata. Defaults to risingwave.
+	// +optional
+	// +kubebuilder:default=risingwave
+	// +kubebuilder:validation:Pattern="^[a-z_][a-z0-9_]{0,62}$"
+	Database string `json:"database,omitempty"`
+
+	// Storage of the PostgreSQL.
+	// +optional
+	Storage RisingWaveManagedStoreStorage `json:"storage,omitempty"`
+
+	// Resources of the PostgreSQL container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveMetaStoreMigrationPhase is the phase of a RisingWaveMetaStoreMigration.
+type RisingWaveMetaStoreMigrationPhase string
+
+// All phases of RisingWaveMetaStoreMigration.
+const (
+	// RisingWaveMetaStoreMigrationPhasePending means the RisingWave and the meta stores are being validated.
+	RisingWaveMetaStoreMigrationPhasePending RisingWaveMetaStoreMigrationPhase = "Pending"
+
+	// RisingWaveMetaStoreMigrationPhasePausing means the reconciliation of the RisingWave is paused, and its
+	// components are being stopped.
+	RisingWaveMetaStoreMigrationPhasePausing RisingWaveMetaStoreMigrationPhase = "Pausing"
+
+	// RisingWaveMetaStoreMigrationPhaseMigrating means the Job that copies the metadata from the old meta store to
+	// the new one is running.
+	RisingWaveMetaStoreMigrationPhaseMigrating RisingWaveMetaStoreMigrationPhase = "Migrating"
+
+	// RisingWaveMetaStoreMigrationPhaseSwitching means the metadata is copied, and the meta store of the RisingWave is
+	// being switched to the new one.
+	RisingWaveMetaStoreMigrationPhaseSwitching RisingWaveMetaStoreMigrationPhase = "Switching"
+
+	// RisingWaveMetaStoreMigrationPhaseResuming means the meta store of the RisingWave is switched, and its
+	// reconciliation is being resumed.
+	RisingWaveMetaStoreMigrationPhaseResuming RisingWaveMetaStoreMigrationPhase = "Resuming"
+
+	// RisingWaveMetaStoreMigrationPhaseCompleted means the RisingWave runs on the new meta store. It can't be rolled
+	// back anymore.
+	RisingWaveMetaStoreMigrationPhaseCompleted RisingWaveMetaStoreMigrationPhase = "Completed"
+
+	// RisingWaveMetaStoreMigrationPhaseFailed means the migration can't proceed, e.g., the migration Job fails. The
+	// RisingWave is kept paused until the migration is rolled back.
+	RisingWaveMetaStoreMigrationPhaseFailed RisingWaveMetaStoreMigrationPhase = "Failed"
+
+	// RisingWaveMetaStoreMigrationPhaseRolledBack means the meta store of the RisingWave is restored to the rollback
+	// point, and its reconciliation is resumed.
+	RisingWaveMetaStoreMigrationPhaseRolledBack RisingWaveMetaStoreMigrationPhase = "RolledBack"
+)
+
+// RisingWaveMetaStoreMigrationSpec is the spec of RisingWaveMetaStoreMigration.
+type RisingWaveMetaStoreMigrationSpec struct {
+	// RisingWave is the name of the RisingWave in the same namespace. Its meta store must be etcd.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="risingwave is immutable"
+	RisingWave string `json:"risingwave"`
+
+	// MetaStore is the new meta store of the RisingWave, which must be MySQL or PostgreSQL. The database must exist.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="metaStore is immutable"
+	MetaStore RisingWaveMetaStoreBackend `json:"metaStore"`
+
+	// Image that runs the migration tool. Defaults to the image of the RisingWave.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Force the migration even if the new meta store isn't empty, e.g., to retry a failed migration.
+	// +optional
+	Force bool `json:"force,omitempty"`
+
+	// Rollback the migration. It restores the meta store of the RisingWave to the rollback point and resumes the
+	// reconciliation. It's allowed in any phase before the migration is completed.
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// RisingWaveMetaStoreMigrationPhaseRecord is the record of a phase that the migration has entered.
+type RisingWaveMetaStoreMigrationPhaseRecord struct {
+	// Phase entered.
+	Phase RisingWaveMetaStoreMigrationPhase `json:"phase"`
+
+	// StartTime is when the phase is entered.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Message of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RisingWaveMetaStoreMigrationRollbackPoint is the state of the RisingWave that the migration is rolled back to.
+type RisingWaveMetaStoreMigrationRollbackPoint struct {
+	// MetaStore of the RisingWave before the migration.
+	MetaStore RisingWaveMetaStoreBackend `json:"metaStore"`
+
+	// Time when the rollback point is recorded.
+	Time metav1.Time `json:"time"`
+}
+
+// RisingWaveMetaStoreMigrationStatus is the status of RisingWaveMetaStoreMigration.
+type RisingWaveMetaStoreMigrationStatus struct {
+	// ObservedGeneration is the generation of the spec observed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase of the migration.
+	// +optional
+	Phase RisingWaveMetaStoreMigrationPhase `json:"phase,omitempty"`
+
+	// Message of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Job is the name of the migration Job.
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// RollbackPoint is recorded before the RisingWave is paused.
+	// +optional
+	RollbackPoint *RisingWaveMetaStoreMigrationRollbackPoint `json:"rollbackPoint,omitempty"`
+
+	// Switched tells if the meta store of the RisingWave has been switched to the new one.
+	// +optional
+	Switched bool `json:"switched,omitempty"`
+
+	// Phases entered, the earliest first.
+	// +optional
+	// +listType=atomic
+	Phases []RisingWaveMetaStoreMigrationPhaseRecord `json:"phases,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="RISINGWAVE",type=string,JSONPath=`.spec.risingwave`
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="SWITCHED",type=boolean,JSONPath=`.status.switched`
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=rwmsm,categories=all;streaming
+
+// RisingWaveMetaStoreMigration migrates the meta store of a RisingWave from etcd to MySQL or PostgreSQL. It pauses the
+// RisingWave, copies the metadata with the migration tool of RisingWave in a Job, switches the meta store of the
+// RisingWave, and resumes it.
+type RisingWaveMetaStoreMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RisingWaveMetaStoreMigrationSpec   `json:"spec,omitempty"`
+	Status RisingWaveMetaStoreMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RisingWaveMetaStoreMigrationList contains a list of RisingWaveMetaStoreMigrations.
+type RisingWaveMetaStoreMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RisingWaveMetaStoreMigration `json:"items"`
+}
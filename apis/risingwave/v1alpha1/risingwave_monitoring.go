@@ -0,0 +1,184 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveMonitorKind is the kind of the object (from Prometheus operator) to scrape the metrics.
+// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+type RisingWaveMonitorKind string
+
+// All monitor kinds.
+const (
+	RisingWaveMonitorKindServiceMonitor RisingWaveMonitorKind = "ServiceMonitor"
+	RisingWaveMonitorKindPodMonitor     RisingWaveMonitorKind = "PodMonitor"
+)
+
+// RisingWaveMetricsEndpoint is an additional endpoint to scrape the metrics from.
+type RisingWaveMetricsEndpoint struct {
+	// Port is the name of the port of the Services, or of the containers in the PodMonitor kind.
+	Port string `json:"port"`
+
+	// Path to scrape the metrics from. Defaults to /metrics.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Scheme to scrape the metrics with. Defaults to http.
+	// +optional
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+
+	// Interval of the scrapes. Defaults to the interval of the default endpoint.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout of the scrapes. Defaults to the scrape timeout of the default endpoint.
+	// +optional
+	ScrapeTimeout *metav1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// MetricRelabelings are the relabel rules applied to the samples before ingestion.
+	// +optional
+	MetricRelabelings []prometheusv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+}
+
+// RisingWaveAlert is the name of a default alert generated in the PrometheusRule.
+// +kubebuilder:validation:Enum=RisingWaveBarrierLatencyHigh;RisingWaveCompactionBacklogHigh;RisingWaveMetaLeaderChurn;RisingWavePodNotReady;RisingWaveContainerOOMKilled
+type RisingWaveAlert string
+
+// All default alerts.
+const (
+	RisingWaveAlertBarrierLatencyHigh    RisingWaveAlert = "RisingWaveBarrierLatencyHigh"
+	RisingWaveAlertCompactionBacklogHigh RisingWaveAlert = "RisingWaveCompactionBacklogHigh"
+	RisingWaveAlertMetaLeaderChurn       RisingWaveAlert = "RisingWaveMetaLeaderChurn"
+	RisingWaveAlertPodNotReady           RisingWaveAlert = "RisingWavePodNotReady"
+	RisingWaveAlertContainerOOMKilled    RisingWaveAlert = "RisingWaveContainerOOMKilled"
+)
+
+// RisingWaveAlertThresholds are the thresholds of the default alerts. Defaults are used for the unset ones.
+type RisingWaveAlertThresholds struct {
+	// BarrierLatency is the p99 latency of the barriers above which RisingWaveBarrierLatencyHigh fires.
+	// Defaults to 1m.
+	// +optional
+	BarrierLatency *metav1.Duration `json:"barrierLatency,omitempty"`
+
+	// CompactionPendingBytes is the size of the data pending compaction above which RisingWaveCompactionBacklogHigh
+	// fires. Defaults to 50Gi.
+	// +optional
+	CompactionPendingBytes *resource.Quantity `json:"compactionPendingBytes,omitempty"`
+
+	// MetaLeaderChanges is the number of the meta leader changes within an hour above which RisingWaveMetaLeaderChurn
+	// fires. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MetaLeaderChanges *int32 `json:"metaLeaderChanges,omitempty"`
+
+	// PodNotReady is the duration for which a Pod stays not ready before RisingWavePodNotReady fires. Defaults to 10m.
+	// +optional
+	PodNotReady *metav1.Duration `json:"podNotReady,omitempty"`
+}
+
+// RisingWavePrometheusRule determines the PrometheusRule (from Prometheus operator) generated for the RisingWave.
+// The alerts are scoped to the RisingWave with the labels attached by the ServiceMonitor and the namespace.
+type RisingWavePrometheusRule struct {
+	// Enabled tells the operator to generate the PrometheusRule. The controller creates it only if the CRD is
+	// installed. Defaults to false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Labels to add onto the PrometheusRule, e.g., to match the rule selector of the Prometheus. Labels with the
+	// prefix 'risingwave/' are system reserved.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// AlertLabels to add onto every alert, e.g., to route the alerts. They override the default severity label.
+	// +optional
+	AlertLabels map[string]string `json:"alertLabels,omitempty"`
+
+	// DisabledAlerts are the default alerts that won't be generated.
+	// +optional
+	// +listType=set
+	DisabledAlerts []RisingWaveAlert `json:"disabledAlerts,omitempty"`
+
+	// Thresholds of the default alerts.
+	// +optional
+	Thresholds RisingWaveAlertThresholds `json:"thresholds,omitempty"`
+}
+
+// RisingWaveGrafanaDashboard determines the ConfigMap of the Grafana dashboard generated for the RisingWave. The
+// ConfigMap is labeled to be discovered by the dashboard sidecar of Grafana, and the panels are scoped to the
+// RisingWave.
+type RisingWaveGrafanaDashboard struct {
+	// Enabled tells the operator to generate the ConfigMap. Defaults to false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Labels to add onto the ConfigMap to be discovered by the sidecar. Defaults to grafana_dashboard: "1". Labels
+	// with the prefix 'risingwave/' are system reserved.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to add onto the ConfigMap, e.g., grafana_folder to put the dashboard into a folder.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RisingWaveMonitoring configures the scrapes of the metrics and the monitoring resources generated alongside them.
+// The scrapes are enabled with spec.enableDefaultServiceMonitor.
+type RisingWaveMonitoring struct {
+	// MonitorKind is the kind of the object to scrape the metrics. PodMonitor selects the Pods directly, which
+	// avoids the duplicated targets of the Services sharing the same Pods, e.g., the frontend headless Service.
+	// Defaults to ServiceMonitor.
+	// +optional
+	MonitorKind RisingWaveMonitorKind `json:"monitorKind,omitempty"`
+
+	// Interval of the scrapes. Defaults to 15s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout of the scrapes. It must not be greater than the interval. Defaults to 15s.
+	// +optional
+	ScrapeTimeout *metav1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// Labels to add onto the ServiceMonitor or PodMonitor, e.g., to match the selector of the Prometheus. Labels
+	// with the prefix 'risingwave/' are system reserved.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// KeepHighCardinalityMetrics tells the operator not to drop the metric families that produce too many series,
+	// i.e., batch_.+ and stream_exchange_.+. Defaults to false.
+	// +optional
+	KeepHighCardinalityMetrics *bool `json:"keepHighCardinalityMetrics,omitempty"`
+
+	// MetricRelabelings are the relabel rules applied to the samples of the default endpoint before ingestion,
+	// after the drops of the high cardinality metrics.
+	// +optional
+	MetricRelabelings []prometheusv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+
+	// AdditionalEndpoints to scrape besides the metrics port of the components.
+	// +optional
+	AdditionalEndpoints []RisingWaveMetricsEndpoint `json:"additionalEndpoints,omitempty"`
+
+	// PrometheusRule configures the default alerts of the RisingWave.
+	// +optional
+	PrometheusRule *RisingWavePrometheusRule `json:"prometheusRule,omitempty"`
+
+	// GrafanaDashboard configures the Grafana dashboard of the RisingWave.
+	// +optional
+	GrafanaDashboard *RisingWaveGrafanaDashboard `json:"grafanaDashboard,omitempty"`
+}
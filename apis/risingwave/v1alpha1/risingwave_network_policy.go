@@ -0,0 +1,52 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// RisingWaveNetworkPolicy determines the NetworkPolicies generated for the RisingWave components. The policies allow
+// the traffic between the components of the same RisingWave, the access to the meta service from the operator, the
+// access to the frontend from the clients, the access to the dashboard and the webhook listener from the HTTP clients,
+// and the scrapes of the metrics from the monitoring namespace.
+type RisingWaveNetworkPolicy struct {
+	// Enabled tells the operator to generate the NetworkPolicies. Defaults to false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// FrontendClients are the peers, e.g., Pod selectors, namespace selectors and CIDRs, that are allowed to access
+	// the SQL and webhook ports of the frontend. When it's empty, the frontend is only accessible from the Pods of
+	// the same RisingWave.
+	// +optional
+	FrontendClients []networkingv1.NetworkPolicyPeer `json:"frontendClients,omitempty"`
+
+	// HTTPClients are the peers, e.g., the namespaces of the Ingress controllers or the Gateways, that are allowed to
+	// access the dashboard of the meta service and the webhook listener of the frontend. When it's empty, they are
+	// only accessible from the Pods of the same RisingWave. It's required when any endpoint is exposed in
+	// externalAccess.
+	// +optional
+	HTTPClients []networkingv1.NetworkPolicyPeer `json:"httpClients,omitempty"`
+
+	// MonitoringNamespace is the namespace that is allowed to scrape the metrics. Defaults to monitoring.
+	// +optional
+	MonitoringNamespace string `json:"monitoringNamespace,omitempty"`
+
+	// OperatorNamespace is the namespace that the operator runs in. The operator needs to access the meta service, and
+	// the metrics of the frontends to drain them on deletion.
+	// Defaults to the namespace of the operator Pod.
+	// +optional
+	OperatorNamespace string `json:"operatorNamespace,omitempty"`
+}
@@ -366,6 +366,34 @@ type RisingWaveNodeGroupUpgradeStrategy struct {
 	InPlaceUpdateStrategy *kruisepubs.InPlaceUpdateStrategy `json:"inPlaceUpdateStrategy,omitempty"`
 }
 
+// RisingWaveComputeRole is the role of the compute nodes.
+type RisingWaveComputeRole string
+
+// All valid compute roles.
+const (
+	// RisingWaveComputeRoleStreaming means the compute nodes only run the streaming jobs.
+	RisingWaveComputeRoleStreaming RisingWaveComputeRole = "streaming"
+
+	// RisingWaveComputeRoleServing means the compute nodes only serve the batch queries.
+	RisingWaveComputeRoleServing RisingWaveComputeRole = "serving"
+
+	// RisingWaveComputeRoleBoth means the compute nodes run the streaming jobs and serve the batch queries.
+	RisingWaveComputeRoleBoth RisingWaveComputeRole = "both"
+)
+
+// RisingWaveNodeGroupZones determines how a node group is expanded into zones.
+type RisingWaveNodeGroupZones struct {
+	// Values are the zones to expand the node group into, i.e., the values of the topology key. If it's empty,
+	// the zones are discovered from the labels of the Kubernetes nodes.
+	// +optional
+	// +listType=set
+	Values []string `json:"values,omitempty"`
+
+	// TopologyKey is the key of the node label that identifies the zones. Defaults to topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
 // RisingWaveNodeGroup is the definition of a group of RisingWave nodes of the same component.
 type RisingWaveNodeGroup struct {
 	// Name of the node group.
@@ -425,6 +453,32 @@ type RisingWaveNodeGroup struct {
 	// not be estimated during the time a deployment is paused. Defaults to 600s.
 	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty" protobuf:"varint,9,opt,name=progressDeadlineSeconds"`
 
+	// ResourceRecommendation enables the vertical resource recommender on the node group. The recommender tracks
+	// the resource usage and OOM terminations of the Pods and publishes the recommended limits in the status.
+	// +optional
+	ResourceRecommendation *RisingWaveResourceRecommendationPolicy `json:"resourceRecommendation,omitempty"`
+
+	// ComputeRole is the role of the compute nodes in the group. It's only allowed on the node groups of the
+	// compute component. Valid values are streaming, serving and both. When it's empty, the compute nodes run
+	// as both, or as streaming if the embedded serving mode is enabled.
+	// +optional
+	// +kubebuilder:validation:Enum=streaming;serving;both
+	ComputeRole RisingWaveComputeRole `json:"computeRole,omitempty"`
+
+	// ResourceGroup is the resource group that the compute nodes in the group join. It's only allowed on the node
+	// groups of the compute component. When it's empty, the node group name is used, or the default resource group
+	// if the name is empty as well. The compute nodes in the standalone Pods always join the default resource group.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// Zones expands the node group into one workload per zone. The replicas are split evenly across the zones, and
+	// the Pods of each workload are pinned to their zone with a node selector on the topology key. The workloads
+	// are named after the node group and the zone, e.g., "<group>-<zone>". The node group isn't expanded until the
+	// zones are discovered. The meta and compute Pods of the node group are spread across the zones and the hosts by
+	// default if there are no topology spread constraints in the template.
+	// +optional
+	Zones *RisingWaveNodeGroupZones `json:"zones,omitempty"`
+
 	// Template tells how the Pod should be started. It is an optional field. If it's empty, then the pod template in
 	// the first-level fields under spec will be used.
 	// +optional
@@ -0,0 +1,176 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveNotificationFormat is the format of the notifications sent to the sink.
+// +kubebuilder:validation:Enum=CloudEvents;JSON
+type RisingWaveNotificationFormat string
+
+// All notification formats.
+const (
+	// RisingWaveNotificationFormatCloudEvents sends the notifications as CloudEvents in the structured content mode.
+	RisingWaveNotificationFormatCloudEvents RisingWaveNotificationFormat = "CloudEvents"
+
+	// RisingWaveNotificationFormatJSON sends the notifications rendered with the template, e.g., the payloads of the
+	// Slack incoming webhooks.
+	RisingWaveNotificationFormatJSON RisingWaveNotificationFormat = "JSON"
+)
+
+// RisingWaveNotificationEvent is the lifecycle event of the RisingWave, or the event of a health issue or a remediation.
+// +kubebuilder:validation:Enum=Initializing;Running;Recovering;Upgrading;Unhealthy;CrashLoopBackOff;OOMKilled;ImagePullBackOff;Unschedulable;ProgressDeadlineExceeded;Remediated;RemediationBudgetExhausted
+type RisingWaveNotificationEvent string
+
+// RisingWaveNotificationSink is the HTTP endpoint to deliver the notifications to.
+type RisingWaveNotificationSink struct {
+	// URL of the endpoint. The notifications are sent with POST requests.
+	// +kubebuilder:validation:Pattern=`^https?://.+`
+	URL string `json:"url"`
+
+	// Format of the notifications. Defaults to CloudEvents.
+	// +optional
+	// +kubebuilder:default=CloudEvents
+	Format RisingWaveNotificationFormat `json:"format,omitempty"`
+
+	// Template of the body in the JSON format, in the Go template syntax. The fields of the notification, i.e.,
+	// .Namespace, .Name, .Event, .Type, .Message, .Generation and .Time, are available, and the json function
+	// quotes a string. Defaults to a Slack compatible payload like {"text": "..."}.
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// Headers to add onto the requests.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// HeadersFrom the secret in the same namespace. The keys of the secret are the names of the headers, e.g.,
+	// Authorization. They override the ones in headers.
+	// +optional
+	HeadersFrom *RisingWaveNotificationHeadersSource `json:"headersFrom,omitempty"`
+}
+
+// RisingWaveNotificationHeadersSource is the source of the headers.
+type RisingWaveNotificationHeadersSource struct {
+	// SecretName is the name of the secret.
+	SecretName string `json:"secretName"`
+}
+
+// RisingWaveNotificationRetry is the retry policy of the deliveries.
+type RisingWaveNotificationRetry struct {
+	// MaxAttempts is the max attempts to deliver a notification, including the first one. The notification is
+	// retried on the connection errors, the 429 and 5xx responses. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// Backoff before the first retry, doubled for the following ones. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// RisingWaveNotificationSpec is the spec of RisingWaveNotification.
+type RisingWaveNotificationSpec struct {
+	// Selector of the RisingWaves in the same namespace to notify about. Empty selects all.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Events to notify about. Empty means all.
+	// +optional
+	// +listType=set
+	Events []RisingWaveNotificationEvent `json:"events,omitempty"`
+
+	// Sink to deliver the notifications to.
+	Sink RisingWaveNotificationSink `json:"sink"`
+
+	// Retry policy of the deliveries.
+	// +optional
+	Retry RisingWaveNotificationRetry `json:"retry,omitempty"`
+
+	// Suspend the deliveries. The notifications while suspended are dropped.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+}
+
+// RisingWaveNotificationDelivery is the record of a delivery.
+type RisingWaveNotificationDelivery struct {
+	// RisingWave the notification is about.
+	RisingWave string `json:"risingwave"`
+
+	// Event of the notification.
+	Event RisingWaveNotificationEvent `json:"event"`
+
+	// Time of the last attempt.
+	Time metav1.Time `json:"time"`
+
+	// Attempts made.
+	Attempts int32 `json:"attempts"`
+
+	// Succeeded tells if it's delivered.
+	Succeeded bool `json:"succeeded"`
+
+	// Message of the last attempt, e.g., the status of the response or the error.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RisingWaveNotificationStatus is the status of RisingWaveNotification.
+type RisingWaveNotificationStatus struct {
+	// Delivered is the count of the notifications delivered.
+	// +optional
+	Delivered int64 `json:"delivered,omitempty"`
+
+	// Failed is the count of the notifications failed to deliver after all the attempts.
+	// +optional
+	Failed int64 `json:"failed,omitempty"`
+
+	// LastDelivery is the record of the last delivery.
+	// +optional
+	LastDelivery *RisingWaveNotificationDelivery `json:"lastDelivery,omitempty"`
+
+	// LastFailure is the record of the last failed delivery.
+	// +optional
+	LastFailure *RisingWaveNotificationDelivery `json:"lastFailure,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.sink.url`
+// +kubebuilder:printcolumn:name="FORMAT",type=string,JSONPath=`.spec.sink.format`
+// +kubebuilder:printcolumn:name="DELIVERED",type=integer,JSONPath=`.status.delivered`
+// +kubebuilder:printcolumn:name="FAILED",type=integer,JSONPath=`.status.failed`
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=rwn,categories=all;streaming
+
+// RisingWaveNotification delivers the lifecycle events of the RisingWaves to an HTTP endpoint.
+type RisingWaveNotification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RisingWaveNotificationSpec   `json:"spec,omitempty"`
+	Status RisingWaveNotificationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RisingWaveNotificationList contains a list of RisingWaveNotifications.
+type RisingWaveNotificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RisingWaveNotification `json:"items"`
+}
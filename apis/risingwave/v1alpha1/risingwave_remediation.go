@@ -0,0 +1,101 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveRemediationPolicy controls the remediation of the Pods of the stateful components that get stuck in a
+// rollout, e.g., a Pod on the old revision that never gets ready after a bad configuration, or a crash looping ordinal
+// that blocks the OrderedReady rollout. The stuck Pods are deleted so that they are recreated on the update revision.
+type RisingWaveRemediationPolicy struct {
+	// Components to remediate. Defaults to all the stateful components, i.e., meta, frontend (when it's deployed
+	// with StatefulSets), compute and standalone.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=meta;frontend;compute;standalone
+	Components []string `json:"components,omitempty"`
+
+	// StuckThreshold is how long a Pod stays not ready during a rollout before it's considered stuck. Defaults to 10m.
+	// +optional
+	StuckThreshold *metav1.Duration `json:"stuckThreshold,omitempty"`
+
+	// MaxInterventions is the max number of Pods deleted within the budget window. The stuck Pods are left as is once
+	// the budget is exhausted. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	MaxInterventions *int32 `json:"maxInterventions,omitempty"`
+
+	// BudgetWindow is the sliding window of the budget. Defaults to 1h.
+	// +optional
+	BudgetWindow *metav1.Duration `json:"budgetWindow,omitempty"`
+}
+
+// RisingWaveRemediationReason is the reason why a Pod is considered stuck.
+// +kubebuilder:validation:Enum=Outdated;NotReady
+type RisingWaveRemediationReason string
+
+// All remediation reasons.
+const (
+	// RisingWaveRemediationReasonOutdated means the Pod stays not ready on the old revision, which the StatefulSet
+	// won't replace until it's ready.
+	RisingWaveRemediationReasonOutdated RisingWaveRemediationReason = "Outdated"
+
+	// RisingWaveRemediationReasonNotReady means the Pod on the update revision never gets ready and blocks the
+	// following ordinals.
+	RisingWaveRemediationReasonNotReady RisingWaveRemediationReason = "NotReady"
+)
+
+// RisingWaveRemediationIntervention is a Pod deleted by the remediation.
+type RisingWaveRemediationIntervention struct {
+	// Time of the deletion.
+	Time metav1.Time `json:"time"`
+
+	// Component of the Pod, e.g., compute.
+	Component string `json:"component"`
+
+	// Group of the Pod.
+	Group string `json:"group"`
+
+	// Pod deleted.
+	Pod string `json:"pod"`
+
+	// Reason why the Pod is considered stuck.
+	Reason RisingWaveRemediationReason `json:"reason"`
+
+	// Message with the details, e.g., how long the Pod has been not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RisingWaveRemediationStatus is the status of the remediation.
+type RisingWaveRemediationStatus struct {
+	// Interventions made recently, the latest last. The latest 20 of them are kept, or all of those within the budget
+	// window if there are more.
+	// +optional
+	// +listType=atomic
+	Interventions []RisingWaveRemediationIntervention `json:"interventions,omitempty"`
+
+	// TotalInterventions is the number of all the interventions made.
+	// +optional
+	TotalInterventions int64 `json:"totalInterventions,omitempty"`
+
+	// Withheld are the stuck Pods left as is because the budget is exhausted.
+	// +optional
+	// +listType=atomic
+	Withheld []string `json:"withheld,omitempty"`
+}
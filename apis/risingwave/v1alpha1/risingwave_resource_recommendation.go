@@ -0,0 +1,90 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveResourceRecommendationMode is the mode of the vertical resource recommender.
+type RisingWaveResourceRecommendationMode string
+
+// Valid values of RisingWaveResourceRecommendationMode.
+const (
+	// RisingWaveResourceRecommendationModeRecommend only publishes the recommendations in the status.
+	RisingWaveResourceRecommendationModeRecommend RisingWaveResourceRecommendationMode = "Recommend"
+
+	// RisingWaveResourceRecommendationModeAuto publishes the recommendations and applies them to the Pods. The
+	// Pods are resized in place when the cluster supports it, and rolled with the limits in status otherwise.
+	RisingWaveResourceRecommendationModeAuto RisingWaveResourceRecommendationMode = "Auto"
+)
+
+// RisingWaveResourceRecommendationPolicy controls the vertical resource recommender of a node group.
+type RisingWaveResourceRecommendationPolicy struct {
+	// Mode of the recommender. Defaults to Recommend, which only publishes the recommendations in the status.
+	// +optional
+	// +kubebuilder:default=Recommend
+	// +kubebuilder:validation:Enum=Recommend;Auto
+	Mode RisingWaveResourceRecommendationMode `json:"mode,omitempty"`
+
+	// MinAllowed is the lower bound of the recommended cpu and memory limits.
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed is the upper bound of the recommended cpu and memory limits.
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// RisingWaveResourceRecommendation is the resource recommendation of a node group.
+type RisingWaveResourceRecommendation struct {
+	// Component of the node group.
+	Component string `json:"component"`
+
+	// Group name of the node group.
+	Group string `json:"group"`
+
+	// Target is the recommended cpu and memory limits of the RisingWave container.
+	// +optional
+	Target corev1.ResourceList `json:"target,omitempty"`
+
+	// Applied is the target that's applied to the Pods of the node group in the Auto mode when the Pods can't be
+	// resized in place. The Pods are built with the applied limits instead of the ones in the template, and the spec
+	// of the RisingWave is never changed.
+	// +optional
+	Applied corev1.ResourceList `json:"applied,omitempty"`
+
+	// PeakUsage is the peak cpu and memory usage observed across the Pods of the node group. The peak cpu usage
+	// decays over time.
+	// +optional
+	PeakUsage corev1.ResourceList `json:"peakUsage,omitempty"`
+
+	// LastSampleTime is the last time the usage of the Pods was sampled.
+	// +optional
+	LastSampleTime *metav1.Time `json:"lastSampleTime,omitempty"`
+
+	// OOMKills is the number of OOM terminations observed on the Pods of the node group.
+	// +optional
+	OOMKills int32 `json:"oomKills,omitempty"`
+
+	// LastOOMTime is the time of the last observed OOM termination.
+	// +optional
+	LastOOMTime *metav1.Time `json:"lastOOMTime,omitempty"`
+
+	// LastUpdateTime is the last time the recommendation was updated.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
@@ -35,10 +35,11 @@ type RisingWaveStandaloneComponent struct {
 	// trigger a full recreation of the Pods. Defaults to nil.
 	RestartAt *metav1.Time `json:"restartAt,omitempty"`
 
-	// Replicas is the number of the standalone Pods. Maximum is 1. Defaults to 1.
+	// Replicas is the number of the standalone Pods. Defaults to 1. More than one replica runs the standalone Pods in
+	// the high availability mode, where the meta leader is elected among the Pods. It requires a PostgreSQL or MySQL
+	// meta store and an object storage state store.
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Minimum=0
-	// +kubebuilder:validation:Maximum=1
 	Replicas int32 `json:"replicas"`
 
 	// Upgrade strategy for the components. By default, it is the same as the
@@ -85,3 +86,53 @@ type RisingWaveStandaloneComponent struct {
 	// +optional
 	Template RisingWaveNodePodTemplate `json:"template,omitempty"`
 }
+
+// RisingWaveStandaloneMigration describes the migration from the standalone mode to the distributed mode.
+type RisingWaveStandaloneMigration struct {
+	// SQLiteVolume is the name of the volume, or the volume claim template, of the standalone Pod that holds the
+	// SQLite DB file of the meta store. The volume is mounted into the meta Pod at the same path so that the meta store
+	// is carried over. Defaults to the volume mounted on the closest parent directory of the DB file.
+	// +optional
+	SQLiteVolume string `json:"sqliteVolume,omitempty"`
+}
+
+// RisingWaveStandaloneMigrationPhase is the phase of the migration from the standalone mode.
+type RisingWaveStandaloneMigrationPhase string
+
+// All phases of the migration from the standalone mode.
+const (
+	// RisingWaveStandaloneMigrationPhasePending means the migration is blocked, e.g., the meta store can't be carried
+	// over. The standalone Pods are kept running.
+	RisingWaveStandaloneMigrationPhasePending RisingWaveStandaloneMigrationPhase = "Pending"
+
+	// RisingWaveStandaloneMigrationPhaseStoppingStandalone means the standalone Pods are being stopped.
+	RisingWaveStandaloneMigrationPhaseStoppingStandalone RisingWaveStandaloneMigrationPhase = "StoppingStandalone"
+
+	// RisingWaveStandaloneMigrationPhaseStartingMeta means the meta nodes are being brought up on the meta store.
+	RisingWaveStandaloneMigrationPhaseStartingMeta RisingWaveStandaloneMigrationPhase = "StartingMeta"
+
+	// RisingWaveStandaloneMigrationPhaseStartingComponents means the frontend, compute and compactor nodes are being
+	// brought up.
+	RisingWaveStandaloneMigrationPhaseStartingComponents RisingWaveStandaloneMigrationPhase = "StartingComponents"
+
+	// RisingWaveStandaloneMigrationPhaseCompleted means all the components are ready.
+	RisingWaveStandaloneMigrationPhaseCompleted RisingWaveStandaloneMigrationPhase = "Completed"
+)
+
+// RisingWaveStandaloneMigrationStatus is the status of the migration from the standalone mode.
+type RisingWaveStandaloneMigrationStatus struct {
+	// Phase of the migration.
+	Phase RisingWaveStandaloneMigrationPhase `json:"phase,omitempty"`
+
+	// Message explains the phase, e.g., why the migration is blocked.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time when the migration started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the migration completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -296,6 +296,14 @@ type RisingWaveStateStoreBackend struct {
 	// +kubebuilder:validation:Pattern="^[0-9a-zA-Z_/-]{1,}$"
 	DataDirectory string `json:"dataDirectory,omitempty"`
 
+	// DeletionPolicy determines what happens to the data under the data directory when the RisingWave is deleted.
+	// Defaults to Retain. Delete is only supported by S3, MinIO, Aliyun OSS and Huawei Cloud OBS with the credentials
+	// in a Secret, and is refused when the data directory is shared with another RisingWave. The data of a managed
+	// MinIO is always deleted with the RisingWave.
+	// +optional
+	// +kubebuilder:default=Retain
+	DeletionPolicy RisingWaveDeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// Memory indicates to store the data in memory. It's only for test usage and strongly discouraged to
 	// be used in production.
 	// +optional
@@ -310,6 +318,10 @@ type RisingWaveStateStoreBackend struct {
 	// +optional
 	MinIO *RisingWaveStateStoreBackendMinIO `json:"minio,omitempty"`
 
+	// ManagedMinIO provisions a MinIO as the storage. It's only for development and testing.
+	// +optional
+	ManagedMinIO *RisingWaveStateStoreBackendManagedMinIO `json:"managedMinIO,omitempty"`
+
 	// S3 storage spec.
 	// +optional
 	S3 *RisingWaveStateStoreBackendS3 `json:"s3,omitempty"`
@@ -94,7 +94,8 @@ type RisingWaveSpec struct {
 
 	// Flag to indicate if a default ServiceMonitor (from Prometheus operator) should be created by the controller.
 	// False and an empty value means the ServiceMonitor won't be created automatically. But even if it's set to true,
-	// the controller will determine if it can create the resource by checking if the CRDs are installed.
+	// the controller will determine if it can create the resource by checking if the CRDs are installed. A PodMonitor
+	// is created instead if it's chosen in spec.monitoring.monitorKind.
 	// +optional
 	EnableDefaultServiceMonitor *bool `json:"enableDefaultServiceMonitor,omitempty"`
 
@@ -127,6 +128,15 @@ type RisingWaveSpec struct {
 	// +kubebuilder:default=true
 	EnableWebhookListener *bool `json:"enableWebhookListener,omitempty"`
 
+	// Flag to control whether to verify the meta store and the state store before rolling out the components.
+	// If enabled, the controller checks the connectivity and the credentials of the stores and reports the result
+	// in the StoresReachable condition. The rollout is held until the checks pass. The state stores accessed with the
+	// workload identity, i.e., S3 with the service account, GCS and Azure Blob, are probed by a Job running with the
+	// service account and the scheduling constraints of the compute nodes.
+	// +optional
+	// +kubebuilder:default=false
+	EnablePreflightChecks *bool `json:"enablePreflightChecks,omitempty"`
+
 	// Image for RisingWave component.
 	Image string `json:"image"`
 
@@ -168,6 +178,60 @@ type RisingWaveSpec struct {
 
 	// SecretStore is the configuration of the secret store.
 	SecretStore RisingWaveSecretStore `json:"secretStore,omitempty"`
+
+	// NetworkPolicy configures the NetworkPolicies generated for the RisingWave components. No NetworkPolicy is
+	// generated unless it's enabled.
+	// +optional
+	NetworkPolicy *RisingWaveNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// ExternalAccess configures the Ingresses and HTTPRoutes that expose the dashboard and the webhook listener
+	// outside the cluster. When the NetworkPolicies are enabled, the Ingress controllers or the Gateways must be
+	// allowed in networkPolicy.httpClients.
+	// +optional
+	ExternalAccess *RisingWaveExternalAccess `json:"externalAccess,omitempty"`
+
+	// Monitoring configures the alerts and the dashboard generated for the RisingWave.
+	// +optional
+	Monitoring *RisingWaveMonitoring `json:"monitoring,omitempty"`
+
+	// Remediation enables the remediation of the Pods of the stateful components that get stuck in a rollout. The
+	// Pods are left as is unless it's set.
+	// +optional
+	Remediation *RisingWaveRemediationPolicy `json:"remediation,omitempty"`
+
+	// MaintenanceWindows defer the disruptive changes of the workloads, e.g., the image, the restarts and the Pod
+	// template changes, until one of the windows opens. The non-disruptive changes, e.g., the replicas, are applied
+	// immediately. The changes are applied immediately if it's empty.
+	// +optional
+	// +listType=atomic
+	MaintenanceWindows []RisingWaveMaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// StandaloneMigration migrates the RisingWave from the standalone mode to the distributed mode when it's set and
+	// the standalone mode is disabled. The standalone Pods are stopped first, then the meta nodes are brought up on
+	// the same meta store, and then the other components. The meta store must be able to be carried over, i.e., it
+	// can't be the memory, and the SQLite DB file must be on a persistent volume of the standalone Pod. Without it,
+	// the standalone Pods and the components are stopped and started at the same time.
+	// +optional
+	StandaloneMigration *RisingWaveStandaloneMigration `json:"standaloneMigration,omitempty"`
+
+	// DeletionProtection forbids the deletion of the RisingWave by the validating webhook, unless the deletion is
+	// confirmed by setting the annotation risingwave.risingwavelabs.com/confirm-deletion to the name of the RisingWave.
+	// When it's off, the frontends are drained before the rest of the RisingWave is removed.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// FrontendDrainTimeout is the maximum time to wait for the frontends to drain the connections before the rest of
+	// the RisingWave is removed. Defaults to 5m.
+	// +optional
+	FrontendDrainTimeout *metav1.Duration `json:"frontendDrainTimeout,omitempty"`
+
+	// CloneFrom bootstraps the RisingWave with a copy of the data of an existing RisingWave, e.g., to debug the issues
+	// of a production cluster. A meta snapshot is taken from the source and restored into the meta store, and the
+	// objects in the data directory of the source are copied into the data directory. The meta store must be a fresh
+	// MySQL or PostgreSQL database, and the state store must be the same S3 compatible bucket as the source's. The
+	// components are created after the clone is completed. It can't be added, removed or pointed at another source.
+	// +optional
+	CloneFrom *RisingWaveCloneFrom `json:"cloneFrom,omitempty"`
 }
 
 // ComponentGroupReplicasStatus are the running status of Pods in group.
@@ -183,6 +247,50 @@ type ComponentGroupReplicasStatus struct {
 
 	// Existence status of the group.
 	Exists bool `json:"exists,omitempty"`
+
+	// Role of the compute nodes in the group. Only reported for the compute component.
+	// +optional
+	Role RisingWaveComputeRole `json:"role,omitempty"`
+
+	// Zones are the replicas of each zone if the group is expanded into zones.
+	// +optional
+	Zones []ComponentZoneReplicasStatus `json:"zones,omitempty"`
+}
+
+// ComponentZoneReplicasStatus are the running status of Pods in a zone of a group.
+type ComponentZoneReplicasStatus struct {
+	// Name of the zone.
+	Name string `json:"name"`
+
+	// Target replicas in the zone.
+	Target int32 `json:"target"`
+
+	// Running replicas in the zone.
+	Running int32 `json:"running"`
+
+	// Existence status of the workload of the zone.
+	Exists bool `json:"exists,omitempty"`
+}
+
+// RisingWaveTopologyZones are the zones discovered from the labels of the Kubernetes nodes.
+type RisingWaveTopologyZones struct {
+	// TopologyKey is the key of the node label.
+	TopologyKey string `json:"topologyKey"`
+
+	// Values of the node label, sorted.
+	Values []string `json:"values,omitempty"`
+}
+
+// RisingWaveResourceGroupStatus is the status of a resource group reported by the meta service.
+type RisingWaveResourceGroupStatus struct {
+	// Name of the resource group.
+	Name string `json:"name"`
+
+	// Workers is the number of compute nodes in the resource group.
+	Workers int32 `json:"workers"`
+
+	// StreamingJobs is the number of streaming jobs assigned to the resource group.
+	StreamingJobs int32 `json:"streamingJobs"`
 }
 
 // ComponentReplicasStatus are the running status of Pods of the component.
@@ -225,6 +333,10 @@ const (
 	RisingWaveConditionUpgrading    RisingWaveConditionType = "Upgrading"
 	RisingWaveConditionFailed       RisingWaveConditionType = "Failed"
 	RisingWaveConditionUnknown      RisingWaveConditionType = "Unknown"
+
+	RisingWaveConditionStoresReachable RisingWaveConditionType = "StoresReachable"
+
+	RisingWaveConditionPendingMaintenance RisingWaveConditionType = "PendingMaintenance"
 )
 
 // RisingWaveCondition indicates a condition of RisingWave.
@@ -316,6 +428,46 @@ type RisingWaveStatus struct {
 	// Internal status.
 	Internal RisingWaveInternalStatus `json:"internal,omitempty"`
 
+	// Health issues found on the Pods and the workloads, e.g., the Pods in CrashLoopBackOff. It's empty when all of
+	// them are healthy.
+	// +optional
+	// +listType=atomic
+	Health []RisingWaveHealthIssue `json:"health,omitempty"`
+
+	// Remediation of the stuck Pods, only reported when it's enabled.
+	// +optional
+	Remediation *RisingWaveRemediationStatus `json:"remediation,omitempty"`
+
+	// Resource recommendations of the node groups that have the recommender enabled.
+	// +optional
+	ResourceRecommendations []RisingWaveResourceRecommendation `json:"resourceRecommendations,omitempty"`
+
+	// Resource groups of the compute nodes reported by the meta service.
+	// +optional
+	ResourceGroups []RisingWaveResourceGroupStatus `json:"resourceGroups,omitempty"`
+
+	// Zones discovered for the node groups that are expanded into zones by a topology key only.
+	// +optional
+	// +listType=map
+	// +listMapKey=topologyKey
+	TopologyZones []RisingWaveTopologyZones `json:"topologyZones,omitempty"`
+
+	// External endpoints exposed by the Ingresses and HTTPRoutes.
+	// +optional
+	ExternalEndpoints []RisingWaveExternalEndpointStatus `json:"externalEndpoints,omitempty"`
+
+	// Status of cleaning up the data in the stores after the RisingWave is deleted.
+	// +optional
+	DataCleanup *RisingWaveDataCleanupStatus `json:"dataCleanup,omitempty"`
+
+	// Status of the migration from the standalone mode to the distributed mode.
+	// +optional
+	StandaloneMigration *RisingWaveStandaloneMigrationStatus `json:"standaloneMigration,omitempty"`
+
+	// Status of cloning the RisingWave from the source, including the lineage.
+	// +optional
+	Clone *RisingWaveCloneStatus `json:"clone,omitempty"`
+
 	// -----------------------------------v1alpha2 features ------------------------------------------ //
 
 	// Status of the meta store.
@@ -0,0 +1,216 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RisingWaveUpgradePhase is the phase of a RisingWaveUpgrade.
+type RisingWaveUpgradePhase string
+
+// All phases of RisingWaveUpgrade.
+const (
+	// RisingWaveUpgradePhasePending means the source is being validated, and the alias Service is created to point at
+	// the source.
+	RisingWaveUpgradePhasePending RisingWaveUpgradePhase = "Pending"
+
+	// RisingWaveUpgradePhaseProvisioning means the green RisingWave is being created.
+	RisingWaveUpgradePhaseProvisioning RisingWaveUpgradePhase = "Provisioning"
+
+	// RisingWaveUpgradePhaseWaitingForGreen means the green RisingWave is created, and it's either not running yet or
+	// not serving the reads of the verification.
+	RisingWaveUpgradePhaseWaitingForGreen RisingWaveUpgradePhase = "WaitingForGreen"
+
+	// RisingWaveUpgradePhaseSwitched means the alias Service points at the green RisingWave, and the upgrade waits
+	// to be confirmed or rolled back.
+	RisingWaveUpgradePhaseSwitched RisingWaveUpgradePhase = "Switched"
+
+	// RisingWaveUpgradePhaseCompleted means the upgrade is confirmed and the alias Service is handed over to the green
+	// RisingWave. The source RisingWave is kept.
+	RisingWaveUpgradePhaseCompleted RisingWaveUpgradePhase = "Completed"
+
+	// RisingWaveUpgradePhaseRolledBack means the alias Service points back at the source RisingWave, and the green
+	// RisingWave is deleted.
+	RisingWaveUpgradePhaseRolledBack RisingWaveUpgradePhase = "RolledBack"
+
+	// RisingWaveUpgradePhaseFailed means the upgrade can't proceed, e.g., the green RisingWave isn't running before
+	// the timeout. It can still be rolled back.
+	RisingWaveUpgradePhaseFailed RisingWaveUpgradePhase = "Failed"
+)
+
+// RisingWaveUpgradeDecision is the decision on a switched upgrade.
+// +kubebuilder:validation:Enum=Confirm;Rollback
+type RisingWaveUpgradeDecision string
+
+// All decisions of RisingWaveUpgrade.
+const (
+	// RisingWaveUpgradeDecisionConfirm confirms the upgrade once it's switched. The source RisingWave is never deleted
+	// by the upgrade, and it's up to the user to delete it.
+	RisingWaveUpgradeDecisionConfirm RisingWaveUpgradeDecision = "Confirm"
+
+	// RisingWaveUpgradeDecisionRollback points the alias Service back at the source RisingWave, and deletes the
+	// green RisingWave. It's allowed in any phase before the upgrade is completed.
+	RisingWaveUpgradeDecisionRollback RisingWaveUpgradeDecision = "Rollback"
+)
+
+// RisingWaveUpgradeService is the alias Service that clients connect to, which points at the frontend of either the
+// source or the green RisingWave.
+type RisingWaveUpgradeService struct {
+	// Name of the Service. Defaults to <risingwave>-stable.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Type of the Service. Defaults to ClusterIP.
+	// +optional
+	// +kubebuilder:default=ClusterIP
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type corev1.ServiceType `json:"type,omitempty"`
+}
+
+// RisingWaveUpgradeVerification is the query run against the frontend of the green RisingWave to verify that it serves
+// the reads.
+type RisingWaveUpgradeVerification struct {
+	// Query to run, e.g., a SELECT from a representative materialized view. Defaults to a query on the catalog.
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// Database to connect to. Defaults to dev.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Credentials of the user to run the query. Defaults to the user root without password.
+	// +optional
+	Credentials *RisingWaveDBCredentials `json:"credentials,omitempty"`
+}
+
+// RisingWaveUpgradeSpec is the spec of RisingWaveUpgrade.
+type RisingWaveUpgradeSpec struct {
+	// RisingWave is the name of the source RisingWave in the same namespace.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="risingwave is immutable"
+	RisingWave string `json:"risingwave"`
+
+	// Image of the green RisingWave. The images of the node groups are reset to it as well.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="image is immutable"
+	Image string `json:"image"`
+
+	// Green is the name of the green RisingWave. Defaults to <risingwave>-green.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="green is immutable"
+	Green string `json:"green,omitempty"`
+
+	// MetaStore of the green RisingWave, which must be an empty MySQL or PostgreSQL database. The green RisingWave is
+	// cloned from the source, i.e., a meta snapshot of the source is restored into it.
+	MetaStore RisingWaveMetaStoreBackend `json:"metaStore"`
+
+	// StateStoreDataDirectory is the fresh data directory of the green RisingWave in the state store, which the data
+	// of the source is copied into. Defaults to <the data directory of the source>-<green>.
+	// +optional
+	StateStoreDataDirectory string `json:"stateStoreDataDirectory,omitempty"`
+
+	// Verification of the reads from the green RisingWave before the alias Service is switched to it.
+	// +optional
+	Verification RisingWaveUpgradeVerification `json:"verification,omitempty"`
+
+	// Service is the alias Service switched from the source to the green RisingWave.
+	// +optional
+	Service RisingWaveUpgradeService `json:"service,omitempty"`
+
+	// Timeout of the green RisingWave to become running. The upgrade fails after it. Defaults to 1h.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Decision on the upgrade. Confirm takes effect once it's switched, while Rollback takes effect in any phase
+	// before it's completed.
+	// +optional
+	Decision RisingWaveUpgradeDecision `json:"decision,omitempty"`
+}
+
+// RisingWaveUpgradePhaseRecord is the record of a phase that the upgrade has entered.
+type RisingWaveUpgradePhaseRecord struct {
+	// Phase entered.
+	Phase RisingWaveUpgradePhase `json:"phase"`
+
+	// StartTime is when the phase is entered.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Message of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RisingWaveUpgradeStatus is the status of RisingWaveUpgrade.
+type RisingWaveUpgradeStatus struct {
+	// ObservedGeneration is the generation of the spec observed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase of the upgrade.
+	// +optional
+	Phase RisingWaveUpgradePhase `json:"phase,omitempty"`
+
+	// Message of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Green is the name of the green RisingWave.
+	// +optional
+	Green string `json:"green,omitempty"`
+
+	// Service is the name of the alias Service.
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// Active is the name of the RisingWave that the alias Service points at.
+	// +optional
+	Active string `json:"active,omitempty"`
+
+	// Phases entered, the earliest first.
+	// +optional
+	// +listType=atomic
+	Phases []RisingWaveUpgradePhaseRecord `json:"phases,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="RISINGWAVE",type=string,JSONPath=`.spec.risingwave`
+// +kubebuilder:printcolumn:name="GREEN",type=string,JSONPath=`.status.green`
+// +kubebuilder:printcolumn:name="IMAGE",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="ACTIVE",type=string,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=rwu,categories=all;streaming
+
+// RisingWaveUpgrade upgrades a RisingWave in the blue/green way. It clones the source RisingWave with a new image into
+// a green RisingWave, waits for it to become running, and switches an alias Service to its frontend. The source is
+// kept until the upgrade is confirmed or rolled back.
+type RisingWaveUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RisingWaveUpgradeSpec   `json:"spec,omitempty"`
+	Status RisingWaveUpgradeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RisingWaveUpgradeList contains a list of RisingWaveUpgrades.
+type RisingWaveUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RisingWaveUpgrade `json:"items"`
+}
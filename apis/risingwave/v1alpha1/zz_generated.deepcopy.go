@@ -22,8 +22,11 @@ package v1alpha1
 
 import (
 	"github.com/openkruise/kruise-api/apps/pub"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -31,6 +34,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentGroupReplicasStatus) DeepCopyInto(out *ComponentGroupReplicasStatus) {
 	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ComponentZoneReplicasStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentGroupReplicasStatus.
@@ -49,7 +57,9 @@ func (in *ComponentReplicasStatus) DeepCopyInto(out *ComponentReplicasStatus) {
 	if in.Groups != nil {
 		in, out := &in.Groups, &out.Groups
 		*out = make([]ComponentGroupReplicasStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -63,6 +73,21 @@ func (in *ComponentReplicasStatus) DeepCopy() *ComponentReplicasStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentZoneReplicasStatus) DeepCopyInto(out *ComponentZoneReplicasStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentZoneReplicasStatus.
+func (in *ComponentZoneReplicasStatus) DeepCopy() *ComponentZoneReplicasStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentZoneReplicasStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartialObjectMeta) DeepCopyInto(out *PartialObjectMeta) {
 	*out = *in
@@ -170,6 +195,41 @@ func (in *RisingWave) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveAlertThresholds) DeepCopyInto(out *RisingWaveAlertThresholds) {
+	*out = *in
+	if in.BarrierLatency != nil {
+		in, out := &in.BarrierLatency, &out.BarrierLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CompactionPendingBytes != nil {
+		in, out := &in.CompactionPendingBytes, &out.CompactionPendingBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MetaLeaderChanges != nil {
+		in, out := &in.MetaLeaderChanges, &out.MetaLeaderChanges
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodNotReady != nil {
+		in, out := &in.PodNotReady, &out.PodNotReady
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveAlertThresholds.
+func (in *RisingWaveAlertThresholds) DeepCopy() *RisingWaveAlertThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveAlertThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RisingWaveAliyunOSSCredentials) DeepCopyInto(out *RisingWaveAliyunOSSCredentials) {
 	*out = *in
@@ -205,6 +265,76 @@ func (in *RisingWaveAzureBlobCredentials) DeepCopy() *RisingWaveAzureBlobCredent
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveCloneFrom) DeepCopyInto(out *RisingWaveCloneFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveCloneFrom.
+func (in *RisingWaveCloneFrom) DeepCopy() *RisingWaveCloneFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveCloneFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveCloneLineage) DeepCopyInto(out *RisingWaveCloneLineage) {
+	*out = *in
+	if in.SnapshotID != nil {
+		in, out := &in.SnapshotID, &out.SnapshotID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveCloneLineage.
+func (in *RisingWaveCloneLineage) DeepCopy() *RisingWaveCloneLineage {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveCloneLineage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveCloneStatus) DeepCopyInto(out *RisingWaveCloneStatus) {
+	*out = *in
+	if in.Lineage != nil {
+		in, out := &in.Lineage, &out.Lineage
+		*out = make([]RisingWaveCloneLineage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackupJobID != nil {
+		in, out := &in.BackupJobID, &out.BackupJobID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveCloneStatus.
+func (in *RisingWaveCloneStatus) DeepCopy() *RisingWaveCloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveCloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RisingWaveComponent) DeepCopyInto(out *RisingWaveComponent) {
 	*out = *in
@@ -330,218 +460,237 @@ func (in *RisingWaveConfigurationSpec) DeepCopy() *RisingWaveConfigurationSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveDBCredentials) DeepCopyInto(out *RisingWaveDBCredentials) {
+func (in *RisingWaveDBCABundle) DeepCopyInto(out *RisingWaveDBCABundle) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveDBCredentials.
-func (in *RisingWaveDBCredentials) DeepCopy() *RisingWaveDBCredentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveDBCABundle.
+func (in *RisingWaveDBCABundle) DeepCopy() *RisingWaveDBCABundle {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveDBCredentials)
+	out := new(RisingWaveDBCABundle)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveEtcdCredentials) DeepCopyInto(out *RisingWaveEtcdCredentials) {
+func (in *RisingWaveDBClientCertificate) DeepCopyInto(out *RisingWaveDBClientCertificate) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveEtcdCredentials.
-func (in *RisingWaveEtcdCredentials) DeepCopy() *RisingWaveEtcdCredentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveDBClientCertificate.
+func (in *RisingWaveDBClientCertificate) DeepCopy() *RisingWaveDBClientCertificate {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveEtcdCredentials)
+	out := new(RisingWaveDBClientCertificate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveGCSCredentials) DeepCopyInto(out *RisingWaveGCSCredentials) {
+func (in *RisingWaveDBCredentials) DeepCopyInto(out *RisingWaveDBCredentials) {
 	*out = *in
-	if in.UseWorkloadIdentity != nil {
-		in, out := &in.UseWorkloadIdentity, &out.UseWorkloadIdentity
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveGCSCredentials.
-func (in *RisingWaveGCSCredentials) DeepCopy() *RisingWaveGCSCredentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveDBCredentials.
+func (in *RisingWaveDBCredentials) DeepCopy() *RisingWaveDBCredentials {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveGCSCredentials)
+	out := new(RisingWaveDBCredentials)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveGlobalReplicas) DeepCopyInto(out *RisingWaveGlobalReplicas) {
+func (in *RisingWaveDBTLS) DeepCopyInto(out *RisingWaveDBTLS) {
 	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(RisingWaveDBCABundle)
+		**out = **in
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(RisingWaveDBClientCertificate)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveGlobalReplicas.
-func (in *RisingWaveGlobalReplicas) DeepCopy() *RisingWaveGlobalReplicas {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveDBTLS.
+func (in *RisingWaveDBTLS) DeepCopy() *RisingWaveDBTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveGlobalReplicas)
+	out := new(RisingWaveDBTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveHuaweiCloudOBSCredentials) DeepCopyInto(out *RisingWaveHuaweiCloudOBSCredentials) {
+func (in *RisingWaveDataCleanupStatus) DeepCopyInto(out *RisingWaveDataCleanupStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveHuaweiCloudOBSCredentials.
-func (in *RisingWaveHuaweiCloudOBSCredentials) DeepCopy() *RisingWaveHuaweiCloudOBSCredentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveDataCleanupStatus.
+func (in *RisingWaveDataCleanupStatus) DeepCopy() *RisingWaveDataCleanupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveHuaweiCloudOBSCredentials)
+	out := new(RisingWaveDataCleanupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveInternalStatus) DeepCopyInto(out *RisingWaveInternalStatus) {
+func (in *RisingWaveEtcdCredentials) DeepCopyInto(out *RisingWaveEtcdCredentials) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveInternalStatus.
-func (in *RisingWaveInternalStatus) DeepCopy() *RisingWaveInternalStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveEtcdCredentials.
+func (in *RisingWaveEtcdCredentials) DeepCopy() *RisingWaveEtcdCredentials {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveInternalStatus)
+	out := new(RisingWaveEtcdCredentials)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveLicenseKey) DeepCopyInto(out *RisingWaveLicenseKey) {
+func (in *RisingWaveExternalAccess) DeepCopyInto(out *RisingWaveExternalAccess) {
 	*out = *in
-	if in.PassAsFile != nil {
-		in, out := &in.PassAsFile, &out.PassAsFile
-		*out = new(bool)
-		**out = **in
+	if in.Dashboard != nil {
+		in, out := &in.Dashboard, &out.Dashboard
+		*out = new(RisingWaveExternalEndpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(RisingWaveExternalEndpoint)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveLicenseKey.
-func (in *RisingWaveLicenseKey) DeepCopy() *RisingWaveLicenseKey {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveExternalAccess.
+func (in *RisingWaveExternalAccess) DeepCopy() *RisingWaveExternalAccess {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveLicenseKey)
+	out := new(RisingWaveExternalAccess)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveList) DeepCopyInto(out *RisingWaveList) {
+func (in *RisingWaveExternalEndpoint) DeepCopyInto(out *RisingWaveExternalEndpoint) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]RisingWave, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(RisingWaveIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(RisingWaveHTTPRoute)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveList.
-func (in *RisingWaveList) DeepCopy() *RisingWaveList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveExternalEndpoint.
+func (in *RisingWaveExternalEndpoint) DeepCopy() *RisingWaveExternalEndpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveList)
+	out := new(RisingWaveExternalEndpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RisingWaveList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveExternalEndpointStatus) DeepCopyInto(out *RisingWaveExternalEndpointStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveExternalEndpointStatus.
+func (in *RisingWaveExternalEndpointStatus) DeepCopy() *RisingWaveExternalEndpointStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(RisingWaveExternalEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMetaStoreBackend) DeepCopyInto(out *RisingWaveMetaStoreBackend) {
+func (in *RisingWaveGCSCredentials) DeepCopyInto(out *RisingWaveGCSCredentials) {
 	*out = *in
-	if in.Memory != nil {
-		in, out := &in.Memory, &out.Memory
+	if in.UseWorkloadIdentity != nil {
+		in, out := &in.UseWorkloadIdentity, &out.UseWorkloadIdentity
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Etcd != nil {
-		in, out := &in.Etcd, &out.Etcd
-		*out = new(RisingWaveMetaStoreBackendEtcd)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SQLite != nil {
-		in, out := &in.SQLite, &out.SQLite
-		*out = new(RisingWaveMetaStoreBackendSQLite)
-		**out = **in
-	}
-	if in.MySQL != nil {
-		in, out := &in.MySQL, &out.MySQL
-		*out = new(RisingWaveMetaStoreBackendMySQL)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PostgreSQL != nil {
-		in, out := &in.PostgreSQL, &out.PostgreSQL
-		*out = new(RisingWaveMetaStoreBackendPostgreSQL)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackend.
-func (in *RisingWaveMetaStoreBackend) DeepCopy() *RisingWaveMetaStoreBackend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveGCSCredentials.
+func (in *RisingWaveGCSCredentials) DeepCopy() *RisingWaveGCSCredentials {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMetaStoreBackend)
+	out := new(RisingWaveGCSCredentials)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMetaStoreBackendEtcd) DeepCopyInto(out *RisingWaveMetaStoreBackendEtcd) {
+func (in *RisingWaveGatewayReference) DeepCopyInto(out *RisingWaveGatewayReference) {
 	*out = *in
-	if in.RisingWaveEtcdCredentials != nil {
-		in, out := &in.RisingWaveEtcdCredentials, &out.RisingWaveEtcdCredentials
-		*out = new(RisingWaveEtcdCredentials)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveGatewayReference.
+func (in *RisingWaveGatewayReference) DeepCopy() *RisingWaveGatewayReference {
+	if in == nil {
+		return nil
 	}
+	out := new(RisingWaveGatewayReference)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendEtcd.
-func (in *RisingWaveMetaStoreBackendEtcd) DeepCopy() *RisingWaveMetaStoreBackendEtcd {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveGlobalReplicas) DeepCopyInto(out *RisingWaveGlobalReplicas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveGlobalReplicas.
+func (in *RisingWaveGlobalReplicas) DeepCopy() *RisingWaveGlobalReplicas {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMetaStoreBackendEtcd)
+	out := new(RisingWaveGlobalReplicas)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMetaStoreBackendMySQL) DeepCopyInto(out *RisingWaveMetaStoreBackendMySQL) {
+func (in *RisingWaveGrafanaDashboard) DeepCopyInto(out *RisingWaveGrafanaDashboard) {
 	*out = *in
-	out.RisingWaveDBCredentials = in.RisingWaveDBCredentials
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -549,22 +698,26 @@ func (in *RisingWaveMetaStoreBackendMySQL) DeepCopyInto(out *RisingWaveMetaStore
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendMySQL.
-func (in *RisingWaveMetaStoreBackendMySQL) DeepCopy() *RisingWaveMetaStoreBackendMySQL {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveGrafanaDashboard.
+func (in *RisingWaveGrafanaDashboard) DeepCopy() *RisingWaveGrafanaDashboard {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMetaStoreBackendMySQL)
+	out := new(RisingWaveGrafanaDashboard)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMetaStoreBackendPostgreSQL) DeepCopyInto(out *RisingWaveMetaStoreBackendPostgreSQL) {
+func (in *RisingWaveHTTPRoute) DeepCopyInto(out *RisingWaveHTTPRoute) {
 	*out = *in
-	out.RisingWaveDBCredentials = in.RisingWaveDBCredentials
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]RisingWaveGatewayReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -572,33 +725,476 @@ func (in *RisingWaveMetaStoreBackendPostgreSQL) DeepCopyInto(out *RisingWaveMeta
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendPostgreSQL.
-func (in *RisingWaveMetaStoreBackendPostgreSQL) DeepCopy() *RisingWaveMetaStoreBackendPostgreSQL {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveHTTPRoute.
+func (in *RisingWaveHTTPRoute) DeepCopy() *RisingWaveHTTPRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMetaStoreBackendPostgreSQL)
+	out := new(RisingWaveHTTPRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMetaStoreBackendSQLite) DeepCopyInto(out *RisingWaveMetaStoreBackendSQLite) {
+func (in *RisingWaveHealthIssue) DeepCopyInto(out *RisingWaveHealthIssue) {
 	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendSQLite.
-func (in *RisingWaveMetaStoreBackendSQLite) DeepCopy() *RisingWaveMetaStoreBackendSQLite {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveHealthIssue.
+func (in *RisingWaveHealthIssue) DeepCopy() *RisingWaveHealthIssue {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMetaStoreBackendSQLite)
+	out := new(RisingWaveHealthIssue)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMetaStoreStatus) DeepCopyInto(out *RisingWaveMetaStoreStatus) {
+func (in *RisingWaveHuaweiCloudOBSCredentials) DeepCopyInto(out *RisingWaveHuaweiCloudOBSCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveHuaweiCloudOBSCredentials.
+func (in *RisingWaveHuaweiCloudOBSCredentials) DeepCopy() *RisingWaveHuaweiCloudOBSCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveHuaweiCloudOBSCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveIngress) DeepCopyInto(out *RisingWaveIngress) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveIngress.
+func (in *RisingWaveIngress) DeepCopy() *RisingWaveIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveInternalStatus) DeepCopyInto(out *RisingWaveInternalStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveInternalStatus.
+func (in *RisingWaveInternalStatus) DeepCopy() *RisingWaveInternalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveInternalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveLicenseKey) DeepCopyInto(out *RisingWaveLicenseKey) {
+	*out = *in
+	if in.PassAsFile != nil {
+		in, out := &in.PassAsFile, &out.PassAsFile
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveLicenseKey.
+func (in *RisingWaveLicenseKey) DeepCopy() *RisingWaveLicenseKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveLicenseKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveList) DeepCopyInto(out *RisingWaveList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RisingWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveList.
+func (in *RisingWaveList) DeepCopy() *RisingWaveList {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMaintenanceWindow) DeepCopyInto(out *RisingWaveMaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMaintenanceWindow.
+func (in *RisingWaveMaintenanceWindow) DeepCopy() *RisingWaveMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveManagedStoreStorage) DeepCopyInto(out *RisingWaveManagedStoreStorage) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveManagedStoreStorage.
+func (in *RisingWaveManagedStoreStorage) DeepCopy() *RisingWaveManagedStoreStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveManagedStoreStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreBackend) DeepCopyInto(out *RisingWaveMetaStoreBackend) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(RisingWaveMetaStoreBackendEtcd)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQLite != nil {
+		in, out := &in.SQLite, &out.SQLite
+		*out = new(RisingWaveMetaStoreBackendSQLite)
+		**out = **in
+	}
+	if in.MySQL != nil {
+		in, out := &in.MySQL, &out.MySQL
+		*out = new(RisingWaveMetaStoreBackendMySQL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(RisingWaveMetaStoreBackendPostgreSQL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedPostgreSQL != nil {
+		in, out := &in.ManagedPostgreSQL, &out.ManagedPostgreSQL
+		*out = new(RisingWaveMetaStoreBackendManagedPostgreSQL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackend.
+func (in *RisingWaveMetaStoreBackend) DeepCopy() *RisingWaveMetaStoreBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreBackendEtcd) DeepCopyInto(out *RisingWaveMetaStoreBackendEtcd) {
+	*out = *in
+	if in.RisingWaveEtcdCredentials != nil {
+		in, out := &in.RisingWaveEtcdCredentials, &out.RisingWaveEtcdCredentials
+		*out = new(RisingWaveEtcdCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendEtcd.
+func (in *RisingWaveMetaStoreBackendEtcd) DeepCopy() *RisingWaveMetaStoreBackendEtcd {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreBackendEtcd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreBackendManagedPostgreSQL) DeepCopyInto(out *RisingWaveMetaStoreBackendManagedPostgreSQL) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendManagedPostgreSQL.
+func (in *RisingWaveMetaStoreBackendManagedPostgreSQL) DeepCopy() *RisingWaveMetaStoreBackendManagedPostgreSQL {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreBackendManagedPostgreSQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreBackendMySQL) DeepCopyInto(out *RisingWaveMetaStoreBackendMySQL) {
+	*out = *in
+	out.RisingWaveDBCredentials = in.RisingWaveDBCredentials
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RisingWaveDBTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendMySQL.
+func (in *RisingWaveMetaStoreBackendMySQL) DeepCopy() *RisingWaveMetaStoreBackendMySQL {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreBackendMySQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreBackendPostgreSQL) DeepCopyInto(out *RisingWaveMetaStoreBackendPostgreSQL) {
+	*out = *in
+	out.RisingWaveDBCredentials = in.RisingWaveDBCredentials
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RisingWaveDBTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendPostgreSQL.
+func (in *RisingWaveMetaStoreBackendPostgreSQL) DeepCopy() *RisingWaveMetaStoreBackendPostgreSQL {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreBackendPostgreSQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreBackendSQLite) DeepCopyInto(out *RisingWaveMetaStoreBackendSQLite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreBackendSQLite.
+func (in *RisingWaveMetaStoreBackendSQLite) DeepCopy() *RisingWaveMetaStoreBackendSQLite {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreBackendSQLite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreMigration) DeepCopyInto(out *RisingWaveMetaStoreMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreMigration.
+func (in *RisingWaveMetaStoreMigration) DeepCopy() *RisingWaveMetaStoreMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveMetaStoreMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreMigrationList) DeepCopyInto(out *RisingWaveMetaStoreMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RisingWaveMetaStoreMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreMigrationList.
+func (in *RisingWaveMetaStoreMigrationList) DeepCopy() *RisingWaveMetaStoreMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveMetaStoreMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreMigrationPhaseRecord) DeepCopyInto(out *RisingWaveMetaStoreMigrationPhaseRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreMigrationPhaseRecord.
+func (in *RisingWaveMetaStoreMigrationPhaseRecord) DeepCopy() *RisingWaveMetaStoreMigrationPhaseRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreMigrationPhaseRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreMigrationRollbackPoint) DeepCopyInto(out *RisingWaveMetaStoreMigrationRollbackPoint) {
+	*out = *in
+	in.MetaStore.DeepCopyInto(&out.MetaStore)
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreMigrationRollbackPoint.
+func (in *RisingWaveMetaStoreMigrationRollbackPoint) DeepCopy() *RisingWaveMetaStoreMigrationRollbackPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreMigrationRollbackPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreMigrationSpec) DeepCopyInto(out *RisingWaveMetaStoreMigrationSpec) {
+	*out = *in
+	in.MetaStore.DeepCopyInto(&out.MetaStore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreMigrationSpec.
+func (in *RisingWaveMetaStoreMigrationSpec) DeepCopy() *RisingWaveMetaStoreMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreMigrationStatus) DeepCopyInto(out *RisingWaveMetaStoreMigrationStatus) {
+	*out = *in
+	if in.RollbackPoint != nil {
+		in, out := &in.RollbackPoint, &out.RollbackPoint
+		*out = new(RisingWaveMetaStoreMigrationRollbackPoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]RisingWaveMetaStoreMigrationPhaseRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetaStoreMigrationStatus.
+func (in *RisingWaveMetaStoreMigrationStatus) DeepCopy() *RisingWaveMetaStoreMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetaStoreMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetaStoreStatus) DeepCopyInto(out *RisingWaveMetaStoreStatus) {
 	*out = *in
 }
 
@@ -607,400 +1203,963 @@ func (in *RisingWaveMetaStoreStatus) DeepCopy() *RisingWaveMetaStoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMetaStoreStatus)
+	out := new(RisingWaveMetaStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMetricsEndpoint) DeepCopyInto(out *RisingWaveMetricsEndpoint) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScrapeTimeout != nil {
+		in, out := &in.ScrapeTimeout, &out.ScrapeTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMetricsEndpoint.
+func (in *RisingWaveMetricsEndpoint) DeepCopy() *RisingWaveMetricsEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMetricsEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMinIOCredentials) DeepCopyInto(out *RisingWaveMinIOCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMinIOCredentials.
+func (in *RisingWaveMinIOCredentials) DeepCopy() *RisingWaveMinIOCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMinIOCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveMonitoring) DeepCopyInto(out *RisingWaveMonitoring) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScrapeTimeout != nil {
+		in, out := &in.ScrapeTimeout, &out.ScrapeTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KeepHighCardinalityMetrics != nil {
+		in, out := &in.KeepHighCardinalityMetrics, &out.KeepHighCardinalityMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalEndpoints != nil {
+		in, out := &in.AdditionalEndpoints, &out.AdditionalEndpoints
+		*out = make([]RisingWaveMetricsEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrometheusRule != nil {
+		in, out := &in.PrometheusRule, &out.PrometheusRule
+		*out = new(RisingWavePrometheusRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrafanaDashboard != nil {
+		in, out := &in.GrafanaDashboard, &out.GrafanaDashboard
+		*out = new(RisingWaveGrafanaDashboard)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMonitoring.
+func (in *RisingWaveMonitoring) DeepCopy() *RisingWaveMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNetworkPolicy) DeepCopyInto(out *RisingWaveNetworkPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FrontendClients != nil {
+		in, out := &in.FrontendClients, &out.FrontendClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HTTPClients != nil {
+		in, out := &in.HTTPClients, &out.HTTPClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNetworkPolicy.
+func (in *RisingWaveNetworkPolicy) DeepCopy() *RisingWaveNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeConfiguration) DeepCopyInto(out *RisingWaveNodeConfiguration) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(RisingWaveNodeConfigurationConfigMapSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(RisingWaveNodeConfigurationSecretSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeConfiguration.
+func (in *RisingWaveNodeConfiguration) DeepCopy() *RisingWaveNodeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeConfigurationConfigMapSource) DeepCopyInto(out *RisingWaveNodeConfigurationConfigMapSource) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeConfigurationConfigMapSource.
+func (in *RisingWaveNodeConfigurationConfigMapSource) DeepCopy() *RisingWaveNodeConfigurationConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeConfigurationConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeConfigurationSecretSource) DeepCopyInto(out *RisingWaveNodeConfigurationSecretSource) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeConfigurationSecretSource.
+func (in *RisingWaveNodeConfigurationSecretSource) DeepCopy() *RisingWaveNodeConfigurationSecretSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeConfigurationSecretSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeContainer) DeepCopyInto(out *RisingWaveNodeContainer) {
+	*out = *in
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeDevices != nil {
+		in, out := &in.VolumeDevices, &out.VolumeDevices
+		*out = make([]corev1.VolumeDevice, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeContainer.
+func (in *RisingWaveNodeContainer) DeepCopy() *RisingWaveNodeContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeGroup) DeepCopyInto(out *RisingWaveNodeGroup) {
+	*out = *in
+	if in.RestartAt != nil {
+		in, out := &in.RestartAt, &out.RestartAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = new(RisingWaveNodeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceRecommendation != nil {
+		in, out := &in.ResourceRecommendation, &out.ResourceRecommendation
+		*out = new(RisingWaveResourceRecommendationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = new(RisingWaveNodeGroupZones)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroup.
+func (in *RisingWaveNodeGroup) DeepCopy() *RisingWaveNodeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeGroupRollingUpdate) DeepCopyInto(out *RisingWaveNodeGroupRollingUpdate) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupRollingUpdate.
+func (in *RisingWaveNodeGroupRollingUpdate) DeepCopy() *RisingWaveNodeGroupRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeGroupRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeGroupStatus) DeepCopyInto(out *RisingWaveNodeGroupStatus) {
+	*out = *in
+	out.WorkloadReplicaStatus = in.WorkloadReplicaStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupStatus.
+func (in *RisingWaveNodeGroupStatus) DeepCopy() *RisingWaveNodeGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeGroupUpgradeStrategy) DeepCopyInto(out *RisingWaveNodeGroupUpgradeStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RisingWaveNodeGroupRollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InPlaceUpdateStrategy != nil {
+		in, out := &in.InPlaceUpdateStrategy, &out.InPlaceUpdateStrategy
+		*out = new(pub.InPlaceUpdateStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupUpgradeStrategy.
+func (in *RisingWaveNodeGroupUpgradeStrategy) DeepCopy() *RisingWaveNodeGroupUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeGroupUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodeGroupZones) DeepCopyInto(out *RisingWaveNodeGroupZones) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupZones.
+func (in *RisingWaveNodeGroupZones) DeepCopy() *RisingWaveNodeGroupZones {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodeGroupZones)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodePodTemplate) DeepCopyInto(out *RisingWaveNodePodTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodePodTemplate.
+func (in *RisingWaveNodePodTemplate) DeepCopy() *RisingWaveNodePodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodePodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNodePodTemplateSpec) DeepCopyInto(out *RisingWaveNodePodTemplateSpec) {
+	*out = *in
+	in.RisingWaveNodeContainer.DeepCopyInto(&out.RisingWaveNodeContainer)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ShareProcessNamespace != nil {
+		in, out := &in.ShareProcessNamespace, &out.ShareProcessNamespace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreemptionPolicy != nil {
+		in, out := &in.PreemptionPolicy, &out.PreemptionPolicy
+		*out = new(corev1.PreemptionPolicy)
+		**out = **in
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SetHostnameAsFQDN != nil {
+		in, out := &in.SetHostnameAsFQDN, &out.SetHostnameAsFQDN
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OS != nil {
+		in, out := &in.OS, &out.OS
+		*out = new(corev1.PodOS)
+		**out = **in
+	}
+	if in.HostUsers != nil {
+		in, out := &in.HostUsers, &out.HostUsers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalContainers != nil {
+		in, out := &in.AdditionalContainers, &out.AdditionalContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodePodTemplateSpec.
+func (in *RisingWaveNodePodTemplateSpec) DeepCopy() *RisingWaveNodePodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNodePodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNotification) DeepCopyInto(out *RisingWaveNotification) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotification.
+func (in *RisingWaveNotification) DeepCopy() *RisingWaveNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveNotification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNotificationDelivery) DeepCopyInto(out *RisingWaveNotificationDelivery) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationDelivery.
+func (in *RisingWaveNotificationDelivery) DeepCopy() *RisingWaveNotificationDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNotificationDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveNotificationHeadersSource) DeepCopyInto(out *RisingWaveNotificationHeadersSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationHeadersSource.
+func (in *RisingWaveNotificationHeadersSource) DeepCopy() *RisingWaveNotificationHeadersSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveNotificationHeadersSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveMinIOCredentials) DeepCopyInto(out *RisingWaveMinIOCredentials) {
+func (in *RisingWaveNotificationList) DeepCopyInto(out *RisingWaveNotificationList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RisingWaveNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveMinIOCredentials.
-func (in *RisingWaveMinIOCredentials) DeepCopy() *RisingWaveMinIOCredentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationList.
+func (in *RisingWaveNotificationList) DeepCopy() *RisingWaveNotificationList {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveMinIOCredentials)
+	out := new(RisingWaveNotificationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveNotificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeConfiguration) DeepCopyInto(out *RisingWaveNodeConfiguration) {
+func (in *RisingWaveNotificationRetry) DeepCopyInto(out *RisingWaveNotificationRetry) {
 	*out = *in
-	if in.ConfigMap != nil {
-		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(RisingWaveNodeConfigurationConfigMapSource)
-		(*in).DeepCopyInto(*out)
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(RisingWaveNodeConfigurationSecretSource)
-		(*in).DeepCopyInto(*out)
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeConfiguration.
-func (in *RisingWaveNodeConfiguration) DeepCopy() *RisingWaveNodeConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationRetry.
+func (in *RisingWaveNotificationRetry) DeepCopy() *RisingWaveNotificationRetry {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeConfiguration)
+	out := new(RisingWaveNotificationRetry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeConfigurationConfigMapSource) DeepCopyInto(out *RisingWaveNodeConfigurationConfigMapSource) {
+func (in *RisingWaveNotificationSink) DeepCopyInto(out *RisingWaveNotificationSink) {
 	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
-		*out = new(bool)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = new(RisingWaveNotificationHeadersSource)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeConfigurationConfigMapSource.
-func (in *RisingWaveNodeConfigurationConfigMapSource) DeepCopy() *RisingWaveNodeConfigurationConfigMapSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationSink.
+func (in *RisingWaveNotificationSink) DeepCopy() *RisingWaveNotificationSink {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeConfigurationConfigMapSource)
+	out := new(RisingWaveNotificationSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeConfigurationSecretSource) DeepCopyInto(out *RisingWaveNodeConfigurationSecretSource) {
+func (in *RisingWaveNotificationSpec) DeepCopyInto(out *RisingWaveNotificationSpec) {
 	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]RisingWaveNotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	in.Sink.DeepCopyInto(&out.Sink)
+	in.Retry.DeepCopyInto(&out.Retry)
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
 		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeConfigurationSecretSource.
-func (in *RisingWaveNodeConfigurationSecretSource) DeepCopy() *RisingWaveNodeConfigurationSecretSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationSpec.
+func (in *RisingWaveNotificationSpec) DeepCopy() *RisingWaveNotificationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeConfigurationSecretSource)
+	out := new(RisingWaveNotificationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeContainer) DeepCopyInto(out *RisingWaveNodeContainer) {
+func (in *RisingWaveNotificationStatus) DeepCopyInto(out *RisingWaveNotificationStatus) {
 	*out = *in
-	if in.EnvFrom != nil {
-		in, out := &in.EnvFrom, &out.EnvFrom
-		*out = make([]v1.EnvFromSource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VolumeDevices != nil {
-		in, out := &in.VolumeDevices, &out.VolumeDevices
-		*out = make([]v1.VolumeDevice, len(*in))
-		copy(*out, *in)
+	if in.LastDelivery != nil {
+		in, out := &in.LastDelivery, &out.LastDelivery
+		*out = new(RisingWaveNotificationDelivery)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(RisingWaveNotificationDelivery)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeContainer.
-func (in *RisingWaveNodeContainer) DeepCopy() *RisingWaveNodeContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNotificationStatus.
+func (in *RisingWaveNotificationStatus) DeepCopy() *RisingWaveNotificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeContainer)
+	out := new(RisingWaveNotificationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeGroup) DeepCopyInto(out *RisingWaveNodeGroup) {
+func (in *RisingWavePrometheusRule) DeepCopyInto(out *RisingWavePrometheusRule) {
 	*out = *in
-	if in.RestartAt != nil {
-		in, out := &in.RestartAt, &out.RestartAt
-		*out = (*in).DeepCopy()
-	}
-	if in.Configuration != nil {
-		in, out := &in.Configuration, &out.Configuration
-		*out = new(RisingWaveNodeConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
 	}
-	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
-	if in.VolumeClaimTemplates != nil {
-		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]PersistentVolumeClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.PersistentVolumeClaimRetentionPolicy != nil {
-		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
-		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
-		**out = **in
+	if in.AlertLabels != nil {
+		in, out := &in.AlertLabels, &out.AlertLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.ProgressDeadlineSeconds != nil {
-		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
-		*out = new(int32)
-		**out = **in
+	if in.DisabledAlerts != nil {
+		in, out := &in.DisabledAlerts, &out.DisabledAlerts
+		*out = make([]RisingWaveAlert, len(*in))
+		copy(*out, *in)
 	}
-	in.Template.DeepCopyInto(&out.Template)
+	in.Thresholds.DeepCopyInto(&out.Thresholds)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroup.
-func (in *RisingWaveNodeGroup) DeepCopy() *RisingWaveNodeGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWavePrometheusRule.
+func (in *RisingWavePrometheusRule) DeepCopy() *RisingWavePrometheusRule {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeGroup)
+	out := new(RisingWavePrometheusRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeGroupRollingUpdate) DeepCopyInto(out *RisingWaveNodeGroupRollingUpdate) {
+func (in *RisingWaveRemediationIntervention) DeepCopyInto(out *RisingWaveRemediationIntervention) {
 	*out = *in
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.Partition != nil {
-		in, out := &in.Partition, &out.Partition
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.MaxSurge != nil {
-		in, out := &in.MaxSurge, &out.MaxSurge
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupRollingUpdate.
-func (in *RisingWaveNodeGroupRollingUpdate) DeepCopy() *RisingWaveNodeGroupRollingUpdate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveRemediationIntervention.
+func (in *RisingWaveRemediationIntervention) DeepCopy() *RisingWaveRemediationIntervention {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeGroupRollingUpdate)
+	out := new(RisingWaveRemediationIntervention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeGroupStatus) DeepCopyInto(out *RisingWaveNodeGroupStatus) {
+func (in *RisingWaveRemediationPolicy) DeepCopyInto(out *RisingWaveRemediationPolicy) {
 	*out = *in
-	out.WorkloadReplicaStatus = in.WorkloadReplicaStatus
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StuckThreshold != nil {
+		in, out := &in.StuckThreshold, &out.StuckThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInterventions != nil {
+		in, out := &in.MaxInterventions, &out.MaxInterventions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BudgetWindow != nil {
+		in, out := &in.BudgetWindow, &out.BudgetWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupStatus.
-func (in *RisingWaveNodeGroupStatus) DeepCopy() *RisingWaveNodeGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveRemediationPolicy.
+func (in *RisingWaveRemediationPolicy) DeepCopy() *RisingWaveRemediationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeGroupStatus)
+	out := new(RisingWaveRemediationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodeGroupUpgradeStrategy) DeepCopyInto(out *RisingWaveNodeGroupUpgradeStrategy) {
+func (in *RisingWaveRemediationStatus) DeepCopyInto(out *RisingWaveRemediationStatus) {
 	*out = *in
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(RisingWaveNodeGroupRollingUpdate)
-		(*in).DeepCopyInto(*out)
+	if in.Interventions != nil {
+		in, out := &in.Interventions, &out.Interventions
+		*out = make([]RisingWaveRemediationIntervention, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.InPlaceUpdateStrategy != nil {
-		in, out := &in.InPlaceUpdateStrategy, &out.InPlaceUpdateStrategy
-		*out = new(pub.InPlaceUpdateStrategy)
-		**out = **in
+	if in.Withheld != nil {
+		in, out := &in.Withheld, &out.Withheld
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodeGroupUpgradeStrategy.
-func (in *RisingWaveNodeGroupUpgradeStrategy) DeepCopy() *RisingWaveNodeGroupUpgradeStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveRemediationStatus.
+func (in *RisingWaveRemediationStatus) DeepCopy() *RisingWaveRemediationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodeGroupUpgradeStrategy)
+	out := new(RisingWaveRemediationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodePodTemplate) DeepCopyInto(out *RisingWaveNodePodTemplate) {
+func (in *RisingWaveResourceGroupStatus) DeepCopyInto(out *RisingWaveResourceGroupStatus) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodePodTemplate.
-func (in *RisingWaveNodePodTemplate) DeepCopy() *RisingWaveNodePodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveResourceGroupStatus.
+func (in *RisingWaveResourceGroupStatus) DeepCopy() *RisingWaveResourceGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodePodTemplate)
+	out := new(RisingWaveResourceGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RisingWaveNodePodTemplateSpec) DeepCopyInto(out *RisingWaveNodePodTemplateSpec) {
+func (in *RisingWaveResourceRecommendation) DeepCopyInto(out *RisingWaveResourceRecommendation) {
 	*out = *in
-	in.RisingWaveNodeContainer.DeepCopyInto(&out.RisingWaveNodeContainer)
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ActiveDeadlineSeconds != nil {
-		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
-		*out = new(int64)
-		**out = **in
-	}
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
-		**out = **in
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.AutomountServiceAccountToken != nil {
-		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ShareProcessNamespace != nil {
-		in, out := &in.ShareProcessNamespace, &out.ShareProcessNamespace
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.HostAliases != nil {
-		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]v1.HostAlias, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Priority != nil {
-		in, out := &in.Priority, &out.Priority
-		*out = new(int32)
-		**out = **in
-	}
-	if in.DNSConfig != nil {
-		in, out := &in.DNSConfig, &out.DNSConfig
-		*out = new(v1.PodDNSConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
-	}
-	if in.PreemptionPolicy != nil {
-		in, out := &in.PreemptionPolicy, &out.PreemptionPolicy
-		*out = new(v1.PreemptionPolicy)
-		**out = **in
-	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.SetHostnameAsFQDN != nil {
-		in, out := &in.SetHostnameAsFQDN, &out.SetHostnameAsFQDN
-		*out = new(bool)
-		**out = **in
+	if in.PeakUsage != nil {
+		in, out := &in.PeakUsage, &out.PeakUsage
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
 	}
-	if in.OS != nil {
-		in, out := &in.OS, &out.OS
-		*out = new(v1.PodOS)
-		**out = **in
+	if in.LastSampleTime != nil {
+		in, out := &in.LastSampleTime, &out.LastSampleTime
+		*out = (*in).DeepCopy()
 	}
-	if in.HostUsers != nil {
-		in, out := &in.HostUsers, &out.HostUsers
-		*out = new(bool)
-		**out = **in
+	if in.LastOOMTime != nil {
+		in, out := &in.LastOOMTime, &out.LastOOMTime
+		*out = (*in).DeepCopy()
 	}
-	if in.AdditionalContainers != nil {
-		in, out := &in.AdditionalContainers, &out.AdditionalContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveResourceRecommendation.
+func (in *RisingWaveResourceRecommendation) DeepCopy() *RisingWaveResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveResourceRecommendationPolicy) DeepCopyInto(out *RisingWaveResourceRecommendationPolicy) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveNodePodTemplateSpec.
-func (in *RisingWaveNodePodTemplateSpec) DeepCopy() *RisingWaveNodePodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveResourceRecommendationPolicy.
+func (in *RisingWaveResourceRecommendationPolicy) DeepCopy() *RisingWaveResourceRecommendationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RisingWaveNodePodTemplateSpec)
+	out := new(RisingWaveResourceRecommendationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1318,6 +2477,11 @@ func (in *RisingWaveSpec) DeepCopyInto(out *RisingWaveSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnablePreflightChecks != nil {
+		in, out := &in.EnablePreflightChecks, &out.EnablePreflightChecks
+		*out = new(bool)
+		**out = **in
+	}
 	in.AdditionalFrontendServiceMetadata.DeepCopyInto(&out.AdditionalFrontendServiceMetadata)
 	in.AdditionalMetaServiceMetadata.DeepCopyInto(&out.AdditionalMetaServiceMetadata)
 	in.MetaStore.DeepCopyInto(&out.MetaStore)
@@ -1333,6 +2497,51 @@ func (in *RisingWaveSpec) DeepCopyInto(out *RisingWaveSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.SecretStore.DeepCopyInto(&out.SecretStore)
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(RisingWaveNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalAccess != nil {
+		in, out := &in.ExternalAccess, &out.ExternalAccess
+		*out = new(RisingWaveExternalAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(RisingWaveMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RisingWaveRemediationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]RisingWaveMaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.StandaloneMigration != nil {
+		in, out := &in.StandaloneMigration, &out.StandaloneMigration
+		*out = new(RisingWaveStandaloneMigration)
+		**out = **in
+	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FrontendDrainTimeout != nil {
+		in, out := &in.FrontendDrainTimeout, &out.FrontendDrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CloneFrom != nil {
+		in, out := &in.CloneFrom, &out.CloneFrom
+		*out = new(RisingWaveCloneFrom)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveSpec.
@@ -1388,6 +2597,44 @@ func (in *RisingWaveStandaloneComponent) DeepCopy() *RisingWaveStandaloneCompone
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveStandaloneMigration) DeepCopyInto(out *RisingWaveStandaloneMigration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveStandaloneMigration.
+func (in *RisingWaveStandaloneMigration) DeepCopy() *RisingWaveStandaloneMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveStandaloneMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveStandaloneMigrationStatus) DeepCopyInto(out *RisingWaveStandaloneMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveStandaloneMigrationStatus.
+func (in *RisingWaveStandaloneMigrationStatus) DeepCopy() *RisingWaveStandaloneMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveStandaloneMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RisingWaveStateStoreBackend) DeepCopyInto(out *RisingWaveStateStoreBackend) {
 	*out = *in
@@ -1406,6 +2653,11 @@ func (in *RisingWaveStateStoreBackend) DeepCopyInto(out *RisingWaveStateStoreBac
 		*out = new(RisingWaveStateStoreBackendMinIO)
 		**out = **in
 	}
+	if in.ManagedMinIO != nil {
+		in, out := &in.ManagedMinIO, &out.ManagedMinIO
+		*out = new(RisingWaveStateStoreBackendManagedMinIO)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.S3 != nil {
 		in, out := &in.S3, &out.S3
 		*out = new(RisingWaveStateStoreBackendS3)
@@ -1547,6 +2799,23 @@ func (in *RisingWaveStateStoreBackendLocalDisk) DeepCopy() *RisingWaveStateStore
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveStateStoreBackendManagedMinIO) DeepCopyInto(out *RisingWaveStateStoreBackendManagedMinIO) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveStateStoreBackendManagedMinIO.
+func (in *RisingWaveStateStoreBackendManagedMinIO) DeepCopy() *RisingWaveStateStoreBackendManagedMinIO {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveStateStoreBackendManagedMinIO)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RisingWaveStateStoreBackendMinIO) DeepCopyInto(out *RisingWaveStateStoreBackendMinIO) {
 	*out = *in
@@ -1613,6 +2882,57 @@ func (in *RisingWaveStatus) DeepCopyInto(out *RisingWaveStatus) {
 		}
 	}
 	out.Internal = in.Internal
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = make([]RisingWaveHealthIssue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RisingWaveRemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = make([]RisingWaveResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceGroups != nil {
+		in, out := &in.ResourceGroups, &out.ResourceGroups
+		*out = make([]RisingWaveResourceGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopologyZones != nil {
+		in, out := &in.TopologyZones, &out.TopologyZones
+		*out = make([]RisingWaveTopologyZones, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalEndpoints != nil {
+		in, out := &in.ExternalEndpoints, &out.ExternalEndpoints
+		*out = make([]RisingWaveExternalEndpointStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataCleanup != nil {
+		in, out := &in.DataCleanup, &out.DataCleanup
+		*out = new(RisingWaveDataCleanupStatus)
+		**out = **in
+	}
+	if in.StandaloneMigration != nil {
+		in, out := &in.StandaloneMigration, &out.StandaloneMigration
+		*out = new(RisingWaveStandaloneMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(RisingWaveCloneStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	out.MetaStore = in.MetaStore
 	out.StateStore = in.StateStore
 }
@@ -1642,6 +2962,181 @@ func (in *RisingWaveTLSConfiguration) DeepCopy() *RisingWaveTLSConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveTopologyZones) DeepCopyInto(out *RisingWaveTopologyZones) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveTopologyZones.
+func (in *RisingWaveTopologyZones) DeepCopy() *RisingWaveTopologyZones {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveTopologyZones)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgrade) DeepCopyInto(out *RisingWaveUpgrade) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgrade.
+func (in *RisingWaveUpgrade) DeepCopy() *RisingWaveUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgradeList) DeepCopyInto(out *RisingWaveUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RisingWaveUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgradeList.
+func (in *RisingWaveUpgradeList) DeepCopy() *RisingWaveUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RisingWaveUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgradePhaseRecord) DeepCopyInto(out *RisingWaveUpgradePhaseRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgradePhaseRecord.
+func (in *RisingWaveUpgradePhaseRecord) DeepCopy() *RisingWaveUpgradePhaseRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgradePhaseRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgradeService) DeepCopyInto(out *RisingWaveUpgradeService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgradeService.
+func (in *RisingWaveUpgradeService) DeepCopy() *RisingWaveUpgradeService {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgradeService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgradeSpec) DeepCopyInto(out *RisingWaveUpgradeSpec) {
+	*out = *in
+	in.MetaStore.DeepCopyInto(&out.MetaStore)
+	in.Verification.DeepCopyInto(&out.Verification)
+	out.Service = in.Service
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgradeSpec.
+func (in *RisingWaveUpgradeSpec) DeepCopy() *RisingWaveUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgradeStatus) DeepCopyInto(out *RisingWaveUpgradeStatus) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]RisingWaveUpgradePhaseRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgradeStatus.
+func (in *RisingWaveUpgradeStatus) DeepCopy() *RisingWaveUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RisingWaveUpgradeVerification) DeepCopyInto(out *RisingWaveUpgradeVerification) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(RisingWaveDBCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RisingWaveUpgradeVerification.
+func (in *RisingWaveUpgradeVerification) DeepCopy() *RisingWaveUpgradeVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(RisingWaveUpgradeVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadReplicaStatus) DeepCopyInto(out *WorkloadReplicaStatus) {
 	*out = *in
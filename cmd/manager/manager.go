@@ -17,13 +17,17 @@
 package main
 
 import (
+	"cmp"
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -32,18 +36,25 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	kruiseappsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
 	kruiseappsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
 	risingwavecontroller "github.com/risingwavelabs/risingwave-operator/pkg/controller"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory"
 	"github.com/risingwavelabs/risingwave-operator/pkg/features"
 	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/notification"
+	"github.com/risingwavelabs/risingwave-operator/pkg/tracing"
 	risingwavewebhook "github.com/risingwavelabs/risingwave-operator/pkg/webhook"
 )
 
@@ -57,6 +68,7 @@ func init() {
 	utilruntime.Must(risingwavev1alpha1.AddToScheme(scheme))
 	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(prometheusv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.AddToScheme(scheme))
 	utilruntime.Must(kruiseappsv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(kruiseappsv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
@@ -82,6 +94,24 @@ func requireKubernetesVersion(serverVersion *version.Info, minMajor, minMinor in
 	}
 }
 
+// isInPlacePodResizeAvailable tells if the Pods can be resized in place, i.e., the resize subresource of Pods is served.
+func isInPlacePodResizeAvailable(discoveryClient discovery.DiscoveryInterface) bool {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion("v1")
+	if err != nil {
+		setupLog.Error(err, "Unable to discover the core API resources, assume in-place pod resize is unavailable")
+
+		return false
+	}
+
+	for _, r := range resources.APIResources {
+		if r.Name == "pods/resize" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func main() {
 	metrics.InitMetrics()
 	metrics.ReceivingMetricsFromOperator.Inc()
@@ -91,6 +121,9 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&featureGates, "feature-gates", "", "The feature gates arguments for the operator.")
 
+	var tracingOpts tracing.Options
+	tracingOpts.BindFlags(flag.CommandLine)
+
 	opts := zap.Options{
 		Development: true,
 	}
@@ -102,6 +135,13 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 	config := ctrl.GetConfigOrDie()
 
+	ctx := ctrl.SetupSignalHandler()
+	shutdownTracing, err := tracing.Setup(ctx, tracingOpts, operatorVersion)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -111,6 +151,12 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "02bd7444.risingwavelabs.com",
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				// Only the Secrets of the managed stores are cached, the others are read with the API reader.
+				&corev1.Secret{}: {Label: factory.ManagedStoreSecretsSelector()},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -119,7 +165,8 @@ func main() {
 
 	// Barrier to ensure that the operator is not started on Kubernetes lower than 1.21.
 	// This is to avoid the issue that the operator will be stuck in a crash loop if it is started on Kubernetes lower than 1.21.
-	kubernetesVersion, err := discovery.NewDiscoveryClientForConfigOrDie(mgr.GetConfig()).ServerVersion()
+	discoveryClient := discovery.NewDiscoveryClientForConfigOrDie(mgr.GetConfig())
+	kubernetesVersion, err := discoveryClient.ServerVersion()
 	if err != nil {
 		setupLog.Error(err, "Unable to get Kubernetes version")
 		os.Exit(1)
@@ -127,7 +174,16 @@ func main() {
 
 	requireKubernetesVersion(kubernetesVersion, 1, 21)
 
-	if err = risingwavewebhook.SetupWebhooksWithManager(mgr, featureManager.IsFeatureEnabled(features.EnableOpenKruiseFeature)); err != nil {
+	operatorNamespace := cmp.Or(os.Getenv(consts.EnvOperatorNamespace), consts.DefaultOperatorNamespace)
+	operatorUsername := ""
+	if sa := os.Getenv(consts.EnvOperatorServiceAccount); sa != "" {
+		operatorUsername = fmt.Sprintf("system:serviceaccount:%s:%s", operatorNamespace, sa)
+	} else {
+		setupLog.Info("Service account of the operator is unknown, the meta store migrations can't switch the meta stores",
+			"env", consts.EnvOperatorServiceAccount)
+	}
+
+	if err = risingwavewebhook.SetupWebhooksWithManager(mgr, featureManager.IsFeatureEnabled(features.EnableOpenKruiseFeature), operatorUsername); err != nil {
 		setupLog.Error(err, "unable to setup webhooks")
 		os.Exit(1)
 	}
@@ -137,12 +193,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	notificationDispatcher := notification.NewDispatcher(mgr.GetClient(), mgr.GetAPIReader())
+	if err = mgr.Add(notificationDispatcher); err != nil {
+		setupLog.Error(err, "unable to add the notification dispatcher")
+		os.Exit(1)
+	}
+
 	if err = risingwavecontroller.NewRisingWaveController(
 		mgr.GetClient(),
+		mgr.GetAPIReader(),
 		mgr.GetEventRecorder("risingwave-controller"),
+		notificationDispatcher,
 		featureManager.IsFeatureEnabled(features.EnableOpenKruiseFeature),
 		featureManager.IsFeatureEnabled(features.EnableForceUpdate),
+		isInPlacePodResizeAvailable(discoveryClient),
 		operatorVersion,
+		operatorNamespace,
 	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RisingWave")
 		os.Exit(1)
@@ -153,11 +219,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = risingwavecontroller.NewRisingWaveUpgradeController(mgr.GetClient(), mgr.GetAPIReader()).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RisingWaveUpgrade")
+		os.Exit(1)
+	}
+
+	if err = risingwavecontroller.NewRisingWaveMetaStoreMigrationController(
+		mgr.GetClient(),
+		featureManager.IsFeatureEnabled(features.EnableOpenKruiseFeature),
+	).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RisingWaveMetaStoreMigration")
+		os.Exit(1)
+	}
+
 	if err = risingwavecontroller.NewRisingWaveComputeSTSUpdateStrategy(mgr.GetClient()).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RisingWaveComputeSTSUpdateStrategy")
 		os.Exit(1)
 	}
 
+	if err = risingwavecontroller.NewRisingWaveMetaSTSUpdateStrategy(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RisingWaveMetaSTSUpdateStrategy")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -172,7 +256,16 @@ func main() {
 
 	setupLog.Info("starting manager")
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctx)
+
+	// Flush the spans with a fresh context since the signal context is done.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to shut down tracing")
+	}
+	cancel()
+
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
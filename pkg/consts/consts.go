@@ -16,6 +16,8 @@
 
 package consts
 
+import "time"
+
 // =================================================
 // Labels.
 // =================================================
@@ -27,7 +29,9 @@ const (
 	LabelRisingWaveGeneration      = "risingwave/generation"
 	LabelRisingWaveGroup           = "risingwave/group"
 	LabelRisingWaveMetaRole        = "risingwave/meta-role"
+	LabelRisingWaveZone            = "risingwave/zone"
 	LabelRisingWaveOperatorVersion = "risingwave/operator-version"
+	LabelRisingWaveUpgrade         = "risingwave.risingwavelabs.com/upgrade"
 )
 
 // =================================================
@@ -40,6 +44,26 @@ const (
 	AnnotationPauseReconcile          = "risingwave.risingwavelabs.com/pause-reconcile"
 	AnnotationBypassValidatingWebhook = "risingwave.risingwavelabs.com/bypass-validating-webhook"
 	AnnotationInheritLabelPrefix      = "risingwave.risingwavelabs.com/inherit-label-prefix"
+	AnnotationApplyPendingMaintenance = "risingwave.risingwavelabs.com/apply-pending-maintenance"
+	AnnotationPodTemplateHash         = "risingwave.risingwavelabs.com/pod-template-hash"
+	AnnotationPendingMaintenance      = "risingwave.risingwavelabs.com/pending-maintenance"
+	AnnotationMetaStoreMigration      = "risingwave.risingwavelabs.com/meta-store-migration"
+	AnnotationConfirmDeletion         = "risingwave.risingwavelabs.com/confirm-deletion"
+	AnnotationPreflightGeneration     = "risingwave.risingwavelabs.com/preflight-generation"
+	AnnotationAppliedResourcesHash    = "risingwave.risingwavelabs.com/applied-resources-hash"
+)
+
+// =================================================
+// Finalizers.
+// =================================================
+
+// Finalizers of RisingWave.
+const (
+	// FinalizerDataCleanup is the finalizer to clean up the data in the stores before the RisingWave is deleted.
+	FinalizerDataCleanup = "risingwave.risingwavelabs.com/data-cleanup"
+
+	// FinalizerFrontendDrain is the finalizer to drain the frontends before the rest of the RisingWave is removed.
+	FinalizerFrontendDrain = "risingwave.risingwavelabs.com/frontend-drain"
 )
 
 // =================================================
@@ -53,6 +77,44 @@ const (
 	MetaRoleUnknown  = "unknown"
 )
 
+// DefaultResourceGroup is the name of the resource group that the compute nodes join by default.
+const DefaultResourceGroup = "default"
+
+// DefaultZoneTopologyKey is the node label key that identifies the zones by default.
+const DefaultZoneTopologyKey = "topology.kubernetes.io/zone"
+
+// Default namespaces allowed by the generated NetworkPolicies.
+const (
+	DefaultMonitoringNamespace = "monitoring"
+	DefaultOperatorNamespace   = "risingwave-operator-system"
+)
+
+// Environment variables of the operator, set by the downward API.
+const (
+	EnvOperatorNamespace      = "POD_NAMESPACE"
+	EnvOperatorServiceAccount = "POD_SERVICE_ACCOUNT"
+)
+
+// Names of the endpoints exposed by the Ingresses and HTTPRoutes.
+const (
+	ExternalEndpointDashboard = "dashboard"
+	ExternalEndpointWebhook   = "webhook"
+)
+
+// Label to discover the ConfigMaps of the Grafana dashboards by the sidecar, used when no label is specified.
+const (
+	DefaultGrafanaDashboardLabelKey   = "grafana_dashboard"
+	DefaultGrafanaDashboardLabelValue = "1"
+)
+
+// Defaults of the thresholds of the alerts in the PrometheusRule.
+const (
+	DefaultAlertBarrierLatency         = time.Minute
+	DefaultAlertCompactionPendingBytes = "50Gi"
+	DefaultAlertMetaLeaderChanges      = 3
+	DefaultAlertPodNotReady            = 10 * time.Minute
+)
+
 // Special label values of LabelRisingWaveGeneration.
 const (
 	// NoSync indicates that operator won't sync the resource after it's created.
@@ -67,6 +129,10 @@ const (
 	ComponentCompactor  = "compactor"
 	ComponentStandalone = "standalone"
 	ComponentConfig     = "config"
+
+	// Stores provisioned by the operator.
+	ComponentMinIO      = "minio"
+	ComponentPostgreSQL = "postgresql"
 )
 
 // Credential keys for MinIO.
@@ -75,6 +141,12 @@ const (
 	SecretKeyMinIOPassword string = "password"
 )
 
+// Credential keys for PostgreSQL.
+const (
+	SecretKeyPostgreSQLUsername string = "username"
+	SecretKeyPostgreSQLPassword string = "password"
+)
+
 // Credential keys for etcd.
 const (
 	SecretKeyEtcdUsername string = "username"
@@ -121,14 +193,36 @@ const (
 
 // Port numbers of components.
 const (
-	MetaServicePort      int32 = 5690
-	MetaDashboardPort    int32 = 5691
-	MetaMetricsPort      int32 = 1250
-	ComputeServicePort   int32 = 5688
-	ComputeMetricsPort   int32 = 1222
-	FrontendServicePort  int32 = 4567
-	FrontendMetricsPort  int32 = 8080
-	FrontendWebhookPort  int32 = 4560
-	CompactorServicePort int32 = 6660
-	CompactorMetricsPort int32 = 1260
+	MetaServicePort       int32 = 5690
+	MetaDashboardPort     int32 = 5691
+	MetaMetricsPort       int32 = 1250
+	ComputeServicePort    int32 = 5688
+	ComputeMetricsPort    int32 = 1222
+	FrontendServicePort   int32 = 4567
+	FrontendMetricsPort   int32 = 8080
+	FrontendWebhookPort   int32 = 4560
+	CompactorServicePort  int32 = 6660
+	CompactorMetricsPort  int32 = 1260
+	MinIOServicePort      int32 = 9000
+	PostgreSQLServicePort int32 = 5432
+)
+
+// Defaults of the stores provisioned by the operator.
+const (
+	DefaultManagedMinIOImage         = "quay.io/minio/minio:latest"
+	DefaultManagedPostgreSQLImage    = "postgres:17"
+	DefaultManagedStoreStorageSize   = "10Gi"
+	DefaultManagedMinIOBucket        = "hummock"
+	DefaultManagedPostgreSQLDatabase = "risingwave"
+	ManagedStoreUsername             = "risingwave"
+)
+
+// DefaultCloneCopyImage is the default image of the Job copying the data of the source of a clone.
+const DefaultCloneCopyImage = "amazon/aws-cli:2.27.0"
+
+// Images of the Job probing the state store with the workload identity of the RisingWave.
+const (
+	StateStoreProbeS3Image        = "amazon/aws-cli:2.27.0"
+	StateStoreProbeGCSImage       = "gcr.io/google.com/cloudsdktool/google-cloud-cli:522.0.0-slim"
+	StateStoreProbeAzureBlobImage = "mcr.microsoft.com/azure-cli:2.73.0"
 )
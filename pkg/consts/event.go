@@ -31,4 +31,15 @@ var (
 	RisingWaveEventTypeUnhealthy    = RisingWaveEventType{Name: "Unhealthy", Type: corev1.EventTypeWarning}
 	RisingWaveEventTypeRecovering   = RisingWaveEventType{Name: "Recovering", Type: corev1.EventTypeNormal}
 	RisingWaveEventTypeUpgrading    = RisingWaveEventType{Name: "Upgrading", Type: corev1.EventTypeNormal}
+
+	// Events of the health issues found on the Pods and the workloads.
+	RisingWaveEventTypeCrashLoopBackOff         = RisingWaveEventType{Name: "CrashLoopBackOff", Type: corev1.EventTypeWarning}
+	RisingWaveEventTypeOOMKilled                = RisingWaveEventType{Name: "OOMKilled", Type: corev1.EventTypeWarning}
+	RisingWaveEventTypeImagePullBackOff         = RisingWaveEventType{Name: "ImagePullBackOff", Type: corev1.EventTypeWarning}
+	RisingWaveEventTypeUnschedulable            = RisingWaveEventType{Name: "Unschedulable", Type: corev1.EventTypeWarning}
+	RisingWaveEventTypeProgressDeadlineExceeded = RisingWaveEventType{Name: "ProgressDeadlineExceeded", Type: corev1.EventTypeWarning}
+
+	// Events of the remediation of the stuck Pods.
+	RisingWaveEventTypeRemediated                 = RisingWaveEventType{Name: "Remediated", Type: corev1.EventTypeWarning}
+	RisingWaveEventTypeRemediationBudgetExhausted = RisingWaveEventType{Name: "RemediationBudgetExhausted", Type: corev1.EventTypeWarning}
 )
@@ -147,33 +147,69 @@ func (mpl *MetaPodRoleLabeler) getEndpointFromEnvVars(pod *corev1.Pod, envVars [
 	return endpoint
 }
 
-func (mpl *MetaPodRoleLabeler) syncRoleLabelForSinglePod(ctx context.Context, pod *corev1.Pod) (string, error) {
-	// Extract information from the Pod.
-	if !mpl.isRisingWaveMetaPod(pod) {
-		return "", errors.New("not a meta pod")
+// getStandaloneMetaArgs returns the arguments of the meta node in the standalone container, i.e., the value of
+// --meta-opts split by spaces.
+func (mpl *MetaPodRoleLabeler) getStandaloneMetaArgs(container *corev1.Container) []string {
+	for _, arg := range container.Args {
+		if metaOpts, ok := strings.CutPrefix(arg, "--meta-opts="); ok {
+			return strings.Fields(metaOpts)
+		}
 	}
 
-	if pod.Status.PodIP == "" {
-		return "", errors.New("not running")
+	return nil
+}
+
+// getMetaEndpointAndPort returns the advertised host and the service port of the meta node in the Pod.
+func (mpl *MetaPodRoleLabeler) getMetaEndpointAndPort(pod *corev1.Pod) (string, int32, error) {
+	if pod.Labels[consts.LabelRisingWaveComponent] == consts.ComponentStandalone {
+		standaloneContainer := utils.GetContainerFromPod(pod, "standalone")
+		if standaloneContainer == nil {
+			return "", 0, errors.New("standalone container not found")
+		}
+
+		endpoint := mpl.getEndpointFromArgs(pod, mpl.getStandaloneMetaArgs(standaloneContainer))
+		if len(endpoint) == 0 {
+			return "", 0, errors.New("endpoint not found")
+		}
+
+		return endpoint, consts.MetaServicePort, nil
 	}
 
 	metaContainer := utils.GetContainerFromPod(pod, "meta")
 	if metaContainer == nil {
-		return "", errors.New("meta container not found")
+		return "", 0, errors.New("meta container not found")
 	}
 
 	svcPort, ok := utils.GetPortFromContainer(metaContainer, consts.PortService)
 	if !ok {
-		return "", errors.New("service port not found")
+		return "", 0, errors.New("service port not found")
 	}
 
 	endpoint := mpl.getEndpointFromArgs(pod, metaContainer.Args)
 	if len(endpoint) == 0 {
 		if endpoint = mpl.getEndpointFromEnvVars(pod, metaContainer.Env); len(endpoint) == 0 {
-			return "", errors.New("endpoint not found")
+			return "", 0, errors.New("endpoint not found")
 		}
 	}
 
+	return endpoint, svcPort, nil
+}
+
+func (mpl *MetaPodRoleLabeler) syncRoleLabelForSinglePod(ctx context.Context, pod *corev1.Pod) (string, error) {
+	// Extract information from the Pod.
+	if !mpl.isRisingWaveMetaPod(pod) {
+		return "", errors.New("not a meta pod")
+	}
+
+	if pod.Status.PodIP == "" {
+		return "", errors.New("not running")
+	}
+
+	endpoint, svcPort, err := mpl.getMetaEndpointAndPort(pod)
+	if err != nil {
+		return "", err
+	}
+
 	logger := log.FromContext(ctx).WithValues("pod", pod.Name)
 
 	// Send a gRPC request and get the current role.
@@ -222,7 +258,7 @@ func (mpl *MetaPodRoleLabeler) syncRoleLabels(ctx context.Context, pod *corev1.P
 
 		err := mpl.List(ctx, &leaderPodList, client.InNamespace(pod.Namespace), client.MatchingLabels{
 			consts.LabelRisingWaveName:      risingwaveName,
-			consts.LabelRisingWaveComponent: consts.ComponentMeta,
+			consts.LabelRisingWaveComponent: pod.Labels[consts.LabelRisingWaveComponent],
 			consts.LabelRisingWaveMetaRole:  consts.MetaRoleLeader,
 		})
 		if err != nil {
@@ -251,15 +287,22 @@ func (mpl *MetaPodRoleLabeler) isRisingWaveMetaPod(pod *corev1.Pod) bool {
 		return false
 	}
 
-	if pod.Labels[consts.LabelRisingWaveComponent] != consts.ComponentMeta {
-		return false
-	}
+	switch pod.Labels[consts.LabelRisingWaveComponent] {
+	case consts.ComponentMeta:
+		return utils.GetContainerFromPod(pod, "meta") != nil
+	case consts.ComponentStandalone:
+		// Only the standalone Pods in the high availability mode elect the meta leader among each other. The others
+		// advertise the loopback address or run the single-node subcommand.
+		standaloneContainer := utils.GetContainerFromPod(pod, "standalone")
+		if standaloneContainer == nil {
+			return false
+		}
+		endpoint := mpl.getEndpointFromArgs(pod, mpl.getStandaloneMetaArgs(standaloneContainer))
 
-	if utils.GetContainerFromPod(pod, "meta") == nil {
+		return len(endpoint) > 0 && !strings.HasPrefix(endpoint, "127.")
+	default:
 		return false
 	}
-
-	return true
 }
 
 // Reconcile handles the pods of the meta service. Will add the metaLeaderLabel to the pods.
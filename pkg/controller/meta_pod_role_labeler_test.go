@@ -0,0 +1,66 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory"
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+func newStandalonePodForTest(risingwave *risingwavev1alpha1.RisingWave) *corev1.Pod {
+	template := factory.NewRisingWaveObjectFactory(risingwave, testutils.Scheme, "").NewStandaloneStatefulSet().Spec.Template
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: risingwave.Namespace,
+			Name:      risingwave.Name + "-standalone-0",
+			Labels:    template.Labels,
+		},
+		Spec:   template.Spec,
+		Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+}
+
+func TestMetaPodRoleLabeler_StandalonePods(t *testing.T) {
+	mpl := NewMetaPodRoleLabeler(nil)
+
+	// The meta nodes in the standalone Pods in the high availability mode advertise the Pod IPs.
+	risingwave := testutils.FakeRisingWaveStandaloneHA()
+	risingwave.Spec.StandaloneMode = 1
+	pod := newStandalonePodForTest(risingwave)
+	require.True(t, mpl.isRisingWaveMetaPod(pod))
+
+	endpoint, port, err := mpl.getMetaEndpointAndPort(pod)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", endpoint)
+	assert.Equal(t, int32(consts.MetaServicePort), port)
+
+	// A single standalone Pod advertises the loopback address.
+	risingwave.Spec.Components.Standalone.Replicas = 1
+	assert.False(t, mpl.isRisingWaveMetaPod(newStandalonePodForTest(risingwave)))
+
+	// The single-node subcommand has no meta options.
+	risingwave.Spec.StandaloneMode = 2
+	assert.False(t, mpl.isRisingWaveMetaPod(newStandalonePodForTest(risingwave)))
+}
@@ -0,0 +1,463 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v3.21.12
+// source: backup_service.proto
+
+package proto
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BackupJobStatus int32
+
+const (
+	BackupJobStatus_UNSPECIFIED BackupJobStatus = 0
+	BackupJobStatus_RUNNING     BackupJobStatus = 1
+	BackupJobStatus_SUCCEEDED   BackupJobStatus = 2
+	BackupJobStatus_NOT_FOUND   BackupJobStatus = 3
+	BackupJobStatus_FAILED      BackupJobStatus = 4
+)
+
+// Enum value maps for BackupJobStatus.
+var (
+	BackupJobStatus_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "RUNNING",
+		2: "SUCCEEDED",
+		3: "NOT_FOUND",
+		4: "FAILED",
+	}
+	BackupJobStatus_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"RUNNING":     1,
+		"SUCCEEDED":   2,
+		"NOT_FOUND":   3,
+		"FAILED":      4,
+	}
+)
+
+func (x BackupJobStatus) Enum() *BackupJobStatus {
+	p := new(BackupJobStatus)
+	*p = x
+	return p
+}
+
+func (x BackupJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackupJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_backup_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BackupJobStatus) Type() protoreflect.EnumType {
+	return &file_backup_service_proto_enumTypes[0]
+}
+
+func (x BackupJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackupJobStatus.Descriptor instead.
+func (BackupJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{0}
+}
+
+type BackupMetaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Remarks       *string                `protobuf:"bytes,1,opt,name=remarks,proto3,oneof" json:"remarks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupMetaRequest) Reset() {
+	*x = BackupMetaRequest{}
+	mi := &file_backup_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupMetaRequest) ProtoMessage() {}
+
+func (x *BackupMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupMetaRequest.ProtoReflect.Descriptor instead.
+func (*BackupMetaRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BackupMetaRequest) GetRemarks() string {
+	if x != nil && x.Remarks != nil {
+		return *x.Remarks
+	}
+	return ""
+}
+
+type BackupMetaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         uint64                 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupMetaResponse) Reset() {
+	*x = BackupMetaResponse{}
+	mi := &file_backup_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupMetaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupMetaResponse) ProtoMessage() {}
+
+func (x *BackupMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupMetaResponse.ProtoReflect.Descriptor instead.
+func (*BackupMetaResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BackupMetaResponse) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type GetBackupJobStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         uint64                 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupJobStatusRequest) Reset() {
+	*x = GetBackupJobStatusRequest{}
+	mi := &file_backup_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupJobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupJobStatusRequest) ProtoMessage() {}
+
+func (x *GetBackupJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBackupJobStatusRequest) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type GetBackupJobStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         uint64                 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobStatus     BackupJobStatus        `protobuf:"varint,2,opt,name=job_status,json=jobStatus,proto3,enum=backup_service.BackupJobStatus" json:"job_status,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupJobStatusResponse) Reset() {
+	*x = GetBackupJobStatusResponse{}
+	mi := &file_backup_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupJobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupJobStatusResponse) ProtoMessage() {}
+
+func (x *GetBackupJobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupJobStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupJobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBackupJobStatusResponse) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *GetBackupJobStatusResponse) GetJobStatus() BackupJobStatus {
+	if x != nil {
+		return x.JobStatus
+	}
+	return BackupJobStatus_UNSPECIFIED
+}
+
+func (x *GetBackupJobStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteMetaSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SnapshotIds   []uint64               `protobuf:"varint,1,rep,packed,name=snapshot_ids,json=snapshotIds,proto3" json:"snapshot_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetaSnapshotRequest) Reset() {
+	*x = DeleteMetaSnapshotRequest{}
+	mi := &file_backup_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetaSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetaSnapshotRequest) ProtoMessage() {}
+
+func (x *DeleteMetaSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetaSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMetaSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteMetaSnapshotRequest) GetSnapshotIds() []uint64 {
+	if x != nil {
+		return x.SnapshotIds
+	}
+	return nil
+}
+
+type DeleteMetaSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetaSnapshotResponse) Reset() {
+	*x = DeleteMetaSnapshotResponse{}
+	mi := &file_backup_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetaSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetaSnapshotResponse) ProtoMessage() {}
+
+func (x *DeleteMetaSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetaSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMetaSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_proto_rawDescGZIP(), []int{5}
+}
+
+var File_backup_service_proto protoreflect.FileDescriptor
+
+const file_backup_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14backup_service.proto\x12\x0ebackup_service\">\n" +
+	"\x11BackupMetaRequest\x12\x1d\n" +
+	"\aremarks\x18\x01 \x01(\tH\x00R\aremarks\x88\x01\x01B\n" +
+	"\n" +
+	"\b_remarks\"+\n" +
+	"\x12BackupMetaResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x04R\x05jobId\"2\n" +
+	"\x19GetBackupJobStatusRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x04R\x05jobId\"\x8d\x01\n" +
+	"\x1aGetBackupJobStatusResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x04R\x05jobId\x12>\n" +
+	"\n" +
+	"job_status\x18\x02 \x01(\x0e2\x1f.backup_service.BackupJobStatusR\tjobStatus\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\">\n" +
+	"\x19DeleteMetaSnapshotRequest\x12!\n" +
+	"\fsnapshot_ids\x18\x01 \x03(\x04R\vsnapshotIds\"\x1c\n" +
+	"\x1aDeleteMetaSnapshotResponse*Y\n" +
+	"\x0fBackupJobStatus\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aRUNNING\x10\x01\x12\r\n" +
+	"\tSUCCEEDED\x10\x02\x12\r\n" +
+	"\tNOT_FOUND\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x042\xbe\x02\n" +
+	"\rBackupService\x12S\n" +
+	"\n" +
+	"BackupMeta\x12!.backup_service.BackupMetaRequest\x1a\".backup_service.BackupMetaResponse\x12k\n" +
+	"\x12GetBackupJobStatus\x12).backup_service.GetBackupJobStatusRequest\x1a*.backup_service.GetBackupJobStatusResponse\x12k\n" +
+	"\x12DeleteMetaSnapshot\x12).backup_service.DeleteMetaSnapshotRequest\x1a*.backup_service.DeleteMetaSnapshotResponseBQ\n" +
+	"\x14com.risingwave.protoH\x01Z7github.com/risingwavelabs/risingwave-operator/pkg/protob\x06proto3"
+
+var (
+	file_backup_service_proto_rawDescOnce sync.Once
+	file_backup_service_proto_rawDescData []byte
+)
+
+func file_backup_service_proto_rawDescGZIP() []byte {
+	file_backup_service_proto_rawDescOnce.Do(func() {
+		file_backup_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_backup_service_proto_rawDesc), len(file_backup_service_proto_rawDesc)))
+	})
+	return file_backup_service_proto_rawDescData
+}
+
+var file_backup_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_backup_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_backup_service_proto_goTypes = []any{
+	(BackupJobStatus)(0),               // 0: backup_service.BackupJobStatus
+	(*BackupMetaRequest)(nil),          // 1: backup_service.BackupMetaRequest
+	(*BackupMetaResponse)(nil),         // 2: backup_service.BackupMetaResponse
+	(*GetBackupJobStatusRequest)(nil),  // 3: backup_service.GetBackupJobStatusRequest
+	(*GetBackupJobStatusResponse)(nil), // 4: backup_service.GetBackupJobStatusResponse
+	(*DeleteMetaSnapshotRequest)(nil),  // 5: backup_service.DeleteMetaSnapshotRequest
+	(*DeleteMetaSnapshotResponse)(nil), // 6: backup_service.DeleteMetaSnapshotResponse
+}
+var file_backup_service_proto_depIdxs = []int32{
+	0, // 0: backup_service.GetBackupJobStatusResponse.job_status:type_name -> backup_service.BackupJobStatus
+	1, // 1: backup_service.BackupService.BackupMeta:input_type -> backup_service.BackupMetaRequest
+	3, // 2: backup_service.BackupService.GetBackupJobStatus:input_type -> backup_service.GetBackupJobStatusRequest
+	5, // 3: backup_service.BackupService.DeleteMetaSnapshot:input_type -> backup_service.DeleteMetaSnapshotRequest
+	2, // 4: backup_service.BackupService.BackupMeta:output_type -> backup_service.BackupMetaResponse
+	4, // 5: backup_service.BackupService.GetBackupJobStatus:output_type -> backup_service.GetBackupJobStatusResponse
+	6, // 6: backup_service.BackupService.DeleteMetaSnapshot:output_type -> backup_service.DeleteMetaSnapshotResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_backup_service_proto_init() }
+func file_backup_service_proto_init() {
+	if File_backup_service_proto != nil {
+		return
+	}
+	file_backup_service_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_backup_service_proto_rawDesc), len(file_backup_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_backup_service_proto_goTypes,
+		DependencyIndexes: file_backup_service_proto_depIdxs,
+		EnumInfos:         file_backup_service_proto_enumTypes,
+		MessageInfos:      file_backup_service_proto_msgTypes,
+	}.Build()
+	File_backup_service_proto = out.File
+	file_backup_service_proto_goTypes = nil
+	file_backup_service_proto_depIdxs = nil
+}
@@ -14,7 +14,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.36.12
 // 	protoc        v3.21.12
 // source: common.proto
 
@@ -23,6 +23,7 @@ package proto
 import (
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -35,22 +36,77 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WorkerType int32
+
+const (
+	WorkerType_WORKER_TYPE_UNSPECIFIED  WorkerType = 0
+	WorkerType_WORKER_TYPE_FRONTEND     WorkerType = 1
+	WorkerType_WORKER_TYPE_COMPUTE_NODE WorkerType = 2
+	WorkerType_WORKER_TYPE_RISE_CTL     WorkerType = 3
+	WorkerType_WORKER_TYPE_COMPACTOR    WorkerType = 4
+	WorkerType_WORKER_TYPE_META         WorkerType = 5
+)
+
+// Enum value maps for WorkerType.
+var (
+	WorkerType_name = map[int32]string{
+		0: "WORKER_TYPE_UNSPECIFIED",
+		1: "WORKER_TYPE_FRONTEND",
+		2: "WORKER_TYPE_COMPUTE_NODE",
+		3: "WORKER_TYPE_RISE_CTL",
+		4: "WORKER_TYPE_COMPACTOR",
+		5: "WORKER_TYPE_META",
+	}
+	WorkerType_value = map[string]int32{
+		"WORKER_TYPE_UNSPECIFIED":  0,
+		"WORKER_TYPE_FRONTEND":     1,
+		"WORKER_TYPE_COMPUTE_NODE": 2,
+		"WORKER_TYPE_RISE_CTL":     3,
+		"WORKER_TYPE_COMPACTOR":    4,
+		"WORKER_TYPE_META":         5,
+	}
+)
+
+func (x WorkerType) Enum() *WorkerType {
+	p := new(WorkerType)
+	*p = x
+	return p
+}
+
+func (x WorkerType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkerType) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkerType) Type() protoreflect.EnumType {
+	return &file_common_proto_enumTypes[0]
+}
+
+func (x WorkerType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkerType.Descriptor instead.
+func (WorkerType) EnumDescriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{0}
+}
+
 type HostAddress struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	Port int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HostAddress) Reset() {
 	*x = HostAddress{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *HostAddress) String() string {
@@ -61,7 +117,7 @@ func (*HostAddress) ProtoMessage() {}
 
 func (x *HostAddress) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -90,44 +146,203 @@ func (x *HostAddress) GetPort() int32 {
 	return 0
 }
 
-var File_common_proto protoreflect.FileDescriptor
+// Only the fields used by the operator are kept.
+type WorkerNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          WorkerType             `protobuf:"varint,2,opt,name=type,proto3,enum=common.WorkerType" json:"type,omitempty"`
+	Host          *HostAddress           `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Property      *WorkerNode_Property   `protobuf:"bytes,6,opt,name=property,proto3" json:"property,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerNode) Reset() {
+	*x = WorkerNode{}
+	mi := &file_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerNode) ProtoMessage() {}
+
+func (x *WorkerNode) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerNode.ProtoReflect.Descriptor instead.
+func (*WorkerNode) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkerNode) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WorkerNode) GetType() WorkerType {
+	if x != nil {
+		return x.Type
+	}
+	return WorkerType_WORKER_TYPE_UNSPECIFIED
+}
+
+func (x *WorkerNode) GetHost() *HostAddress {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+func (x *WorkerNode) GetProperty() *WorkerNode_Property {
+	if x != nil {
+		return x.Property
+	}
+	return nil
+}
+
+type WorkerNode_Property struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IsStreaming     bool                   `protobuf:"varint,1,opt,name=is_streaming,json=isStreaming,proto3" json:"is_streaming,omitempty"`
+	IsServing       bool                   `protobuf:"varint,2,opt,name=is_serving,json=isServing,proto3" json:"is_serving,omitempty"`
+	IsUnschedulable bool                   `protobuf:"varint,3,opt,name=is_unschedulable,json=isUnschedulable,proto3" json:"is_unschedulable,omitempty"`
+	ResourceGroup   *string                `protobuf:"bytes,7,opt,name=resource_group,json=resourceGroup,proto3,oneof" json:"resource_group,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WorkerNode_Property) Reset() {
+	*x = WorkerNode_Property{}
+	mi := &file_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerNode_Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerNode_Property) ProtoMessage() {}
+
+func (x *WorkerNode_Property) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerNode_Property.ProtoReflect.Descriptor instead.
+func (*WorkerNode_Property) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{1, 0}
+}
 
-var file_common_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x22, 0x35, 0x0a, 0x0b, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x51, 0x0a,
-	0x14, 0x63, 0x6f, 0x6d, 0x2e, 0x72, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x77, 0x61, 0x76, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x01, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x77, 0x61, 0x76, 0x65, 0x6c, 0x61,
-	0x62, 0x73, 0x2f, 0x72, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x77, 0x61, 0x76, 0x65, 0x2d, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *WorkerNode_Property) GetIsStreaming() bool {
+	if x != nil {
+		return x.IsStreaming
+	}
+	return false
+}
+
+func (x *WorkerNode_Property) GetIsServing() bool {
+	if x != nil {
+		return x.IsServing
+	}
+	return false
 }
 
+func (x *WorkerNode_Property) GetIsUnschedulable() bool {
+	if x != nil {
+		return x.IsUnschedulable
+	}
+	return false
+}
+
+func (x *WorkerNode_Property) GetResourceGroup() string {
+	if x != nil && x.ResourceGroup != nil {
+		return *x.ResourceGroup
+	}
+	return ""
+}
+
+var File_common_proto protoreflect.FileDescriptor
+
+const file_common_proto_rawDesc = "" +
+	"\n" +
+	"\fcommon.proto\x12\x06common\"5\n" +
+	"\vHostAddress\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\"\xdf\x02\n" +
+	"\n" +
+	"WorkerNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12&\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x12.common.WorkerTypeR\x04type\x12'\n" +
+	"\x04host\x18\x03 \x01(\v2\x13.common.HostAddressR\x04host\x127\n" +
+	"\bproperty\x18\x06 \x01(\v2\x1b.common.WorkerNode.PropertyR\bproperty\x1a\xb6\x01\n" +
+	"\bProperty\x12!\n" +
+	"\fis_streaming\x18\x01 \x01(\bR\visStreaming\x12\x1d\n" +
+	"\n" +
+	"is_serving\x18\x02 \x01(\bR\tisServing\x12)\n" +
+	"\x10is_unschedulable\x18\x03 \x01(\bR\x0fisUnschedulable\x12*\n" +
+	"\x0eresource_group\x18\a \x01(\tH\x00R\rresourceGroup\x88\x01\x01B\x11\n" +
+	"\x0f_resource_group*\xac\x01\n" +
+	"\n" +
+	"WorkerType\x12\x1b\n" +
+	"\x17WORKER_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14WORKER_TYPE_FRONTEND\x10\x01\x12\x1c\n" +
+	"\x18WORKER_TYPE_COMPUTE_NODE\x10\x02\x12\x18\n" +
+	"\x14WORKER_TYPE_RISE_CTL\x10\x03\x12\x19\n" +
+	"\x15WORKER_TYPE_COMPACTOR\x10\x04\x12\x14\n" +
+	"\x10WORKER_TYPE_META\x10\x05BQ\n" +
+	"\x14com.risingwave.protoH\x01Z7github.com/risingwavelabs/risingwave-operator/pkg/protob\x06proto3"
+
 var (
 	file_common_proto_rawDescOnce sync.Once
-	file_common_proto_rawDescData = file_common_proto_rawDesc
+	file_common_proto_rawDescData []byte
 )
 
 func file_common_proto_rawDescGZIP() []byte {
 	file_common_proto_rawDescOnce.Do(func() {
-		file_common_proto_rawDescData = protoimpl.X.CompressGZIP(file_common_proto_rawDescData)
+		file_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_proto_rawDesc), len(file_common_proto_rawDesc)))
 	})
 	return file_common_proto_rawDescData
 }
 
-var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_common_proto_goTypes = []interface{}{
-	(*HostAddress)(nil), // 0: common.HostAddress
+var file_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_proto_goTypes = []any{
+	(WorkerType)(0),             // 0: common.WorkerType
+	(*HostAddress)(nil),         // 1: common.HostAddress
+	(*WorkerNode)(nil),          // 2: common.WorkerNode
+	(*WorkerNode_Property)(nil), // 3: common.WorkerNode.Property
 }
 var file_common_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: common.WorkerNode.type:type_name -> common.WorkerType
+	1, // 1: common.WorkerNode.host:type_name -> common.HostAddress
+	3, // 2: common.WorkerNode.property:type_name -> common.WorkerNode.Property
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_common_proto_init() }
@@ -135,36 +350,23 @@ func file_common_proto_init() {
 	if File_common_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_common_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HostAddress); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
+	file_common_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_common_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_proto_rawDesc), len(file_common_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_common_proto_goTypes,
 		DependencyIndexes: file_common_proto_depIdxs,
+		EnumInfos:         file_common_proto_enumTypes,
 		MessageInfos:      file_common_proto_msgTypes,
 	}.Build()
 	File_common_proto = out.File
-	file_common_proto_rawDesc = nil
 	file_common_proto_goTypes = nil
 	file_common_proto_depIdxs = nil
 }
@@ -14,7 +14,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.36.12
 // 	protoc        v3.21.12
 // source: meta.proto
 
@@ -23,6 +23,7 @@ package proto
 import (
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -36,18 +37,16 @@ const (
 )
 
 type MembersRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MembersRequest) Reset() {
 	*x = MembersRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_meta_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_meta_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *MembersRequest) String() string {
@@ -58,7 +57,7 @@ func (*MembersRequest) ProtoMessage() {}
 
 func (x *MembersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_meta_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -74,21 +73,18 @@ func (*MembersRequest) Descriptor() ([]byte, []int) {
 }
 
 type MetaMember struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *HostAddress           `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	IsLeader      bool                   `protobuf:"varint,2,opt,name=is_leader,json=isLeader,proto3" json:"is_leader,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Address  *HostAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	IsLeader bool         `protobuf:"varint,2,opt,name=is_leader,json=isLeader,proto3" json:"is_leader,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MetaMember) Reset() {
 	*x = MetaMember{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_meta_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_meta_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *MetaMember) String() string {
@@ -99,7 +95,7 @@ func (*MetaMember) ProtoMessage() {}
 
 func (x *MetaMember) ProtoReflect() protoreflect.Message {
 	mi := &file_meta_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -129,20 +125,17 @@ func (x *MetaMember) GetIsLeader() bool {
 }
 
 type MembersResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*MetaMember          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Members []*MetaMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MembersResponse) Reset() {
 	*x = MembersResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_meta_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_meta_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *MembersResponse) String() string {
@@ -153,7 +146,7 @@ func (*MembersResponse) ProtoMessage() {}
 
 func (x *MembersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_meta_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -175,64 +168,611 @@ func (x *MembersResponse) GetMembers() []*MetaMember {
 	return nil
 }
 
-var File_meta_proto protoreflect.FileDescriptor
+type ListAllNodesRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	WorkerType           *WorkerType            `protobuf:"varint,1,opt,name=worker_type,json=workerType,proto3,enum=common.WorkerType,oneof" json:"worker_type,omitempty"`
+	IncludeStartingNodes bool                   `protobuf:"varint,2,opt,name=include_starting_nodes,json=includeStartingNodes,proto3" json:"include_starting_nodes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ListAllNodesRequest) Reset() {
+	*x = ListAllNodesRequest{}
+	mi := &file_meta_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAllNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllNodesRequest) ProtoMessage() {}
+
+func (x *ListAllNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllNodesRequest.ProtoReflect.Descriptor instead.
+func (*ListAllNodesRequest) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAllNodesRequest) GetWorkerType() WorkerType {
+	if x != nil && x.WorkerType != nil {
+		return *x.WorkerType
+	}
+	return WorkerType_WORKER_TYPE_UNSPECIFIED
+}
+
+func (x *ListAllNodesRequest) GetIncludeStartingNodes() bool {
+	if x != nil {
+		return x.IncludeStartingNodes
+	}
+	return false
+}
+
+type ListAllNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*WorkerNode          `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAllNodesResponse) Reset() {
+	*x = ListAllNodesResponse{}
+	mi := &file_meta_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAllNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllNodesResponse) ProtoMessage() {}
+
+func (x *ListAllNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllNodesResponse.ProtoReflect.Descriptor instead.
+func (*ListAllNodesResponse) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAllNodesResponse) GetNodes() []*WorkerNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type ListStreamingJobStatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStreamingJobStatesRequest) Reset() {
+	*x = ListStreamingJobStatesRequest{}
+	mi := &file_meta_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStreamingJobStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStreamingJobStatesRequest) ProtoMessage() {}
+
+func (x *ListStreamingJobStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStreamingJobStatesRequest.ProtoReflect.Descriptor instead.
+func (*ListStreamingJobStatesRequest) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{5}
+}
+
+// Only the fields used by the operator are kept.
+type ListStreamingJobStatesResponse struct {
+	state         protoimpl.MessageState                              `protogen:"open.v1"`
+	States        []*ListStreamingJobStatesResponse_StreamingJobState `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStreamingJobStatesResponse) Reset() {
+	*x = ListStreamingJobStatesResponse{}
+	mi := &file_meta_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStreamingJobStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStreamingJobStatesResponse) ProtoMessage() {}
+
+func (x *ListStreamingJobStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStreamingJobStatesResponse.ProtoReflect.Descriptor instead.
+func (*ListStreamingJobStatesResponse) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListStreamingJobStatesResponse) GetStates() []*ListStreamingJobStatesResponse_StreamingJobState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+// Only the fields used by the operator are kept.
+type SystemParams struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	StateStore             *string                `protobuf:"bytes,6,opt,name=state_store,json=stateStore,proto3,oneof" json:"state_store,omitempty"`
+	DataDirectory          *string                `protobuf:"bytes,7,opt,name=data_directory,json=dataDirectory,proto3,oneof" json:"data_directory,omitempty"`
+	BackupStorageUrl       *string                `protobuf:"bytes,8,opt,name=backup_storage_url,json=backupStorageUrl,proto3,oneof" json:"backup_storage_url,omitempty"`
+	BackupStorageDirectory *string                `protobuf:"bytes,9,opt,name=backup_storage_directory,json=backupStorageDirectory,proto3,oneof" json:"backup_storage_directory,omitempty"`
+	PauseOnNextBootstrap   *bool                  `protobuf:"varint,13,opt,name=pause_on_next_bootstrap,json=pauseOnNextBootstrap,proto3,oneof" json:"pause_on_next_bootstrap,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SystemParams) Reset() {
+	*x = SystemParams{}
+	mi := &file_meta_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemParams) ProtoMessage() {}
+
+func (x *SystemParams) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemParams.ProtoReflect.Descriptor instead.
+func (*SystemParams) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SystemParams) GetStateStore() string {
+	if x != nil && x.StateStore != nil {
+		return *x.StateStore
+	}
+	return ""
+}
+
+func (x *SystemParams) GetDataDirectory() string {
+	if x != nil && x.DataDirectory != nil {
+		return *x.DataDirectory
+	}
+	return ""
+}
+
+func (x *SystemParams) GetBackupStorageUrl() string {
+	if x != nil && x.BackupStorageUrl != nil {
+		return *x.BackupStorageUrl
+	}
+	return ""
+}
+
+func (x *SystemParams) GetBackupStorageDirectory() string {
+	if x != nil && x.BackupStorageDirectory != nil {
+		return *x.BackupStorageDirectory
+	}
+	return ""
+}
+
+func (x *SystemParams) GetPauseOnNextBootstrap() bool {
+	if x != nil && x.PauseOnNextBootstrap != nil {
+		return *x.PauseOnNextBootstrap
+	}
+	return false
+}
+
+type GetSystemParamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemParamsRequest) Reset() {
+	*x = GetSystemParamsRequest{}
+	mi := &file_meta_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemParamsRequest) ProtoMessage() {}
+
+func (x *GetSystemParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemParamsRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemParamsRequest) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{8}
+}
+
+type GetSystemParamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Params        *SystemParams          `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemParamsResponse) Reset() {
+	*x = GetSystemParamsResponse{}
+	mi := &file_meta_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemParamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemParamsResponse) ProtoMessage() {}
+
+func (x *GetSystemParamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemParamsResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemParamsResponse) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSystemParamsResponse) GetParams() *SystemParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SetSystemParamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Param string                 `protobuf:"bytes,1,opt,name=param,proto3" json:"param,omitempty"`
+	// None means set to default value.
+	Value         *string `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSystemParamRequest) Reset() {
+	*x = SetSystemParamRequest{}
+	mi := &file_meta_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSystemParamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSystemParamRequest) ProtoMessage() {}
+
+func (x *SetSystemParamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSystemParamRequest.ProtoReflect.Descriptor instead.
+func (*SetSystemParamRequest) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetSystemParamRequest) GetParam() string {
+	if x != nil {
+		return x.Param
+	}
+	return ""
+}
+
+func (x *SetSystemParamRequest) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+type SetSystemParamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Params        *SystemParams          `protobuf:"bytes,1,opt,name=params,proto3,oneof" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSystemParamResponse) Reset() {
+	*x = SetSystemParamResponse{}
+	mi := &file_meta_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSystemParamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSystemParamResponse) ProtoMessage() {}
+
+func (x *SetSystemParamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSystemParamResponse.ProtoReflect.Descriptor instead.
+func (*SetSystemParamResponse) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetSystemParamResponse) GetParams() *SystemParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type ListStreamingJobStatesResponse_StreamingJobState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       uint32                 `protobuf:"varint,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	ResourceGroup string                 `protobuf:"bytes,6,opt,name=resource_group,json=resourceGroup,proto3" json:"resource_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStreamingJobStatesResponse_StreamingJobState) Reset() {
+	*x = ListStreamingJobStatesResponse_StreamingJobState{}
+	mi := &file_meta_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStreamingJobStatesResponse_StreamingJobState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStreamingJobStatesResponse_StreamingJobState) ProtoMessage() {}
+
+func (x *ListStreamingJobStatesResponse_StreamingJobState) ProtoReflect() protoreflect.Message {
+	mi := &file_meta_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-var file_meta_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6d, 0x65,
-	0x74, 0x61, 0x1a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x10, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x58, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x61, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x2d, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
-	0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x3d, 0x0a, 0x0f,
-	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x4d, 0x65, 0x6d, 0x62,
-	0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x32, 0x4b, 0x0a, 0x11, 0x4d,
-	0x65, 0x74, 0x61, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x36, 0x0a, 0x07, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x14, 0x2e, 0x6d, 0x65,
-	0x74, 0x61, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x51, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x2e,
-	0x72, 0x69, 0x73, 0x69, 0x6e, 0x67, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x48, 0x01, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72,
-	0x69, 0x73, 0x69, 0x6e, 0x67, 0x77, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x72, 0x69,
-	0x73, 0x69, 0x6e, 0x67, 0x77, 0x61, 0x76, 0x65, 0x2d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+// Deprecated: Use ListStreamingJobStatesResponse_StreamingJobState.ProtoReflect.Descriptor instead.
+func (*ListStreamingJobStatesResponse_StreamingJobState) Descriptor() ([]byte, []int) {
+	return file_meta_proto_rawDescGZIP(), []int{6, 0}
 }
 
+func (x *ListStreamingJobStatesResponse_StreamingJobState) GetTableId() uint32 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *ListStreamingJobStatesResponse_StreamingJobState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListStreamingJobStatesResponse_StreamingJobState) GetResourceGroup() string {
+	if x != nil {
+		return x.ResourceGroup
+	}
+	return ""
+}
+
+var File_meta_proto protoreflect.FileDescriptor
+
+const file_meta_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"meta.proto\x12\x04meta\x1a\fcommon.proto\"\x10\n" +
+	"\x0eMembersRequest\"X\n" +
+	"\n" +
+	"MetaMember\x12-\n" +
+	"\aaddress\x18\x01 \x01(\v2\x13.common.HostAddressR\aaddress\x12\x1b\n" +
+	"\tis_leader\x18\x02 \x01(\bR\bisLeader\"=\n" +
+	"\x0fMembersResponse\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.meta.MetaMemberR\amembers\"\x95\x01\n" +
+	"\x13ListAllNodesRequest\x128\n" +
+	"\vworker_type\x18\x01 \x01(\x0e2\x12.common.WorkerTypeH\x00R\n" +
+	"workerType\x88\x01\x01\x124\n" +
+	"\x16include_starting_nodes\x18\x02 \x01(\bR\x14includeStartingNodesB\x0e\n" +
+	"\f_worker_type\"@\n" +
+	"\x14ListAllNodesResponse\x12(\n" +
+	"\x05nodes\x18\x02 \x03(\v2\x12.common.WorkerNodeR\x05nodes\"\x1f\n" +
+	"\x1dListStreamingJobStatesRequest\"\xdb\x01\n" +
+	"\x1eListStreamingJobStatesResponse\x12N\n" +
+	"\x06states\x18\x01 \x03(\v26.meta.ListStreamingJobStatesResponse.StreamingJobStateR\x06states\x1ai\n" +
+	"\x11StreamingJobState\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\rR\atableId\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12%\n" +
+	"\x0eresource_group\x18\x06 \x01(\tR\rresourceGroup\"\x81\x03\n" +
+	"\fSystemParams\x12$\n" +
+	"\vstate_store\x18\x06 \x01(\tH\x00R\n" +
+	"stateStore\x88\x01\x01\x12*\n" +
+	"\x0edata_directory\x18\a \x01(\tH\x01R\rdataDirectory\x88\x01\x01\x121\n" +
+	"\x12backup_storage_url\x18\b \x01(\tH\x02R\x10backupStorageUrl\x88\x01\x01\x12=\n" +
+	"\x18backup_storage_directory\x18\t \x01(\tH\x03R\x16backupStorageDirectory\x88\x01\x01\x12:\n" +
+	"\x17pause_on_next_bootstrap\x18\r \x01(\bH\x04R\x14pauseOnNextBootstrap\x88\x01\x01B\x0e\n" +
+	"\f_state_storeB\x11\n" +
+	"\x0f_data_directoryB\x15\n" +
+	"\x13_backup_storage_urlB\x1b\n" +
+	"\x19_backup_storage_directoryB\x1a\n" +
+	"\x18_pause_on_next_bootstrap\"\x18\n" +
+	"\x16GetSystemParamsRequest\"E\n" +
+	"\x17GetSystemParamsResponse\x12*\n" +
+	"\x06params\x18\x01 \x01(\v2\x12.meta.SystemParamsR\x06params\"R\n" +
+	"\x15SetSystemParamRequest\x12\x14\n" +
+	"\x05param\x18\x01 \x01(\tR\x05param\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tH\x00R\x05value\x88\x01\x01B\b\n" +
+	"\x06_value\"T\n" +
+	"\x16SetSystemParamResponse\x12/\n" +
+	"\x06params\x18\x01 \x01(\v2\x12.meta.SystemParamsH\x00R\x06params\x88\x01\x01B\t\n" +
+	"\a_params2K\n" +
+	"\x11MetaMemberService\x126\n" +
+	"\aMembers\x12\x14.meta.MembersRequest\x1a\x15.meta.MembersResponse2W\n" +
+	"\x0eClusterService\x12E\n" +
+	"\fListAllNodes\x12\x19.meta.ListAllNodesRequest\x1a\x1a.meta.ListAllNodesResponse2{\n" +
+	"\x14StreamManagerService\x12c\n" +
+	"\x16ListStreamingJobStates\x12#.meta.ListStreamingJobStatesRequest\x1a$.meta.ListStreamingJobStatesResponse2\xb2\x01\n" +
+	"\x13SystemParamsService\x12N\n" +
+	"\x0fGetSystemParams\x12\x1c.meta.GetSystemParamsRequest\x1a\x1d.meta.GetSystemParamsResponse\x12K\n" +
+	"\x0eSetSystemParam\x12\x1b.meta.SetSystemParamRequest\x1a\x1c.meta.SetSystemParamResponseBQ\n" +
+	"\x14com.risingwave.protoH\x01Z7github.com/risingwavelabs/risingwave-operator/pkg/protob\x06proto3"
+
 var (
 	file_meta_proto_rawDescOnce sync.Once
-	file_meta_proto_rawDescData = file_meta_proto_rawDesc
+	file_meta_proto_rawDescData []byte
 )
 
 func file_meta_proto_rawDescGZIP() []byte {
 	file_meta_proto_rawDescOnce.Do(func() {
-		file_meta_proto_rawDescData = protoimpl.X.CompressGZIP(file_meta_proto_rawDescData)
+		file_meta_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_meta_proto_rawDesc), len(file_meta_proto_rawDesc)))
 	})
 	return file_meta_proto_rawDescData
 }
 
-var file_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_meta_proto_goTypes = []interface{}{
-	(*MembersRequest)(nil),  // 0: meta.MembersRequest
-	(*MetaMember)(nil),      // 1: meta.MetaMember
-	(*MembersResponse)(nil), // 2: meta.MembersResponse
-	(*HostAddress)(nil),     // 3: common.HostAddress
+var file_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_meta_proto_goTypes = []any{
+	(*MembersRequest)(nil),                                   // 0: meta.MembersRequest
+	(*MetaMember)(nil),                                       // 1: meta.MetaMember
+	(*MembersResponse)(nil),                                  // 2: meta.MembersResponse
+	(*ListAllNodesRequest)(nil),                              // 3: meta.ListAllNodesRequest
+	(*ListAllNodesResponse)(nil),                             // 4: meta.ListAllNodesResponse
+	(*ListStreamingJobStatesRequest)(nil),                    // 5: meta.ListStreamingJobStatesRequest
+	(*ListStreamingJobStatesResponse)(nil),                   // 6: meta.ListStreamingJobStatesResponse
+	(*SystemParams)(nil),                                     // 7: meta.SystemParams
+	(*GetSystemParamsRequest)(nil),                           // 8: meta.GetSystemParamsRequest
+	(*GetSystemParamsResponse)(nil),                          // 9: meta.GetSystemParamsResponse
+	(*SetSystemParamRequest)(nil),                            // 10: meta.SetSystemParamRequest
+	(*SetSystemParamResponse)(nil),                           // 11: meta.SetSystemParamResponse
+	(*ListStreamingJobStatesResponse_StreamingJobState)(nil), // 12: meta.ListStreamingJobStatesResponse.StreamingJobState
+	(*HostAddress)(nil),                                      // 13: common.HostAddress
+	(WorkerType)(0),                                          // 14: common.WorkerType
+	(*WorkerNode)(nil),                                       // 15: common.WorkerNode
 }
 var file_meta_proto_depIdxs = []int32{
-	3, // 0: meta.MetaMember.address:type_name -> common.HostAddress
-	1, // 1: meta.MembersResponse.members:type_name -> meta.MetaMember
-	0, // 2: meta.MetaMemberService.Members:input_type -> meta.MembersRequest
-	2, // 3: meta.MetaMemberService.Members:output_type -> meta.MembersResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	13, // 0: meta.MetaMember.address:type_name -> common.HostAddress
+	1,  // 1: meta.MembersResponse.members:type_name -> meta.MetaMember
+	14, // 2: meta.ListAllNodesRequest.worker_type:type_name -> common.WorkerType
+	15, // 3: meta.ListAllNodesResponse.nodes:type_name -> common.WorkerNode
+	12, // 4: meta.ListStreamingJobStatesResponse.states:type_name -> meta.ListStreamingJobStatesResponse.StreamingJobState
+	7,  // 5: meta.GetSystemParamsResponse.params:type_name -> meta.SystemParams
+	7,  // 6: meta.SetSystemParamResponse.params:type_name -> meta.SystemParams
+	0,  // 7: meta.MetaMemberService.Members:input_type -> meta.MembersRequest
+	3,  // 8: meta.ClusterService.ListAllNodes:input_type -> meta.ListAllNodesRequest
+	5,  // 9: meta.StreamManagerService.ListStreamingJobStates:input_type -> meta.ListStreamingJobStatesRequest
+	8,  // 10: meta.SystemParamsService.GetSystemParams:input_type -> meta.GetSystemParamsRequest
+	10, // 11: meta.SystemParamsService.SetSystemParam:input_type -> meta.SetSystemParamRequest
+	2,  // 12: meta.MetaMemberService.Members:output_type -> meta.MembersResponse
+	4,  // 13: meta.ClusterService.ListAllNodes:output_type -> meta.ListAllNodesResponse
+	6,  // 14: meta.StreamManagerService.ListStreamingJobStates:output_type -> meta.ListStreamingJobStatesResponse
+	9,  // 15: meta.SystemParamsService.GetSystemParams:output_type -> meta.GetSystemParamsResponse
+	11, // 16: meta.SystemParamsService.SetSystemParam:output_type -> meta.SetSystemParamResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_meta_proto_init() }
@@ -241,60 +781,25 @@ func file_meta_proto_init() {
 		return
 	}
 	file_common_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_meta_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MembersRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_meta_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetaMember); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_meta_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MembersResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
+	file_meta_proto_msgTypes[3].OneofWrappers = []any{}
+	file_meta_proto_msgTypes[7].OneofWrappers = []any{}
+	file_meta_proto_msgTypes[10].OneofWrappers = []any{}
+	file_meta_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_meta_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_meta_proto_rawDesc), len(file_meta_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   13,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_meta_proto_goTypes,
 		DependencyIndexes: file_meta_proto_depIdxs,
 		MessageInfos:      file_meta_proto_msgTypes,
 	}.Build()
 	File_meta_proto = out.File
-	file_meta_proto_rawDesc = nil
 	file_meta_proto_goTypes = nil
 	file_meta_proto_depIdxs = nil
 }
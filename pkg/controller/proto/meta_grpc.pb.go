@@ -118,3 +118,297 @@ var MetaMemberService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "meta.proto",
 }
+
+// ClusterServiceClient is the client API for ClusterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ClusterServiceClient interface {
+	ListAllNodes(ctx context.Context, in *ListAllNodesRequest, opts ...grpc.CallOption) (*ListAllNodesResponse, error)
+}
+
+type clusterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClusterServiceClient(cc grpc.ClientConnInterface) ClusterServiceClient {
+	return &clusterServiceClient{cc}
+}
+
+func (c *clusterServiceClient) ListAllNodes(ctx context.Context, in *ListAllNodesRequest, opts ...grpc.CallOption) (*ListAllNodesResponse, error) {
+	out := new(ListAllNodesResponse)
+	err := c.cc.Invoke(ctx, "/meta.ClusterService/ListAllNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterServiceServer is the server API for ClusterService service.
+// All implementations must embed UnimplementedClusterServiceServer
+// for forward compatibility
+type ClusterServiceServer interface {
+	ListAllNodes(context.Context, *ListAllNodesRequest) (*ListAllNodesResponse, error)
+	mustEmbedUnimplementedClusterServiceServer()
+}
+
+// UnimplementedClusterServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedClusterServiceServer struct {
+}
+
+func (UnimplementedClusterServiceServer) ListAllNodes(context.Context, *ListAllNodesRequest) (*ListAllNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllNodes not implemented")
+}
+func (UnimplementedClusterServiceServer) mustEmbedUnimplementedClusterServiceServer() {}
+
+// UnsafeClusterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClusterServiceServer will
+// result in compilation errors.
+type UnsafeClusterServiceServer interface {
+	mustEmbedUnimplementedClusterServiceServer()
+}
+
+func RegisterClusterServiceServer(s grpc.ServiceRegistrar, srv ClusterServiceServer) {
+	s.RegisterService(&ClusterService_ServiceDesc, srv)
+}
+
+func _ClusterService_ListAllNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).ListAllNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.ClusterService/ListAllNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).ListAllNodes(ctx, req.(*ListAllNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ClusterService_ServiceDesc is the grpc.ServiceDesc for ClusterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClusterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "meta.ClusterService",
+	HandlerType: (*ClusterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAllNodes",
+			Handler:    _ClusterService_ListAllNodes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "meta.proto",
+}
+
+// StreamManagerServiceClient is the client API for StreamManagerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StreamManagerServiceClient interface {
+	ListStreamingJobStates(ctx context.Context, in *ListStreamingJobStatesRequest, opts ...grpc.CallOption) (*ListStreamingJobStatesResponse, error)
+}
+
+type streamManagerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreamManagerServiceClient(cc grpc.ClientConnInterface) StreamManagerServiceClient {
+	return &streamManagerServiceClient{cc}
+}
+
+func (c *streamManagerServiceClient) ListStreamingJobStates(ctx context.Context, in *ListStreamingJobStatesRequest, opts ...grpc.CallOption) (*ListStreamingJobStatesResponse, error) {
+	out := new(ListStreamingJobStatesResponse)
+	err := c.cc.Invoke(ctx, "/meta.StreamManagerService/ListStreamingJobStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamManagerServiceServer is the server API for StreamManagerService service.
+// All implementations must embed UnimplementedStreamManagerServiceServer
+// for forward compatibility
+type StreamManagerServiceServer interface {
+	ListStreamingJobStates(context.Context, *ListStreamingJobStatesRequest) (*ListStreamingJobStatesResponse, error)
+	mustEmbedUnimplementedStreamManagerServiceServer()
+}
+
+// UnimplementedStreamManagerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStreamManagerServiceServer struct {
+}
+
+func (UnimplementedStreamManagerServiceServer) ListStreamingJobStates(context.Context, *ListStreamingJobStatesRequest) (*ListStreamingJobStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStreamingJobStates not implemented")
+}
+func (UnimplementedStreamManagerServiceServer) mustEmbedUnimplementedStreamManagerServiceServer() {}
+
+// UnsafeStreamManagerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StreamManagerServiceServer will
+// result in compilation errors.
+type UnsafeStreamManagerServiceServer interface {
+	mustEmbedUnimplementedStreamManagerServiceServer()
+}
+
+func RegisterStreamManagerServiceServer(s grpc.ServiceRegistrar, srv StreamManagerServiceServer) {
+	s.RegisterService(&StreamManagerService_ServiceDesc, srv)
+}
+
+func _StreamManagerService_ListStreamingJobStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStreamingJobStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamManagerServiceServer).ListStreamingJobStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.StreamManagerService/ListStreamingJobStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamManagerServiceServer).ListStreamingJobStates(ctx, req.(*ListStreamingJobStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StreamManagerService_ServiceDesc is the grpc.ServiceDesc for StreamManagerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StreamManagerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "meta.StreamManagerService",
+	HandlerType: (*StreamManagerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStreamingJobStates",
+			Handler:    _StreamManagerService_ListStreamingJobStates_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "meta.proto",
+}
+
+// SystemParamsServiceClient is the client API for SystemParamsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SystemParamsServiceClient interface {
+	GetSystemParams(ctx context.Context, in *GetSystemParamsRequest, opts ...grpc.CallOption) (*GetSystemParamsResponse, error)
+	SetSystemParam(ctx context.Context, in *SetSystemParamRequest, opts ...grpc.CallOption) (*SetSystemParamResponse, error)
+}
+
+type systemParamsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSystemParamsServiceClient(cc grpc.ClientConnInterface) SystemParamsServiceClient {
+	return &systemParamsServiceClient{cc}
+}
+
+func (c *systemParamsServiceClient) GetSystemParams(ctx context.Context, in *GetSystemParamsRequest, opts ...grpc.CallOption) (*GetSystemParamsResponse, error) {
+	out := new(GetSystemParamsResponse)
+	err := c.cc.Invoke(ctx, "/meta.SystemParamsService/GetSystemParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemParamsServiceClient) SetSystemParam(ctx context.Context, in *SetSystemParamRequest, opts ...grpc.CallOption) (*SetSystemParamResponse, error) {
+	out := new(SetSystemParamResponse)
+	err := c.cc.Invoke(ctx, "/meta.SystemParamsService/SetSystemParam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SystemParamsServiceServer is the server API for SystemParamsService service.
+// All implementations must embed UnimplementedSystemParamsServiceServer
+// for forward compatibility
+type SystemParamsServiceServer interface {
+	GetSystemParams(context.Context, *GetSystemParamsRequest) (*GetSystemParamsResponse, error)
+	SetSystemParam(context.Context, *SetSystemParamRequest) (*SetSystemParamResponse, error)
+	mustEmbedUnimplementedSystemParamsServiceServer()
+}
+
+// UnimplementedSystemParamsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSystemParamsServiceServer struct {
+}
+
+func (UnimplementedSystemParamsServiceServer) GetSystemParams(context.Context, *GetSystemParamsRequest) (*GetSystemParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystemParams not implemented")
+}
+func (UnimplementedSystemParamsServiceServer) SetSystemParam(context.Context, *SetSystemParamRequest) (*SetSystemParamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSystemParam not implemented")
+}
+func (UnimplementedSystemParamsServiceServer) mustEmbedUnimplementedSystemParamsServiceServer() {}
+
+// UnsafeSystemParamsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SystemParamsServiceServer will
+// result in compilation errors.
+type UnsafeSystemParamsServiceServer interface {
+	mustEmbedUnimplementedSystemParamsServiceServer()
+}
+
+func RegisterSystemParamsServiceServer(s grpc.ServiceRegistrar, srv SystemParamsServiceServer) {
+	s.RegisterService(&SystemParamsService_ServiceDesc, srv)
+}
+
+func _SystemParamsService_GetSystemParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSystemParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemParamsServiceServer).GetSystemParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.SystemParamsService/GetSystemParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemParamsServiceServer).GetSystemParams(ctx, req.(*GetSystemParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemParamsService_SetSystemParam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSystemParamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemParamsServiceServer).SetSystemParam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.SystemParamsService/SetSystemParam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemParamsServiceServer).SetSystemParam(ctx, req.(*SetSystemParamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SystemParamsService_ServiceDesc is the grpc.ServiceDesc for SystemParamsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SystemParamsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "meta.SystemParamsService",
+	HandlerType: (*SystemParamsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSystemParams",
+			Handler:    _SystemParamsService_GetSystemParams_Handler,
+		},
+		{
+			MethodName: "SetSystemParam",
+			Handler:    _SystemParamsService_SetSystemParam_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "meta.proto",
+}
@@ -0,0 +1,619 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/risingwavelabs/ctrlkit"
+	"github.com/samber/lo"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	pb "github.com/risingwavelabs/risingwave-operator/pkg/controller/proto"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory"
+	"github.com/risingwavelabs/risingwave-operator/pkg/manager"
+	"github.com/risingwavelabs/risingwave-operator/pkg/meta"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+)
+
+// Actions to clone a RisingWave from the source.
+const (
+	RisingWaveAction_ValidateCloneSource       = "ValidateCloneSource"
+	RisingWaveAction_SnapshotCloneSource       = "SnapshotCloneSource"
+	RisingWaveAction_CopyCloneSourceData       = "CopyCloneSourceData"
+	RisingWaveAction_RestoreCloneMetaSnapshot  = "RestoreCloneMetaSnapshot"
+	RisingWaveAction_PauseCloneOnBootstrap     = "PauseCloneOnBootstrap"
+	RisingWaveAction_DeleteCloneSourceSnapshot = "DeleteCloneSourceSnapshot"
+	RisingWaveAction_MarkCloneCompleted        = "MarkCloneCompleted"
+	RisingWaveAction_SyncClonePauseOnBootstrap = "SyncClonePauseOnBootstrap"
+)
+
+const (
+	// cloneRetryInterval is the interval to retry the clone after it fails.
+	cloneRetryInterval = 30 * time.Second
+
+	// cloneCheckInterval is the interval to check if the meta snapshot is taken or restored.
+	cloneCheckInterval = 5 * time.Second
+
+	// clonePauseInterval is the interval to pause the streaming jobs of the completed clone again.
+	clonePauseInterval = 30 * time.Second
+
+	// cloneMetaRequestTimeout is the timeout of the requests to the meta service.
+	cloneMetaRequestTimeout = 10 * time.Second
+
+	// systemParamPauseOnNextBootstrap is the system parameter to pause the streaming jobs on the next bootstrap.
+	systemParamPauseOnNextBootstrap = "pause_on_next_bootstrap"
+)
+
+// isCloneInProgress tells if the RisingWave is a clone which isn't completed yet. No component is created before the
+// clone completes.
+func isCloneInProgress(risingwave *risingwavev1alpha1.RisingWave) bool {
+	return risingwave.Spec.CloneFrom != nil &&
+		(risingwave.Status.Clone == nil || risingwave.Status.Clone.Phase != risingwavev1alpha1.RisingWaveClonePhaseCompleted)
+}
+
+// cloneSourceKey returns the key of the source of the clone.
+func cloneSourceKey(risingwave *risingwavev1alpha1.RisingWave) client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: cmp.Or(risingwave.Spec.CloneFrom.Namespace, risingwave.Namespace),
+		Name:      risingwave.Spec.CloneFrom.Name,
+	}
+}
+
+// validateCloneSource tells why the RisingWave can't be cloned from the source. It returns an empty string if it can.
+func validateCloneSource(clone, source *risingwavev1alpha1.RisingWave) string {
+	metaStore := &clone.Spec.MetaStore
+	if metaStore.MySQL == nil && metaStore.PostgreSQL == nil {
+		return "meta store must be MySQL or PostgreSQL"
+	}
+
+	if !stores.IsBucketSupported(&clone.Spec.StateStore) {
+		return "state store must be an S3 compatible bucket with the credentials in a Secret"
+	}
+
+	// The meta service can't run alone in the standalone mode to pause the streaming jobs before the first bootstrap.
+	if object.NewRisingWaveReader(clone).IsStandaloneModeEnabled() && !clone.Spec.CloneFrom.AllowSinks {
+		return "sinks must be allowed in the standalone mode"
+	}
+
+	cloneLocation, _ := stores.DataLocationOf(clone)
+	sourceLocation, ok := stores.DataLocationOf(source)
+	if !ok || sourceLocation.Store != cloneLocation.Store {
+		return "state store must be the same bucket as the source's"
+	}
+
+	if cloneLocation.Overlaps(sourceLocation) {
+		return fmt.Sprintf("data directory %s overlaps with the source's %s", cloneLocation.Directory, sourceLocation.Directory)
+	}
+
+	return ""
+}
+
+// metaClientOf creates a client of the meta service served by the Pod.
+func (c *RisingWaveController) metaClientOf(pod *corev1.Pod) (*meta.Client, error) {
+	addr := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(consts.MetaServicePort)))
+	if c.dialMeta != nil {
+		return c.dialMeta(addr)
+	}
+
+	return meta.NewClient(addr)
+}
+
+// findMetaPodOf returns a running Pod serving the meta service of the RisingWave, preferring the leader. It returns
+// nil if there's none.
+func (c *RisingWaveController) findMetaPodOf(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := c.Client.List(ctx, &pods, client.InNamespace(risingwave.Namespace), client.MatchingLabelsSelector{
+		Selector: workloadSelector(risingwave, consts.ComponentMeta, consts.ComponentStandalone),
+	}); err != nil {
+		return nil, err
+	}
+
+	component := lo.If(object.NewRisingWaveReader(risingwave).IsStandaloneModeEnabled(), consts.ComponentStandalone).
+		Else(consts.ComponentMeta)
+
+	return manager.FindMetaPod(pods.Items, component), nil
+}
+
+// newMetaClientOf creates a client of the meta service of the RisingWave. It returns nil if no meta Pod is running.
+func (c *RisingWaveController) newMetaClientOf(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) (*meta.Client, error) {
+	pod, err := c.findMetaPodOf(ctx, risingwave)
+	if err != nil || pod == nil {
+		return nil, err
+	}
+
+	return c.metaClientOf(pod)
+}
+
+func (c *RisingWaveController) cloneWorkflow(risingwaveManger *object.RisingWaveManager, mgr *manager.RisingWaveControllerManager) ctrlkit.Action {
+	updateCloneStatus := func(f func(status *risingwavev1alpha1.RisingWaveCloneStatus)) {
+		risingwaveManger.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+			if status.Clone == nil {
+				status.Clone = &risingwavev1alpha1.RisingWaveCloneStatus{}
+			}
+			f(status.Clone)
+		})
+	}
+
+	// The status is read from the after image, since the later steps depend on the progress of the earlier ones.
+	cloneStatus := func() risingwavev1alpha1.RisingWaveCloneStatus {
+		return ptr.Deref(risingwaveManger.RisingWaveAfterImage().Status.Clone, risingwavev1alpha1.RisingWaveCloneStatus{})
+	}
+
+	markCloneInProgress := func(phase risingwavev1alpha1.RisingWaveClonePhase, message string) {
+		updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+			status.Phase = phase
+			status.Message = message
+		})
+	}
+
+	markCloneFailed := func(message string) (ctrl.Result, error) {
+		updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+			status.Phase = risingwavev1alpha1.RisingWaveClonePhaseFailed
+			status.Message = message
+		})
+
+		return ctrlkit.RequeueAfter(cloneRetryInterval)
+	}
+
+	// The source is read by the validation, which always runs first.
+	var source *risingwavev1alpha1.RisingWave
+
+	validateSource := mgr.NewAction(RisingWaveAction_ValidateCloneSource, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		clone := risingwaveManger.RisingWave()
+		key := cloneSourceKey(clone)
+
+		source = &risingwavev1alpha1.RisingWave{}
+		if err := c.Client.Get(ctx, key, source); err != nil {
+			if apierrors.IsNotFound(err) {
+				return markCloneFailed(fmt.Sprintf("source RisingWave %s not found", key))
+			}
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to get source risingwave", err)
+		}
+
+		if reason := validateCloneSource(clone, source); reason != "" {
+			return markCloneFailed(reason)
+		}
+
+		// The data copied from the source must not be used by any other RisingWave, e.g., another clone.
+		other, err := c.findSharedDataLocation(ctx, clone)
+		if err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to list risingwaves", err)
+		}
+		if other != nil {
+			return markCloneFailed(fmt.Sprintf("data directory is shared with RisingWave %s/%s", other.Namespace, other.Name))
+		}
+
+		status := cloneStatus()
+		if len(status.Lineage) > 0 && status.Lineage[0].UID != source.UID {
+			return markCloneFailed(fmt.Sprintf("source RisingWave %s is recreated during the clone", key))
+		}
+
+		// Record the lineage once, the source first, followed by the ones it's cloned from.
+		if len(status.Lineage) == 0 {
+			lineage := []risingwavev1alpha1.RisingWaveCloneLineage{
+				{Namespace: source.Namespace, Name: source.Name, UID: source.UID},
+			}
+			if source.Status.Clone != nil {
+				lineage = append(lineage, source.Status.Clone.Lineage...)
+			}
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.Lineage = lineage
+			})
+		}
+
+		return ctrlkit.Continue()
+	})
+
+	snapshotSource := mgr.NewAction(RisingWaveAction_SnapshotCloneSource, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		status := cloneStatus()
+		if status.Lineage[0].SnapshotID != nil {
+			return ctrlkit.Continue()
+		}
+
+		metaClient, err := c.newMetaClientOf(ctx, source)
+		if err != nil {
+			return markCloneFailed(err.Error())
+		}
+		if metaClient == nil {
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseSnapshotting, "Waiting for the meta service of the source to run")
+
+			return ctrlkit.RequeueAfter(cloneRetryInterval)
+		}
+		defer metaClient.Close() //nolint:errcheck
+
+		ctx, cancel := context.WithTimeout(ctx, cloneMetaRequestTimeout)
+		defer cancel()
+
+		// Take a snapshot if there's none in progress.
+		if status.BackupJobID == nil {
+			params, err := metaClient.GetSystemParams(ctx)
+			if err != nil {
+				return markCloneFailed(err.Error())
+			}
+
+			// The snapshot is restored with the credentials of the clone, so it must be in the same bucket.
+			if params.GetBackupStorageUrl() != strings.TrimPrefix(params.GetStateStore(), "hummock+") {
+				return markCloneFailed("backup storage of the source must be its state store")
+			}
+
+			jobID, err := metaClient.BackupMeta(ctx)
+			if err != nil {
+				return markCloneFailed(err.Error())
+			}
+
+			l.Info("Taking meta snapshot of the source", "source", client.ObjectKeyFromObject(source), "job", jobID)
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.BackupJobID = ptr.To(int64(jobID))
+				status.SourceBackupDirectory = params.GetBackupStorageDirectory()
+			})
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseSnapshotting, fmt.Sprintf("Taking meta snapshot %d of the source", jobID))
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+
+		jobID := *status.BackupJobID
+		jobStatus, message, err := metaClient.GetBackupJobStatus(ctx, uint64(jobID))
+		if err != nil {
+			return markCloneFailed(err.Error())
+		}
+
+		switch jobStatus {
+		case pb.BackupJobStatus_SUCCEEDED:
+			l.Info("Meta snapshot of the source taken", "source", client.ObjectKeyFromObject(source), "snapshot", jobID)
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.Lineage[0].SnapshotID = ptr.To(jobID)
+				status.Lineage[0].SnapshotTime = ptr.To(metav1.Now())
+			})
+
+			return ctrlkit.Continue()
+		case pb.BackupJobStatus_RUNNING:
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseSnapshotting, fmt.Sprintf("Taking meta snapshot %d of the source", jobID))
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		default:
+			// Take another snapshot on the next try.
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.BackupJobID = nil
+			})
+
+			return markCloneFailed(fmt.Sprintf("backup job %d of the source is %s: %s", jobID, strings.ToLower(jobStatus.String()), message))
+		}
+	})
+
+	copySourceData := mgr.NewAction(RisingWaveAction_CopyCloneSourceData, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		status := cloneStatus()
+		if status.DataCopied {
+			return ctrlkit.Continue()
+		}
+
+		clone := risingwaveManger.RisingWave()
+		name := clone.Name + "-clone-copy"
+		sourceLocation, _ := stores.DataLocationOf(source)
+		cloneLocation, _ := stores.DataLocationOf(clone)
+		copyingMessage := fmt.Sprintf("Copying objects from %s to %s with Job %s", sourceLocation.Directory, cloneLocation.Directory, name)
+
+		var job batchv1.Job
+		err := c.Client.Get(ctx, client.ObjectKey{Namespace: clone.Namespace, Name: name}, &job)
+		if apierrors.IsNotFound(err) {
+			bucket, err := stores.ResolveBucket(ctx, c.apiReader, clone)
+			if err != nil {
+				return markCloneFailed(err.Error())
+			}
+
+			newJob := factory.NewRisingWaveObjectFactory(clone, c.Client.Scheme(), c.operatorVersion).
+				NewCloneCopyJob(name, bucket.Name, bucket.EndpointURL(), bucket.Region, bucket.AddressingStyle(), sourceLocation.Directory)
+			if err := controllerutil.SetControllerReference(clone, newJob, c.Client.Scheme()); err != nil {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to set controller reference", err)
+			}
+			if err := c.Client.Create(ctx, newJob); err != nil && !apierrors.IsAlreadyExists(err) {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to create copy job", err)
+			}
+
+			l.Info("Copying data of the source", "job", name, "bucket", bucket.Name)
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.CopyJob = name
+			})
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseCopying, copyingMessage)
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+		if err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to get copy job", err)
+		}
+
+		if !metav1.IsControlledBy(&job, clone) {
+			return markCloneFailed(fmt.Sprintf("Job %s isn't created by the clone", name))
+		}
+
+		// The objects copied are skipped by the next Job, so delete the failed one to retry.
+		if failed, message := isJobConditionTrue(&job, batchv1.JobFailed); failed {
+			err := c.Client.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if client.IgnoreNotFound(err) != nil {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to delete copy job", err)
+			}
+
+			return markCloneFailed(fmt.Sprintf("Job %s failed: %s", name, message))
+		}
+
+		if completed, _ := isJobConditionTrue(&job, batchv1.JobComplete); !completed {
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseCopying, copyingMessage)
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+
+		l.Info("Data of the source copied", "job", name, "from", sourceLocation.Directory, "to", cloneLocation.Directory)
+		updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+			status.DataCopied = true
+		})
+
+		return ctrlkit.Continue()
+	})
+
+	restoreMetaSnapshot := mgr.NewAction(RisingWaveAction_RestoreCloneMetaSnapshot, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		clone := risingwaveManger.RisingWave()
+		name := clone.Name + "-clone-restore"
+
+		var job batchv1.Job
+		err := c.Client.Get(ctx, client.ObjectKey{Namespace: clone.Namespace, Name: name}, &job)
+		if apierrors.IsNotFound(err) {
+			status := cloneStatus()
+			newJob := factory.NewRisingWaveObjectFactory(clone, c.Client.Scheme(), c.operatorVersion).
+				NewCloneRestoreJob(name, *status.Lineage[0].SnapshotID, status.SourceBackupDirectory)
+			if err := controllerutil.SetControllerReference(clone, newJob, c.Client.Scheme()); err != nil {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to set controller reference", err)
+			}
+			if err := c.Client.Create(ctx, newJob); err != nil && !apierrors.IsAlreadyExists(err) {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to create restore job", err)
+			}
+
+			l.Info("Restoring meta snapshot of the source", "job", name)
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.Job = name
+			})
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseRestoring, fmt.Sprintf("Restoring meta snapshot with Job %s", name))
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+		if err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to get restore job", err)
+		}
+
+		if !metav1.IsControlledBy(&job, clone) {
+			return markCloneFailed(fmt.Sprintf("Job %s isn't created by the clone", name))
+		}
+
+		// Never retry, since the restore requires an empty meta store. The Job is recreated after it's deleted.
+		if failed, message := isJobConditionTrue(&job, batchv1.JobFailed); failed {
+			return markCloneFailed(fmt.Sprintf("Job %s failed: %s, clean up the meta store and delete the Job to retry", name, message))
+		}
+
+		if completed, _ := isJobConditionTrue(&job, batchv1.JobComplete); !completed {
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhaseRestoring, fmt.Sprintf("Restoring meta snapshot with Job %s", name))
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+
+		return ctrlkit.Continue()
+	})
+
+	// The meta service of the clone runs alone until the streaming jobs are paused on bootstrap, so that no compute
+	// node runs the sinks before that.
+	syncMetaComponent := ctrlkit.Sequential(
+		mgr.SyncConfigConfigMap(),
+		ctrlkit.ParallelJoin(
+			mgr.SyncMetaService(),
+			mgr.SyncMetaStatefulSets(),
+			ctrlkit.If(c.openKruiseAvailable, mgr.SyncMetaAdvancedStatefulSets()),
+		),
+	)
+
+	pauseOnBootstrap := mgr.NewAction(RisingWaveAction_PauseCloneOnBootstrap, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		clone := risingwaveManger.RisingWave()
+		status := cloneStatus()
+
+		metaClient, err := c.newMetaClientOf(ctx, clone)
+		if err != nil {
+			return markCloneFailed(err.Error())
+		}
+		if metaClient == nil {
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhasePausing, "Waiting for the meta service of the clone to run")
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+		defer metaClient.Close() //nolint:errcheck
+
+		ctx, cancel := context.WithTimeout(ctx, cloneMetaRequestTimeout)
+		defer cancel()
+
+		params, err := metaClient.GetSystemParams(ctx)
+		if err != nil {
+			return markCloneFailed(err.Error())
+		}
+		if params.PauseOnNextBootstrap == nil {
+			return markCloneFailed(fmt.Sprintf("system parameter %s isn't supported, allow the sinks to clone", systemParamPauseOnNextBootstrap))
+		}
+
+		// The meta service has bootstrapped before the parameter is set, so restart it to bootstrap again.
+		if !status.PauseRequested {
+			if err := metaClient.SetSystemParam(ctx, systemParamPauseOnNextBootstrap, "true"); err != nil {
+				return markCloneFailed(err.Error())
+			}
+			if err := c.deleteMetaPodsOf(ctx, clone); err != nil {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to restart meta pods", err)
+			}
+
+			l.Info("Restarting the meta service of the clone to pause on bootstrap")
+			updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+				status.PauseRequested = true
+			})
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhasePausing, "Restarting the meta service to pause the streaming jobs on bootstrap")
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+
+		// The parameter is reset once it's taken by a bootstrap.
+		if params.GetPauseOnNextBootstrap() {
+			markCloneInProgress(risingwavev1alpha1.RisingWaveClonePhasePausing, "Waiting for the meta service to bootstrap with the streaming jobs paused")
+
+			return ctrlkit.RequeueAfter(cloneCheckInterval)
+		}
+
+		return ctrlkit.Continue()
+	})
+
+	deleteSourceSnapshot := mgr.NewAction(RisingWaveAction_DeleteCloneSourceSnapshot, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		// The snapshot pins the data of the source from being vacuumed. Delete it on the best effort, since the clone
+		// doesn't depend on it anymore.
+		snapshotID := *cloneStatus().Lineage[0].SnapshotID
+		err := func() error {
+			metaClient, err := c.newMetaClientOf(ctx, source)
+			if err != nil || metaClient == nil {
+				return cmp.Or(err, errors.New("meta service of the source isn't running"))
+			}
+			defer metaClient.Close() //nolint:errcheck
+
+			ctx, cancel := context.WithTimeout(ctx, cloneMetaRequestTimeout)
+			defer cancel()
+
+			return metaClient.DeleteMetaSnapshots(ctx, uint64(snapshotID))
+		}()
+		if err != nil {
+			l.Info("Failed to delete meta snapshot of the source, skip", "snapshot", snapshotID, "error", err.Error())
+		}
+
+		return ctrlkit.Continue()
+	})
+
+	markCloneCompleted := mgr.NewAction(RisingWaveAction_MarkCloneCompleted, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		updateCloneStatus(func(status *risingwavev1alpha1.RisingWaveCloneStatus) {
+			status.Phase = risingwavev1alpha1.RisingWaveClonePhaseCompleted
+			status.Message = ""
+			status.CompletionTime = ptr.To(metav1.Now())
+		})
+
+		return ctrlkit.Continue()
+	})
+
+	return ctrlkit.Sequential(
+		validateSource,
+		snapshotSource,
+		copySourceData,
+		restoreMetaSnapshot,
+		ctrlkit.If(!risingwaveManger.RisingWave().Spec.CloneFrom.AllowSinks, ctrlkit.Sequential(syncMetaComponent, pauseOnBootstrap)),
+		deleteSourceSnapshot,
+		markCloneCompleted,
+	)
+}
+
+// syncClonePauseOnBootstrap keeps the streaming jobs of the completed clone paused unless the sinks are allowed. The
+// parameter is set again since it's reset after each bootstrap, and the streaming jobs are paused again in case they
+// are resumed, e.g., with risectl. Once the sinks are allowed, the streaming jobs paused by the clone are resumed.
+func (c *RisingWaveController) syncClonePauseOnBootstrap(risingwaveManger *object.RisingWaveManager, mgr *manager.RisingWaveControllerManager) ctrlkit.Action {
+	return mgr.NewAction(RisingWaveAction_SyncClonePauseOnBootstrap, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		clone := risingwaveManger.RisingWave()
+		if !risingwaveManger.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionRunning, true) {
+			return ctrlkit.Continue()
+		}
+
+		pause := !clone.Spec.CloneFrom.AllowSinks
+		err := func() error {
+			metaClient, err := c.newMetaClientOf(ctx, clone)
+			if err != nil || metaClient == nil {
+				return err
+			}
+			defer metaClient.Close() //nolint:errcheck
+
+			ctx, cancel := context.WithTimeout(ctx, cloneMetaRequestTimeout)
+			defer cancel()
+
+			params, err := metaClient.GetSystemParams(ctx)
+			if err != nil {
+				return err
+			}
+
+			// Skip the versions without the parameter.
+			if params.PauseOnNextBootstrap != nil && params.GetPauseOnNextBootstrap() != pause {
+				if err := metaClient.SetSystemParam(ctx, systemParamPauseOnNextBootstrap, strconv.FormatBool(pause)); err != nil {
+					return err
+				}
+			}
+
+			if pause {
+				return metaClient.Pause(ctx)
+			}
+
+			// Resume the streaming jobs paused by the clone once the sinks are allowed, e.g., when the upgrade
+			// that clones it is confirmed. Those paused by the user afterward are left as they are.
+			if clone.Status.Clone != nil && clone.Status.Clone.PauseRequested {
+				if err := metaClient.Resume(ctx); err != nil {
+					return err
+				}
+				risingwaveManger.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+					status.Clone.PauseRequested = false
+				})
+			}
+
+			return nil
+		}()
+		if err != nil {
+			l.Info("Failed to keep the clone paused", "error", err.Error())
+		}
+
+		if pause {
+			return ctrlkit.RequeueAfter(clonePauseInterval)
+		}
+
+		return ctrlkit.Continue()
+	})
+}
+
+// deleteMetaPodsOf deletes the meta Pods of the RisingWave to restart the meta service.
+func (c *RisingWaveController) deleteMetaPodsOf(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	var pods corev1.PodList
+	if err := c.Client.List(ctx, &pods, client.InNamespace(risingwave.Namespace), client.MatchingLabelsSelector{
+		Selector: workloadSelector(risingwave, consts.ComponentMeta),
+	}); err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if err := c.Client.Delete(ctx, pod, client.Preconditions{UID: &pod.UID}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -19,15 +19,20 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	kruiseappsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
 	kruiseappsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"github.com/risingwavelabs/ctrlkit"
+	"github.com/samber/lo"
 	"golang.org/x/time/rate"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -46,9 +52,15 @@ import (
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
 	"github.com/risingwavelabs/risingwave-operator/pkg/event"
+	"github.com/risingwavelabs/risingwave-operator/pkg/maintenance"
 	"github.com/risingwavelabs/risingwave-operator/pkg/manager"
+	"github.com/risingwavelabs/risingwave-operator/pkg/meta"
 	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/notification"
 	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/preflight"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+	"github.com/risingwavelabs/risingwave-operator/pkg/tracing"
 	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
 )
 
@@ -64,6 +76,7 @@ const (
 	RisingWaveAction_WaitBeforeMetaAdvancedStatefulSetsReady     = manager.RisingWaveAction_WaitBeforeMetaAdvancedStatefulSetsReady
 	RisingWaveAction_SyncFrontendService                         = manager.RisingWaveAction_SyncFrontendService
 	RisingWaveAction_SyncFrontendHeadlessService                 = manager.RisingWaveAction_SyncFrontendHeadlessService
+	RisingWaveAction_SyncStandaloneHeadlessService               = manager.RisingWaveAction_SyncStandaloneHeadlessService
 	RisingWaveAction_SyncFrontendDeployments                     = manager.RisingWaveAction_SyncFrontendDeployments
 	RisingWaveAction_SyncFrontendStatefulSets                    = manager.RisingWaveAction_SyncFrontendStatefulSets
 	RisingWaveAction_SyncFrontendCloneSets                       = manager.RisingWaveAction_SyncFrontendCloneSets
@@ -85,6 +98,20 @@ const (
 	RisingWaveAction_SyncConfigConfigMap                         = manager.RisingWaveAction_SyncConfigConfigMap
 	RisingWaveAction_CollectRunningStatisticsAndSyncStatus       = manager.RisingWaveAction_CollectRunningStatisticsAndSyncStatus
 	RisingWaveAction_SyncServiceMonitor                          = manager.RisingWaveAction_SyncServiceMonitor
+	RisingWaveAction_SyncPodMonitor                              = manager.RisingWaveAction_SyncPodMonitor
+	RisingWaveAction_SyncResourceRecommendations                 = manager.RisingWaveAction_SyncResourceRecommendations
+	RisingWaveAction_CollectResourceGroupsAndSyncStatus          = manager.RisingWaveAction_CollectResourceGroupsAndSyncStatus
+	RisingWaveAction_SyncNetworkPolicies                         = manager.RisingWaveAction_SyncNetworkPolicies
+	RisingWaveAction_SyncIngresses                               = manager.RisingWaveAction_SyncIngresses
+	RisingWaveAction_SyncHTTPRoutes                              = manager.RisingWaveAction_SyncHTTPRoutes
+	RisingWaveAction_SyncPrometheusRule                          = manager.RisingWaveAction_SyncPrometheusRule
+	RisingWaveAction_SyncGrafanaDashboardConfigMap               = manager.RisingWaveAction_SyncGrafanaDashboardConfigMap
+	RisingWaveAction_SyncManagedMinIO                            = manager.RisingWaveAction_SyncManagedMinIO
+	RisingWaveAction_SyncManagedPostgreSQL                       = manager.RisingWaveAction_SyncManagedPostgreSQL
+	RisingWaveAction_WaitBeforeManagedStoresReady                = manager.RisingWaveAction_WaitBeforeManagedStoresReady
+	RisingWaveAction_RemediateStuckPods                          = manager.RisingWaveAction_RemediateStuckPods
+	RisingWaveAction_RemediateOpenKruiseStuckPods                = manager.RisingWaveAction_RemediateOpenKruiseStuckPods
+	RisingWaveAction_StopStandaloneForMigration                  = manager.RisingWaveAction_StopStandaloneForMigration
 )
 
 // Actions defined in controller.
@@ -103,9 +130,22 @@ const (
 	RisingWaveAction_MarkConditionUpgradingAsFalse      = "MarkConditionUpgradingAsFalse"
 	RisingWaveAction_BarrierObservedGenerationOutdated  = "BarrierObservedGenerationOutdated"
 	RisingWaveAction_SyncObservedGeneration             = "SyncObservedGeneration"
-	RisingWaveAction_BarrierPrometheusCRDsInstalled     = "BarrierPrometheusCRDsInstalled"
+	RisingWaveAction_BarrierServiceMonitorCRDInstalled  = "BarrierServiceMonitorCRDInstalled"
+	RisingWaveAction_BarrierPodMonitorCRDInstalled      = "BarrierPodMonitorCRDInstalled"
+	RisingWaveAction_BarrierPrometheusRuleCRDInstalled  = "BarrierPrometheusRuleCRDInstalled"
+	RisingWaveAction_BarrierGatewayAPICRDsInstalled     = "BarrierGatewayAPICRDsInstalled"
 	RisingWaveAction_ReleaseScaleViewLock               = "ReleaseScaleViewLock"
 	RisingWaveAction_SyncInternalStatus                 = "SyncInternalStatus"
+	RisingWaveAction_SyncTopologyZones                  = "SyncTopologyZones"
+	RisingWaveAction_PreflightChecks                    = "PreflightChecks"
+	RisingWaveAction_EnsureManagedMinIOBucket           = "EnsureManagedMinIOBucket"
+	RisingWaveAction_BarrierPendingMaintenanceAllowed   = "BarrierPendingMaintenanceAllowed"
+	RisingWaveAction_RemoveConditionPendingMaintenance  = "RemoveConditionPendingMaintenance"
+	RisingWaveAction_SyncPendingMaintenance             = "SyncPendingMaintenance"
+
+	// Actions for the migration from the standalone mode.
+	RisingWaveAction_MarkStandaloneMigrationStartingComponents = "MarkStandaloneMigrationStartingComponents"
+	RisingWaveAction_MarkStandaloneMigrationCompleted          = "MarkStandaloneMigrationCompleted"
 )
 
 // +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwaves,verbs=get;list;watch;create;update;patch;delete
@@ -114,32 +154,58 @@ const (
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets,verbs=get;list;watch;create;delete;update;patch
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets,verbs=get;list;watch;create;delete;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;delete;
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;delete;update;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;delete;update;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;delete;update;patch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=pods/resize,verbs=get;update;patch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 // RisingWaveController is the controller for RisingWave.
 type RisingWaveController struct {
-	Client              client.Client
-	Recorder            events.EventRecorder
-	ActionHookFactory   func() ctrlkit.ActionHook
-	forceUpdateEnabled  bool
-	openKruiseAvailable bool
-	operatorVersion     string
+	Client                    client.Client
+	Recorder                  events.EventRecorder
+	ActionHookFactory         func() ctrlkit.ActionHook
+	forceUpdateEnabled        bool
+	openKruiseAvailable       bool
+	inPlacePodResizeAvailable bool
+	operatorVersion           string
+	operatorNamespace         string
+	notifier                  notification.Notifier
+
+	// apiReader reads from the API server without the cache. It's used to read the Secrets provided by the users, e.g.,
+	// the credentials of the stores.
+	apiReader client.Reader
+
+	// dialMeta creates a client of the meta service at the address. Defaults to meta.NewClient.
+	dialMeta func(addr string) (*meta.Client, error)
+
+	// frontendSessions counts the active sessions of the frontend Pod. Defaults to reading the metrics of the Pod.
+	frontendSessions func(ctx context.Context, pod *corev1.Pod) (int, error)
 }
 
-func (c *RisingWaveController) runWorkflow(ctx context.Context, workflow ctrlkit.Action) (result reconcile.Result, err error) {
+// runWorkflow optimizes and runs the workflow, with the durations and the spans of the actions recorded.
+func (c *RisingWaveController) runWorkflow(ctx context.Context, risingwaveMgr *object.RisingWaveManager, workflow ctrlkit.Action) (result reconcile.Result, err error) {
+	workflow = manager.WithContextActionHooks(ctrlkit.OptimizeWorkflow(workflow), NewMetricsHook(risingwaveMgr), tracing.NewActionHook())
+
 	return ctrlkit.IgnoreExit(workflow.Run(ctx))
 }
 
 func (c *RisingWaveController) managerOpts(risingwaveMgr *object.RisingWaveManager, messageStore *event.MessageStore) []manager.RisingWaveControllerManagerOption {
 	opts := make([]manager.RisingWaveControllerManagerOption, 0)
 
-	chainedHooks := ctrlkit.ChainActionHooks(NewEventHook(c.Recorder, risingwaveMgr, messageStore))
+	chainedHooks := ctrlkit.ChainActionHooks(NewEventHook(c.Recorder, risingwaveMgr, messageStore, c.notifier))
 	if c.ActionHookFactory != nil {
 		chainedHooks.Add(c.ActionHookFactory())
 	}
@@ -151,6 +217,9 @@ func (c *RisingWaveController) managerOpts(risingwaveMgr *object.RisingWaveManag
 
 // Reconcile reconciles a request and also adds metrics information to prometheus.
 func (c *RisingWaveController) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, err error) {
+	ctx, span := tracing.StartReconcileSpan(ctx, "RisingWaveController", request.NamespacedName)
+	defer func() { tracing.EndSpan(span, res, err) }()
+
 	logger := log.FromContext(ctx)
 
 	var risingwave risingwavev1alpha1.RisingWave
@@ -158,6 +227,10 @@ func (c *RisingWaveController) Reconcile(ctx context.Context, request reconcile.
 	if err := c.Client.Get(ctx, request.NamespacedName, &risingwave); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.V(1).Info("Not found, abort")
+			metrics.DeleteRisingWaveMetrics(request.NamespacedName)
+			if c.notifier != nil {
+				c.notifier.Forget(request.NamespacedName)
+			}
 
 			return ctrlkit.NoRequeue()
 		}
@@ -169,33 +242,54 @@ func (c *RisingWaveController) Reconcile(ctx context.Context, request reconcile.
 
 	logger = logger.WithValues("generation", risingwave.Generation)
 
-	// Pause and skip the reconciliation if the annotation is found.
+	isDeleted := utils.IsDeleted(&risingwave)
+
+	// Pause and skip the reconciliation if the annotation is found. The frontends are never drained while paused, so
+	// don't hold the deletion with the finalizer.
 	if _, ok := risingwave.Annotations[consts.AnnotationPauseReconcile]; ok {
 		logger.Info("Found annotation " + consts.AnnotationPauseReconcile + ", pause reconciliation...")
 
+		if isDeleted {
+			if err := c.removeFrontendDrainFinalizer(ctx, &risingwave); err != nil {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to remove finalizer", err)
+			}
+		}
+
 		return ctrlkit.NoRequeue()
 	}
 
-	// Abort if deleted.
-	if utils.IsDeleted(&risingwave) {
+	// Abort if deleted, unless the frontends are to be drained or the data in the stores is to be cleaned up.
+	if isDeleted && !controllerutil.ContainsFinalizer(&risingwave, consts.FinalizerFrontendDrain) &&
+		!controllerutil.ContainsFinalizer(&risingwave, consts.FinalizerDataCleanup) {
 		logger.Info("Deleted, abort")
 
 		return ctrlkit.NoRequeue()
 	}
 
+	// Keep the finalizer consistent with the deletion policies. The update triggers another reconciliation.
+	if !isDeleted {
+		if updated, err := c.syncDataCleanupFinalizer(ctx, &risingwave); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync finalizer", err)
+		} else if updated {
+			return ctrlkit.NoRequeue()
+		}
+
+		// Nothing else depends on the finalizer of the frontend drain, so go on with the reconciliation.
+		if err := c.syncFrontendDrainFinalizer(ctx, &risingwave); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync finalizer", err)
+		}
+	}
+
 	risingwaveManager := object.NewRisingWaveManager(c.Client, risingwave.DeepCopy(), c.openKruiseAvailable)
 	eventMessageStore := event.NewMessageStore()
 
 	mgr := manager.NewRisingWaveControllerManager(
 		manager.NewRisingWaveControllerManagerState(c.Client, risingwave.DeepCopy()),
-		manager.NewRisingWaveControllerManagerImpl(c.Client, risingwaveManager, eventMessageStore, c.forceUpdateEnabled, c.operatorVersion),
+		manager.NewRisingWaveControllerManagerImpl(c.Client, risingwaveManager, eventMessageStore, c.forceUpdateEnabled, c.inPlacePodResizeAvailable, c.operatorVersion, c.operatorNamespace),
 		logger,
 		c.managerOpts(risingwaveManager, eventMessageStore)...,
 	)
 
-	// Build a workflow and run.
-	workflow := c.reactiveWorkflow(risingwaveManager, &mgr)
-
 	updateRisingWaveStatus := mgr.NewAction(RisingWaveAction_UpdateRisingWaveStatusViaClient, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
 		err := risingwaveManager.UpdateRemoteRisingWaveStatus(ctx)
 
@@ -213,10 +307,32 @@ func (c *RisingWaveController) Reconcile(ctx context.Context, request reconcile.
 		}
 	})
 
-	return c.runWorkflow(ctx, ctrlkit.OptimizeWorkflow(ctrlkit.SequentialJoin(
+	// Drain the frontends first, as the data cleanup stops all the components at once. Removing the finalizer
+	// triggers another reconciliation.
+	if isDeleted && controllerutil.ContainsFinalizer(&risingwave, consts.FinalizerFrontendDrain) {
+		return c.runWorkflow(ctx, risingwaveManager, c.frontendDrainWorkflow(risingwaveManager, &mgr))
+	}
+
+	// Clean up the data before removing the finalizer if deleted.
+	if isDeleted {
+		return c.runWorkflow(ctx, risingwaveManager, c.dataCleanupWorkflow(risingwaveManager, &mgr, updateRisingWaveStatus))
+	}
+
+	// Clone from the source before any component is created.
+	if isCloneInProgress(&risingwave) {
+		return c.runWorkflow(ctx, risingwaveManager, ctrlkit.SequentialJoin(
+			c.cloneWorkflow(risingwaveManager, &mgr),
+			updateRisingWaveStatus,
+		))
+	}
+
+	// Build a workflow and run.
+	workflow := c.reactiveWorkflow(risingwaveManager, &mgr)
+
+	return c.runWorkflow(ctx, risingwaveManager, ctrlkit.SequentialJoin(
 		workflow,               // Run workflow first,
 		updateRisingWaveStatus, // then update the status, and join the result.
-	)))
+	))
 }
 
 func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingWaveManager, mgr *manager.RisingWaveControllerManager) ctrlkit.Action {
@@ -301,25 +417,79 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 		ctrlkit.If(c.openKruiseAvailable, mgr.WaitBeforeMetaAdvancedStatefulSetsReady()),
 		ctrlkit.Timeout(time.Second, mgr.WaitBeforeMetaServiceIsAvailable()),
 	)
-	prometheusCRDsInstalledBarrier := mgr.NewAction(RisingWaveAction_BarrierPrometheusCRDsInstalled, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+	// Each kind of the monitors is synced only if its CRD is installed. The CRD of the kind not chosen could be
+	// missing, and then there's nothing to delete.
+	monitorCRDInstalledBarrier := func(action, kind string) ctrlkit.Action {
+		return mgr.NewAction(action, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+			crd, err := utils.GetCustomResourceDefinition(ctx, c.Client, metav1.GroupKind{
+				Group: "monitoring.coreos.com",
+				Kind:  kind,
+			})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return ctrlkit.Exit()
+				}
+
+				return ctrlkit.RequeueIfErrorAndWrap("unable to find CRD for "+kind, err)
+			}
+
+			return ctrlkit.ExitIf(!utils.IsVersionServingInCustomResourceDefinition(crd, "v1"))
+		})
+	}
+
+	syncServiceMonitorIfPossible := ctrlkit.If(
+		ptr.Deref(risingwaveManger.RisingWave().Spec.EnableDefaultServiceMonitor, false),
+		ctrlkit.Join(
+			ctrlkit.Sequential(
+				monitorCRDInstalledBarrier(RisingWaveAction_BarrierServiceMonitorCRDInstalled, "ServiceMonitor"),
+				mgr.SyncServiceMonitor(),
+			),
+			ctrlkit.Sequential(
+				monitorCRDInstalledBarrier(RisingWaveAction_BarrierPodMonitorCRDInstalled, "PodMonitor"),
+				mgr.SyncPodMonitor(),
+			),
+		),
+	)
+	prometheusRuleCRDInstalledBarrier := mgr.NewAction(RisingWaveAction_BarrierPrometheusRuleCRDInstalled, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
 		crd, err := utils.GetCustomResourceDefinition(ctx, c.Client, metav1.GroupKind{
 			Group: "monitoring.coreos.com",
-			Kind:  "ServiceMonitor",
+			Kind:  "PrometheusRule",
 		})
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				return ctrlkit.Exit()
 			}
 
-			return ctrlkit.RequeueIfErrorAndWrap("unable to find CRD for ServiceMonitor", err)
+			return ctrlkit.RequeueIfErrorAndWrap("unable to find CRD for PrometheusRule", err)
 		}
 
 		return ctrlkit.ExitIf(!utils.IsVersionServingInCustomResourceDefinition(crd, "v1"))
 	})
+	// Sync the PrometheusRule when it's configured, so that it's deleted once disabled.
+	syncPrometheusRuleIfPossible := ctrlkit.If(
+		isPrometheusRuleConfigured(risingwaveManger.RisingWave()),
+		ctrlkit.Sequential(prometheusRuleCRDInstalledBarrier, mgr.SyncPrometheusRule()),
+	)
+	gatewayAPICRDsInstalledBarrier := mgr.NewAction(RisingWaveAction_BarrierGatewayAPICRDsInstalled, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		crd, err := utils.GetCustomResourceDefinition(ctx, c.Client, metav1.GroupKind{
+			Group: "gateway.networking.k8s.io",
+			Kind:  "HTTPRoute",
+		})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return ctrlkit.Exit()
+			}
 
-	syncServiceMonitorIfPossible := ctrlkit.If(
-		ptr.Deref(risingwaveManger.RisingWave().Spec.EnableDefaultServiceMonitor, false),
-		ctrlkit.Sequential(prometheusCRDsInstalledBarrier, mgr.SyncServiceMonitor()),
+			return ctrlkit.RequeueIfErrorAndWrap("unable to find CRD for HTTPRoute", err)
+		}
+
+		return ctrlkit.ExitIf(!utils.IsVersionServingInCustomResourceDefinition(crd, "v1"))
+	})
+
+	// Sync the HTTPRoutes when they are required or were reported in the status, so that they are cleaned up.
+	syncHTTPRoutesIfPossible := ctrlkit.If(
+		isHTTPRouteRequiredOrReported(risingwaveManger.RisingWave()),
+		ctrlkit.Sequential(gatewayAPICRDsInstalledBarrier, mgr.SyncHTTPRoutes()),
 	)
 	syncOtherComponents := ctrlkit.ParallelJoin(
 		ctrlkit.Sequential(
@@ -362,6 +532,7 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 
 	syncStandaloneComponent := ctrlkit.Join(
 		mgr.SyncStandaloneService(),
+		mgr.SyncStandaloneHeadlessService(),
 		mgr.SyncStandaloneStatefulSet(),
 		ctrlkit.If(c.openKruiseAvailable, mgr.SyncStandaloneAdvancedStatefulSet()),
 	)
@@ -372,9 +543,67 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 	syncAllComponents := ctrlkit.ParallelJoin(syncConfigs, syncMetaComponent, syncOtherComponents, syncStandaloneComponent)
 	allComponentsReadyBarrier := ctrlkit.Join(metaComponentReadyBarrier, otherComponentsReadyBarrier, standaloneReadyBarrier)
 
+	markStandaloneMigrationStartingComponents := mgr.NewAction(RisingWaveAction_MarkStandaloneMigrationStartingComponents, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		risingwaveManger.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStartingComponents,
+			"Bringing up the frontend, compute and compactor nodes")
+
+		return ctrlkit.Continue()
+	})
+	markStandaloneMigrationCompleted := mgr.NewAction(RisingWaveAction_MarkStandaloneMigrationCompleted, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		risingwaveManger.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseCompleted,
+			"Migrated to the distributed mode")
+
+		return ctrlkit.Continue()
+	})
+
+	// Migrating from the standalone mode stops the standalone Pods first, then brings up the meta nodes on the same
+	// meta store, and the other components last.
+	syncAllComponentsAndWait := ctrlkit.IfElse(risingwaveManger.IsStandaloneMigrationInProgress(),
+		ctrlkit.Sequential(
+			syncConfigs,
+			mgr.StopStandaloneForMigration(),
+			syncMetaComponent,
+			metaComponentReadyBarrier,
+			markStandaloneMigrationStartingComponents,
+			ctrlkit.ParallelJoin(syncOtherComponents, syncStandaloneComponent),
+			ctrlkit.Join(otherComponentsReadyBarrier, standaloneReadyBarrier),
+			markStandaloneMigrationCompleted,
+		),
+		ctrlkit.Sequential(
+			syncConfigs,
+			syncAllComponents,
+			allComponentsReadyBarrier,
+		),
+	)
+
 	observedGenerationOutdatedBarrier := mgr.NewAction(RisingWaveAction_BarrierObservedGenerationOutdated, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
 		return ctrlkit.ExitIf(!risingwaveManger.IsObservedGenerationOutdated())
 	})
+	pendingMaintenanceAllowedBarrier := mgr.NewAction(RisingWaveAction_BarrierPendingMaintenanceAllowed, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		allowed, _ := maintenance.IsAllowed(risingwaveManger.RisingWave(), time.Now())
+
+		return ctrlkit.ExitIf(!allowed || !risingwaveManger.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionPendingMaintenance, true))
+	})
+	removeConditionPendingMaintenance := mgr.NewAction(RisingWaveAction_RemoveConditionPendingMaintenance, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		// All the workloads are synced with nothing deferred if it's allowed.
+		if allowed, _ := maintenance.IsAllowed(risingwaveManger.RisingWave(), time.Now()); allowed {
+			risingwaveManger.RemoveCondition(risingwavev1alpha1.RisingWaveConditionPendingMaintenance)
+		}
+
+		return ctrlkit.Continue()
+	})
+	syncPendingMaintenance := mgr.NewAction(RisingWaveAction_SyncPendingMaintenance, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		if !risingwaveManger.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionPendingMaintenance, true) {
+			return ctrlkit.Continue()
+		}
+
+		// Come back when the next window opens.
+		if allowed, nextOpen := maintenance.IsAllowed(risingwaveManger.RisingWave(), time.Now()); !allowed {
+			return ctrlkit.RequeueAfter(max(time.Until(nextOpen), time.Second))
+		}
+
+		return ctrlkit.Continue()
+	})
 	syncObservedGeneration := mgr.NewAction(RisingWaveAction_SyncObservedGeneration, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
 		risingwaveManger.SyncObservedGeneration()
 
@@ -390,15 +619,111 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 			mgr.CollectRunningStatisticsAndSyncStatus(),
 		),
 	)
+	remediateStuckPods := ctrlkit.IfElse(risingwaveManger.IsOpenKruiseEnabled(),
+		mgr.RemediateOpenKruiseStuckPods(),
+		mgr.RemediateStuckPods(),
+	)
+	preflightChecks := mgr.NewAction(RisingWaveAction_PreflightChecks, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		if !ptr.Deref(risingwaveManger.RisingWave().Spec.EnablePreflightChecks, false) {
+			risingwaveManger.RemoveCondition(risingwavev1alpha1.RisingWaveConditionStoresReachable)
+
+			return ctrlkit.Continue()
+		}
+
+		// Check the stores once for each generation.
+		if risingwaveManger.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionStoresReachable, true) &&
+			!risingwaveManger.IsObservedGenerationOutdated() {
+			return ctrlkit.Continue()
+		}
+
+		markFailed := func(message string) (ctrl.Result, error) {
+			l.Info("Preflight checks failed, hold the rollout", "reason", message)
+
+			risingwaveManger.UpdateCondition(risingwavev1alpha1.RisingWaveCondition{
+				Type:    risingwavev1alpha1.RisingWaveConditionStoresReachable,
+				Status:  metav1.ConditionFalse,
+				Reason:  "PreflightChecksFailed",
+				Message: message,
+			})
+
+			return ctrlkit.RequeueAfter(preflightChecksRetryInterval)
+		}
+
+		// Read the credentials without the cache, as the Secrets are read rarely.
+		risingwave := risingwaveManger.RisingWave()
+		if err := preflight.NewChecker(c.apiReader).Check(ctx, risingwave); err != nil {
+			return markFailed(err.Error())
+		}
+
+		// The workload identity is only available in the Pods, probe the state store in a Job.
+		if preflight.RequiresProbeJob(risingwave) {
+			finished, failure, err := c.probeStateStore(ctx, risingwave)
+			if err != nil {
+				return ctrlkit.RequeueIfErrorAndWrap("unable to probe the state store", err)
+			}
+			if !finished {
+				return ctrlkit.RequeueAfter(stateStoreProbeCheckInterval)
+			}
+			if failure != "" {
+				return markFailed("state store: " + failure)
+			}
+		}
+
+		risingwaveManger.UpdateCondition(risingwavev1alpha1.RisingWaveCondition{
+			Type:    risingwavev1alpha1.RisingWaveConditionStoresReachable,
+			Status:  metav1.ConditionTrue,
+			Reason:  "PreflightChecksPassed",
+			Message: "The meta store and the state store are reachable",
+		})
+
+		return ctrlkit.Continue()
+	})
+	syncManagedStores := ctrlkit.Join(
+		mgr.SyncManagedMinIO(),
+		mgr.SyncManagedPostgreSQL(),
+	)
+	ensureManagedMinIOBucket := mgr.NewAction(RisingWaveAction_EnsureManagedMinIOBucket, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		risingwave := risingwaveManger.RisingWave()
+		if risingwave.Spec.StateStore.ManagedMinIO == nil {
+			return ctrlkit.Continue()
+		}
+
+		bucket, err := stores.ResolveBucket(ctx, c.Client, risingwave)
+		if err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to resolve the bucket of managed minio", err)
+		}
+
+		bucketClient, err := bucket.NewClient(nil)
+		if err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to create the client of managed minio", err)
+		}
+
+		if err := stores.EnsureBucket(ctx, bucketClient, bucket.Name); err != nil {
+			l.Info("Failed to create the bucket in managed MinIO, retry later", "bucket", bucket.Name, "reason", err.Error())
+
+			return ctrlkit.RequeueAfter(managedStoresRetryInterval)
+		}
+
+		return ctrlkit.Continue()
+	})
 	syncAllAndWait := ctrlkit.Sequential(
+		// Provision the stores if they are managed by the operator, and wait before they are ready.
+		syncManagedStores,
+		mgr.WaitBeforeManagedStoresReady(),
+		ensureManagedMinIOBucket,
+
+		// Verify the stores before rolling out the components if enabled.
+		preflightChecks,
+
 		// Set .status.observedGeneration = .metadata.generation
 		syncObservedGeneration,
 
 		// Sync ConfigMap, and then all component groups, and wait before the components are ready.
 		// If possible, also sync the service monitor.
-		syncConfigs,
-		syncAllComponents,
-		allComponentsReadyBarrier,
+		syncAllComponentsAndWait,
+
+		// Clear the pending maintenance once the deferred changes are applied.
+		removeConditionPendingMaintenance,
 	)
 	sharedSyncAllAndWait := ctrlkit.Shared(syncAllAndWait)
 
@@ -450,10 +775,49 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 		return ctrlkit.Continue()
 	})
 
+	syncTopologyZones := mgr.NewAction(RisingWaveAction_SyncTopologyZones, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		topologyKeys := risingwaveManger.GetTopologyKeysToDiscover()
+		if len(topologyKeys) == 0 {
+			risingwaveManger.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+				status.TopologyZones = nil
+			})
+
+			return ctrlkit.Continue()
+		}
+
+		var nodes corev1.NodeList
+		if err := c.Client.List(ctx, &nodes); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to list nodes", err)
+		}
+
+		topologyZones := discoverTopologyZones(topologyKeys, nodes.Items, risingwaveManger.RisingWave().Status.TopologyZones)
+		risingwaveManger.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+			status.TopologyZones = topologyZones
+		})
+
+		// The discovered zones don't change the generation, so sync the workloads of the zones by upgrading when
+		// they change while running.
+		if !equality.Semantic.DeepEqual(risingwaveManger.RisingWave().Status.TopologyZones, topologyZones) &&
+			risingwaveManger.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionRunning, true) {
+			l.Info("Topology zones changed, sync the workloads", "zones", topologyZones)
+
+			risingwaveManger.UpdateCondition(risingwavev1alpha1.RisingWaveCondition{
+				Type:   risingwavev1alpha1.RisingWaveConditionUpgrading,
+				Status: metav1.ConditionTrue,
+			})
+		}
+
+		// Nodes aren't watched, check them periodically.
+		return ctrlkit.RequeueAfter(topologyZonesSyncInterval)
+	})
+
 	return ctrlkit.ParallelJoin(
 		// Always sync internal status.
 		syncInternalStatus,
 
+		// Always discover the zones of the node groups that are expanded by a topology key.
+		syncTopologyZones,
+
 		// => Initializing (Running=false)
 		ctrlkit.Sequential(
 			firstTimeObservedBarrier,
@@ -488,6 +852,15 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 			markConditionUpgradingAsTrue,
 		),
 
+		// Running with the pending maintenance allowed, => Upgrading
+		ctrlkit.Sequential(
+			conditionRunningIsTrueBarrier,
+
+			pendingMaintenanceAllowedBarrier,
+
+			markConditionUpgradingAsTrue,
+		),
+
 		// Upgrading or Recovering (Running=false)
 		ctrlkit.Sequential(
 			conditionUpgradingIsTrueBarrier,
@@ -501,13 +874,100 @@ func (c *RisingWaveController) reactiveWorkflow(risingwaveManger *object.RisingW
 		// if it's not running, turn it to Running=false.
 		syncRunningStatus,
 
+		// Always remediate the stuck Pods if it's enabled, since they block the components from getting ready.
+		remediateStuckPods,
+
+		// Always check the pending maintenance again when the next window opens.
+		syncPendingMaintenance,
+
 		// Always sync the service monitor if possible.
 		syncServiceMonitorIfPossible,
 
+		// Always sync the Grafana dashboard, and the PrometheusRule if possible.
+		mgr.SyncGrafanaDashboardConfigMap(),
+		syncPrometheusRuleIfPossible,
+
+		// Always sync the resource recommendations of the node groups.
+		mgr.SyncResourceRecommendations(),
+
+		// Always collect the resource groups from the meta service.
+		mgr.CollectResourceGroupsAndSyncStatus(),
+
+		// Always sync the network policies.
+		mgr.SyncNetworkPolicies(),
+
+		// Always sync the ingresses, and the HTTPRoutes if possible.
+		mgr.SyncIngresses(),
+		syncHTTPRoutesIfPossible,
+
+		// Always ensure the bucket of the managed MinIO once running, so that it's recreated if deleted.
+		ctrlkit.If(risingwaveManger.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionRunning, true), ensureManagedMinIOBucket),
+
+		// Always keep the clone paused on bootstrap unless the sinks are allowed.
+		ctrlkit.If(risingwaveManger.RisingWave().Spec.CloneFrom != nil, c.syncClonePauseOnBootstrap(risingwaveManger, mgr)),
+
 		releaseScaleViewLock,
 	)
 }
 
+// isPrometheusRuleConfigured returns true when the PrometheusRule is configured, either enabled or not.
+func isPrometheusRuleConfigured(risingwave *risingwavev1alpha1.RisingWave) bool {
+	return risingwave.Spec.Monitoring != nil && risingwave.Spec.Monitoring.PrometheusRule != nil
+}
+
+// isHTTPRouteRequiredOrReported returns true when any endpoint is exposed with an HTTPRoute, or any HTTPRoute is
+// reported in the status.
+func isHTTPRouteRequiredOrReported(risingwave *risingwavev1alpha1.RisingWave) bool {
+	reader := object.NewRisingWaveReader(risingwave)
+	for _, endpoint := range []string{consts.ExternalEndpointDashboard, consts.ExternalEndpointWebhook} {
+		if e := reader.GetExternalEndpoint(endpoint); e != nil && e.HTTPRoute != nil {
+			return true
+		}
+	}
+
+	return lo.ContainsBy(risingwave.Status.ExternalEndpoints, func(e risingwavev1alpha1.RisingWaveExternalEndpointStatus) bool {
+		return e.Kind == "HTTPRoute"
+	})
+}
+
+// preflightChecksRetryInterval is the interval to retry the preflight checks after they fail.
+const preflightChecksRetryInterval = 30 * time.Second
+
+// managedStoresRetryInterval is the interval to retry the initialization of the managed stores after it fails.
+const managedStoresRetryInterval = 10 * time.Second
+
+// topologyZonesSyncInterval is the interval to discover the zones from the Kubernetes nodes.
+const topologyZonesSyncInterval = 5 * time.Minute
+
+// discoverTopologyZones collects the values of the topology keys from the labels of the nodes. The previously
+// discovered zones are kept when there's no node labeled with the key, so that the workloads won't be deleted.
+func discoverTopologyZones(topologyKeys []string, nodes []corev1.Node, prev []risingwavev1alpha1.RisingWaveTopologyZones) []risingwavev1alpha1.RisingWaveTopologyZones {
+	topologyZones := make([]risingwavev1alpha1.RisingWaveTopologyZones, 0, len(topologyKeys))
+	for _, topologyKey := range topologyKeys {
+		values := lo.Uniq(lo.FilterMap(nodes, func(node corev1.Node, _ int) (string, bool) {
+			v, ok := node.Labels[topologyKey]
+
+			return v, ok && v != ""
+		}))
+		slices.Sort(values)
+
+		if len(values) == 0 {
+			if p, ok := lo.Find(prev, func(z risingwavev1alpha1.RisingWaveTopologyZones) bool {
+				return z.TopologyKey == topologyKey
+			}); ok {
+				values = p.Values
+			}
+		}
+
+		topologyZones = append(topologyZones, risingwavev1alpha1.RisingWaveTopologyZones{
+			TopologyKey: topologyKey,
+			Values:      values,
+		})
+	}
+
+	return topologyZones
+}
+
 // SetupWithManager sets up the controller with a given manager.
 func (c *RisingWaveController) SetupWithManager(mgr ctrl.Manager) error {
 	gvk, err := apiutil.GVKForObject(&risingwavev1alpha1.RisingWave{}, c.Client.Scheme())
@@ -533,6 +993,9 @@ func (c *RisingWaveController) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&batchv1.Job{}).
 		Watches(
 			&risingwavev1alpha1.RisingWaveScaleView{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
@@ -559,13 +1022,18 @@ func (c *RisingWaveController) SetupWithManager(mgr ctrl.Manager) error {
 	return newCtrl.Complete(metrics.NewControllerMetricsRecorder(c, "RisingWaveController", gvk))
 }
 
-// NewRisingWaveController creates a new RisingWaveController.
-func NewRisingWaveController(client client.Client, recorder events.EventRecorder, openKruiseAvailable, forceUpdateEnabled bool, operatorVersion string) *RisingWaveController {
+// NewRisingWaveController creates a new RisingWaveController. The notifier is optional. The operator namespace is the
+// namespace that the operator runs in.
+func NewRisingWaveController(client client.Client, apiReader client.Reader, recorder events.EventRecorder, notifier notification.Notifier, openKruiseAvailable, forceUpdateEnabled, inPlacePodResizeAvailable bool, operatorVersion, operatorNamespace string) *RisingWaveController {
 	return &RisingWaveController{
-		Client:              client,
-		Recorder:            recorder,
-		openKruiseAvailable: openKruiseAvailable,
-		forceUpdateEnabled:  forceUpdateEnabled,
-		operatorVersion:     operatorVersion,
+		Client:                    tracing.NewClient(client),
+		apiReader:                 apiReader,
+		Recorder:                  recorder,
+		openKruiseAvailable:       openKruiseAvailable,
+		forceUpdateEnabled:        forceUpdateEnabled,
+		inPlacePodResizeAvailable: inPlacePodResizeAvailable,
+		operatorVersion:           operatorVersion,
+		operatorNamespace:         operatorNamespace,
+		notifier:                  notifier,
 	}
 }
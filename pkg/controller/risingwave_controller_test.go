@@ -20,6 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,10 +32,20 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -38,6 +53,7 @@ import (
 	"github.com/risingwavelabs/ctrlkit"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
 	"github.com/risingwavelabs/risingwave-operator/pkg/object"
 	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
 )
@@ -336,3 +352,862 @@ func Test_RisingWaveController_Recovery(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_RisingWaveController_PreflightChecksFailed(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := l.Addr().String()
+	_ = l.Close()
+
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Spec.EnablePreflightChecks = ptr.To(true)
+	risingwave.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+		Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+			Endpoint: closedAddr,
+		},
+	}
+	risingwave.Status = risingwavev1alpha1.RisingWaveStatus{
+		Conditions: []risingwavev1alpha1.RisingWaveCondition{
+			{
+				Type:   risingwavev1alpha1.RisingWaveConditionInitializing,
+				Status: metav1.ConditionTrue,
+			},
+		},
+	}
+
+	recorder := events.NewFakeRecorder(defaultRecorderBufferSize)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testutils.Scheme).
+		WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+		WithObjects(risingwave).
+		Build()
+	controller := &RisingWaveController{
+		Client:    fakeClient,
+		apiReader: fakeClient,
+		Recorder:  recorder,
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, map[string]resultErr{
+				RisingWaveAction_BarrierConditionInitializingIsTrue: newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_PreflightChecks:                    newResultErr(ctrlkit.RequeueAfter(preflightChecksRetryInterval)),
+			}, false)
+		},
+	}
+
+	logger := zap.New(zap.UseDevMode(true))
+	_, err = controller.Reconcile(log.IntoContext(context.Background(), logger), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: risingwave.Name, Namespace: risingwave.Namespace},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var currentRisingwave risingwavev1alpha1.RisingWave
+	if err := controller.Client.Get(context.Background(), types.NamespacedName{
+		Name:      risingwave.Name,
+		Namespace: risingwave.Namespace,
+	}, &currentRisingwave); err != nil {
+		t.Fatal(err)
+	}
+
+	risingwaveManager := object.NewRisingWaveManager(nil, &currentRisingwave, false)
+
+	condition := risingwaveManager.GetCondition(risingwavev1alpha1.RisingWaveConditionStoresReachable)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "PreflightChecksFailed" ||
+		!strings.Contains(condition.Message, "meta store: unable to connect to "+closedAddr) {
+		t.Fatalf("unexpected condition: %s", testutils.JSONMustPrettyPrint(condition))
+	}
+
+	// The rollout must be held.
+	if currentRisingwave.Status.ObservedGeneration != 0 {
+		t.Fatalf("observed generation should not be synced, got %d", currentRisingwave.Status.ObservedGeneration)
+	}
+}
+
+func Test_RisingWaveController_PreflightChecksProbeJob(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	newRisingWave := func() *risingwavev1alpha1.RisingWave {
+		risingwave := testutils.FakeRisingWave()
+		risingwave.Spec.EnablePreflightChecks = ptr.To(true)
+		risingwave.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+			S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+				Bucket:   "hummock",
+				Endpoint: "http://" + l.Addr().String(),
+				RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
+					UseServiceAccount: ptr.To(true),
+				},
+			},
+		}
+		risingwave.Status = risingwavev1alpha1.RisingWaveStatus{
+			Conditions: []risingwavev1alpha1.RisingWaveCondition{
+				{
+					Type:   risingwavev1alpha1.RisingWaveConditionInitializing,
+					Status: metav1.ConditionTrue,
+				},
+			},
+		}
+
+		return risingwave
+	}
+
+	newJob := func(risingwave *risingwavev1alpha1.RisingWave, generation string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   risingwave.Namespace,
+				Name:        stateStoreProbeJobName(risingwave),
+				Annotations: map[string]string{consts.AnnotationPreflightGeneration: generation},
+			},
+		}
+		if conditionType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: conditionType, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+			}
+		}
+		if err := controllerutil.SetControllerReference(risingwave, job, testutils.Scheme); err != nil {
+			t.Fatal(err)
+		}
+
+		return job
+	}
+
+	newJobPod := func(risingwave *risingwavev1alpha1.RisingWave, message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: risingwave.Namespace,
+				Name:      stateStoreProbeJobName(risingwave) + "-abcde",
+				Labels:    map[string]string{"job-name": stateStoreProbeJobName(risingwave)},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "probe",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: message},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testcases := map[string]struct {
+		objects   func(risingwave *risingwavev1alpha1.RisingWave) []client.Object
+		result    reconcile.Result
+		jobExists bool
+		condition *risingwavev1alpha1.RisingWaveCondition
+	}{
+		"create-job": {
+			objects:   func(*risingwavev1alpha1.RisingWave) []client.Object { return nil },
+			result:    reconcile.Result{RequeueAfter: stateStoreProbeCheckInterval},
+			jobExists: true,
+		},
+		"job-running": {
+			objects: func(risingwave *risingwavev1alpha1.RisingWave) []client.Object {
+				return []client.Object{newJob(risingwave, "2", "")}
+			},
+			result:    reconcile.Result{RequeueAfter: stateStoreProbeCheckInterval},
+			jobExists: true,
+		},
+		"job-outdated": {
+			objects: func(risingwave *risingwavev1alpha1.RisingWave) []client.Object {
+				return []client.Object{newJob(risingwave, "1", batchv1.JobComplete)}
+			},
+			result: reconcile.Result{RequeueAfter: stateStoreProbeCheckInterval},
+		},
+		"job-failed": {
+			objects: func(risingwave *risingwavev1alpha1.RisingWave) []client.Object {
+				return []client.Object{
+					newJob(risingwave, "2", batchv1.JobFailed),
+					newJobPod(risingwave, "unable to put objects into s3://hummock/hummock_001: AccessDenied"),
+				}
+			},
+			result: reconcile.Result{RequeueAfter: preflightChecksRetryInterval},
+			condition: &risingwavev1alpha1.RisingWaveCondition{
+				Status:  metav1.ConditionFalse,
+				Reason:  "PreflightChecksFailed",
+				Message: "state store: unable to put objects into s3://hummock/hummock_001: AccessDenied",
+			},
+		},
+		"job-failed-without-message": {
+			objects: func(risingwave *risingwavev1alpha1.RisingWave) []client.Object {
+				return []client.Object{newJob(risingwave, "2", batchv1.JobFailed)}
+			},
+			result: reconcile.Result{RequeueAfter: preflightChecksRetryInterval},
+			condition: &risingwavev1alpha1.RisingWaveCondition{
+				Status:  metav1.ConditionFalse,
+				Reason:  "PreflightChecksFailed",
+				Message: "state store: Job fake-risingwave-preflight failed: BackoffLimitExceeded",
+			},
+		},
+		"job-completed": {
+			objects: func(risingwave *risingwavev1alpha1.RisingWave) []client.Object {
+				return []client.Object{newJob(risingwave, "2", batchv1.JobComplete)}
+			},
+			condition: &risingwavev1alpha1.RisingWaveCondition{
+				Status: metav1.ConditionTrue,
+				Reason: "PreflightChecksPassed",
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := newRisingWave()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testutils.Scheme).
+				WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+				WithObjects(append(tc.objects(risingwave), risingwave)...).
+				Build()
+
+			controller := &RisingWaveController{
+				Client:    fakeClient,
+				apiReader: fakeClient,
+				Recorder:  events.NewFakeRecorder(defaultRecorderBufferSize),
+				ActionHookFactory: func() ctrlkit.ActionHook {
+					return newActionAsserts(t, map[string]resultErr{
+						RisingWaveAction_BarrierConditionInitializingIsTrue: newResultErr(ctrlkit.Continue()),
+						RisingWaveAction_PreflightChecks:                    newResultErr(tc.result, nil),
+					}, false)
+				},
+			}
+
+			_, err := controller.Reconcile(log.IntoContext(context.Background(), zap.New(zap.UseDevMode(true))), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: risingwave.Name, Namespace: risingwave.Namespace},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var job batchv1.Job
+			err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: risingwave.Namespace, Name: stateStoreProbeJobName(risingwave)}, &job)
+			if client.IgnoreNotFound(err) != nil {
+				t.Fatal(err)
+			}
+			if jobExists := err == nil; jobExists != tc.jobExists {
+				t.Fatalf("job exists: %t, expected %t", jobExists, tc.jobExists)
+			}
+
+			var current risingwavev1alpha1.RisingWave
+			if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &current); err != nil {
+				t.Fatal(err)
+			}
+
+			condition := object.NewRisingWaveManager(nil, &current, false).GetCondition(risingwavev1alpha1.RisingWaveConditionStoresReachable)
+			switch {
+			case tc.condition == nil && condition != nil:
+				t.Fatalf("unexpected condition: %s", testutils.JSONMustPrettyPrint(condition))
+			case tc.condition != nil && (condition == nil || condition.Status != tc.condition.Status ||
+				condition.Reason != tc.condition.Reason || (tc.condition.Message != "" && condition.Message != tc.condition.Message)):
+				t.Fatalf("unexpected condition: %s", testutils.JSONMustPrettyPrint(condition))
+			}
+		})
+	}
+}
+
+func Test_discoverTopologyZones(t *testing.T) {
+	newNode := func(labels map[string]string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+	}
+	nodes := []corev1.Node{
+		newNode(map[string]string{"topology.kubernetes.io/zone": "b"}),
+		newNode(map[string]string{"topology.kubernetes.io/zone": "a"}),
+		newNode(map[string]string{"topology.kubernetes.io/zone": "b"}),
+		newNode(nil),
+	}
+	prev := []risingwavev1alpha1.RisingWaveTopologyZones{
+		{TopologyKey: "topology.kubernetes.io/zone", Values: []string{"c"}},
+		{TopologyKey: "example.com/rack", Values: []string{"r1"}},
+	}
+
+	expected := []risingwavev1alpha1.RisingWaveTopologyZones{
+		{TopologyKey: "example.com/rack", Values: []string{"r1"}},
+		{TopologyKey: "topology.kubernetes.io/zone", Values: []string{"a", "b"}},
+	}
+	zones := discoverTopologyZones([]string{"example.com/rack", "topology.kubernetes.io/zone"}, nodes, prev)
+	if !equality.Semantic.DeepEqual(expected, zones) {
+		t.Fatalf("unexpected zones: %v", zones)
+	}
+}
+
+func Test_RisingWaveController_TopologyZonesDiscovered(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{}
+	risingwave.Status.ObservedGeneration = risingwave.Generation
+	risingwave.Status.Conditions = []risingwavev1alpha1.RisingWaveCondition{
+		{Type: risingwavev1alpha1.RisingWaveConditionRunning, Status: metav1.ConditionTrue},
+	}
+	newNode := func(name, zone string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{consts.DefaultZoneTopologyKey: zone},
+		}}
+	}
+
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave, newNode("node-0", "a"), newNode("node-1", "b")).
+			Build(),
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, nil, false)
+		},
+		Recorder: events.NewFakeRecorder(defaultRecorderBufferSize),
+	}
+
+	computeGroups := func() []string {
+		var statefulSets appsv1.StatefulSetList
+		if err := controller.Client.List(context.Background(), &statefulSets, client.MatchingLabels{
+			consts.LabelRisingWaveComponent: consts.ComponentCompute,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		groups := lo.Map(statefulSets.Items, func(sts appsv1.StatefulSet, _ int) string {
+			return sts.Labels[consts.LabelRisingWaveGroup]
+		})
+		slices.Sort(groups)
+
+		return groups
+	}
+
+	assertUpgrading := func() {
+		var current risingwavev1alpha1.RisingWave
+		if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &current); err != nil {
+			t.Fatal(err)
+		}
+		if !object.NewRisingWaveReader(&current).DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionUpgrading, true) {
+			t.Fatalf("not upgrading after the zones are discovered: %v", current.Status.Conditions)
+		}
+	}
+
+	// The zones are discovered, which starts an upgrade to sync the workloads of the zones in the next round,
+	// without any workload created before the zones are known.
+	reconcileRisingWave(t, controller, risingwave)
+	assertUpgrading()
+	if groups := computeGroups(); len(groups) != 0 {
+		t.Fatalf("unexpected compute statefulsets before the zones are discovered: %v", groups)
+	}
+	reconcileRisingWave(t, controller, risingwave)
+	if groups := computeGroups(); !slices.Equal(groups, []string{"a", "b"}) {
+		t.Fatalf("unexpected compute statefulsets: %v", groups)
+	}
+
+	// A new zone is synced as well.
+	if err := controller.Client.Create(context.Background(), newNode("node-2", "c")); err != nil {
+		t.Fatal(err)
+	}
+	reconcileRisingWave(t, controller, risingwave)
+	assertUpgrading()
+	reconcileRisingWave(t, controller, risingwave)
+	if groups := computeGroups(); !slices.Equal(groups, []string{"a", "b", "c"}) {
+		t.Fatalf("unexpected compute statefulsets after a zone is added: %v", groups)
+	}
+}
+
+func newDataCleanupTestRisingWave(name string, endpoint string) *risingwavev1alpha1.RisingWave {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Name = name
+	risingwave.UID = types.UID(name)
+	risingwave.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+		DataDirectory:  "hummock",
+		DeletionPolicy: risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
+		MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+			RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+				SecretName:     "minio-creds",
+				UsernameKeyRef: "username",
+				PasswordKeyRef: "password",
+			},
+			Endpoint: endpoint,
+			Bucket:   "hummock001",
+		},
+	}
+	risingwave.Status.Internal.StateStoreRootPath = name
+
+	return risingwave
+}
+
+func markDeleted(risingwave *risingwavev1alpha1.RisingWave) *risingwavev1alpha1.RisingWave {
+	risingwave.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	risingwave.Finalizers = []string{consts.FinalizerDataCleanup}
+
+	return risingwave
+}
+
+func reconcileRisingWave(t *testing.T, controller *RisingWaveController, risingwave *risingwavev1alpha1.RisingWave) {
+	logger := zap.New(zap.UseDevMode(true))
+	_, err := controller.Reconcile(log.IntoContext(context.Background(), logger), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: risingwave.Name, Namespace: risingwave.Namespace},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_RisingWaveController_DataCleanupFinalizerAdded(t *testing.T) {
+	risingwave := newDataCleanupTestRisingWave("example", "http://127.0.0.1:9301")
+
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave).
+			Build(),
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, nil, true)
+		},
+	}
+
+	reconcileRisingWave(t, controller, risingwave)
+
+	var currentRisingwave risingwavev1alpha1.RisingWave
+	if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); err != nil {
+		t.Fatal(err)
+	}
+	if !controllerutil.ContainsFinalizer(&currentRisingwave, consts.FinalizerDataCleanup) {
+		t.Fatalf("finalizer not added: %v", currentRisingwave.Finalizers)
+	}
+}
+
+func Test_RisingWaveController_DataCleanupRetained(t *testing.T) {
+	risingwave := markDeleted(newDataCleanupTestRisingWave("example", "http://127.0.0.1:9301"))
+	risingwave.Spec.StateStore.DeletionPolicy = risingwavev1alpha1.RisingWaveDeletionPolicyRetain
+
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave).
+			Build(),
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, map[string]resultErr{
+				RisingWaveAction_RemoveDataCleanupFinalizer: newResultErr(ctrlkit.Continue()),
+			}, true)
+		},
+	}
+
+	reconcileRisingWave(t, controller, risingwave)
+
+	var currentRisingwave risingwavev1alpha1.RisingWave
+	if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); !apierrors.IsNotFound(err) {
+		t.Fatalf("risingwave should be gone after the finalizer is removed, err: %v", err)
+	}
+}
+
+func Test_RisingWaveController_DataCleanupRefused(t *testing.T) {
+	risingwave := markDeleted(newDataCleanupTestRisingWave("example", "http://127.0.0.1:9301"))
+	another := newDataCleanupTestRisingWave("another", "http://127.0.0.1:9301")
+	another.Status.Internal.StateStoreRootPath = ""
+	another.Spec.StateStore.DataDirectory = "example"
+
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave, another).
+			Build(),
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, map[string]resultErr{
+				RisingWaveAction_CheckSharedDataLocation:         newResultErr(ctrlkit.RequeueAfter(dataCleanupRetryInterval)),
+				RisingWaveAction_UpdateRisingWaveStatusViaClient: newResultErr(ctrlkit.Continue()),
+			}, true)
+		},
+	}
+
+	reconcileRisingWave(t, controller, risingwave)
+
+	var currentRisingwave risingwavev1alpha1.RisingWave
+	if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); err != nil {
+		t.Fatal(err)
+	}
+
+	status := currentRisingwave.Status.DataCleanup
+	if status == nil || status.Phase != risingwavev1alpha1.RisingWaveDataCleanupPhaseRefused ||
+		!strings.Contains(status.Message, "default/another") {
+		t.Fatalf("unexpected data cleanup status: %s", testutils.JSONMustPrettyPrint(status))
+	}
+	if !controllerutil.ContainsFinalizer(&currentRisingwave, consts.FinalizerDataCleanup) {
+		t.Fatal("finalizer should be kept")
+	}
+}
+
+func Test_RisingWaveController_DataCleanupCompleted(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?prefix="+r.URL.Query().Get("prefix"))
+		// An empty bucket.
+		_, _ = w.Write([]byte(`<ListBucketResult><Name>hummock001</Name><KeyCount>0</KeyCount><IsTruncated>false</IsTruncated></ListBucketResult>`))
+	}))
+	defer server.Close()
+
+	risingwave := markDeleted(newDataCleanupTestRisingWave("example", server.URL))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: risingwave.Namespace, Name: "minio-creds"},
+		Data:       map[string][]byte{"username": []byte("username"), "password": []byte("password")},
+	}
+
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave).
+			Build(),
+		// The credentials are read without the cache.
+		apiReader: fake.NewClientBuilder().WithScheme(testutils.Scheme).WithObjects(secret).Build(),
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, map[string]resultErr{
+				RisingWaveAction_CheckSharedDataLocation:         newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_StopComponentsBeforeDataCleanup: newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_DeleteStateStoreData:            newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_DropMetaDatabase:                newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_MarkDataCleanupCompleted:        newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_UpdateRisingWaveStatusViaClient: newResultErr(ctrlkit.Continue()),
+				RisingWaveAction_RemoveDataCleanupFinalizer:      newResultErr(ctrlkit.Continue()),
+			}, true)
+		},
+	}
+
+	reconcileRisingWave(t, controller, risingwave)
+
+	if !slices.Contains(requests, "GET /hummock001/?prefix=example/hummock/") {
+		t.Fatalf("objects under the data directory not listed, requests: %v", requests)
+	}
+
+	var currentRisingwave risingwavev1alpha1.RisingWave
+	if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); !apierrors.IsNotFound(err) {
+		t.Fatalf("risingwave should be gone after the data is cleaned up, err: %v", err)
+	}
+}
+
+func Test_RisingWaveController_FrontendDrainFinalizerSynced(t *testing.T) {
+	testcases := map[string]struct {
+		deletionProtection *bool
+		finalizers         []string
+		expectFinalizer    bool
+	}{
+		"default": {
+			expectFinalizer: true,
+		},
+		"protection-off": {
+			deletionProtection: ptr.To(false),
+			expectFinalizer:    true,
+		},
+		"protection-on": {
+			deletionProtection: ptr.To(true),
+			expectFinalizer:    false,
+		},
+		"protection-turned-on": {
+			deletionProtection: ptr.To(true),
+			finalizers:         []string{consts.FinalizerFrontendDrain},
+			expectFinalizer:    false,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := testutils.FakeRisingWave()
+			risingwave.Spec.DeletionProtection = tc.deletionProtection
+			risingwave.Finalizers = tc.finalizers
+
+			controller := &RisingWaveController{
+				Client: fake.NewClientBuilder().
+					WithScheme(testutils.Scheme).
+					WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+					WithObjects(risingwave).
+					Build(),
+				ActionHookFactory: func() ctrlkit.ActionHook {
+					return newActionAsserts(t, nil, false)
+				},
+				Recorder: events.NewFakeRecorder(defaultRecorderBufferSize),
+			}
+
+			reconcileRisingWave(t, controller, risingwave)
+
+			var currentRisingwave risingwavev1alpha1.RisingWave
+			if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); err != nil {
+				t.Fatal(err)
+			}
+			if controllerutil.ContainsFinalizer(&currentRisingwave, consts.FinalizerFrontendDrain) != tc.expectFinalizer {
+				t.Fatalf("unexpected finalizers: %v", currentRisingwave.Finalizers)
+			}
+		})
+	}
+}
+
+func Test_RisingWaveController_FrontendDrainSkippedWhenPaused(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Annotations = map[string]string{consts.AnnotationPauseReconcile: "true"}
+	risingwave.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	risingwave.Finalizers = []string{consts.FinalizerFrontendDrain, "example.com/keep"}
+
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave).
+			Build(),
+		ActionHookFactory: func() ctrlkit.ActionHook {
+			return newActionAsserts(t, nil, false)
+		},
+		Recorder: events.NewFakeRecorder(defaultRecorderBufferSize),
+	}
+
+	reconcileRisingWave(t, controller, risingwave)
+
+	var currentRisingwave risingwavev1alpha1.RisingWave
+	if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); err != nil {
+		t.Fatal(err)
+	}
+	if controllerutil.ContainsFinalizer(&currentRisingwave, consts.FinalizerFrontendDrain) {
+		t.Fatalf("finalizer not removed: %v", currentRisingwave.Finalizers)
+	}
+}
+
+func newFrontendDrainTestPod(risingwave *risingwavev1alpha1.RisingWave, component string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: risingwave.Namespace,
+			Name:      risingwave.Name + "-" + component + "-0",
+			Labels: map[string]string{
+				consts.LabelRisingWaveName:      risingwave.Name,
+				consts.LabelRisingWaveComponent: component,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "10.0.0.1",
+		},
+	}
+}
+
+func newFrontendDrainTestService(risingwave *risingwavev1alpha1.RisingWave, name, clusterIP string) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: risingwave.Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				consts.LabelRisingWaveName:      risingwave.Name,
+				consts.LabelRisingWaveComponent: consts.ComponentFrontend,
+			},
+		},
+		Spec: corev1.ServiceSpec{ClusterIP: clusterIP},
+	}
+	_ = controllerutil.SetControllerReference(risingwave, svc, testutils.Scheme)
+
+	return svc
+}
+
+func Test_RisingWaveController_FrontendDrain(t *testing.T) {
+	testcases := map[string]struct {
+		deletedAgo        time.Duration
+		drainTimeout      *metav1.Duration
+		withPods          bool
+		sessions          int
+		sessionsErr       error
+		crdTerminating    bool
+		drainResult       resultErr
+		expectFinalizer   bool
+		expectWorkloads   bool
+		expectServiceGone bool
+	}{
+		"draining": {
+			withPods:          true,
+			sessions:          2,
+			drainResult:       newResultErr(ctrlkit.RequeueAfter(frontendDrainCheckInterval)),
+			expectFinalizer:   true,
+			expectWorkloads:   true,
+			expectServiceGone: true,
+		},
+		"sessions-unknown": {
+			withPods:          true,
+			sessionsErr:       errors.New("connection refused"),
+			drainResult:       newResultErr(ctrlkit.RequeueAfter(frontendDrainCheckInterval)),
+			expectFinalizer:   true,
+			expectWorkloads:   true,
+			expectServiceGone: true,
+		},
+		"sessions-closed": {
+			withPods:          true,
+			drainResult:       newResultErr(ctrlkit.Continue()),
+			expectFinalizer:   false,
+			expectWorkloads:   false,
+			expectServiceGone: true,
+		},
+		"drained": {
+			drainResult:     newResultErr(ctrlkit.Continue()),
+			expectFinalizer: false,
+			expectWorkloads: true,
+		},
+		"timeout": {
+			deletedAgo:      defaultFrontendDrainTimeout + time.Minute,
+			withPods:        true,
+			sessions:        2,
+			drainResult:     newResultErr(ctrlkit.Continue()),
+			expectFinalizer: false,
+			expectWorkloads: true,
+		},
+		"custom-timeout": {
+			deletedAgo:      2 * time.Minute,
+			drainTimeout:    &metav1.Duration{Duration: time.Minute},
+			withPods:        true,
+			sessions:        2,
+			drainResult:     newResultErr(ctrlkit.Continue()),
+			expectFinalizer: false,
+			expectWorkloads: true,
+		},
+		"crd-terminating": {
+			withPods:        true,
+			sessions:        2,
+			crdTerminating:  true,
+			drainResult:     newResultErr(ctrlkit.Continue()),
+			expectFinalizer: false,
+			expectWorkloads: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := testutils.FakeRisingWave()
+			risingwave.Spec.FrontendDrainTimeout = tc.drainTimeout
+			risingwave.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-tc.deletedAgo)}
+			risingwave.Finalizers = []string{consts.FinalizerFrontendDrain, consts.FinalizerDataCleanup}
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: risingwave.Namespace,
+					Name:      risingwave.Name + "-frontend",
+					Labels: map[string]string{
+						consts.LabelRisingWaveName:      risingwave.Name,
+						consts.LabelRisingWaveComponent: consts.ComponentFrontend,
+					},
+				},
+			}
+			_ = controllerutil.SetControllerReference(risingwave, deployment, testutils.Scheme)
+			objects := []client.Object{
+				risingwave,
+				deployment,
+				newFrontendDrainTestService(risingwave, risingwave.Name+"-frontend", "10.96.0.1"),
+				newFrontendDrainTestService(risingwave, risingwave.Name+"-frontend-headless", corev1.ClusterIPNone),
+			}
+			if tc.withPods {
+				objects = append(objects,
+					newFrontendDrainTestPod(risingwave, consts.ComponentFrontend),
+					newFrontendDrainTestPod(risingwave, consts.ComponentCompute),
+				)
+			}
+			if tc.crdTerminating {
+				objects = append(objects, &apiextensionsv1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "risingwaves." + risingwavev1alpha1.GroupVersion.Group,
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						Finalizers:        []string{"customresourcecleanup.apiextensions.k8s.io"},
+					},
+				})
+			}
+
+			asserts := map[string]resultErr{
+				RisingWaveAction_DrainFrontends: tc.drainResult,
+			}
+			if !tc.expectFinalizer {
+				asserts[RisingWaveAction_RemoveFrontendDrainFinalizer] = newResultErr(ctrlkit.Continue())
+			}
+
+			var counted []string
+			controller := &RisingWaveController{
+				Client: fake.NewClientBuilder().
+					WithScheme(testutils.Scheme).
+					WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+					WithObjects(objects...).
+					Build(),
+				ActionHookFactory: func() ctrlkit.ActionHook {
+					return newActionAsserts(t, asserts, true)
+				},
+				frontendSessions: func(ctx context.Context, pod *corev1.Pod) (int, error) {
+					counted = append(counted, pod.Name)
+
+					return tc.sessions, tc.sessionsErr
+				},
+			}
+
+			reconcileRisingWave(t, controller, risingwave)
+
+			var currentRisingwave risingwavev1alpha1.RisingWave
+			if err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &currentRisingwave); err != nil {
+				t.Fatal(err)
+			}
+			if controllerutil.ContainsFinalizer(&currentRisingwave, consts.FinalizerFrontendDrain) != tc.expectFinalizer {
+				t.Fatalf("unexpected finalizers: %v", currentRisingwave.Finalizers)
+			}
+			if !controllerutil.ContainsFinalizer(&currentRisingwave, consts.FinalizerDataCleanup) {
+				t.Fatal("finalizer of the data cleanup should be kept")
+			}
+
+			// Only the sessions of the frontends are counted.
+			if slices.ContainsFunc(counted, func(pod string) bool { return !strings.Contains(pod, consts.ComponentFrontend) }) {
+				t.Fatalf("sessions counted on pods other than the frontends: %v", counted)
+			}
+
+			err := controller.Client.Get(context.Background(), client.ObjectKeyFromObject(deployment), &appsv1.Deployment{})
+			if tc.expectWorkloads != (err == nil) {
+				t.Fatalf("unexpected frontend workloads, expect kept: %v, err: %v", tc.expectWorkloads, err)
+			}
+
+			err = controller.Client.Get(context.Background(), client.ObjectKey{Namespace: risingwave.Namespace, Name: risingwave.Name + "-frontend"}, &corev1.Service{})
+			if tc.expectServiceGone != apierrors.IsNotFound(err) {
+				t.Fatalf("unexpected frontend service, expect gone: %v, err: %v", tc.expectServiceGone, err)
+			}
+			if err := controller.Client.Get(context.Background(), client.ObjectKey{Namespace: risingwave.Namespace, Name: risingwave.Name + "-frontend-headless"}, &corev1.Service{}); err != nil {
+				t.Fatalf("headless service should be kept: %v", err)
+			}
+		})
+	}
+}
+
+func Test_parseFrontendSessions(t *testing.T) {
+	testcases := map[string]struct {
+		metrics  string
+		sessions int
+		err      bool
+	}{
+		"sessions": {
+			metrics: `# HELP frontend_active_sessions Total number of active sessions in frontend
+# TYPE frontend_active_sessions gauge
+frontend_active_sessions 3
+frontend_query_counter_local_execution 10
+`,
+			sessions: 3,
+		},
+		"labelled": {
+			metrics: `frontend_active_sessions{worker_id="1"} 2
+frontend_active_sessions{worker_id="2"} 1
+`,
+			sessions: 3,
+		},
+		"not-found": {
+			metrics: "frontend_query_counter_local_execution 10\n",
+			err:     true,
+		},
+		"invalid": {
+			metrics: "frontend_active_sessions abc\n",
+			err:     true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			sessions, err := parseFrontendSessions(strings.NewReader(tc.metrics))
+			if tc.err != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sessions != tc.sessions {
+				t.Fatalf("unexpected sessions: %d", sessions)
+			}
+		})
+	}
+}
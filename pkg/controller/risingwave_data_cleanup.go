@@ -0,0 +1,350 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kruiseappsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruiseappsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	"github.com/risingwavelabs/ctrlkit"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/manager"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+)
+
+// Actions to clean up the data of a deleted RisingWave.
+const (
+	RisingWaveAction_CheckSharedDataLocation         = "CheckSharedDataLocation"
+	RisingWaveAction_StopComponentsBeforeDataCleanup = "StopComponentsBeforeDataCleanup"
+	RisingWaveAction_DeleteStateStoreData            = "DeleteStateStoreData"
+	RisingWaveAction_DropMetaDatabase                = "DropMetaDatabase"
+	RisingWaveAction_MarkDataCleanupCompleted        = "MarkDataCleanupCompleted"
+	RisingWaveAction_RemoveDataCleanupFinalizer      = "RemoveDataCleanupFinalizer"
+)
+
+const (
+	// dataCleanupBatchSize is the maximum number of objects deleted from the state store in one reconciliation.
+	dataCleanupBatchSize = 1000
+
+	// dataCleanupRetryInterval is the interval to retry the data cleanup after it fails or is refused.
+	dataCleanupRetryInterval = 30 * time.Second
+
+	// componentsStopCheckInterval is the interval to check if the components are stopped.
+	componentsStopCheckInterval = 5 * time.Second
+)
+
+// isDataCleanupRequired tells if any data in the stores should be deleted with the RisingWave.
+func isDataCleanupRequired(risingwave *risingwavev1alpha1.RisingWave) bool {
+	return risingwave.Spec.StateStore.DeletionPolicy == risingwavev1alpha1.RisingWaveDeletionPolicyDelete ||
+		risingwave.Spec.MetaStore.DeletionPolicy == risingwavev1alpha1.RisingWaveDeletionPolicyDelete
+}
+
+// syncDataCleanupFinalizer adds the finalizer when the data is to be deleted with the RisingWave, and removes it
+// otherwise. It returns true if the object is updated.
+func (c *RisingWaveController) syncDataCleanupFinalizer(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) (bool, error) {
+	required := isDataCleanupRequired(risingwave)
+	if required == controllerutil.ContainsFinalizer(risingwave, consts.FinalizerDataCleanup) {
+		return false, nil
+	}
+
+	patch := client.MergeFromWithOptions(risingwave.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if required {
+		controllerutil.AddFinalizer(risingwave, consts.FinalizerDataCleanup)
+	} else {
+		controllerutil.RemoveFinalizer(risingwave, consts.FinalizerDataCleanup)
+	}
+
+	return true, c.Client.Patch(ctx, risingwave, patch)
+}
+
+// findSharedDataLocation returns the RisingWave which shares the data in the state store with the given one.
+func (c *RisingWaveController) findSharedDataLocation(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) (*risingwavev1alpha1.RisingWave, error) {
+	location, ok := stores.DataLocationOf(risingwave)
+	if !ok {
+		return nil, nil
+	}
+
+	var risingwaves risingwavev1alpha1.RisingWaveList
+	if err := c.Client.List(ctx, &risingwaves); err != nil {
+		return nil, err
+	}
+
+	for i := range risingwaves.Items {
+		other := &risingwaves.Items[i]
+		if other.UID == risingwave.UID {
+			continue
+		}
+
+		if otherLocation, ok := stores.DataLocationOf(other); ok && location.Overlaps(otherLocation) {
+			return other, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// workloadSelector selects the objects of the RisingWave, and only the ones of the components if any.
+func workloadSelector(risingwave *risingwavev1alpha1.RisingWave, components ...string) labels.Selector {
+	selector := labels.SelectorFromSet(labels.Set{consts.LabelRisingWaveName: risingwave.Name})
+	if len(components) > 0 {
+		req, _ := labels.NewRequirement(consts.LabelRisingWaveComponent, selection.In, components)
+		selector = selector.Add(*req)
+	}
+
+	return selector
+}
+
+// deleteWorkloads deletes the workloads controlled by the RisingWave, and only the ones of the components if any.
+func deleteWorkloads(ctx context.Context, c client.Client, risingwave *risingwavev1alpha1.RisingWave, openKruiseAvailable bool, components ...string) error {
+	lists := []client.ObjectList{&appsv1.StatefulSetList{}, &appsv1.DeploymentList{}}
+	if openKruiseAvailable {
+		lists = append(lists, &kruiseappsv1alpha1.CloneSetList{}, &kruiseappsv1beta1.StatefulSetList{})
+	}
+
+	for _, list := range lists {
+		if err := c.List(ctx, list, client.InNamespace(risingwave.Namespace), client.MatchingLabelsSelector{
+			Selector: workloadSelector(risingwave, components...),
+		}); err != nil {
+			return err
+		}
+
+		if err := meta.EachListItem(list, func(o runtime.Object) error {
+			obj := o.(client.Object)
+			if !metav1.IsControlledBy(obj, risingwave) {
+				return nil
+			}
+
+			return client.IgnoreNotFound(c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *RisingWaveController) dataCleanupWorkflow(risingwaveManger *object.RisingWaveManager, mgr *manager.RisingWaveControllerManager, updateRisingWaveStatus ctrlkit.Action) ctrlkit.Action {
+	updateDataCleanupStatus := func(f func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus)) {
+		risingwaveManger.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+			if status.DataCleanup == nil {
+				status.DataCleanup = &risingwavev1alpha1.RisingWaveDataCleanupStatus{}
+			}
+			f(status.DataCleanup)
+		})
+	}
+
+	markDataCleanupInProgress := func(message string) {
+		updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+			status.Phase = risingwavev1alpha1.RisingWaveDataCleanupPhaseInProgress
+			status.Message = message
+		})
+	}
+
+	markDataCleanupFailed := func(err error) (ctrl.Result, error) {
+		updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+			status.Phase = risingwavev1alpha1.RisingWaveDataCleanupPhaseFailed
+			status.Message = err.Error()
+		})
+
+		return ctrlkit.RequeueAfter(dataCleanupRetryInterval)
+	}
+
+	isStateStoreDataToDelete := func() bool {
+		risingwave := risingwaveManger.RisingWave()
+
+		return risingwave.Spec.StateStore.DeletionPolicy == risingwavev1alpha1.RisingWaveDeletionPolicyDelete &&
+			(risingwave.Status.DataCleanup == nil || !risingwave.Status.DataCleanup.StateStoreDeleted)
+	}
+
+	checkSharedDataLocation := mgr.NewAction(RisingWaveAction_CheckSharedDataLocation, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		if !isStateStoreDataToDelete() {
+			return ctrlkit.Continue()
+		}
+
+		risingwave := risingwaveManger.RisingWave()
+		other, err := c.findSharedDataLocation(ctx, risingwave)
+		if err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to list risingwaves", err)
+		}
+
+		// Refuse to delete the data which might be used by another RisingWave.
+		if other != nil {
+			l.Info("Data in the state store is shared, refuse to delete", "shared-with", client.ObjectKeyFromObject(other))
+			updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+				status.Phase = risingwavev1alpha1.RisingWaveDataCleanupPhaseRefused
+				status.Message = fmt.Sprintf("data directory is shared with RisingWave %s/%s, "+
+					"set the deletion policy of the state store to Retain to proceed", other.Namespace, other.Name)
+			})
+
+			return ctrlkit.RequeueAfter(dataCleanupRetryInterval)
+		}
+
+		return ctrlkit.Continue()
+	})
+
+	stopComponents := mgr.NewAction(RisingWaveAction_StopComponentsBeforeDataCleanup, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		risingwave := risingwaveManger.RisingWave()
+
+		var pods corev1.PodList
+		if err := c.Client.List(ctx, &pods, client.InNamespace(risingwave.Namespace), client.MatchingLabels{
+			consts.LabelRisingWaveName: risingwave.Name,
+		}); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to list pods", err)
+		}
+
+		if len(pods.Items) == 0 {
+			return ctrlkit.Continue()
+		}
+
+		// Stop the components so that nothing writes to the stores while the data is being deleted.
+		if err := deleteWorkloads(ctx, c.Client, risingwave, c.openKruiseAvailable); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete workloads", err)
+		}
+		markDataCleanupInProgress(fmt.Sprintf("Waiting for %d pods to stop", len(pods.Items)))
+
+		return ctrlkit.RequeueAfter(componentsStopCheckInterval)
+	})
+
+	deleteStateStoreData := mgr.NewAction(RisingWaveAction_DeleteStateStoreData, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		if !isStateStoreDataToDelete() {
+			return ctrlkit.Continue()
+		}
+
+		risingwave := risingwaveManger.RisingWave()
+		bucket, err := stores.ResolveBucket(ctx, c.apiReader, risingwave)
+		if err != nil {
+			return markDataCleanupFailed(err)
+		}
+
+		bucketClient, err := bucket.NewClient(nil)
+		if err != nil {
+			return markDataCleanupFailed(err)
+		}
+
+		location, _ := stores.DataLocationOf(risingwave)
+		deleted, more, err := stores.DeleteObjects(ctx, bucketClient, bucket.Name, location.Directory, dataCleanupBatchSize)
+		updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+			status.DeletedObjects += int64(deleted)
+		})
+		if err != nil {
+			return markDataCleanupFailed(err)
+		}
+
+		if more {
+			markDataCleanupInProgress(fmt.Sprintf("Deleting objects under %s in bucket %s", location.Directory, bucket.Name))
+
+			return ctrlkit.RequeueImmediately()
+		}
+
+		l.Info("Data in the state store deleted", "bucket", bucket.Name, "directory", location.Directory)
+		updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+			status.StateStoreDeleted = true
+		})
+
+		return ctrlkit.Continue()
+	})
+
+	dropMetaDatabase := mgr.NewAction(RisingWaveAction_DropMetaDatabase, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		risingwave := risingwaveManger.RisingWave()
+		metaStore := &risingwave.Spec.MetaStore
+		if metaStore.DeletionPolicy != risingwavev1alpha1.RisingWaveDeletionPolicyDelete ||
+			(risingwave.Status.DataCleanup != nil && risingwave.Status.DataCleanup.MetaDatabaseDropped) {
+			return ctrlkit.Continue()
+		}
+
+		var err error
+		switch {
+		case metaStore.MySQL != nil:
+			err = stores.DropMySQLDatabase(ctx, c.apiReader, risingwave.Namespace, metaStore.MySQL)
+		case metaStore.PostgreSQL != nil:
+			err = stores.DropPostgreSQLDatabase(ctx, c.apiReader, risingwave.Namespace, metaStore.PostgreSQL)
+		default:
+			// Nothing to drop for the other meta stores, which are rejected by the webhook.
+		}
+		if err != nil {
+			return markDataCleanupFailed(err)
+		}
+
+		l.Info("Meta database dropped")
+		updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+			status.MetaDatabaseDropped = true
+		})
+
+		return ctrlkit.Continue()
+	})
+
+	markDataCleanupCompleted := mgr.NewAction(RisingWaveAction_MarkDataCleanupCompleted, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		updateDataCleanupStatus(func(status *risingwavev1alpha1.RisingWaveDataCleanupStatus) {
+			status.Phase = risingwavev1alpha1.RisingWaveDataCleanupPhaseCompleted
+			status.Message = ""
+		})
+
+		return ctrlkit.Continue()
+	})
+
+	removeFinalizer := mgr.NewAction(RisingWaveAction_RemoveDataCleanupFinalizer, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		// The after image carries the resource version after the status is updated.
+		risingwave := risingwaveManger.RisingWaveAfterImage()
+		patch := client.MergeFromWithOptions(risingwave.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if !controllerutil.RemoveFinalizer(risingwave, consts.FinalizerDataCleanup) {
+			return ctrlkit.Continue()
+		}
+
+		err := c.Client.Patch(ctx, risingwave, patch)
+		switch {
+		case apierrors.IsNotFound(err):
+			return ctrlkit.NoRequeue()
+		case apierrors.IsConflict(err):
+			return ctrlkit.RequeueAfter(10 * time.Millisecond)
+		default:
+			return ctrlkit.RequeueIfErrorAndWrap("unable to remove finalizer", err)
+		}
+	})
+
+	return ctrlkit.Sequential(
+		ctrlkit.If(isDataCleanupRequired(risingwaveManger.RisingWave()), ctrlkit.SequentialJoin(
+			ctrlkit.Sequential(
+				checkSharedDataLocation,
+				stopComponents,
+				deleteStateStoreData,
+				dropMetaDatabase,
+				markDataCleanupCompleted,
+			),
+			// Always persist the progress.
+			updateRisingWaveStatus,
+		)),
+		removeFinalizer,
+	)
+}
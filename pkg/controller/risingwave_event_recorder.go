@@ -16,6 +16,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,21 +25,23 @@ import (
 
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
 	"github.com/risingwavelabs/risingwave-operator/pkg/event"
+	"github.com/risingwavelabs/risingwave-operator/pkg/notification"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/object"
 )
 
-// RisingWaveEventRecorder is an action hook for recording events.
+// RisingWaveEventRecorder is an action hook for recording events, and notifying about them if a notifier is set.
 type RisingWaveEventRecorder struct {
 	recorder events.EventRecorder
 	mgr      *object.RisingWaveManager
 	msgStore *event.MessageStore
+	notifier notification.Notifier
 }
 
-// NewEventHook creates an event hook for the given risingwave.
-func NewEventHook(recorder events.EventRecorder, mgr *object.RisingWaveManager, msgStore *event.MessageStore) *RisingWaveEventRecorder {
-	return &RisingWaveEventRecorder{recorder: recorder, mgr: mgr, msgStore: msgStore}
+// NewEventHook creates an event hook for the given risingwave. The notifier is optional.
+func NewEventHook(recorder events.EventRecorder, mgr *object.RisingWaveManager, msgStore *event.MessageStore, notifier notification.Notifier) *RisingWaveEventRecorder {
+	return &RisingWaveEventRecorder{recorder: recorder, mgr: mgr, msgStore: msgStore, notifier: notifier}
 }
 
 // PreRun implements the ActionHook interface.
@@ -52,6 +55,20 @@ func (h *RisingWaveEventRecorder) isAfterConditionTrueAndChanged(before, after *
 
 func (h *RisingWaveEventRecorder) recordEvent(event consts.RisingWaveEventType) {
 	h.recorder.Eventf(h.mgr.RisingWave(), nil, event.Type, event.Name, event.Name, h.msgStore.MessageFor(event.Name))
+
+	if h.notifier != nil {
+		risingwave := h.mgr.RisingWave()
+		h.notifier.Notify(notification.Notification{
+			Namespace:  risingwave.Namespace,
+			Name:       risingwave.Name,
+			Labels:     risingwave.Labels,
+			Event:      event.Name,
+			Type:       event.Type,
+			Message:    h.msgStore.MessageFor(event.Name),
+			Generation: risingwave.Generation,
+			Time:       time.Now(),
+		})
+	}
 }
 
 func (h *RisingWaveEventRecorder) recordConditionChangingEvents() {
@@ -87,6 +104,13 @@ func (h *RisingWaveEventRecorder) recordConditionChangingEvents() {
 func (h *RisingWaveEventRecorder) recordStatesWarningEvents() {
 	warningEvents := []consts.RisingWaveEventType{
 		consts.RisingWaveEventTypeUnhealthy,
+		consts.RisingWaveEventTypeCrashLoopBackOff,
+		consts.RisingWaveEventTypeOOMKilled,
+		consts.RisingWaveEventTypeImagePullBackOff,
+		consts.RisingWaveEventTypeUnschedulable,
+		consts.RisingWaveEventTypeProgressDeadlineExceeded,
+		consts.RisingWaveEventTypeRemediated,
+		consts.RisingWaveEventTypeRemediationBudgetExhausted,
 	}
 
 	for _, ev := range warningEvents {
@@ -0,0 +1,283 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/risingwavelabs/ctrlkit"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/manager"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
+)
+
+// Actions to drain the frontends of a deleted RisingWave.
+const (
+	RisingWaveAction_DrainFrontends               = "DrainFrontends"
+	RisingWaveAction_RemoveFrontendDrainFinalizer = "RemoveFrontendDrainFinalizer"
+)
+
+const (
+	// defaultFrontendDrainTimeout is the default maximum time to wait for the frontends to drain.
+	defaultFrontendDrainTimeout = 5 * time.Minute
+
+	// frontendDrainCheckInterval is the interval to check if the frontends are drained.
+	frontendDrainCheckInterval = 5 * time.Second
+
+	// frontendMetricsTimeout is the timeout to read the metrics of a frontend.
+	frontendMetricsTimeout = 5 * time.Second
+
+	// frontendSessionsMetric is the gauge of the active sessions exported by the frontends.
+	frontendSessionsMetric = "frontend_active_sessions"
+)
+
+// frontendDrainComponents are the components that serve the SQL connections.
+var frontendDrainComponents = []string{consts.ComponentFrontend, consts.ComponentStandalone}
+
+// isFrontendDrainRequired tells if the frontends should be drained before the rest of the RisingWave is removed, which
+// is the case unless the deletion protection is on.
+func isFrontendDrainRequired(risingwave *risingwavev1alpha1.RisingWave) bool {
+	return !ptr.Deref(risingwave.Spec.DeletionProtection, false)
+}
+
+// syncFrontendDrainFinalizer adds the finalizer when the frontends are to be drained, and removes it otherwise.
+func (c *RisingWaveController) syncFrontendDrainFinalizer(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	required := isFrontendDrainRequired(risingwave)
+	if required == controllerutil.ContainsFinalizer(risingwave, consts.FinalizerFrontendDrain) {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(risingwave.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if required {
+		controllerutil.AddFinalizer(risingwave, consts.FinalizerFrontendDrain)
+	} else {
+		controllerutil.RemoveFinalizer(risingwave, consts.FinalizerFrontendDrain)
+	}
+
+	return c.Client.Patch(ctx, risingwave, patch)
+}
+
+// removeFrontendDrainFinalizer removes the finalizer of the frontend drain if present.
+func (c *RisingWaveController) removeFrontendDrainFinalizer(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	patch := client.MergeFromWithOptions(risingwave.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if !controllerutil.RemoveFinalizer(risingwave, consts.FinalizerFrontendDrain) {
+		return nil
+	}
+
+	return client.IgnoreNotFound(c.Client.Patch(ctx, risingwave, patch))
+}
+
+// isRisingWaveCRDTerminating tells if the CRD of the RisingWave is being deleted, e.g., when the operator is being
+// uninstalled.
+func (c *RisingWaveController) isRisingWaveCRDTerminating(ctx context.Context) (bool, error) {
+	crd, err := utils.GetCustomResourceDefinition(ctx, c.Client, metav1.GroupKind{
+		Group: risingwavev1alpha1.GroupVersion.Group,
+		Kind:  "RisingWave",
+	})
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return utils.IsDeleted(crd), nil
+}
+
+// deleteFrontendServices deletes the Services that the clients connect to the frontends through, so that no new
+// connection comes in. The headless Services are kept, since the standalone Pods find each other through them.
+func (c *RisingWaveController) deleteFrontendServices(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	var services corev1.ServiceList
+	if err := c.Client.List(ctx, &services, client.InNamespace(risingwave.Namespace), client.MatchingLabelsSelector{
+		Selector: workloadSelector(risingwave, frontendDrainComponents...),
+	}); err != nil {
+		return err
+	}
+
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if !metav1.IsControlledBy(svc, risingwave) || svc.Spec.ClusterIP == corev1.ClusterIPNone || utils.IsDeleted(svc) {
+			continue
+		}
+		if err := c.Client.Delete(ctx, svc); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countFrontendSessions returns the number of the active sessions of the frontend Pod, read from its metrics.
+func (c *RisingWaveController) countFrontendSessions(ctx context.Context, pod *corev1.Pod) (int, error) {
+	if c.frontendSessions != nil {
+		return c.frontendSessions(ctx, pod)
+	}
+
+	port := lo.If(pod.Labels[consts.LabelRisingWaveComponent] == consts.ComponentStandalone, consts.MetaMetricsPort).
+		Else(consts.FrontendMetricsPort)
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+
+	ctx, cancel := context.WithTimeout(ctx, frontendMetricsTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status of metrics: %s", resp.Status)
+	}
+
+	return parseFrontendSessions(resp.Body)
+}
+
+// parseFrontendSessions sums up the gauge of the active sessions in the metrics of the Prometheus text format.
+func parseFrontendSessions(r io.Reader) (int, error) {
+	var sessions float64
+	found := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if name, _, _ := strings.Cut(fields[0], "{"); name != frontendSessionsMetric {
+			continue
+		}
+
+		// The labels don't contain spaces, so the value follows the name.
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value of %s: %w", frontendSessionsMetric, err)
+		}
+		sessions += value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, fmt.Errorf("metric %s not found", frontendSessionsMetric)
+	}
+
+	return int(sessions), nil
+}
+
+func (c *RisingWaveController) frontendDrainWorkflow(risingwaveManger *object.RisingWaveManager, mgr *manager.RisingWaveControllerManager) ctrlkit.Action {
+	drainFrontends := mgr.NewAction(RisingWaveAction_DrainFrontends, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		risingwave := risingwaveManger.RisingWave()
+
+		timeout := defaultFrontendDrainTimeout
+		if risingwave.Spec.FrontendDrainTimeout != nil {
+			timeout = risingwave.Spec.FrontendDrainTimeout.Duration
+		}
+		if time.Since(risingwave.DeletionTimestamp.Time) > timeout {
+			l.Info("Frontends not drained before the timeout, proceed", "timeout", timeout)
+
+			return ctrlkit.Continue()
+		}
+
+		// Don't hold the uninstallation of the operator, which might be gone before the frontends are drained.
+		if terminating, err := c.isRisingWaveCRDTerminating(ctx); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to get the CRD of risingwave", err)
+		} else if terminating {
+			l.Info("CRD of RisingWave is being deleted, skip draining the frontends")
+
+			return ctrlkit.Continue()
+		}
+
+		var pods corev1.PodList
+		if err := c.Client.List(ctx, &pods, client.InNamespace(risingwave.Namespace), client.MatchingLabelsSelector{
+			Selector: workloadSelector(risingwave, frontendDrainComponents...),
+		}); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to list pods", err)
+		}
+
+		if len(pods.Items) == 0 {
+			return ctrlkit.Continue()
+		}
+
+		if err := c.deleteFrontendServices(ctx, risingwave); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete frontend services", err)
+		}
+
+		// Wait for the running sessions to be closed by the clients. The frontends whose sessions can't be counted
+		// are waited for until the timeout.
+		sessions := 0
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.PodIP == "" || !utils.IsPodRunning(pod) {
+				continue
+			}
+
+			n, err := c.countFrontendSessions(ctx, pod)
+			if err != nil {
+				l.Info("Failed to count the sessions of the frontend", "pod", pod.Name, "error", err.Error())
+				n = 1
+			}
+			sessions += n
+		}
+		if sessions > 0 {
+			l.Info("Waiting for the sessions of the frontends to close", "sessions", sessions)
+
+			return ctrlkit.RequeueAfter(frontendDrainCheckInterval)
+		}
+
+		if err := deleteWorkloads(ctx, c.Client, risingwave, c.openKruiseAvailable, frontendDrainComponents...); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete frontend workloads", err)
+		}
+		l.Info("Frontends drained")
+
+		return ctrlkit.Continue()
+	})
+
+	removeFinalizer := mgr.NewAction(RisingWaveAction_RemoveFrontendDrainFinalizer, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+		err := c.removeFrontendDrainFinalizer(ctx, risingwaveManger.RisingWave().DeepCopy())
+		switch {
+		case apierrors.IsConflict(err):
+			return ctrlkit.RequeueAfter(10 * time.Millisecond)
+		default:
+			return ctrlkit.RequeueIfErrorAndWrap("unable to remove finalizer", err)
+		}
+	})
+
+	return ctrlkit.Sequential(drainFrontends, removeFinalizer)
+}
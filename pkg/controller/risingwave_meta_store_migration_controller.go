@@ -0,0 +1,504 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/risingwavelabs/ctrlkit"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory"
+	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
+)
+
+// metaStoreMigrationCheckInterval is the interval to check the RisingWave while it's being paused.
+const metaStoreMigrationCheckInterval = 5 * time.Second
+
+// metaStoreMigrationStoppedComponents are the components stopped during the migration. The managed stores keep running.
+var metaStoreMigrationStoppedComponents = []string{
+	consts.ComponentMeta,
+	consts.ComponentFrontend,
+	consts.ComponentCompute,
+	consts.ComponentCompactor,
+	consts.ComponentStandalone,
+}
+
+// RisingWaveMetaStoreMigrationController migrates the meta stores of the RisingWaves from etcd to MySQL or PostgreSQL.
+type RisingWaveMetaStoreMigrationController struct {
+	Client              client.Client
+	openKruiseAvailable bool
+}
+
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwavemetastoremigrations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwavemetastoremigrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwaves,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile implements reconcile.Reconciler.
+func (c *RisingWaveMetaStoreMigrationController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var migration risingwavev1alpha1.RisingWaveMetaStoreMigration
+	if err := c.Client.Get(ctx, request.NamespacedName, &migration); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("Not found, abort")
+
+			return ctrlkit.NoRequeue()
+		}
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get risingwavemetastoremigration", err)
+	}
+
+	// The migration Job is garbage collected.
+	if utils.IsDeleted(&migration) {
+		return ctrlkit.NoRequeue()
+	}
+
+	prevStatus := migration.Status.DeepCopy()
+	result, err := c.sync(ctx, &migration)
+
+	if !equality.Semantic.DeepEqual(prevStatus, &migration.Status) {
+		if updateErr := c.Client.Status().Update(ctx, &migration); updateErr != nil {
+			if apierrors.IsConflict(updateErr) {
+				return ctrlkit.RequeueAfter(10 * time.Millisecond)
+			}
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to update status of risingwavemetastoremigration", updateErr)
+		}
+	}
+
+	return result, err
+}
+
+// metaStoreMigrationState is the state of a RisingWaveMetaStoreMigration being reconciled.
+type metaStoreMigrationState struct {
+	migration *risingwavev1alpha1.RisingWaveMetaStoreMigration
+}
+
+func (s *metaStoreMigrationState) jobName() string {
+	return s.migration.Name + "-meta-store-migration"
+}
+
+// enterPhase sets the phase and records it.
+func (s *metaStoreMigrationState) enterPhase(phase risingwavev1alpha1.RisingWaveMetaStoreMigrationPhase, message string) {
+	status := &s.migration.Status
+	status.Phase, status.Message = phase, message
+	status.Phases = append(status.Phases, risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseRecord{
+		Phase:     phase,
+		StartTime: metav1.Now(),
+		Message:   message,
+	})
+}
+
+// setMessage updates the message of the current phase.
+func (s *metaStoreMigrationState) setMessage(message string) {
+	s.migration.Status.Message = message
+	if n := len(s.migration.Status.Phases); n > 0 {
+		s.migration.Status.Phases[n-1].Message = message
+	}
+}
+
+// isPausedByMigration tells if the RisingWave is paused by the migration.
+func isPausedByMigration(risingwave *risingwavev1alpha1.RisingWave, migration *risingwavev1alpha1.RisingWaveMetaStoreMigration) bool {
+	return risingwave.Annotations[consts.AnnotationMetaStoreMigration] == migration.Name
+}
+
+func (c *RisingWaveMetaStoreMigrationController) getRisingWave(ctx context.Context, migration *risingwavev1alpha1.RisingWaveMetaStoreMigration) (*risingwavev1alpha1.RisingWave, error) {
+	var risingwave risingwavev1alpha1.RisingWave
+	if err := c.Client.Get(ctx, types.NamespacedName{Namespace: migration.Namespace, Name: migration.Spec.RisingWave}, &risingwave); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &risingwave, nil
+}
+
+// patchRisingWave patches the RisingWave with the mutations made by f.
+func (c *RisingWaveMetaStoreMigrationController) patchRisingWave(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave, f func(risingwave *risingwavev1alpha1.RisingWave)) error {
+	patch := client.MergeFromWithOptions(risingwave.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	f(risingwave)
+
+	return c.Client.Patch(ctx, risingwave, patch)
+}
+
+// sync moves the migration forward by one phase at most. The status update triggers the next round.
+func (c *RisingWaveMetaStoreMigrationController) sync(ctx context.Context, migration *risingwavev1alpha1.RisingWaveMetaStoreMigration) (reconcile.Result, error) {
+	s := &metaStoreMigrationState{migration: migration}
+	migration.Status.ObservedGeneration = migration.Generation
+	migration.Status.Job = s.jobName()
+
+	switch migration.Status.Phase {
+	case risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseCompleted, risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseRolledBack:
+		return ctrlkit.NoRequeue()
+	case "":
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhasePending, "")
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if migration.Spec.Rollback {
+		return c.rollback(ctx, s)
+	}
+
+	switch migration.Status.Phase {
+	case risingwavev1alpha1.RisingWaveMetaStoreMigrationPhasePending:
+		return c.syncPending(ctx, s)
+	case risingwavev1alpha1.RisingWaveMetaStoreMigrationPhasePausing:
+		return c.syncPausing(ctx, s)
+	case risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseMigrating:
+		return c.syncMigrating(ctx, s)
+	case risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseSwitching:
+		return c.syncSwitching(ctx, s)
+	case risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseResuming:
+		return c.syncResuming(ctx, s)
+	default:
+		// Failed, waiting for the rollback.
+		return ctrlkit.NoRequeue()
+	}
+}
+
+// validateMetaStoreMigration returns the reason why the meta store of the RisingWave can't be migrated, or empty if it
+// can.
+func validateMetaStoreMigration(migration *risingwavev1alpha1.RisingWaveMetaStoreMigration, risingwave *risingwavev1alpha1.RisingWave) string {
+	target := &migration.Spec.MetaStore
+
+	switch {
+	case risingwave.Spec.MetaStore.Etcd == nil:
+		return fmt.Sprintf("the meta store of RisingWave %s isn't etcd", risingwave.Name)
+	case (target.MySQL == nil) == (target.PostgreSQL == nil) || target.Memory != nil || target.Etcd != nil ||
+		target.SQLite != nil || target.ManagedPostgreSQL != nil:
+		return "the new meta store must be either MySQL or PostgreSQL"
+	}
+
+	if other := risingwave.Annotations[consts.AnnotationMetaStoreMigration]; other != "" && other != migration.Name {
+		return fmt.Sprintf("RisingWave %s is being migrated by %s", risingwave.Name, other)
+	}
+
+	return ""
+}
+
+func (c *RisingWaveMetaStoreMigrationController) syncPending(ctx context.Context, s *metaStoreMigrationState) (reconcile.Result, error) {
+	migration := s.migration
+
+	risingwave, err := c.getRisingWave(ctx, migration)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the risingwave", err)
+	}
+	if risingwave == nil {
+		s.setMessage(fmt.Sprintf("RisingWave %s not found", migration.Spec.RisingWave))
+
+		return ctrlkit.RequeueAfter(metaStoreMigrationCheckInterval)
+	}
+
+	if reason := validateMetaStoreMigration(migration, risingwave); reason != "" {
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed, reason)
+
+		return ctrlkit.NoRequeue()
+	}
+
+	migration.Status.RollbackPoint = &risingwavev1alpha1.RisingWaveMetaStoreMigrationRollbackPoint{
+		MetaStore: *risingwave.Spec.MetaStore.DeepCopy(),
+		Time:      metav1.Now(),
+	}
+	s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhasePausing, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+// syncPausing pauses the reconciliation of the RisingWave and stops its components, so that nothing writes to the old
+// meta store during the migration.
+func (c *RisingWaveMetaStoreMigrationController) syncPausing(ctx context.Context, s *metaStoreMigrationState) (reconcile.Result, error) {
+	migration := s.migration
+
+	risingwave, err := c.getRisingWave(ctx, migration)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the risingwave", err)
+	}
+	if risingwave == nil {
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed, fmt.Sprintf("RisingWave %s not found", migration.Spec.RisingWave))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if !isPausedByMigration(risingwave, migration) {
+		if err := c.patchRisingWave(ctx, risingwave, func(risingwave *risingwavev1alpha1.RisingWave) {
+			if risingwave.Annotations == nil {
+				risingwave.Annotations = make(map[string]string)
+			}
+			risingwave.Annotations[consts.AnnotationPauseReconcile] = "true"
+			risingwave.Annotations[consts.AnnotationMetaStoreMigration] = migration.Name
+		}); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to pause the risingwave", err)
+		}
+	}
+
+	var pods corev1.PodList
+	if err := c.Client.List(ctx, &pods, client.InNamespace(risingwave.Namespace), client.MatchingLabelsSelector{
+		Selector: workloadSelector(risingwave, metaStoreMigrationStoppedComponents...),
+	}); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to list pods", err)
+	}
+
+	if len(pods.Items) > 0 {
+		if err := deleteWorkloads(ctx, c.Client, risingwave, c.openKruiseAvailable, metaStoreMigrationStoppedComponents...); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete workloads", err)
+		}
+		s.setMessage(fmt.Sprintf("Waiting for %d pods to stop", len(pods.Items)))
+
+		return ctrlkit.RequeueAfter(metaStoreMigrationCheckInterval)
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseMigrating, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+func (c *RisingWaveMetaStoreMigrationController) getJob(ctx context.Context, s *metaStoreMigrationState) (*batchv1.Job, error) {
+	var job batchv1.Job
+	if err := c.Client.Get(ctx, types.NamespacedName{Namespace: s.migration.Namespace, Name: s.jobName()}, &job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func isJobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) (bool, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == conditionType && cond.Status == corev1.ConditionTrue {
+			return true, cond.Message
+		}
+	}
+
+	return false, ""
+}
+
+// syncMigrating runs the migration Job and waits for it to finish.
+func (c *RisingWaveMetaStoreMigrationController) syncMigrating(ctx context.Context, s *metaStoreMigrationState) (reconcile.Result, error) {
+	migration := s.migration
+
+	risingwave, err := c.getRisingWave(ctx, migration)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the risingwave", err)
+	}
+	if risingwave == nil {
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed, fmt.Sprintf("RisingWave %s not found", migration.Spec.RisingWave))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	job, err := c.getJob(ctx, s)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the migration job", err)
+	}
+
+	if job == nil {
+		// Create the Job from the rollback point, in case that the RisingWave is switched by others.
+		source := risingwave.DeepCopy()
+		source.Spec.MetaStore = *migration.Status.RollbackPoint.MetaStore.DeepCopy()
+
+		job = factory.NewRisingWaveObjectFactory(source, c.Client.Scheme(), "").
+			NewMetaStoreMigrationJob(s.jobName(), migration.Spec.Image, &migration.Spec.MetaStore, migration.Spec.Force)
+		if err := controllerutil.SetControllerReference(migration, job, c.Client.Scheme()); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to set the owner of the migration job", err)
+		}
+		if err := c.Client.Create(ctx, job); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to create the migration job", err)
+		}
+		s.setMessage(fmt.Sprintf("Waiting for Job %s to complete", job.Name))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if !metav1.IsControlledBy(job, migration) {
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed,
+			fmt.Sprintf("Job %s already exists and isn't created by the migration", job.Name))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if failed, message := isJobConditionTrue(job, batchv1.JobFailed); failed {
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed, fmt.Sprintf("Job %s failed: %s", job.Name, message))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if completed, _ := isJobConditionTrue(job, batchv1.JobComplete); !completed {
+		s.setMessage(fmt.Sprintf("Waiting for Job %s to complete", job.Name))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseSwitching, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+// syncSwitching switches the meta store of the paused RisingWave to the new one. The validating webhook allows it as
+// the RisingWave is annotated by the migration.
+func (c *RisingWaveMetaStoreMigrationController) syncSwitching(ctx context.Context, s *metaStoreMigrationState) (reconcile.Result, error) {
+	migration := s.migration
+
+	risingwave, err := c.getRisingWave(ctx, migration)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the risingwave", err)
+	}
+	if risingwave == nil || !isPausedByMigration(risingwave, migration) {
+		s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed,
+			fmt.Sprintf("RisingWave %s not found or not paused by the migration", migration.Spec.RisingWave))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if !equality.Semantic.DeepEqual(risingwave.Spec.MetaStore, migration.Spec.MetaStore) {
+		if err := c.patchRisingWave(ctx, risingwave, func(risingwave *risingwavev1alpha1.RisingWave) {
+			risingwave.Spec.MetaStore = *migration.Spec.MetaStore.DeepCopy()
+		}); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to switch the meta store of the risingwave", err)
+		}
+	}
+
+	migration.Status.Switched = true
+	s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseResuming, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+// resumeRisingWave removes the annotations of the migration from the RisingWave, so that its components are started.
+func (c *RisingWaveMetaStoreMigrationController) resumeRisingWave(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave, migration *risingwavev1alpha1.RisingWaveMetaStoreMigration) error {
+	if !isPausedByMigration(risingwave, migration) {
+		return nil
+	}
+
+	return c.patchRisingWave(ctx, risingwave, func(risingwave *risingwavev1alpha1.RisingWave) {
+		delete(risingwave.Annotations, consts.AnnotationPauseReconcile)
+		delete(risingwave.Annotations, consts.AnnotationMetaStoreMigration)
+	})
+}
+
+func (c *RisingWaveMetaStoreMigrationController) syncResuming(ctx context.Context, s *metaStoreMigrationState) (reconcile.Result, error) {
+	migration := s.migration
+
+	risingwave, err := c.getRisingWave(ctx, migration)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the risingwave", err)
+	}
+	if risingwave != nil {
+		if err := c.resumeRisingWave(ctx, risingwave, migration); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to resume the risingwave", err)
+		}
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseCompleted,
+		fmt.Sprintf("RisingWave %s is resumed on the new meta store", migration.Spec.RisingWave))
+
+	return ctrlkit.NoRequeue()
+}
+
+// rollback deletes the migration Job, restores the meta store of the RisingWave to the rollback point if it's
+// switched, and resumes the RisingWave. The data copied to the new meta store is kept.
+func (c *RisingWaveMetaStoreMigrationController) rollback(ctx context.Context, s *metaStoreMigrationState) (reconcile.Result, error) {
+	migration := s.migration
+
+	job, err := c.getJob(ctx, s)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the migration job", err)
+	}
+	if job != nil && metav1.IsControlledBy(job, migration) {
+		if err := c.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete the migration job", err)
+		}
+	}
+
+	risingwave, err := c.getRisingWave(ctx, migration)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the risingwave", err)
+	}
+
+	if risingwave != nil {
+		if point := migration.Status.RollbackPoint; point != nil && migration.Status.Switched {
+			// The RisingWave must still be paused, otherwise the changes made on the new meta store would be lost.
+			if !isPausedByMigration(risingwave, migration) {
+				s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseCompleted,
+					fmt.Sprintf("RisingWave %s is already resumed on the new meta store", risingwave.Name))
+
+				return ctrlkit.NoRequeue()
+			}
+
+			if !equality.Semantic.DeepEqual(risingwave.Spec.MetaStore, point.MetaStore) {
+				if err := c.patchRisingWave(ctx, risingwave, func(risingwave *risingwavev1alpha1.RisingWave) {
+					risingwave.Spec.MetaStore = *point.MetaStore.DeepCopy()
+				}); err != nil {
+					return ctrlkit.RequeueIfErrorAndWrap("unable to restore the meta store of the risingwave", err)
+				}
+			}
+			migration.Status.Switched = false
+		}
+
+		if err := c.resumeRisingWave(ctx, risingwave, migration); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to resume the risingwave", err)
+		}
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseRolledBack,
+		fmt.Sprintf("RisingWave %s is resumed on the old meta store", migration.Spec.RisingWave))
+
+	return ctrlkit.NoRequeue()
+}
+
+// SetupWithManager sets up the controller with a given manager.
+func (c *RisingWaveMetaStoreMigrationController) SetupWithManager(mgr ctrl.Manager) error {
+	gvk, err := apiutil.GVKForObject(&risingwavev1alpha1.RisingWaveMetaStoreMigration{}, c.Client.Scheme())
+	if err != nil {
+		return fmt.Errorf("unable to find gvk for RisingWaveMetaStoreMigration: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&risingwavev1alpha1.RisingWaveMetaStoreMigration{}).
+		Owns(&batchv1.Job{}).
+		Complete(metrics.NewControllerMetricsRecorder(c, "RisingWaveMetaStoreMigrationController", gvk))
+}
+
+// NewRisingWaveMetaStoreMigrationController creates a new RisingWaveMetaStoreMigrationController.
+func NewRisingWaveMetaStoreMigrationController(client client.Client, openKruiseAvailable bool) *RisingWaveMetaStoreMigrationController {
+	return &RisingWaveMetaStoreMigrationController{
+		Client:              client,
+		openKruiseAvailable: openKruiseAvailable,
+	}
+}
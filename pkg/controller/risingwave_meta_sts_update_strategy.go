@@ -0,0 +1,279 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/risingwavelabs/ctrlkit"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
+)
+
+const (
+	// metaPodRejoinTimeout is how long a ready meta Pod without a known role is waited for before it's considered
+	// rejoined, so that the rollout isn't blocked forever when the roles can't be told.
+	metaPodRejoinTimeout = time.Minute
+
+	// metaSTSUpdateRetryInterval is the interval to check the rollout again when waiting for the Pods.
+	metaSTSUpdateRetryInterval = 5 * time.Second
+)
+
+// RisingWaveMetaSTSUpdateStrategy implements a leader-aware update strategy for the StatefulSets that control the
+// meta nodes. The meta StatefulSets of a RisingWave, one for each node group, are rolled out together: the outdated
+// followers are deleted one at a time across all of them, each after the previous one has rejoined, and the leader is
+// deleted last, so that there's only one leader election in a rollout. It only takes care of the StatefulSets with the
+// OnDelete update strategy. The requests are keyed by the RisingWave.
+type RisingWaveMetaSTSUpdateStrategy struct {
+	client client.Client
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+
+// isMetaPodRejoined returns true if the Pod is ready and has been recognized as a leader or a follower, or has been
+// ready for the timeout.
+func isMetaPodRejoined(pod *corev1.Pod, now time.Time) bool {
+	readyCond, ok := lo.Find(pod.Status.Conditions, func(cond corev1.PodCondition) bool {
+		return cond.Type == corev1.PodReady
+	})
+	if !ok || readyCond.Status != corev1.ConditionTrue {
+		return false
+	}
+
+	switch pod.Labels[consts.LabelRisingWaveMetaRole] {
+	case consts.MetaRoleLeader, consts.MetaRoleFollower:
+		return true
+	default:
+		return now.Sub(readyCond.LastTransitionTime.Time) >= metaPodRejoinTimeout
+	}
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (s *RisingWaveMetaSTSUpdateStrategy) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	metaLabels := client.MatchingLabels{
+		consts.LabelRisingWaveName:      request.Name,
+		consts.LabelRisingWaveComponent: consts.ComponentMeta,
+	}
+
+	// StatefulSets of the meta node groups of the RisingWave.
+	var stsList appsv1.StatefulSetList
+
+	err := s.client.List(ctx, &stsList, client.InNamespace(request.Namespace), metaLabels)
+	if err != nil {
+		logger.Error(err, "Failed to list StatefulSets")
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to list statefulsets", err)
+	}
+
+	// Other strategies are handled by the StatefulSet controller.
+	statefulSets := lo.Filter(stsList.Items, func(sts appsv1.StatefulSet, _ int) bool {
+		return sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType
+	})
+	if len(statefulSets) == 0 {
+		return ctrlkit.NoRequeue()
+	}
+
+	// Controller manager hasn't observed the change. Wait for another second and retry.
+	if lo.ContainsBy(statefulSets, func(sts appsv1.StatefulSet) bool { return sts.Generation != sts.Status.ObservedGeneration }) {
+		return ctrlkit.RequeueAfter(time.Second)
+	}
+
+	// Meta Pods of the RisingWave, including the ones of the StatefulSets with other strategies, which count for the
+	// availability as well.
+	var podList corev1.PodList
+
+	err = s.client.List(ctx, &podList, client.InNamespace(request.Namespace), metaLabels)
+	if err != nil {
+		logger.Error(err, "Failed to list Pods")
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to list pods", err)
+	}
+
+	isControlledBy := func(pod *corev1.Pod, sts *appsv1.StatefulSet) bool {
+		controller := metav1.GetControllerOfNoCopy(pod)
+
+		return controller != nil && controller.UID == sts.UID
+	}
+	statefulSetOf := func(pod *corev1.Pod) *appsv1.StatefulSet {
+		sts, _ := lo.Find(lo.ToSlicePtr(statefulSets), func(sts *appsv1.StatefulSet) bool { return isControlledBy(pod, sts) })
+
+		return sts
+	}
+
+	// A Pod is considered up-to-date if and only if its revision equals to the update revision of its StatefulSet. See
+	// the RisingWaveComputeSTSUpdateStrategy for the caveats.
+	outdatedPods := lo.Filter(podList.Items, func(pod corev1.Pod, _ int) bool {
+		sts := statefulSetOf(&pod)
+
+		return sts != nil && pod.GetLabels()[appsv1.StatefulSetRevisionLabel] != sts.Status.UpdateRevision
+	})
+	if len(outdatedPods) == 0 {
+		return ctrlkit.NoRequeue()
+	}
+
+	// Wait until the previously deleted Pod is recreated and every Pod has rejoined the cluster, so that at most one
+	// meta node of the RisingWave is unavailable at any time.
+	now := time.Now()
+	notRejoinedPods := lo.Filter(podList.Items, func(pod corev1.Pod, _ int) bool {
+		return utils.IsDeleted(&pod) || !isMetaPodRejoined(&pod, now)
+	})
+	notRecreated := lo.ContainsBy(statefulSets, func(sts appsv1.StatefulSet) bool {
+		pods := lo.CountBy(podList.Items, func(pod corev1.Pod) bool { return isControlledBy(&pod, &sts) })
+
+		return pods < int(ptr.Deref(sts.Spec.Replicas, 1))
+	})
+	if notRecreated || len(notRejoinedPods) > 0 {
+		logger.V(1).Info("Waiting for the meta Pods to rejoin...", "pods", utils.MapObjectsToNames[corev1.Pod, *corev1.Pod](notRejoinedPods))
+
+		return ctrlkit.RequeueAfter(metaSTSUpdateRetryInterval)
+	}
+
+	// Update the followers (and the Pods with unknown roles) first, in the reverse order of the names like the
+	// StatefulSet controller does with the ordinals, and the leader last.
+	slices.SortFunc(outdatedPods, func(a, b corev1.Pod) int {
+		aIsLeader := a.Labels[consts.LabelRisingWaveMetaRole] == consts.MetaRoleLeader
+		bIsLeader := b.Labels[consts.LabelRisingWaveMetaRole] == consts.MetaRoleLeader
+		if aIsLeader != bIsLeader {
+			return lo.Ternary(aIsLeader, 1, -1)
+		}
+
+		return strings.Compare(b.Name, a.Name)
+	})
+
+	pod := &outdatedPods[0]
+
+	logger.V(1).Info("Deleting pod...", "pod", pod.Name, "role", pod.Labels[consts.LabelRisingWaveMetaRole])
+
+	err = s.client.Delete(ctx, pod, client.Preconditions{UID: &pod.UID})
+	if client.IgnoreNotFound(err) != nil {
+		logger.V(10).Error(err, "Failed to delete pod", "pod", pod.Name)
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to delete pod", err)
+	}
+
+	return ctrlkit.RequeueAfter(metaSTSUpdateRetryInterval)
+}
+
+// SetupWithManager registers itself with the given manager.
+func (s *RisingWaveMetaSTSUpdateStrategy) SetupWithManager(mgr ctrl.Manager) error {
+	gvk, err := apiutil.GVKForObject(&risingwavev1alpha1.RisingWave{}, s.client.Scheme())
+	if err != nil {
+		return fmt.Errorf("unable to find gvk for RisingWave: %w", err)
+	}
+
+	stsGVK, err := apiutil.GVKForObject(&appsv1.StatefulSet{}, s.client.Scheme())
+	if err != nil {
+		return fmt.Errorf("unable to find gvk for StatefulSet: %w", err)
+	}
+
+	isMetaObject := func(object client.Object) bool {
+		return object.GetLabels()[consts.LabelRisingWaveName] != "" &&
+			object.GetLabels()[consts.LabelRisingWaveComponent] == consts.ComponentMeta
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("RisingWaveMetaSTSUpdateStrategy").
+		Watches(
+			&appsv1.StatefulSet{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				if object == nil {
+					return nil
+				}
+
+				// Owned by RisingWave.
+				ownerRef := metav1.GetControllerOfNoCopy(object)
+				if ownerRef == nil {
+					return nil
+				}
+				if ownerRef.APIVersion != gvk.GroupVersion().String() || ownerRef.Kind != gvk.Kind {
+					return nil
+				}
+
+				// Has certain labels.
+				if !isMetaObject(object) {
+					return nil
+				}
+
+				return []reconcile.Request{
+					{
+						NamespacedName: types.NamespacedName{
+							Namespace: object.GetNamespace(),
+							Name:      object.GetLabels()[consts.LabelRisingWaveName],
+						},
+					},
+				}
+			}),
+			builder.WithPredicates(utils.UpdateEventFilter, predicate.GenerationChangedPredicate{}),
+		).
+		// The readiness and the roles of the Pods drive the rollout.
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				if object == nil || !isMetaObject(object) {
+					return nil
+				}
+
+				// Owned by StatefulSet.
+				ownerRef := metav1.GetControllerOfNoCopy(object)
+				if ownerRef == nil {
+					return nil
+				}
+				if ownerRef.APIVersion != stsGVK.GroupVersion().String() || ownerRef.Kind != stsGVK.Kind {
+					return nil
+				}
+
+				return []reconcile.Request{
+					{
+						NamespacedName: types.NamespacedName{
+							Namespace: object.GetNamespace(),
+							Name:      object.GetLabels()[consts.LabelRisingWaveName],
+						},
+					},
+				}
+			}),
+		).
+		Complete(metrics.NewControllerMetricsRecorder(s, "RisingWaveMetaSTSUpdateStrategy", gvk))
+}
+
+// NewRisingWaveMetaSTSUpdateStrategy creates a new RisingWaveMetaSTSUpdateStrategy.
+func NewRisingWaveMetaSTSUpdateStrategy(client client.Client) *RisingWaveMetaSTSUpdateStrategy {
+	return &RisingWaveMetaSTSUpdateStrategy{
+		client: client,
+	}
+}
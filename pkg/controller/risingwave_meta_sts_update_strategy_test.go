@@ -0,0 +1,243 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+func newMetaStatefulSetForTest() *appsv1.StatefulSet {
+	return newMetaGroupStatefulSetForTest("meta")
+}
+
+// newMetaGroupStatefulSetForTest returns a meta StatefulSet of a node group of the RisingWave rw.
+func newMetaGroupStatefulSetForTest(name string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-uid"),
+			Labels: map[string]string{
+				consts.LabelRisingWaveName:      "rw",
+				consts.LabelRisingWaveComponent: consts.ComponentMeta,
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To[int32](3),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{consts.LabelRisingWaveComponent: consts.ComponentMeta},
+			},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        3,
+			UpdateRevision:  "new",
+			CurrentRevision: "old",
+		},
+	}
+}
+
+func newMetaPodForTest(name, role, revision string, ready bool) *corev1.Pod {
+	return newMetaGroupPodForTest("meta", name, role, revision, ready)
+}
+
+// newMetaGroupPodForTest returns a meta Pod of the StatefulSet.
+func newMetaGroupPodForTest(sts, name, role, revision string, ready bool) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-uid"),
+			Labels: map[string]string{
+				consts.LabelRisingWaveName:      "rw",
+				consts.LabelRisingWaveComponent: consts.ComponentMeta,
+				consts.LabelRisingWaveMetaRole:  role,
+				appsv1.StatefulSetRevisionLabel: revision,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: sts, UID: types.UID(sts + "-uid"), Controller: ptr.To(true)},
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.PodReady,
+					Status:             lo.Ternary(ready, corev1.ConditionTrue, corev1.ConditionFalse),
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+}
+
+func reconcileMetaSTSUpdateStrategy(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	c := fake.NewClientBuilder().WithScheme(testutils.Scheme).WithObjects(objects...).Build()
+
+	_, err := NewRisingWaveMetaSTSUpdateStrategy(c).Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "rw"},
+	})
+	require.NoError(t, err)
+
+	return c
+}
+
+func existingPodNames(t *testing.T, c client.Client) []string {
+	t.Helper()
+
+	var podList corev1.PodList
+	require.NoError(t, c.List(context.Background(), &podList))
+
+	return lo.Map(podList.Items, func(pod corev1.Pod, _ int) string { return pod.Name })
+}
+
+func TestRisingWaveMetaSTSUpdateStrategy_FollowersFirst(t *testing.T) {
+	c := reconcileMetaSTSUpdateStrategy(t,
+		newMetaStatefulSetForTest(),
+		newMetaPodForTest("meta-0", consts.MetaRoleFollower, "old", true),
+		newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+		newMetaPodForTest("meta-2", consts.MetaRoleFollower, "new", true),
+	)
+
+	assert.ElementsMatch(t, []string{"meta-1", "meta-2"}, existingPodNames(t, c), "only the outdated follower should be deleted")
+}
+
+func TestRisingWaveMetaSTSUpdateStrategy_LeaderLast(t *testing.T) {
+	c := reconcileMetaSTSUpdateStrategy(t,
+		newMetaStatefulSetForTest(),
+		newMetaPodForTest("meta-0", consts.MetaRoleFollower, "new", true),
+		newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+		newMetaPodForTest("meta-2", consts.MetaRoleFollower, "new", true),
+	)
+
+	assert.ElementsMatch(t, []string{"meta-0", "meta-2"}, existingPodNames(t, c), "the leader should be deleted")
+}
+
+func TestRisingWaveMetaSTSUpdateStrategy_WaitForRejoin(t *testing.T) {
+	testcases := map[string][]client.Object{
+		"not-ready": {
+			newMetaPodForTest("meta-0", consts.MetaRoleFollower, "new", false),
+			newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+			newMetaPodForTest("meta-2", consts.MetaRoleFollower, "old", true),
+		},
+		"role-unknown": {
+			newMetaPodForTest("meta-0", consts.MetaRoleUnknown, "new", true),
+			newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+			newMetaPodForTest("meta-2", consts.MetaRoleFollower, "old", true),
+		},
+		"not-recreated": {
+			newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+			newMetaPodForTest("meta-2", consts.MetaRoleFollower, "old", true),
+		},
+	}
+
+	for name, pods := range testcases {
+		t.Run(name, func(t *testing.T) {
+			c := reconcileMetaSTSUpdateStrategy(t, append([]client.Object{newMetaStatefulSetForTest()}, pods...)...)
+
+			assert.Len(t, existingPodNames(t, c), len(pods), "no pod should be deleted")
+		})
+	}
+}
+
+func TestRisingWaveMetaSTSUpdateStrategy_UnknownRoleTimeout(t *testing.T) {
+	pod := newMetaPodForTest("meta-0", consts.MetaRoleUnknown, "new", true)
+	pod.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-metaPodRejoinTimeout))
+
+	c := reconcileMetaSTSUpdateStrategy(t,
+		newMetaStatefulSetForTest(),
+		pod,
+		newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+		newMetaPodForTest("meta-2", consts.MetaRoleFollower, "old", true),
+	)
+
+	assert.ElementsMatch(t, []string{"meta-0", "meta-1"}, existingPodNames(t, c))
+}
+
+func TestRisingWaveMetaSTSUpdateStrategy_IgnoreOtherStrategies(t *testing.T) {
+	sts := newMetaStatefulSetForTest()
+	sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+
+	c := reconcileMetaSTSUpdateStrategy(t,
+		sts,
+		newMetaPodForTest("meta-0", consts.MetaRoleFollower, "old", true),
+		newMetaPodForTest("meta-1", consts.MetaRoleLeader, "old", true),
+		newMetaPodForTest("meta-2", consts.MetaRoleFollower, "old", true),
+	)
+
+	assert.Len(t, existingPodNames(t, c), 3)
+}
+
+func TestRisingWaveMetaSTSUpdateStrategy_AcrossNodeGroups(t *testing.T) {
+	// The leader of a node group is deleted after the followers of the other node groups.
+	c := reconcileMetaSTSUpdateStrategy(t,
+		newMetaGroupStatefulSetForTest("meta-a"),
+		newMetaGroupStatefulSetForTest("meta-b"),
+		newMetaGroupPodForTest("meta-a", "meta-a-0", consts.MetaRoleLeader, "old", true),
+		newMetaGroupPodForTest("meta-a", "meta-a-1", consts.MetaRoleFollower, "new", true),
+		newMetaGroupPodForTest("meta-a", "meta-a-2", consts.MetaRoleFollower, "new", true),
+		newMetaGroupPodForTest("meta-b", "meta-b-0", consts.MetaRoleFollower, "old", true),
+		newMetaGroupPodForTest("meta-b", "meta-b-1", consts.MetaRoleFollower, "new", true),
+		newMetaGroupPodForTest("meta-b", "meta-b-2", consts.MetaRoleFollower, "new", true),
+	)
+
+	assert.NotContains(t, existingPodNames(t, c), "meta-b-0", "the outdated follower of the other node group should be deleted")
+	assert.Len(t, existingPodNames(t, c), 5, "only one pod should be deleted")
+
+	// Only one Pod is unavailable across the node groups.
+	c = reconcileMetaSTSUpdateStrategy(t,
+		newMetaGroupStatefulSetForTest("meta-a"),
+		newMetaGroupStatefulSetForTest("meta-b"),
+		newMetaGroupPodForTest("meta-a", "meta-a-0", consts.MetaRoleLeader, "old", true),
+		newMetaGroupPodForTest("meta-a", "meta-a-1", consts.MetaRoleFollower, "old", true),
+		newMetaGroupPodForTest("meta-a", "meta-a-2", consts.MetaRoleFollower, "old", true),
+		newMetaGroupPodForTest("meta-b", "meta-b-0", consts.MetaRoleFollower, "new", true),
+		newMetaGroupPodForTest("meta-b", "meta-b-1", consts.MetaRoleFollower, "new", true),
+	)
+
+	assert.Len(t, existingPodNames(t, c), 5, "no pod should be deleted before the other node group recovers")
+
+	// The Pods of the other strategies count for the availability.
+	rollingUpdate := newMetaGroupStatefulSetForTest("meta-b")
+	rollingUpdate.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+	c = reconcileMetaSTSUpdateStrategy(t,
+		newMetaGroupStatefulSetForTest("meta-a"),
+		rollingUpdate,
+		newMetaGroupPodForTest("meta-a", "meta-a-0", consts.MetaRoleLeader, "old", true),
+		newMetaGroupPodForTest("meta-a", "meta-a-1", consts.MetaRoleFollower, "old", true),
+		newMetaGroupPodForTest("meta-a", "meta-a-2", consts.MetaRoleFollower, "old", true),
+		newMetaGroupPodForTest("meta-b", "meta-b-0", consts.MetaRoleFollower, "new", false),
+	)
+
+	assert.Len(t, existingPodNames(t, c), 4, "no pod should be deleted before the pods of the other strategies rejoin")
+}
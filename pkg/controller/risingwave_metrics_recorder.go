@@ -0,0 +1,57 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+)
+
+// actionStartTimeKey is the key of the start time of the action in the context.
+type actionStartTimeKey struct{}
+
+// RisingWaveMetricsRecorder is a context action hook for recording the durations of the actions, and the metrics of the
+// RisingWave once its status is updated.
+type RisingWaveMetricsRecorder struct {
+	mgr *object.RisingWaveManager
+}
+
+// NewMetricsHook creates a metrics hook for the given risingwave.
+func NewMetricsHook(mgr *object.RisingWaveManager) *RisingWaveMetricsRecorder {
+	return &RisingWaveMetricsRecorder{mgr: mgr}
+}
+
+// PreRun records the start time in the context of the action, so that each run of the action is measured on its own,
+// even if the actions of the same name run concurrently.
+func (h *RisingWaveMetricsRecorder) PreRun(ctx context.Context, logger logr.Logger, action string) context.Context {
+	return context.WithValue(ctx, actionStartTimeKey{}, time.Now())
+}
+
+// PostRun records the duration of the action.
+func (h *RisingWaveMetricsRecorder) PostRun(ctx context.Context, logger logr.Logger, action string, result reconcile.Result, err error) {
+	if startTime, ok := ctx.Value(actionStartTimeKey{}).(time.Time); ok {
+		metrics.ObserveRisingWaveControllerActionDuration(action, metrics.ActionResult(result, err), time.Since(startTime))
+	}
+
+	if action == RisingWaveAction_UpdateRisingWaveStatusViaClient && err == nil {
+		metrics.UpdateRisingWaveMetrics(h.mgr.RisingWaveAfterImage())
+	}
+}
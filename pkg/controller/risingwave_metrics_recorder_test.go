@@ -0,0 +1,125 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/risingwavelabs/ctrlkit"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+
+	"github.com/risingwavelabs/risingwave-operator/pkg/manager"
+	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+// initMetricsOnce registers the metrics with the registry once for all the tests.
+var initMetricsOnce sync.Once
+
+// actionDurationCount returns the number of the durations observed for the action.
+func actionDurationCount(t *testing.T, action string) uint64 {
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "risingwave_controller_action_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "action" && label.GetValue() == action {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestRisingWaveMetricsRecorder_ConcurrentActions(t *testing.T) {
+	const action = "TestConcurrentAction"
+	initMetricsOnce.Do(metrics.InitMetrics)
+
+	risingwaveManager := object.NewRisingWaveManager(nil, testutils.FakeRisingWave(), false)
+	mgr := manager.NewRisingWaveControllerManager(manager.RisingWaveControllerManagerState{}, nil, logr.Discard())
+
+	newAction := func(d time.Duration) ctrlkit.Action {
+		return mgr.NewAction(action, func(ctx context.Context, l logr.Logger) (ctrl.Result, error) {
+			time.Sleep(d)
+
+			return ctrlkit.Continue()
+		})
+	}
+
+	before := actionDurationCount(t, action)
+	workflow := manager.WithContextActionHooks(ctrlkit.ParallelJoin(newAction(10*time.Millisecond), newAction(20*time.Millisecond)),
+		NewMetricsHook(risingwaveManager))
+	if _, err := workflow.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := actionDurationCount(t, action) - before; count != 2 {
+		t.Fatalf("expected the durations of both actions to be observed, got %d", count)
+	}
+}
+
+func TestRisingWaveController_ActionDurations(t *testing.T) {
+	initMetricsOnce.Do(metrics.InitMetrics)
+
+	risingwave := &risingwavev1alpha1.RisingWave{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+	}
+	controller := &RisingWaveController{
+		Client: fake.NewClientBuilder().
+			WithScheme(testutils.Scheme).
+			WithStatusSubresource(&risingwavev1alpha1.RisingWave{}).
+			WithObjects(risingwave).
+			Build(),
+		Recorder: events.NewFakeRecorder(defaultRecorderBufferSize),
+	}
+
+	// The durations of the generated actions and the ones created by the controller are both observed.
+	actions := []string{manager.RisingWaveAction_CollectRunningStatisticsAndSyncStatus, RisingWaveAction_UpdateRisingWaveStatusViaClient}
+	before := lo.Map(actions, func(action string, _ int) uint64 { return actionDurationCount(t, action) })
+	if _, err := controller.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(risingwave)}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, action := range actions {
+		if count := actionDurationCount(t, action) - before[i]; count != 1 {
+			t.Errorf("expected the duration of action %s to be observed once, got %d", action, count)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory"
+)
+
+// stateStoreProbeCheckInterval is the interval to check if the Job probing the state store has finished.
+const stateStoreProbeCheckInterval = 5 * time.Second
+
+// stateStoreProbeJobName returns the name of the Job probing the state store of the RisingWave.
+func stateStoreProbeJobName(risingwave *risingwavev1alpha1.RisingWave) string {
+	return risingwave.Name + "-preflight"
+}
+
+// probeStateStore runs the Job probing the state store with the workload identity of the RisingWave. It returns
+// false if the Job hasn't finished yet, otherwise the reason of the failure, which is empty if the probe succeeded.
+// The finished Job is deleted, so that the next check runs a new one.
+func (c *RisingWaveController) probeStateStore(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) (bool, string, error) {
+	name := stateStoreProbeJobName(risingwave)
+
+	var job batchv1.Job
+	err := c.Client.Get(ctx, client.ObjectKey{Namespace: risingwave.Namespace, Name: name}, &job)
+	if apierrors.IsNotFound(err) {
+		newJob := factory.NewRisingWaveObjectFactory(risingwave, c.Client.Scheme(), c.operatorVersion).NewStateStoreProbeJob(name)
+		if err := controllerutil.SetControllerReference(risingwave, newJob, c.Client.Scheme()); err != nil {
+			return false, "", fmt.Errorf("unable to set controller reference: %w", err)
+		}
+		if err := c.Client.Create(ctx, newJob); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, "", fmt.Errorf("unable to create state store probe job: %w", err)
+		}
+
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("unable to get state store probe job: %w", err)
+	}
+
+	if !metav1.IsControlledBy(&job, risingwave) {
+		return true, fmt.Sprintf("Job %s isn't created by the RisingWave", name), nil
+	}
+
+	deleteJob := func() error {
+		err := c.Client.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete state store probe job: %w", err)
+		}
+
+		return nil
+	}
+
+	// The Job of a previous generation may probe with the outdated spec, run a new one.
+	if job.Annotations[consts.AnnotationPreflightGeneration] != strconv.FormatInt(risingwave.Generation, 10) {
+		return false, "", deleteJob()
+	}
+
+	if failed, message := isJobConditionTrue(&job, batchv1.JobFailed); failed {
+		failure, err := c.stateStoreProbeFailure(ctx, &job)
+		if err != nil {
+			return false, "", err
+		}
+		if failure == "" {
+			failure = fmt.Sprintf("Job %s failed: %s", name, message)
+		}
+
+		return true, failure, deleteJob()
+	}
+
+	if completed, _ := isJobConditionTrue(&job, batchv1.JobComplete); completed {
+		return true, "", deleteJob()
+	}
+
+	return false, "", nil
+}
+
+// stateStoreProbeFailure reads the reason of the failure from the termination message of the probe container.
+func (c *RisingWaveController) stateStoreProbeFailure(ctx context.Context, job *batchv1.Job) (string, error) {
+	var podList corev1.PodList
+	if err := c.Client.List(ctx, &podList, client.InNamespace(job.Namespace), client.MatchingLabels{
+		"job-name": job.Name,
+	}); err != nil {
+		return "", fmt.Errorf("unable to list pods of state store probe job: %w", err)
+	}
+
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+				if message := strings.TrimSpace(status.State.Terminated.Message); message != "" {
+					return message, nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,668 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/risingwavelabs/ctrlkit"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
+)
+
+const (
+	// defaultUpgradeTimeout is the default timeout of the green RisingWave to become running.
+	defaultUpgradeTimeout = time.Hour
+
+	// upgradeCheckInterval is the interval to check the green RisingWave while waiting for it.
+	upgradeCheckInterval = 10 * time.Second
+
+	// upgradeVerificationTimeout is the timeout of the verification query against the green RisingWave.
+	upgradeVerificationTimeout = 10 * time.Second
+
+	// defaultUpgradeVerificationQuery is the verification query when there's none in the spec.
+	defaultUpgradeVerificationQuery = "SELECT count(*) FROM rw_catalog.rw_relations"
+)
+
+// RisingWaveUpgradeController upgrades the RisingWaves in the blue/green way.
+type RisingWaveUpgradeController struct {
+	Client client.Client
+
+	// apiReader reads from the API server without the cache. It's used to read the credentials of the verification.
+	apiReader client.Reader
+
+	// verifyGreen runs the verification against the green RisingWave. It's replaced in tests.
+	verifyGreen func(ctx context.Context, green *risingwavev1alpha1.RisingWave, verification *risingwavev1alpha1.RisingWaveUpgradeVerification) error
+}
+
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwaveupgrades,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwaveupgrades/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwaves,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;delete
+
+// Reconcile implements reconcile.Reconciler.
+func (c *RisingWaveUpgradeController) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var upgrade risingwavev1alpha1.RisingWaveUpgrade
+	if err := c.Client.Get(ctx, request.NamespacedName, &upgrade); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("Not found, abort")
+
+			return ctrlkit.NoRequeue()
+		}
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get risingwaveupgrade", err)
+	}
+
+	// The alias Service is garbage collected if it's still owned by the upgrade.
+	if utils.IsDeleted(&upgrade) {
+		return ctrlkit.NoRequeue()
+	}
+
+	prevStatus := upgrade.Status.DeepCopy()
+	result, err := c.sync(ctx, &upgrade)
+
+	if !equality.Semantic.DeepEqual(prevStatus, &upgrade.Status) {
+		if updateErr := c.Client.Status().Update(ctx, &upgrade); updateErr != nil {
+			if apierrors.IsConflict(updateErr) {
+				return ctrlkit.RequeueAfter(10 * time.Millisecond)
+			}
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to update status of risingwaveupgrade", updateErr)
+		}
+	}
+
+	return result, err
+}
+
+// upgradeState is the state of a RisingWaveUpgrade being reconciled.
+type upgradeState struct {
+	upgrade *risingwavev1alpha1.RisingWaveUpgrade
+}
+
+func (s *upgradeState) greenName() string {
+	return cmp.Or(s.upgrade.Spec.Green, s.upgrade.Spec.RisingWave+"-green")
+}
+
+func (s *upgradeState) serviceName() string {
+	return cmp.Or(s.upgrade.Spec.Service.Name, s.upgrade.Spec.RisingWave+"-stable")
+}
+
+// enterPhase sets the phase and records it.
+func (s *upgradeState) enterPhase(phase risingwavev1alpha1.RisingWaveUpgradePhase, message string) {
+	status := &s.upgrade.Status
+	status.Phase, status.Message = phase, message
+	status.Phases = append(status.Phases, risingwavev1alpha1.RisingWaveUpgradePhaseRecord{
+		Phase:     phase,
+		StartTime: metav1.Now(),
+		Message:   message,
+	})
+}
+
+// setMessage updates the message of the current phase.
+func (s *upgradeState) setMessage(message string) {
+	s.upgrade.Status.Message = message
+	if n := len(s.upgrade.Status.Phases); n > 0 {
+		s.upgrade.Status.Phases[n-1].Message = message
+	}
+}
+
+func (s *upgradeState) phaseStartTime() time.Time {
+	if n := len(s.upgrade.Status.Phases); n > 0 {
+		return s.upgrade.Status.Phases[n-1].StartTime.Time
+	}
+
+	return s.upgrade.CreationTimestamp.Time
+}
+
+func (c *RisingWaveUpgradeController) getRisingWave(ctx context.Context, namespace, name string) (*risingwavev1alpha1.RisingWave, error) {
+	var risingwave risingwavev1alpha1.RisingWave
+	if err := c.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &risingwave); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &risingwave, nil
+}
+
+// sync moves the upgrade forward by one phase at most. The status update triggers the next round.
+func (c *RisingWaveUpgradeController) sync(ctx context.Context, upgrade *risingwavev1alpha1.RisingWaveUpgrade) (reconcile.Result, error) {
+	s := &upgradeState{upgrade: upgrade}
+	upgrade.Status.ObservedGeneration = upgrade.Generation
+	upgrade.Status.Green, upgrade.Status.Service = s.greenName(), s.serviceName()
+
+	switch upgrade.Status.Phase {
+	case risingwavev1alpha1.RisingWaveUpgradePhaseCompleted, risingwavev1alpha1.RisingWaveUpgradePhaseRolledBack:
+		return ctrlkit.NoRequeue()
+	case "":
+		s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhasePending, "")
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if upgrade.Spec.Decision == risingwavev1alpha1.RisingWaveUpgradeDecisionRollback {
+		return c.rollback(ctx, s)
+	}
+
+	switch upgrade.Status.Phase {
+	case risingwavev1alpha1.RisingWaveUpgradePhasePending:
+		return c.syncPending(ctx, s)
+	case risingwavev1alpha1.RisingWaveUpgradePhaseProvisioning:
+		return c.syncProvisioning(ctx, s)
+	case risingwavev1alpha1.RisingWaveUpgradePhaseWaitingForGreen:
+		return c.syncWaitingForGreen(ctx, s)
+	case risingwavev1alpha1.RisingWaveUpgradePhaseSwitched:
+		return c.syncSwitched(ctx, s)
+	default:
+		// Failed, waiting for the rollback.
+		return ctrlkit.NoRequeue()
+	}
+}
+
+// validateSource returns the reason why the source can't be upgraded in the blue/green way, or empty if it can.
+func validateSource(upgrade *risingwavev1alpha1.RisingWaveUpgrade, source *risingwavev1alpha1.RisingWave) string {
+	switch {
+	case source.Spec.StateStore.Memory != nil && *source.Spec.StateStore.Memory:
+		return "the memory state store isn't supported"
+	case source.Spec.StateStore.LocalDisk != nil:
+		return "the local disk state store isn't supported"
+	case source.Spec.StateStore.ManagedMinIO != nil:
+		return "the managed MinIO state store isn't supported"
+	case upgrade.Spec.MetaStore.Memory != nil && *upgrade.Spec.MetaStore.Memory:
+		return "the memory meta store isn't supported"
+	case upgrade.Spec.MetaStore.MySQL == nil && upgrade.Spec.MetaStore.PostgreSQL == nil:
+		return "the meta store must be MySQL or PostgreSQL"
+	case !stores.IsBucketSupported(&source.Spec.StateStore):
+		return "the state store must be an S3 compatible bucket with the credentials in a Secret"
+	case ptr.Deref(source.Spec.EnableStandaloneMode, false):
+		// The green RisingWave is cloned with the sinks paused, which isn't possible in the standalone mode.
+		return "the standalone mode isn't supported"
+	default:
+		return ""
+	}
+}
+
+func (c *RisingWaveUpgradeController) syncPending(ctx context.Context, s *upgradeState) (reconcile.Result, error) {
+	upgrade := s.upgrade
+
+	source, err := c.getRisingWave(ctx, upgrade.Namespace, upgrade.Spec.RisingWave)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the source risingwave", err)
+	}
+	if source == nil {
+		s.setMessage(fmt.Sprintf("RisingWave %s not found", upgrade.Spec.RisingWave))
+
+		return ctrlkit.RequeueAfter(upgradeCheckInterval)
+	}
+
+	if reason := validateSource(upgrade, source); reason != "" {
+		s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed, reason)
+
+		return ctrlkit.NoRequeue()
+	}
+
+	// Clients can connect to the alias Service before the switch.
+	if ok, err := c.syncAliasService(ctx, s, source, upgrade); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync the alias service", err)
+	} else if !ok {
+		return ctrlkit.RequeueAfter(upgradeCheckInterval)
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseProvisioning, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+// greenExcludedAnnotations are the annotations of the source that aren't copied to the green RisingWave, since they
+// control the reconciliation, the validation, the deletion or the maintenance of the source only.
+var greenExcludedAnnotations = []string{
+	corev1.LastAppliedConfigAnnotation,
+	consts.AnnotationPauseReconcile,
+	consts.AnnotationBypassValidatingWebhook,
+	consts.AnnotationConfirmDeletion,
+	consts.AnnotationApplyPendingMaintenance,
+	consts.AnnotationPendingMaintenance,
+	consts.AnnotationMetaStoreMigration,
+}
+
+// newGreenRisingWave clones the source RisingWave with the image, the meta store and the data directory of the upgrade.
+// The green RisingWave is a clone of the source, so that the meta snapshot of the source is restored into the meta
+// store with the data directory rewritten, and the data of the source is copied into the data directory. The source
+// is never touched, except for the meta snapshot.
+func newGreenRisingWave(s *upgradeState, source *risingwavev1alpha1.RisingWave) *risingwavev1alpha1.RisingWave {
+	upgrade := s.upgrade
+
+	labels := maps.Clone(source.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[consts.LabelRisingWaveUpgrade] = upgrade.Name
+
+	annotations := maps.Clone(source.Annotations)
+	for _, key := range greenExcludedAnnotations {
+		delete(annotations, key)
+	}
+
+	green := &risingwavev1alpha1.RisingWave{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   upgrade.Namespace,
+			Name:        s.greenName(),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	green.Spec.Image = upgrade.Spec.Image
+	for _, nodeGroups := range [][]risingwavev1alpha1.RisingWaveNodeGroup{
+		green.Spec.Components.Meta.NodeGroups,
+		green.Spec.Components.Frontend.NodeGroups,
+		green.Spec.Components.Compute.NodeGroups,
+		green.Spec.Components.Compactor.NodeGroups,
+	} {
+		for i := range nodeGroups {
+			nodeGroups[i].Template.Spec.Image = ""
+		}
+	}
+	if green.Spec.Components.Standalone != nil {
+		green.Spec.Components.Standalone.Template.Spec.Image = ""
+	}
+
+	green.Spec.MetaStore = *upgrade.Spec.MetaStore.DeepCopy()
+	green.Spec.StateStore.DataDirectory = cmp.Or(upgrade.Spec.StateStoreDataDirectory,
+		cmp.Or(source.Spec.StateStore.DataDirectory, "hummock")+"-"+s.greenName())
+	green.Spec.CloneFrom = &risingwavev1alpha1.RisingWaveCloneFrom{Name: source.Name}
+
+	// Keep the green RisingWave deletable on rollback. The deletion protection of the source is copied on confirm.
+	green.Spec.DeletionProtection = nil
+
+	return green
+}
+
+func isCreatedByUpgrade(risingwave *risingwavev1alpha1.RisingWave, upgrade *risingwavev1alpha1.RisingWaveUpgrade) bool {
+	return risingwave.Labels[consts.LabelRisingWaveUpgrade] == upgrade.Name
+}
+
+func (c *RisingWaveUpgradeController) syncProvisioning(ctx context.Context, s *upgradeState) (reconcile.Result, error) {
+	upgrade := s.upgrade
+
+	source, err := c.getRisingWave(ctx, upgrade.Namespace, upgrade.Spec.RisingWave)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the source risingwave", err)
+	}
+	if source == nil {
+		s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed, fmt.Sprintf("RisingWave %s not found", upgrade.Spec.RisingWave))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	green, err := c.getRisingWave(ctx, upgrade.Namespace, s.greenName())
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the green risingwave", err)
+	}
+
+	if green == nil {
+		if err := c.Client.Create(ctx, newGreenRisingWave(s, source)); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to create the green risingwave", err)
+		}
+	} else if !isCreatedByUpgrade(green, upgrade) {
+		s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed,
+			fmt.Sprintf("RisingWave %s already exists and isn't created by the upgrade", green.Name))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseWaitingForGreen, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+func (c *RisingWaveUpgradeController) syncWaitingForGreen(ctx context.Context, s *upgradeState) (reconcile.Result, error) {
+	upgrade := s.upgrade
+
+	green, err := c.getRisingWave(ctx, upgrade.Namespace, s.greenName())
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the green risingwave", err)
+	}
+	if green == nil {
+		s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed, fmt.Sprintf("RisingWave %s not found", s.greenName()))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if !object.NewRisingWaveReader(green).DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionRunning, true) {
+		if c.isWaitingForGreenTimedOut(s) {
+			s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed,
+				fmt.Sprintf("RisingWave %s isn't running after %s", green.Name, upgradeTimeout(upgrade)))
+
+			return ctrlkit.NoRequeue()
+		}
+
+		// Surface the progress of the clone.
+		if green.Status.Clone != nil && green.Status.Clone.Phase != risingwavev1alpha1.RisingWaveClonePhaseCompleted {
+			s.setMessage(fmt.Sprintf("RisingWave %s is cloning (%s): %s", green.Name, green.Status.Clone.Phase, green.Status.Clone.Message))
+		}
+
+		return ctrlkit.RequeueAfter(upgradeCheckInterval)
+	}
+
+	// Switch only after the green RisingWave serves the reads.
+	if err := c.verifyGreenReads(ctx, green, &upgrade.Spec.Verification); err != nil {
+		s.setMessage(fmt.Sprintf("RisingWave %s doesn't serve the reads: %s", green.Name, err.Error()))
+
+		if c.isWaitingForGreenTimedOut(s) {
+			s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed, upgrade.Status.Message)
+
+			return ctrlkit.NoRequeue()
+		}
+
+		return ctrlkit.RequeueAfter(upgradeCheckInterval)
+	}
+
+	if ok, err := c.syncAliasService(ctx, s, green, upgrade); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync the alias service", err)
+	} else if !ok {
+		return ctrlkit.RequeueAfter(upgradeCheckInterval)
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseSwitched, "")
+
+	return ctrlkit.NoRequeue()
+}
+
+func (c *RisingWaveUpgradeController) syncSwitched(ctx context.Context, s *upgradeState) (reconcile.Result, error) {
+	upgrade := s.upgrade
+
+	green, err := c.getRisingWave(ctx, upgrade.Namespace, s.greenName())
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the green risingwave", err)
+	}
+	if green == nil {
+		s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseFailed, fmt.Sprintf("RisingWave %s not found", s.greenName()))
+
+		return ctrlkit.NoRequeue()
+	}
+
+	if upgrade.Spec.Decision != risingwavev1alpha1.RisingWaveUpgradeDecisionConfirm {
+		// Keep the alias Service in sync, e.g., with the ports of the frontend.
+		if ok, err := c.syncAliasService(ctx, s, green, upgrade); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync the alias service", err)
+		} else if !ok {
+			return ctrlkit.RequeueAfter(upgradeCheckInterval)
+		}
+
+		return ctrlkit.NoRequeue()
+	}
+
+	// Hand the alias Service over to the green RisingWave, so that it outlives the source and the upgrade.
+	if ok, err := c.syncAliasService(ctx, s, green, green); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync the alias service", err)
+	} else if !ok {
+		return ctrlkit.RequeueAfter(upgradeCheckInterval)
+	}
+
+	// The green RisingWave takes over the deletion protection of the source, and its streaming jobs, kept paused
+	// since it's cloned, are resumed to take over the ingestion and the sinks of the source.
+	source, err := c.getRisingWave(ctx, upgrade.Namespace, upgrade.Spec.RisingWave)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the source risingwave", err)
+	}
+	deletionProtection := green.Spec.DeletionProtection
+	if source != nil {
+		deletionProtection = source.Spec.DeletionProtection
+	}
+	if !ptr.Equal(green.Spec.DeletionProtection, deletionProtection) || (green.Spec.CloneFrom != nil && !green.Spec.CloneFrom.AllowSinks) {
+		patch := client.MergeFrom(green.DeepCopy())
+		green.Spec.DeletionProtection = deletionProtection
+		if green.Spec.CloneFrom != nil {
+			green.Spec.CloneFrom.AllowSinks = true
+		}
+		if err := c.Client.Patch(ctx, green, patch); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to hand the source over to the green risingwave", err)
+		}
+	}
+
+	// The source is never deleted by the upgrade, since it's the only way back until the green one is verified by the
+	// user in production.
+	s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseCompleted,
+		fmt.Sprintf("RisingWave %s is kept, delete it once it's no longer needed", upgrade.Spec.RisingWave))
+
+	return ctrlkit.NoRequeue()
+}
+
+// rollback points the alias Service back at the source, hands it over to the source, and deletes the green RisingWave
+// created by the upgrade.
+func (c *RisingWaveUpgradeController) rollback(ctx context.Context, s *upgradeState) (reconcile.Result, error) {
+	upgrade := s.upgrade
+
+	source, err := c.getRisingWave(ctx, upgrade.Namespace, upgrade.Spec.RisingWave)
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the source risingwave", err)
+	}
+	if source != nil {
+		if ok, err := c.syncAliasService(ctx, s, source, source); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync the alias service", err)
+		} else if !ok {
+			return ctrlkit.RequeueAfter(upgradeCheckInterval)
+		}
+	}
+
+	green, err := c.getRisingWave(ctx, upgrade.Namespace, s.greenName())
+	if err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to get the green risingwave", err)
+	}
+	if green != nil && isCreatedByUpgrade(green, upgrade) {
+		if err := c.Client.Delete(ctx, green); client.IgnoreNotFound(err) != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete the green risingwave", err)
+		}
+	}
+
+	s.enterPhase(risingwavev1alpha1.RisingWaveUpgradePhaseRolledBack, fmt.Sprintf("RisingWave %s is deleted", s.greenName()))
+
+	return ctrlkit.NoRequeue()
+}
+
+// syncAliasService creates or updates the alias Service to select the frontend Pods of the target RisingWave, by
+// copying the selector and the ports of its frontend Service, and sets the owner as its controller. It returns false
+// with the message set if the Service can't be synced for now.
+func (c *RisingWaveUpgradeController) syncAliasService(ctx context.Context, s *upgradeState, target *risingwavev1alpha1.RisingWave, owner client.Object) (bool, error) {
+	upgrade := s.upgrade
+
+	var frontendService corev1.Service
+	if err := c.Client.Get(ctx, types.NamespacedName{Namespace: target.Namespace, Name: target.Name + "-frontend"}, &frontendService); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.setMessage(fmt.Sprintf("frontend Service of RisingWave %s not found", target.Name))
+
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	ownerGVK, err := apiutil.GVKForObject(owner, c.Client.Scheme())
+	if err != nil {
+		return false, err
+	}
+
+	var service corev1.Service
+	err = c.Client.Get(ctx, types.NamespacedName{Namespace: upgrade.Namespace, Name: s.serviceName()}, &service)
+	if client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+	exists := err == nil
+
+	// Only adopt the Service managed by the upgrade or by one of the RisingWaves, e.g., by a previous upgrade.
+	if exists {
+		if ref := metav1.GetControllerOfNoCopy(&service); ref != nil && !(ref.UID == upgrade.UID ||
+			(ref.Kind == "RisingWave" && (ref.Name == upgrade.Spec.RisingWave || ref.Name == s.greenName()))) {
+			s.setMessage(fmt.Sprintf("Service %s already exists and isn't managed by the upgrade", service.Name))
+
+			return false, nil
+		}
+	}
+
+	service.Namespace, service.Name = upgrade.Namespace, s.serviceName()
+	service.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, ownerGVK)}
+	service.Spec.Type = cmp.Or(upgrade.Spec.Service.Type, corev1.ServiceTypeClusterIP)
+	service.Spec.Selector = maps.Clone(frontendService.Spec.Selector)
+
+	ports := make([]corev1.ServicePort, 0, len(frontendService.Spec.Ports))
+	for _, port := range frontendService.Spec.Ports {
+		ports = append(ports, corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   port.Protocol,
+			Port:       port.Port,
+			TargetPort: port.TargetPort,
+		})
+	}
+	// Keep the allocated node ports.
+	for i := range ports {
+		for _, prev := range service.Spec.Ports {
+			if prev.Name == ports[i].Name && service.Spec.Type != corev1.ServiceTypeClusterIP {
+				ports[i].NodePort = prev.NodePort
+			}
+		}
+	}
+	service.Spec.Ports = ports
+
+	if exists {
+		err = c.Client.Update(ctx, &service)
+	} else {
+		err = c.Client.Create(ctx, &service)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	upgrade.Status.Active = target.Name
+	s.setMessage("")
+
+	return true, nil
+}
+
+func upgradeTimeout(upgrade *risingwavev1alpha1.RisingWaveUpgrade) time.Duration {
+	if upgrade.Spec.Timeout != nil {
+		return upgrade.Spec.Timeout.Duration
+	}
+
+	return defaultUpgradeTimeout
+}
+
+func (c *RisingWaveUpgradeController) isWaitingForGreenTimedOut(s *upgradeState) bool {
+	return time.Since(s.phaseStartTime()) > upgradeTimeout(s.upgrade)
+}
+
+// verifyGreenReads runs the verification query against the frontend of the green RisingWave.
+func (c *RisingWaveUpgradeController) verifyGreenReads(ctx context.Context, green *risingwavev1alpha1.RisingWave, verification *risingwavev1alpha1.RisingWaveUpgradeVerification) error {
+	if c.verifyGreen != nil {
+		return c.verifyGreen(ctx, green, verification)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, upgradeVerificationTimeout)
+	defer cancel()
+
+	cfg, err := pgx.ParseConfig("sslmode=prefer")
+	if err != nil {
+		return err
+	}
+	cfg.Host = fmt.Sprintf("%s-frontend.%s.svc", green.Name, green.Namespace)
+	cfg.Port = uint16(consts.FrontendServicePort)
+	cfg.Database = cmp.Or(verification.Database, "dev")
+	cfg.User = "root"
+
+	if creds := verification.Credentials; creds != nil {
+		values, err := stores.SecretValues(ctx, c.apiReader, green.Namespace, creds.SecretName,
+			cmp.Or(creds.UsernameKeyRef, "username"), cmp.Or(creds.PasswordKeyRef, "password"))
+		if err != nil {
+			return err
+		}
+		cfg.User, cfg.Password = values[0], values[1]
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background()) //nolint:errcheck
+
+	rows, err := conn.Query(ctx, cmp.Or(verification.Query, defaultUpgradeVerificationQuery))
+	if err != nil {
+		return err
+	}
+	// Read all the rows.
+	rows.Close()
+
+	return rows.Err()
+}
+
+// SetupWithManager sets up the controller with a given manager.
+func (c *RisingWaveUpgradeController) SetupWithManager(mgr ctrl.Manager) error {
+	gvk, err := apiutil.GVKForObject(&risingwavev1alpha1.RisingWaveUpgrade{}, c.Client.Scheme())
+	if err != nil {
+		return fmt.Errorf("unable to find gvk for RisingWaveUpgrade: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&risingwavev1alpha1.RisingWaveUpgrade{}).
+		Watches(
+			&risingwavev1alpha1.RisingWave{},
+			// Enqueue the upgrades of the green RisingWave, so that the switch happens as soon as it's running.
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				name, ok := object.GetLabels()[consts.LabelRisingWaveUpgrade]
+				if !ok {
+					return nil
+				}
+
+				return []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: name}},
+				}
+			}),
+		).
+		Complete(metrics.NewControllerMetricsRecorder(c, "RisingWaveUpgradeController", gvk))
+}
+
+// NewRisingWaveUpgradeController creates a new RisingWaveUpgradeController.
+func NewRisingWaveUpgradeController(client client.Client, apiReader client.Reader) *RisingWaveUpgradeController {
+	return &RisingWaveUpgradeController{
+		Client:    client,
+		apiReader: apiReader,
+	}
+}
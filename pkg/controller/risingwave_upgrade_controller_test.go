@@ -0,0 +1,299 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+func newFrontendServiceForUpgradeTest(risingwave string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: risingwave + "-frontend"},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeNodePort,
+			Selector: map[string]string{
+				consts.LabelRisingWaveName:      risingwave,
+				consts.LabelRisingWaveComponent: consts.ComponentFrontend,
+			},
+			Ports: []corev1.ServicePort{
+				{Name: consts.PortService, Port: 4567, TargetPort: intstr.FromString(consts.PortService), NodePort: 30000},
+			},
+		},
+	}
+}
+
+func newUpgradeControllerForTest(t *testing.T, upgrade *risingwavev1alpha1.RisingWaveUpgrade) *RisingWaveUpgradeController {
+	t.Helper()
+
+	source := testutils.FakeRisingWave()
+	source.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+		DataDirectory: "hummock",
+		S3:            &risingwavev1alpha1.RisingWaveStateStoreBackendS3{Bucket: "bucket", Region: "us-east-1"},
+	}
+	source.Spec.Components.Compute.NodeGroups[0].Template.Spec.Image = "ghcr.io/risingwavelabs/risingwave:old"
+	source.Spec.DeletionProtection = ptr.To(true)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testutils.Scheme).
+		WithStatusSubresource(&risingwavev1alpha1.RisingWave{}, &risingwavev1alpha1.RisingWaveUpgrade{}).
+		WithObjects(
+			source,
+			upgrade,
+			newFrontendServiceForUpgradeTest(source.Name),
+			newFrontendServiceForUpgradeTest(source.Name+"-green"),
+		).
+		Build()
+	c := NewRisingWaveUpgradeController(fakeClient, fakeClient)
+	c.verifyGreen = func(ctx context.Context, green *risingwavev1alpha1.RisingWave, verification *risingwavev1alpha1.RisingWaveUpgradeVerification) error {
+		return nil
+	}
+
+	return c
+}
+
+func newUpgradeForTest() *risingwavev1alpha1.RisingWaveUpgrade {
+	return &risingwavev1alpha1.RisingWaveUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "upgrade", UID: "upgrade-uid"},
+		Spec: risingwavev1alpha1.RisingWaveUpgradeSpec{
+			RisingWave: "fake-risingwave",
+			Image:      "ghcr.io/risingwavelabs/risingwave:new",
+			MetaStore: risingwavev1alpha1.RisingWaveMetaStoreBackend{
+				PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{Host: "copy", Port: 5432, Database: "rw"},
+			},
+		},
+	}
+}
+
+// reconcileUpgrade reconciles the upgrade until the phase stops changing, and returns the upgrade.
+func reconcileUpgrade(t *testing.T, c *RisingWaveUpgradeController) *risingwavev1alpha1.RisingWaveUpgrade {
+	t.Helper()
+
+	key := types.NamespacedName{Namespace: "default", Name: "upgrade"}
+	var upgrade risingwavev1alpha1.RisingWaveUpgrade
+	for range 10 {
+		_, err := c.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+		require.NoError(t, err)
+
+		prevPhase := upgrade.Status.Phase
+		require.NoError(t, c.Client.Get(context.Background(), key, &upgrade))
+		if upgrade.Status.Phase == prevPhase {
+			break
+		}
+	}
+
+	return &upgrade
+}
+
+func getRisingWaveForTest(t *testing.T, c *RisingWaveUpgradeController, name string) *risingwavev1alpha1.RisingWave {
+	t.Helper()
+
+	var risingwave risingwavev1alpha1.RisingWave
+	if err := c.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, &risingwave); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		t.Fatal(err)
+	}
+
+	return &risingwave
+}
+
+func getAliasServiceForTest(t *testing.T, c *RisingWaveUpgradeController) *corev1.Service {
+	t.Helper()
+
+	var service corev1.Service
+	require.NoError(t, c.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "fake-risingwave-stable"}, &service))
+
+	return &service
+}
+
+func markRisingWaveRunningForTest(t *testing.T, c *RisingWaveUpgradeController, name string) {
+	t.Helper()
+
+	risingwave := getRisingWaveForTest(t, c, name)
+	risingwave.Status.Conditions = []risingwavev1alpha1.RisingWaveCondition{
+		{Type: risingwavev1alpha1.RisingWaveConditionRunning, Status: metav1.ConditionTrue},
+	}
+	require.NoError(t, c.Client.Status().Update(context.Background(), risingwave))
+}
+
+func setUpgradeDecisionForTest(t *testing.T, c *RisingWaveUpgradeController, decision risingwavev1alpha1.RisingWaveUpgradeDecision) {
+	t.Helper()
+
+	upgrade := &risingwavev1alpha1.RisingWaveUpgrade{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "upgrade"}}
+	patch := client.RawPatch(types.MergePatchType, []byte(`{"spec":{"decision":"`+decision+`"}}`))
+	require.NoError(t, c.Client.Patch(context.Background(), upgrade, patch))
+}
+
+func Test_RisingWaveUpgradeController_Confirm(t *testing.T) {
+	c := newUpgradeControllerForTest(t, newUpgradeForTest())
+
+	// The annotations controlling the source only aren't copied to the green RisingWave.
+	source := getRisingWaveForTest(t, c, "fake-risingwave")
+	source.Annotations = map[string]string{
+		"example.com/owner":                      "team",
+		consts.AnnotationPauseReconcile:          "",
+		consts.AnnotationConfirmDeletion:         "fake-risingwave",
+		consts.AnnotationBypassValidatingWebhook: "true",
+	}
+	require.NoError(t, c.Client.Update(context.Background(), source))
+
+	upgrade := reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseWaitingForGreen, upgrade.Status.Phase)
+	assert.Equal(t, "fake-risingwave", upgrade.Status.Active)
+
+	service := getAliasServiceForTest(t, c)
+	assert.Equal(t, "fake-risingwave", service.Spec.Selector[consts.LabelRisingWaveName])
+	assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type)
+	if assert.Len(t, service.Spec.Ports, 1) {
+		assert.Zero(t, service.Spec.Ports[0].NodePort)
+	}
+	assert.Equal(t, types.UID("upgrade-uid"), metav1.GetControllerOf(service).UID)
+
+	green := getRisingWaveForTest(t, c, "fake-risingwave-green")
+	require.NotNil(t, green)
+	assert.Equal(t, "upgrade", green.Labels[consts.LabelRisingWaveUpgrade])
+	assert.Equal(t, "ghcr.io/risingwavelabs/risingwave:new", green.Spec.Image)
+	assert.Empty(t, green.Spec.Components.Compute.NodeGroups[0].Template.Spec.Image)
+	assert.Equal(t, "copy", green.Spec.MetaStore.PostgreSQL.Host)
+	assert.Equal(t, "hummock-fake-risingwave-green", green.Spec.StateStore.DataDirectory)
+	assert.Equal(t, &risingwavev1alpha1.RisingWaveCloneFrom{Name: "fake-risingwave"}, green.Spec.CloneFrom)
+	assert.Equal(t, map[string]string{"example.com/owner": "team"}, green.Annotations)
+	assert.Nil(t, green.Spec.DeletionProtection)
+	assert.Empty(t, green.OwnerReferences)
+
+	// Not switched until the green RisingWave serves the reads.
+	c.verifyGreen = func(ctx context.Context, green *risingwavev1alpha1.RisingWave, verification *risingwavev1alpha1.RisingWaveUpgradeVerification) error {
+		return errors.New("connection refused")
+	}
+	markRisingWaveRunningForTest(t, c, "fake-risingwave-green")
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseWaitingForGreen, upgrade.Status.Phase)
+	assert.Equal(t, "RisingWave fake-risingwave-green doesn't serve the reads: connection refused", upgrade.Status.Message)
+	assert.Equal(t, "fake-risingwave", getAliasServiceForTest(t, c).Spec.Selector[consts.LabelRisingWaveName])
+
+	c.verifyGreen = func(ctx context.Context, green *risingwavev1alpha1.RisingWave, verification *risingwavev1alpha1.RisingWaveUpgradeVerification) error {
+		return nil
+	}
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseSwitched, upgrade.Status.Phase)
+	assert.Equal(t, "fake-risingwave-green", upgrade.Status.Active)
+	assert.Equal(t, "fake-risingwave-green", getAliasServiceForTest(t, c).Spec.Selector[consts.LabelRisingWaveName])
+
+	// The source is kept until the upgrade is confirmed.
+	assert.NotNil(t, getRisingWaveForTest(t, c, "fake-risingwave"))
+
+	setUpgradeDecisionForTest(t, c, risingwavev1alpha1.RisingWaveUpgradeDecisionConfirm)
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseCompleted, upgrade.Status.Phase)
+	assert.Equal(t, "fake-risingwave-green", metav1.GetControllerOf(getAliasServiceForTest(t, c)).Name)
+
+	// The source is never deleted, and the green RisingWave takes over its deletion protection, with its streaming
+	// jobs no longer kept paused.
+	assert.NotNil(t, getRisingWaveForTest(t, c, "fake-risingwave"))
+	green = getRisingWaveForTest(t, c, "fake-risingwave-green")
+	assert.Equal(t, ptr.To(true), green.Spec.DeletionProtection)
+	assert.True(t, green.Spec.CloneFrom.AllowSinks)
+
+	var phases []risingwavev1alpha1.RisingWaveUpgradePhase
+	for _, record := range upgrade.Status.Phases {
+		phases = append(phases, record.Phase)
+	}
+	assert.Equal(t, []risingwavev1alpha1.RisingWaveUpgradePhase{
+		risingwavev1alpha1.RisingWaveUpgradePhasePending,
+		risingwavev1alpha1.RisingWaveUpgradePhaseProvisioning,
+		risingwavev1alpha1.RisingWaveUpgradePhaseWaitingForGreen,
+		risingwavev1alpha1.RisingWaveUpgradePhaseSwitched,
+		risingwavev1alpha1.RisingWaveUpgradePhaseCompleted,
+	}, phases)
+}
+
+func Test_RisingWaveUpgradeController_Rollback(t *testing.T) {
+	c := newUpgradeControllerForTest(t, newUpgradeForTest())
+
+	reconcileUpgrade(t, c)
+	markRisingWaveRunningForTest(t, c, "fake-risingwave-green")
+	upgrade := reconcileUpgrade(t, c)
+	require.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseSwitched, upgrade.Status.Phase)
+
+	setUpgradeDecisionForTest(t, c, risingwavev1alpha1.RisingWaveUpgradeDecisionRollback)
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseRolledBack, upgrade.Status.Phase)
+	assert.Equal(t, "fake-risingwave", upgrade.Status.Active)
+	assert.Nil(t, getRisingWaveForTest(t, c, "fake-risingwave-green"))
+	assert.NotNil(t, getRisingWaveForTest(t, c, "fake-risingwave"))
+
+	service := getAliasServiceForTest(t, c)
+	assert.Equal(t, "fake-risingwave", service.Spec.Selector[consts.LabelRisingWaveName])
+	assert.Equal(t, "fake-risingwave", metav1.GetControllerOf(service).Name)
+}
+
+func Test_RisingWaveUpgradeController_Failed(t *testing.T) {
+	upgrade := newUpgradeForTest()
+	upgrade.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{Memory: ptr.To(true)}
+	c := newUpgradeControllerForTest(t, upgrade)
+
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseFailed, upgrade.Status.Phase)
+	assert.Equal(t, "the memory meta store isn't supported", upgrade.Status.Message)
+	assert.Nil(t, getRisingWaveForTest(t, c, "fake-risingwave-green"))
+
+	upgrade = newUpgradeForTest()
+	upgrade.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{SQLite: &risingwavev1alpha1.RisingWaveMetaStoreBackendSQLite{Path: "/data/rw.db"}}
+	c = newUpgradeControllerForTest(t, upgrade)
+
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseFailed, upgrade.Status.Phase)
+	assert.Equal(t, "the meta store must be MySQL or PostgreSQL", upgrade.Status.Message)
+
+	standalone := testutils.FakeRisingWave()
+	standalone.Spec.EnableStandaloneMode = ptr.To(true)
+	standalone.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+		S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{Bucket: "bucket", Region: "us-east-1"},
+	}
+	assert.Equal(t, "the standalone mode isn't supported", validateSource(newUpgradeForTest(), standalone))
+
+	// The green RisingWave not created by the upgrade is left alone.
+	c = newUpgradeControllerForTest(t, newUpgradeForTest())
+	green := testutils.FakeRisingWave()
+	green.Name, green.UID, green.ResourceVersion = "fake-risingwave-green", "", ""
+	require.NoError(t, c.Client.Create(context.Background(), green))
+
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseFailed, upgrade.Status.Phase)
+
+	setUpgradeDecisionForTest(t, c, risingwavev1alpha1.RisingWaveUpgradeDecisionRollback)
+	upgrade = reconcileUpgrade(t, c)
+	assert.Equal(t, risingwavev1alpha1.RisingWaveUpgradePhaseRolledBack, upgrade.Status.Phase)
+	assert.NotNil(t, getRisingWaveForTest(t, c, "fake-risingwave-green"))
+}
@@ -17,6 +17,7 @@
 package factory
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"net/url"
@@ -34,11 +35,16 @@ import (
 	"golang.org/x/mod/semver"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
@@ -60,6 +66,14 @@ const (
 	risingwaveConfigFileName    = "risingwave.toml"
 	risingwaveLicenseKeyPath    = "/license/license.jwt"
 	risingwaveTLSCertsMountPath = "/risingwave/tls"
+
+	metaStoreCABundleVolume           = "meta-store-ca-bundle"
+	metaStoreClientCertVolume         = "meta-store-client-cert"
+	metaStoreCABundleMountPath        = "/risingwave/meta-store-tls/ca"
+	metaStoreClientCertMountPath      = "/risingwave/meta-store-tls/client"
+	metaStoreCABundleFileName         = "ca.crt"
+	metaStoreClientCertFileName       = "tls.crt"
+	metaStoreClientPrivateKeyFileName = "tls.key"
 )
 
 var (
@@ -125,7 +139,11 @@ func (f *RisingWaveObjectFactory) isStateStoreWebHDFS() bool {
 }
 
 func (f *RisingWaveObjectFactory) isStateStoreMinIO() bool {
-	return f.risingwave.Spec.StateStore.MinIO != nil
+	return f.risingwave.Spec.StateStore.MinIO != nil || f.isStateStoreManagedMinIO()
+}
+
+func (f *RisingWaveObjectFactory) isStateStoreManagedMinIO() bool {
+	return f.risingwave.Spec.StateStore.ManagedMinIO != nil
 }
 
 func (f *RisingWaveObjectFactory) isStateStoreLocalDisk() bool {
@@ -153,7 +171,11 @@ func (f *RisingWaveObjectFactory) isMetaStoreMySQL() bool {
 }
 
 func (f *RisingWaveObjectFactory) isMetaStorePostgreSQL() bool {
-	return f.risingwave.Spec.MetaStore.PostgreSQL != nil
+	return f.risingwave.Spec.MetaStore.PostgreSQL != nil || f.isMetaStoreManagedPostgreSQL()
+}
+
+func (f *RisingWaveObjectFactory) isMetaStoreManagedPostgreSQL() bool {
+	return f.risingwave.Spec.MetaStore.ManagedPostgreSQL != nil
 }
 
 func (f *RisingWaveObjectFactory) isFullKubernetesAddr() bool {
@@ -174,8 +196,8 @@ func (f *RisingWaveObjectFactory) hummockConnectionStr() string {
 		bucket := stateStore.S3.Bucket
 
 		return "hummock+s3://" + bucket
-	case stateStore.MinIO != nil:
-		minio := stateStore.MinIO
+	case f.isStateStoreMinIO():
+		minio := object.NewRisingWaveReader(f.risingwave).GetMinIO()
 
 		return fmt.Sprintf("hummock+minio://$(%s):$(%s)@%s/%s", envs.MinIOUsername, envs.MinIOPassword, minio.Endpoint, minio.Bucket)
 	case f.isStateStoreGCS():
@@ -229,6 +251,8 @@ func (f *RisingWaveObjectFactory) componentName(component, group string) string
 		return f.risingwave.Name + "-standalone"
 	case consts.ComponentConfig:
 		return f.risingwave.Name + "-default-config"
+	case consts.ComponentMinIO, consts.ComponentPostgreSQL:
+		return object.NewRisingWaveReader(f.risingwave).ManagedStoreName(component)
 	default:
 		panic("never reach here")
 	}
@@ -238,6 +262,18 @@ func (f *RisingWaveObjectFactory) frontendHeadlessServiceName() string {
 	return f.risingwave.Name + "-frontend-headless"
 }
 
+func (f *RisingWaveObjectFactory) standaloneHeadlessServiceName() string {
+	return f.risingwave.Name + "-standalone-headless"
+}
+
+func (f *RisingWaveObjectFactory) standaloneHeadlessServiceAddr() string {
+	if f.isFullKubernetesAddr() {
+		return f.standaloneHeadlessServiceName() + ".$(POD_NAMESPACE).svc"
+	}
+
+	return f.standaloneHeadlessServiceName()
+}
+
 func (f *RisingWaveObjectFactory) statefulWorkloadServiceName(component string) string {
 	if component == consts.ComponentFrontend {
 		return f.frontendHeadlessServiceName()
@@ -442,7 +478,7 @@ func (f *RisingWaveObjectFactory) envsForMySQL() []corev1.EnvVar {
 }
 
 func (f *RisingWaveObjectFactory) envsForPostgreSQL() []corev1.EnvVar {
-	creds := &f.risingwave.Spec.MetaStore.PostgreSQL.RisingWaveDBCredentials
+	creds := &object.NewRisingWaveReader(f.risingwave).GetPostgreSQL().RisingWaveDBCredentials
 
 	return []corev1.EnvVar{
 		{
@@ -509,6 +545,138 @@ func (f *RisingWaveObjectFactory) envsForMetaStore() []corev1.EnvVar {
 	}
 }
 
+// metaStoreTLS returns the TLS settings of the SQL meta store, or nil if not set.
+func (f *RisingWaveObjectFactory) metaStoreTLS() *risingwavev1alpha1.RisingWaveDBTLS {
+	switch {
+	case f.isMetaStoreMySQL():
+		return f.risingwave.Spec.MetaStore.MySQL.TLS
+	case f.isMetaStorePostgreSQL():
+		return object.NewRisingWaveReader(f.risingwave).GetPostgreSQL().TLS
+	default:
+		return nil
+	}
+}
+
+// mysqlSSLModes translates the SSL modes into the ones of MySQL.
+var mysqlSSLModes = map[risingwavev1alpha1.RisingWaveDBSSLMode]string{
+	risingwavev1alpha1.RisingWaveDBSSLModeDisable:    "DISABLED",
+	risingwavev1alpha1.RisingWaveDBSSLModePrefer:     "PREFERRED",
+	risingwavev1alpha1.RisingWaveDBSSLModeRequire:    "REQUIRED",
+	risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA:   "VERIFY_CA",
+	risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull: "VERIFY_IDENTITY",
+}
+
+// sqlConnectionOptions merges the options of the TLS settings into the connection options. The certificates are
+// referenced by the paths where they are mounted.
+func sqlConnectionOptions(opts map[string]string, tls *risingwavev1alpha1.RisingWaveDBTLS, isMySQL bool) map[string]string {
+	if tls == nil {
+		return opts
+	}
+
+	// Option keys of SSL mode, CA bundle, client certificate and private key.
+	keys := lo.Ternary(isMySQL,
+		[]string{"ssl-mode", "ssl-ca", "ssl-cert", "ssl-key"},
+		[]string{"sslmode", "sslrootcert", "sslcert", "sslkey"},
+	)
+
+	merged := make(map[string]string, len(opts)+len(keys))
+	for k, v := range opts {
+		merged[k] = v
+	}
+
+	merged[keys[0]] = lo.Ternary(isMySQL, mysqlSSLModes[tls.SSLMode], string(tls.SSLMode))
+	if tls.CABundle != nil {
+		merged[keys[1]] = path.Join(metaStoreCABundleMountPath, metaStoreCABundleFileName)
+	}
+	if tls.ClientCertificate != nil {
+		merged[keys[2]] = path.Join(metaStoreClientCertMountPath, metaStoreClientCertFileName)
+		merged[keys[3]] = path.Join(metaStoreClientCertMountPath, metaStoreClientPrivateKeyFileName)
+	}
+
+	return merged
+}
+
+func (f *RisingWaveObjectFactory) setupVolumeAndVolumeMountForMetaStoreTLS(podSpec *corev1.PodSpec, container *corev1.Container) {
+	volumes, volumeMounts := f.volumeAndVolumeMountForMetaStoreTLS()
+	for _, vol := range volumes {
+		podSpec.Volumes = mergeListWhenKeyEquals(podSpec.Volumes, vol, func(a, b *corev1.Volume) bool {
+			return a.Name == b.Name
+		})
+	}
+	for _, volMount := range volumeMounts {
+		container.VolumeMounts = mergeListWhenKeyEquals(container.VolumeMounts, volMount, func(a, b *corev1.VolumeMount) bool {
+			return a.MountPath == b.MountPath
+		})
+	}
+}
+
+func (f *RisingWaveObjectFactory) volumeAndVolumeMountForMetaStoreTLS() ([]corev1.Volume, []corev1.VolumeMount) {
+	tls := f.metaStoreTLS()
+	if tls == nil {
+		return nil, nil
+	}
+
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+
+	if caBundle := tls.CABundle; caBundle != nil {
+		items := []corev1.KeyToPath{
+			{
+				Key:  lo.Ternary(caBundle.Key != "", caBundle.Key, metaStoreCABundleFileName),
+				Path: metaStoreCABundleFileName,
+			},
+		}
+
+		vol := corev1.Volume{Name: metaStoreCABundleVolume}
+		if caBundle.SecretName != "" {
+			vol.Secret = &corev1.SecretVolumeSource{
+				SecretName: caBundle.SecretName,
+				Items:      items,
+			}
+		} else {
+			vol.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: caBundle.ConfigMapName},
+				Items:                items,
+			}
+		}
+
+		volumes = append(volumes, vol)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      metaStoreCABundleVolume,
+			ReadOnly:  true,
+			MountPath: metaStoreCABundleMountPath,
+		})
+	}
+
+	if clientCert := tls.ClientCertificate; clientCert != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: metaStoreClientCertVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: clientCert.SecretName,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  lo.Ternary(clientCert.CertificateKeyRef != "", clientCert.CertificateKeyRef, corev1.TLSCertKey),
+							Path: metaStoreClientCertFileName,
+						},
+						{
+							Key:  lo.Ternary(clientCert.PrivateKeyKeyRef != "", clientCert.PrivateKeyKeyRef, corev1.TLSPrivateKeyKey),
+							Path: metaStoreClientPrivateKeyFileName,
+						},
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      metaStoreClientCertVolume,
+			ReadOnly:  true,
+			MountPath: metaStoreClientCertMountPath,
+		})
+	}
+
+	return volumes, volumeMounts
+}
+
 func formatConnectionOptions(opts map[string]string) string {
 	if len(opts) == 0 {
 		return ""
@@ -668,6 +836,25 @@ func (f *RisingWaveObjectFactory) envsForSecretStore() []corev1.EnvVar {
 	return nil
 }
 
+// mysqlEndpointURL returns the URL of the MySQL meta store, with the credentials from the environment variables.
+func (f *RisingWaveObjectFactory) mysqlEndpointURL() string {
+	mysql := f.risingwave.Spec.MetaStore.MySQL
+
+	return fmt.Sprintf("mysql://$(%s):$(%s)@%s:%d/%s%s", envs.RWMySQLUsername, envs.RWMySQLPassword,
+		mysql.Host, mysql.Port, mysql.Database,
+		formatConnectionOptions(sqlConnectionOptions(mysql.Options, mysql.TLS, true)))
+}
+
+// postgreSQLEndpointURL returns the URL of the PostgreSQL meta store, with the credentials from the environment
+// variables.
+func (f *RisingWaveObjectFactory) postgreSQLEndpointURL() string {
+	postgreSQL := object.NewRisingWaveReader(f.risingwave).GetPostgreSQL()
+
+	return fmt.Sprintf("postgres://$(%s):$(%s)@%s:%d/%s%s", envs.RWPostgresUsername,
+		envs.RWPostgresPassword, postgreSQL.Host, postgreSQL.Port,
+		postgreSQL.Database, formatConnectionOptions(sqlConnectionOptions(postgreSQL.Options, postgreSQL.TLS, false)))
+}
+
 func (f *RisingWaveObjectFactory) coreEnvsForMeta(image string) []corev1.EnvVar {
 	metaStore := &f.risingwave.Spec.MetaStore
 	version := utils.GetVersionFromImage(image)
@@ -746,7 +933,7 @@ func (f *RisingWaveObjectFactory) coreEnvsForMeta(image string) []corev1.EnvVar
 				{
 					Name: envs.RWSQLEndpoint,
 					Value: fmt.Sprintf("%s:%d%s", metaStore.MySQL.Host, metaStore.MySQL.Port,
-						formatConnectionOptions(metaStore.MySQL.Options)),
+						formatConnectionOptions(sqlConnectionOptions(metaStore.MySQL.Options, metaStore.MySQL.TLS, true))),
 				},
 				{
 					Name:  envs.RWSQLDatabase,
@@ -760,14 +947,13 @@ func (f *RisingWaveObjectFactory) coreEnvsForMeta(image string) []corev1.EnvVar
 					Value: "sql",
 				},
 				{
-					Name: envs.RWSQLEndpoint,
-					Value: fmt.Sprintf("mysql://$(%s):$(%s)@%s:%d/%s%s", envs.RWMySQLUsername, envs.RWMySQLPassword,
-						metaStore.MySQL.Host, metaStore.MySQL.Port, metaStore.MySQL.Database,
-						formatConnectionOptions(metaStore.MySQL.Options)),
+					Name:  envs.RWSQLEndpoint,
+					Value: f.mysqlEndpointURL(),
 				},
 			}...)
 		}
 	case f.isMetaStorePostgreSQL():
+		postgreSQL := object.NewRisingWaveReader(f.risingwave).GetPostgreSQL()
 		if isSQLCredentialsViaEnvSupported(version) {
 			envVars = append(envVars, []corev1.EnvVar{
 				{
@@ -776,12 +962,12 @@ func (f *RisingWaveObjectFactory) coreEnvsForMeta(image string) []corev1.EnvVar
 				},
 				{
 					Name: envs.RWSQLEndpoint,
-					Value: fmt.Sprintf("%s:%d%s", metaStore.PostgreSQL.Host, metaStore.PostgreSQL.Port,
-						formatConnectionOptions(metaStore.PostgreSQL.Options)),
+					Value: fmt.Sprintf("%s:%d%s", postgreSQL.Host, postgreSQL.Port,
+						formatConnectionOptions(sqlConnectionOptions(postgreSQL.Options, postgreSQL.TLS, false))),
 				},
 				{
 					Name:  envs.RWSQLDatabase,
-					Value: metaStore.PostgreSQL.Database,
+					Value: postgreSQL.Database,
 				},
 			}...)
 		} else {
@@ -791,10 +977,8 @@ func (f *RisingWaveObjectFactory) coreEnvsForMeta(image string) []corev1.EnvVar
 					Value: "sql",
 				},
 				{
-					Name: envs.RWSQLEndpoint,
-					Value: fmt.Sprintf("postgres://$(%s):$(%s)@%s:%d/%s%s", envs.RWPostgresUsername,
-						envs.RWPostgresPassword, metaStore.PostgreSQL.Host, metaStore.PostgreSQL.Port,
-						metaStore.PostgreSQL.Database, formatConnectionOptions(metaStore.PostgreSQL.Options)),
+					Name:  envs.RWSQLEndpoint,
+					Value: f.postgreSQLEndpointURL(),
 				},
 			}...)
 		}
@@ -1019,8 +1203,8 @@ func (f *RisingWaveObjectFactory) envsForCompactorArgs() []corev1.EnvVar {
 }
 
 func (f *RisingWaveObjectFactory) envsForMinIO() []corev1.EnvVar {
-	stateStore := &f.risingwave.Spec.StateStore
-	credentials := &stateStore.MinIO.RisingWaveMinIOCredentials
+	minio := object.NewRisingWaveReader(f.risingwave).GetMinIO()
+	credentials := &minio.RisingWaveMinIOCredentials
 
 	secretRef := corev1.LocalObjectReference{
 		Name: credentials.SecretName,
@@ -1029,11 +1213,11 @@ func (f *RisingWaveObjectFactory) envsForMinIO() []corev1.EnvVar {
 	return []corev1.EnvVar{
 		{
 			Name:  envs.MinIOEndpoint,
-			Value: stateStore.MinIO.Endpoint,
+			Value: minio.Endpoint,
 		},
 		{
 			Name:  envs.MinIOBucket,
-			Value: stateStore.MinIO.Bucket,
+			Value: minio.Bucket,
 		},
 		{
 			Name: envs.MinIOUsername,
@@ -1618,8 +1802,33 @@ func (f *RisingWaveObjectFactory) setupMetaContainer(podSpec *corev1.PodSpec, co
 		return a.MountPath == b.MountPath
 	})
 
+	// Mount the certificates to connect to the meta store.
+	f.setupVolumeAndVolumeMountForMetaStoreTLS(podSpec, container)
+
 	// Set the license key.
 	f.setupVolumeAndVolumeMountForLicenseKey(podSpec, container)
+
+	// Carry the SQLite DB file over from the standalone Pod.
+	f.setupVolumeAndVolumeMountForStandaloneSQLite(podSpec, container)
+}
+
+func (f *RisingWaveObjectFactory) setupVolumeAndVolumeMountForStandaloneSQLite(podSpec *corev1.PodSpec, container *corev1.Container) {
+	reader := object.NewRisingWaveReader(f.risingwave)
+	if !reader.IsStandaloneMigrationEnabled() {
+		return
+	}
+
+	volume, volumeMount := reader.GetStandaloneSQLiteVolume()
+	if volume == nil {
+		return
+	}
+
+	podSpec.Volumes = mergeListWhenKeyEquals(podSpec.Volumes, *volume, func(a, b *corev1.Volume) bool {
+		return a.Name == b.Name
+	})
+	container.VolumeMounts = mergeListWhenKeyEquals(container.VolumeMounts, *volumeMount, func(a, b *corev1.VolumeMount) bool {
+		return a.MountPath == b.MountPath
+	})
 }
 
 func rollingUpdateOrDefault(rollingUpdate *risingwavev1alpha1.RisingWaveNodeGroupRollingUpdate) risingwavev1alpha1.RisingWaveNodeGroupRollingUpdate {
@@ -1735,6 +1944,52 @@ func newPodSpecFromNodeGroupTemplate(template *risingwavev1alpha1.RisingWaveNode
 	return podTemplateSpec
 }
 
+// setupComputeContainerRole replaces the role in the args of the compute container with the given one.
+func setupComputeContainerRole(container *corev1.Container, role risingwavev1alpha1.RisingWaveComputeRole) {
+	container.Args = slices.DeleteFunc(container.Args, func(arg string) bool {
+		return strings.HasPrefix(arg, "--role=")
+	})
+	container.Args = append(container.Args, fmt.Sprintf("--role=%s", role))
+}
+
+// setupContainerForInPlaceResize makes the env vars derived from the resource limits follow the limits of the running
+// container, and restarts the container on resize so that RisingWave picks up the new values.
+func setupContainerForInPlaceResize(container *corev1.Container) {
+	resourceFields := map[string]string{
+		envs.RWParallelism:      "limits.cpu",
+		envs.RWTotalMemoryBytes: "limits.memory",
+	}
+	generatedValues := map[string]string{
+		envs.RWParallelism:      strconv.FormatInt(int64(math.Ceil(container.Resources.Limits.Cpu().AsApproximateFloat64())), 10),
+		envs.RWTotalMemoryBytes: strconv.FormatInt(container.Resources.Limits.Memory().Value(), 10),
+	}
+
+	for i := range container.Env {
+		env := &container.Env[i]
+
+		// Leave the env vars set by users untouched.
+		field, ok := resourceFields[env.Name]
+		if !ok || env.ValueFrom != nil || env.Value != generatedValues[env.Name] {
+			continue
+		}
+
+		// The downward API rounds the cpu up to the divisor, the same as what's done in envsForResourceLimits.
+		env.Value = ""
+		env.ValueFrom = &corev1.EnvVarSource{
+			ResourceFieldRef: &corev1.ResourceFieldSelector{
+				ContainerName: container.Name,
+				Resource:      field,
+				Divisor:       resource.MustParse("1"),
+			},
+		}
+	}
+
+	container.ResizePolicy = []corev1.ContainerResizePolicy{
+		{ResourceName: corev1.ResourceCPU, RestartPolicy: corev1.RestartContainer},
+		{ResourceName: corev1.ResourceMemory, RestartPolicy: corev1.RestartContainer},
+	}
+}
+
 func (f *RisingWaveObjectFactory) buildPodTemplateFromNodeGroup(component string, nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup, setupRisingWaveContainer func(podSpec *corev1.PodSpec, container *corev1.Container)) corev1.PodTemplateSpec {
 	podTemplate := newPodSpecFromNodeGroupTemplate(&nodeGroup.Template)
 
@@ -1749,15 +2004,15 @@ func (f *RisingWaveObjectFactory) buildPodTemplateFromNodeGroup(component string
 		})
 	}
 
-	// Set resource group in compute component.
-	if component == consts.ComponentCompute && nodeGroup.Name != "" {
+	// Set resource group in compute component. Nodes of the unnamed group without a resource group join the default one.
+	if component == consts.ComponentCompute && (nodeGroup.Name != "" || nodeGroup.ResourceGroup != "") {
 		container := &podTemplate.Spec.Containers[0]
 		if !slices.ContainsFunc(container.Env, func(e corev1.EnvVar) bool {
 			return e.Name == envs.RWResourceGroup
 		}) {
 			container.Env = append(container.Env, corev1.EnvVar{
 				Name:  envs.RWResourceGroup,
-				Value: nodeGroup.Name,
+				Value: object.NewRisingWaveReader(f.risingwave).GetResourceGroup(nodeGroup),
 			})
 		}
 	}
@@ -1770,12 +2025,56 @@ func (f *RisingWaveObjectFactory) buildPodTemplateFromNodeGroup(component string
 	// Run container setup for RisingWave's container.
 	setupRisingWaveContainer(&podTemplate.Spec, &podTemplate.Spec.Containers[0])
 
+	// Override the role of the compute nodes if it's set on the node group.
+	if component == consts.ComponentCompute && nodeGroup.ComputeRole != "" {
+		setupComputeContainerRole(&podTemplate.Spec.Containers[0], nodeGroup.ComputeRole)
+	}
+
+	// The recommender could resize the Pods in place.
+	if nodeGroup.ResourceRecommendation != nil && nodeGroup.ResourceRecommendation.Mode == risingwavev1alpha1.RisingWaveResourceRecommendationModeAuto {
+		setupContainerForInPlaceResize(&podTemplate.Spec.Containers[0])
+	}
+
+	// Spread the meta and compute nodes of the zone-aware node groups across the zones and hosts if there are no
+	// constraints set. The other node groups are left as is, so that upgrading the operator doesn't roll them.
+	if (component == consts.ComponentMeta || component == consts.ComponentCompute) && isZoneAware(nodeGroup) &&
+		len(podTemplate.Spec.TopologySpreadConstraints) == 0 {
+		podTemplate.Spec.TopologySpreadConstraints = f.defaultTopologySpreadConstraints(component, nodeGroup.Name)
+	}
+
 	// Keep the pod spec consistent.
 	keepPodSpecConsistent(&podTemplate.Spec)
 
 	return podTemplate
 }
 
+// isZoneAware tells if the node group is expanded into zones, or it's one of the node groups expanded from it.
+func isZoneAware(nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) bool {
+	_, expanded := nodeGroup.Template.ObjectMeta.Labels[consts.LabelRisingWaveZone]
+
+	return nodeGroup.Zones != nil || expanded
+}
+
+// defaultTopologySpreadConstraints returns the soft constraints that spread the Pods of the given group evenly across
+// the zones and the hosts.
+func (f *RisingWaveObjectFactory) defaultTopologySpreadConstraints(component, group string) []corev1.TopologySpreadConstraint {
+	constraintOn := func(topologyKey string) corev1.TopologySpreadConstraint {
+		return corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: f.podLabelsOrSelectorsForComponentGroup(component, group),
+			},
+		}
+	}
+
+	return []corev1.TopologySpreadConstraint{
+		constraintOn(consts.DefaultZoneTopologyKey),
+		constraintOn(corev1.LabelHostname),
+	}
+}
+
 func (f *RisingWaveObjectFactory) newDeployment(component string, nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup, template *corev1.PodTemplateSpec) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: f.getObjectMetaForComponentGroupLevelResources(component, nodeGroup.Name, true),
@@ -1854,7 +2153,7 @@ func (f *RisingWaveObjectFactory) newStatefulSet(component string, nodeGroup *ri
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName:    f.statefulWorkloadServiceName(component),
 			Replicas:       ptr.To(nodeGroup.Replicas),
-			UpdateStrategy: buildUpgradeStrategyForStatefulSet(nodeGroup.UpgradeStrategy),
+			UpdateStrategy: buildUpgradeStrategyForComponentStatefulSet(component, nodeGroup.UpgradeStrategy),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: f.podLabelsOrSelectorsForComponentGroup(component, nodeGroup.Name),
 			},
@@ -1944,7 +2243,7 @@ func (f *RisingWaveObjectFactory) overrideFieldsOfNodeGroup(nodeGroup *risingwav
 }
 
 func newWorkloadObjectForComponentNodeGroup[T client.Object](f *RisingWaveObjectFactory, component, group string, builder func(component string, nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup, template *corev1.PodTemplateSpec) T) T {
-	nodeGroup := object.NewRisingWaveReader(f.risingwave).GetNodeGroup(component, group)
+	nodeGroup := object.NewRisingWaveReader(f.risingwave).GetWorkloadNodeGroup(component, group)
 	template := f.newPodTemplateSpecFromNodeGroupByComponent(component, f.overrideFieldsOfNodeGroup(nodeGroup))
 	workloadObj := builder(component, nodeGroup, &template)
 
@@ -2166,13 +2465,22 @@ func (f *RisingWaveObjectFactory) argsForStandaloneMetaStore() []string {
 }
 
 func (f *RisingWaveObjectFactory) argsForStandalone() []string {
+	// The components in a single Pod talk to each other through the loopback. In the high availability mode, they
+	// advertise the Pod IP instead, and find the meta leader among all the standalone Pods.
+	listenHost, advertiseHost := "127.0.0.1", "127.0.0.1"
+	metaAddr := fmt.Sprintf("http://127.0.0.1:%d", consts.MetaServicePort)
+	if object.NewRisingWaveReader(f.risingwave).IsStandaloneHAEnabled() {
+		listenHost, advertiseHost = "0.0.0.0", fmt.Sprintf("$(%s)", envs.PodIP)
+		metaAddr = fmt.Sprintf("load-balance+http://%s:%d", f.standaloneHeadlessServiceAddr(), consts.MetaServicePort)
+	}
+
 	return []string{
 		"--config-path=$(RW_CONFIG_PATH)",
 		fmt.Sprintf("--prometheus-listener-addr=0.0.0.0:%d", consts.MetaMetricsPort),
 		"--meta-opts=" + strings.Join(append(
 			[]string{
-				fmt.Sprintf("--listen-addr 127.0.0.1:%d", consts.MetaServicePort),
-				fmt.Sprintf("--advertise-addr 127.0.0.1:%d", consts.MetaServicePort),
+				fmt.Sprintf("--listen-addr %s:%d", listenHost, consts.MetaServicePort),
+				fmt.Sprintf("--advertise-addr %s:%d", advertiseHost, consts.MetaServicePort),
 				fmt.Sprintf("--dashboard-host 0.0.0.0:%d", consts.MetaDashboardPort),
 				fmt.Sprintf("--prometheus-host 0.0.0.0:%d", consts.MetaMetricsPort),
 				"--state-store $(RW_STATE_STORE)",
@@ -2182,19 +2490,19 @@ func (f *RisingWaveObjectFactory) argsForStandalone() []string {
 			f.argsForStandaloneMetaStore()...,
 		), " "),
 		"--compute-opts=" + strings.Join([]string{
-			fmt.Sprintf("--listen-addr 127.0.0.1:%d", consts.ComputeServicePort),
-			fmt.Sprintf("--advertise-addr 127.0.0.1:%d", consts.ComputeServicePort),
-			fmt.Sprintf("--meta-address http://127.0.0.1:%d", consts.MetaServicePort),
+			fmt.Sprintf("--listen-addr %s:%d", listenHost, consts.ComputeServicePort),
+			fmt.Sprintf("--advertise-addr %s:%d", advertiseHost, consts.ComputeServicePort),
+			fmt.Sprintf("--meta-address %s", metaAddr),
 		}, " "),
 		"--frontend-opts=" + strings.Join([]string{
 			fmt.Sprintf("--listen-addr 0.0.0.0:%d", consts.FrontendServicePort),
-			fmt.Sprintf("--advertise-addr 127.0.0.1:%d", consts.FrontendServicePort),
-			fmt.Sprintf("--meta-addr http://127.0.0.1:%d", consts.MetaServicePort),
+			fmt.Sprintf("--advertise-addr %s:%d", advertiseHost, consts.FrontendServicePort),
+			fmt.Sprintf("--meta-addr %s", metaAddr),
 		}, " "),
 		"--compactor-opts=" + strings.Join([]string{
-			fmt.Sprintf("--listen-addr 127.0.0.1:%d", consts.CompactorServicePort),
-			fmt.Sprintf("--advertise-addr 127.0.0.1:%d", consts.CompactorServicePort),
-			fmt.Sprintf("--meta-address http://127.0.0.1:%d", consts.MetaServicePort),
+			fmt.Sprintf("--listen-addr %s:%d", listenHost, consts.CompactorServicePort),
+			fmt.Sprintf("--advertise-addr %s:%d", advertiseHost, consts.CompactorServicePort),
+			fmt.Sprintf("--meta-address %s", metaAddr),
 		}, " "),
 	}
 }
@@ -2254,6 +2562,11 @@ func (f *RisingWaveObjectFactory) setupStandaloneContainer(podSpec *corev1.PodSp
 		useV2Style = true
 	}
 
+	// The single-node subcommand can't run with more than one replica.
+	if object.NewRisingWaveReader(f.risingwave).IsStandaloneHAEnabled() {
+		useV2Style = false
+	}
+
 	if !useV2Style {
 		container.Command = []string{
 			risingwaveExecutablePath,
@@ -2318,6 +2631,9 @@ func (f *RisingWaveObjectFactory) setupStandaloneContainer(podSpec *corev1.PodSp
 	container.VolumeMounts = mergeListWhenKeyEquals(container.VolumeMounts, f.volumeMountForConfig(),
 		func(a, b *corev1.VolumeMount) bool { return a.MountPath == b.MountPath })
 
+	// Mount the certificates to connect to the meta store.
+	f.setupVolumeAndVolumeMountForMetaStoreTLS(podSpec, container)
+
 	// Set the license key.
 	f.setupVolumeAndVolumeMountForLicenseKey(podSpec, container)
 }
@@ -2397,6 +2713,24 @@ func (f *RisingWaveObjectFactory) NewStandaloneService() *corev1.Service {
 	return mustSetControllerReference(f.risingwave, standaloneSvc, f.scheme)
 }
 
+// NewStandaloneHeadlessService creates a headless Service for the standalone Pods to find the meta leader among each
+// other in the high availability mode. The Pods that aren't ready are published as well, so that they can bootstrap.
+func (f *RisingWaveObjectFactory) NewStandaloneHeadlessService() *corev1.Service {
+	standaloneSvc := f.newService(consts.ComponentStandalone, corev1.ServiceTypeClusterIP, []corev1.ServicePort{
+		{
+			Name:       consts.PortService,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       consts.MetaServicePort,
+			TargetPort: intstr.FromInt32(consts.MetaServicePort),
+		},
+	})
+	standaloneSvc.Name = f.standaloneHeadlessServiceName()
+	standaloneSvc.Spec.ClusterIP = corev1.ClusterIPNone
+	standaloneSvc.Spec.PublishNotReadyAddresses = true
+
+	return mustSetControllerReference(f.risingwave, standaloneSvc, f.scheme)
+}
+
 // NewMetaService creates a new Service for the meta.
 func (f *RisingWaveObjectFactory) NewMetaService() *corev1.Service {
 	metaSvc := f.newService(consts.ComponentMeta, corev1.ServiceTypeClusterIP, []corev1.ServicePort{
@@ -2602,6 +2936,21 @@ func buildUpgradeStrategyForStatefulSet(strategy risingwavev1alpha1.RisingWaveNo
 	}
 }
 
+// buildUpgradeStrategyForComponentStatefulSet builds the update strategy of the StatefulSet of the component. The meta
+// StatefulSets with the default rolling update are updated on delete, so that the Pods are rolled by the
+// RisingWaveMetaSTSUpdateStrategy with the followers first and the leader last.
+func buildUpgradeStrategyForComponentStatefulSet(component string, strategy risingwavev1alpha1.RisingWaveNodeGroupUpgradeStrategy) appsv1.StatefulSetUpdateStrategy {
+	isDefaultRollingUpdate := (strategy.Type == "" || strategy.Type == risingwavev1alpha1.RisingWaveUpgradeStrategyTypeRollingUpdate) &&
+		strategy.RollingUpdate == nil
+	if component == consts.ComponentMeta && isDefaultRollingUpdate {
+		return appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}
+	}
+
+	return buildUpgradeStrategyForStatefulSet(strategy)
+}
+
 func buildUpgradeStrategyForAdvancedStatefulSet(strategy risingwavev1alpha1.RisingWaveNodeGroupUpgradeStrategy) kruiseappsv1beta1.StatefulSetUpdateStrategy {
 	advancedStatefulSetUpgradeStrategy := kruiseappsv1beta1.StatefulSetUpdateStrategy{}
 	advancedStatefulSetUpgradeStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
@@ -2663,15 +3012,83 @@ func (f *RisingWaveObjectFactory) NewConfigConfigMap(val string) *corev1.ConfigM
 	return mustSetControllerReference(f.risingwave, risingwaveConfigConfigMap, f.scheme)
 }
 
+// defaultScrapeInterval is the default interval and timeout of the scrapes of the metrics.
+const defaultScrapeInterval = 15 * time.Second
+
+func (f *RisingWaveObjectFactory) monitoring() *risingwavev1alpha1.RisingWaveMonitoring {
+	if f.risingwave.Spec.Monitoring == nil {
+		return &risingwavev1alpha1.RisingWaveMonitoring{}
+	}
+	return f.risingwave.Spec.Monitoring
+}
+
+func scrapeDuration(d *metav1.Duration, defaultValue prometheusv1.Duration) prometheusv1.Duration {
+	if d == nil {
+		return defaultValue
+	}
+	return prometheusDuration(d.Duration)
+}
+
+// metricRelabelConfigs returns the relabel rules of the samples of the default metrics endpoint.
+func (f *RisingWaveObjectFactory) metricRelabelConfigs() []prometheusv1.RelabelConfig {
+	monitoring := f.monitoring()
+
+	var relabelConfigs []prometheusv1.RelabelConfig
+	if !ptr.Deref(monitoring.KeepHighCardinalityMetrics, false) {
+		// we need to drop some metrics which maybe will produce too many series.
+		relabelConfigs = append(relabelConfigs,
+			prometheusv1.RelabelConfig{
+				SourceLabels: []prometheusv1.LabelName{"__name__"},
+				Action:       "drop",
+				Regex:        "batch_.+",
+			},
+			prometheusv1.RelabelConfig{
+				SourceLabels: []prometheusv1.LabelName{"__name__"},
+				Action:       "drop",
+				Regex:        "stream_exchange_.+",
+			},
+		)
+	}
+
+	return append(relabelConfigs, monitoring.MetricRelabelings...)
+}
+
+// metricsEndpoints returns the endpoints to scrape, converted from the default and the additional endpoints.
+func metricsEndpoints[E any](f *RisingWaveObjectFactory, convert func(port, path, scheme string, interval, scrapeTimeout prometheusv1.Duration, relabelConfigs []prometheusv1.RelabelConfig) E) []E {
+	monitoring := f.monitoring()
+	interval := scrapeDuration(monitoring.Interval, prometheusDuration(defaultScrapeInterval))
+	scrapeTimeout := scrapeDuration(monitoring.ScrapeTimeout, prometheusDuration(defaultScrapeInterval))
+
+	endpoints := []E{
+		convert(consts.PortMetrics, "", "", interval, scrapeTimeout, f.metricRelabelConfigs()),
+	}
+	for _, e := range monitoring.AdditionalEndpoints {
+		endpoints = append(endpoints, convert(e.Port, e.Path, e.Scheme,
+			scrapeDuration(e.Interval, interval), scrapeDuration(e.ScrapeTimeout, scrapeTimeout), e.MetricRelabelings))
+	}
+
+	return endpoints
+}
+
+func prometheusScheme(scheme string) *prometheusv1.Scheme {
+	if scheme == "" {
+		return nil
+	}
+	return ptr.To(prometheusv1.Scheme(scheme))
+}
+
+func (f *RisingWaveObjectFactory) getObjectMetaForMonitors() metav1.ObjectMeta {
+	objectMeta := f.getObjectMetaForGeneralResources("risingwave-"+f.risingwave.Name, true)
+	if labels := f.monitoring().Labels; len(labels) > 0 {
+		objectMeta.Labels = mergeMap(labels, objectMeta.Labels)
+	}
+	return objectMeta
+}
+
 // NewServiceMonitor creates a new ServiceMonitor.
 func (f *RisingWaveObjectFactory) NewServiceMonitor() *prometheusv1.ServiceMonitor {
-	const (
-		interval      = 15 * time.Second
-		scrapeTimeout = 15 * time.Second
-	)
-
 	serviceMonitor := &prometheusv1.ServiceMonitor{
-		ObjectMeta: f.getObjectMetaForGeneralResources("risingwave-"+f.risingwave.Name, true),
+		ObjectMeta: f.getObjectMetaForMonitors(),
 		Spec: prometheusv1.ServiceMonitorSpec{
 			JobLabel: "risingwave/" + f.risingwave.Name,
 			TargetLabels: []string{
@@ -2679,26 +3096,53 @@ func (f *RisingWaveObjectFactory) NewServiceMonitor() *prometheusv1.ServiceMonit
 				consts.LabelRisingWaveComponent,
 				consts.LabelRisingWaveGroup,
 			},
-			Endpoints: []prometheusv1.Endpoint{
-				{
-					Port:          consts.PortMetrics,
-					Interval:      prometheusv1.Duration(fmt.Sprintf("%.0fs", interval.Seconds())),
-					ScrapeTimeout: prometheusv1.Duration(fmt.Sprintf("%.0fs", scrapeTimeout.Seconds())),
-					// we need to drop some metrics which maybe will produce too many series.
-					MetricRelabelConfigs: []prometheusv1.RelabelConfig{
-						{
-							SourceLabels: []prometheusv1.LabelName{"__name__"},
-							Action:       "drop",
-							Regex:        "batch_.+",
-						},
-						{
-							SourceLabels: []prometheusv1.LabelName{"__name__"},
-							Action:       "drop",
-							Regex:        "stream_exchange_.+",
-						},
+			Endpoints: metricsEndpoints(f, func(port, path, scheme string, interval, scrapeTimeout prometheusv1.Duration, relabelConfigs []prometheusv1.RelabelConfig) prometheusv1.Endpoint {
+				return prometheusv1.Endpoint{
+					Port:                 port,
+					Path:                 path,
+					Scheme:               prometheusScheme(scheme),
+					Interval:             interval,
+					ScrapeTimeout:        scrapeTimeout,
+					MetricRelabelConfigs: relabelConfigs,
+				}
+			}),
+			Selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      consts.LabelRisingWaveName,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{f.risingwave.Name},
 					},
 				},
 			},
+		},
+	}
+
+	return mustSetControllerReference(f.risingwave, serviceMonitor, f.scheme)
+}
+
+// NewPodMonitor creates a new PodMonitor. It selects the Pods of the components directly, so that the Pods
+// behind multiple Services are scraped only once.
+func (f *RisingWaveObjectFactory) NewPodMonitor() *prometheusv1.PodMonitor {
+	podMonitor := &prometheusv1.PodMonitor{
+		ObjectMeta: f.getObjectMetaForMonitors(),
+		Spec: prometheusv1.PodMonitorSpec{
+			JobLabel: "risingwave/" + f.risingwave.Name,
+			PodTargetLabels: []string{
+				consts.LabelRisingWaveName,
+				consts.LabelRisingWaveComponent,
+				consts.LabelRisingWaveGroup,
+			},
+			PodMetricsEndpoints: metricsEndpoints(f, func(port, path, scheme string, interval, scrapeTimeout prometheusv1.Duration, relabelConfigs []prometheusv1.RelabelConfig) prometheusv1.PodMetricsEndpoint {
+				return prometheusv1.PodMetricsEndpoint{
+					Port:                 ptr.To(port),
+					Path:                 path,
+					Scheme:               prometheusScheme(scheme),
+					Interval:             interval,
+					ScrapeTimeout:        scrapeTimeout,
+					MetricRelabelConfigs: relabelConfigs,
+				}
+			}),
 			Selector: metav1.LabelSelector{
 				MatchExpressions: []metav1.LabelSelectorRequirement{
 					{
@@ -2706,12 +3150,500 @@ func (f *RisingWaveObjectFactory) NewServiceMonitor() *prometheusv1.ServiceMonit
 						Operator: metav1.LabelSelectorOpIn,
 						Values:   []string{f.risingwave.Name},
 					},
+					{
+						Key:      consts.LabelRisingWaveComponent,
+						Operator: metav1.LabelSelectorOpIn,
+						Values: []string{
+							consts.ComponentMeta,
+							consts.ComponentFrontend,
+							consts.ComponentCompute,
+							consts.ComponentCompactor,
+							consts.ComponentStandalone,
+						},
+					},
 				},
 			},
 		},
 	}
 
-	return mustSetControllerReference(f.risingwave, serviceMonitor, f.scheme)
+	return mustSetControllerReference(f.risingwave, podMonitor, f.scheme)
+}
+
+func networkPolicyPorts(ports ...int32) []networkingv1.NetworkPolicyPort {
+	return lo.Map(ports, func(port int32, _ int) networkingv1.NetworkPolicyPort {
+		return networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromInt32(port)),
+		}
+	})
+}
+
+func networkPolicyPeerOfNamespace(namespace string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				corev1.LabelMetadataName: namespace,
+			},
+		},
+	}
+}
+
+func (f *RisingWaveObjectFactory) newNetworkPolicy(component string, metricsPort int32, rules ...networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	spec := f.risingwave.Spec.NetworkPolicy
+
+	// Allow all the traffic between the components of the same RisingWave, and the scrapes of the metrics from the
+	// monitoring namespace.
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							consts.LabelRisingWaveName: f.risingwave.Name,
+						},
+					},
+				},
+			},
+		},
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				networkPolicyPeerOfNamespace(lo.Ternary(spec.MonitoringNamespace != "", spec.MonitoringNamespace, consts.DefaultMonitoringNamespace)),
+			},
+			Ports: networkPolicyPorts(metricsPort),
+		},
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: f.getObjectMetaForComponentLevelResources(component, true),
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: f.podLabelsOrSelectorsForComponent(component),
+			},
+			Ingress:     append(ingress, rules...),
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+
+	return mustSetControllerReference(f.risingwave, networkPolicy, f.scheme)
+}
+
+// NewNetworkPolicies creates the NetworkPolicies for the RisingWave components. It returns nil when the NetworkPolicies
+// aren't enabled. The operator is allowed to access the meta service from the given namespace, unless it's
+// overridden in the spec.
+func (f *RisingWaveObjectFactory) NewNetworkPolicies(operatorNamespace string) []*networkingv1.NetworkPolicy {
+	spec := f.risingwave.Spec.NetworkPolicy
+	if spec == nil || !ptr.Deref(spec.Enabled, false) {
+		return nil
+	}
+
+	// The operator accesses the meta service, and reads the metrics of the frontends to drain them on deletion.
+	operatorRule := func(ports ...int32) networkingv1.NetworkPolicyIngressRule {
+		return networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				networkPolicyPeerOfNamespace(cmp.Or(spec.OperatorNamespace, operatorNamespace, consts.DefaultOperatorNamespace)),
+			},
+			Ports: networkPolicyPorts(ports...),
+		}
+	}
+
+	webhookListenerEnabled := ptr.Deref(f.risingwave.Spec.EnableWebhookListener, false)
+
+	// The clients access the frontend.
+	var frontendRules []networkingv1.NetworkPolicyIngressRule
+	if len(spec.FrontendClients) > 0 {
+		frontendPorts := []int32{consts.FrontendServicePort}
+		if webhookListenerEnabled {
+			frontendPorts = append(frontendPorts, consts.FrontendWebhookPort)
+		}
+		frontendRules = append(frontendRules, networkingv1.NetworkPolicyIngressRule{
+			From:  spec.FrontendClients,
+			Ports: networkPolicyPorts(frontendPorts...),
+		})
+	}
+
+	// The HTTP clients, e.g., the Ingress controllers and the Gateways, access the dashboard and the webhook listener.
+	var dashboardRules, webhookRules []networkingv1.NetworkPolicyIngressRule
+	if len(spec.HTTPClients) > 0 {
+		dashboardRules = append(dashboardRules, networkingv1.NetworkPolicyIngressRule{
+			From:  spec.HTTPClients,
+			Ports: networkPolicyPorts(consts.MetaDashboardPort),
+		})
+		if webhookListenerEnabled {
+			webhookRules = append(webhookRules, networkingv1.NetworkPolicyIngressRule{
+				From:  spec.HTTPClients,
+				Ports: networkPolicyPorts(consts.FrontendWebhookPort),
+			})
+		}
+	}
+
+	if object.NewRisingWaveReader(f.risingwave).IsStandaloneModeEnabled() {
+		standaloneRules := append([]networkingv1.NetworkPolicyIngressRule{operatorRule(consts.MetaServicePort, consts.MetaMetricsPort)}, frontendRules...)
+		standaloneRules = append(standaloneRules, dashboardRules...)
+		standaloneRules = append(standaloneRules, webhookRules...)
+
+		return []*networkingv1.NetworkPolicy{
+			f.newNetworkPolicy(consts.ComponentStandalone, consts.MetaMetricsPort, standaloneRules...),
+		}
+	}
+
+	return []*networkingv1.NetworkPolicy{
+		f.newNetworkPolicy(consts.ComponentMeta, consts.MetaMetricsPort, append([]networkingv1.NetworkPolicyIngressRule{operatorRule(consts.MetaServicePort)}, dashboardRules...)...),
+		f.newNetworkPolicy(consts.ComponentFrontend, consts.FrontendMetricsPort, append(append([]networkingv1.NetworkPolicyIngressRule{operatorRule(consts.FrontendMetricsPort)}, frontendRules...), webhookRules...)...),
+		f.newNetworkPolicy(consts.ComponentCompute, consts.ComputeMetricsPort),
+		f.newNetworkPolicy(consts.ComponentCompactor, consts.CompactorMetricsPort),
+	}
+}
+
+func (f *RisingWaveObjectFactory) externalEndpointObjectName(endpoint string) string {
+	return f.risingwave.Name + "-" + endpoint
+}
+
+// externalEndpointBackend returns the name and the port of the Service behind the given endpoint.
+func (f *RisingWaveObjectFactory) externalEndpointBackend(endpoint string) (string, int32) {
+	switch endpoint {
+	case consts.ExternalEndpointDashboard:
+		if object.NewRisingWaveReader(f.risingwave).IsStandaloneModeEnabled() {
+			return f.componentName(consts.ComponentStandalone, ""), consts.MetaDashboardPort
+		}
+
+		return f.componentName(consts.ComponentMeta, ""), consts.MetaDashboardPort
+	case consts.ExternalEndpointWebhook:
+		// The frontend Service selects the standalone Pods in standalone mode.
+		return f.componentName(consts.ComponentFrontend, ""), consts.FrontendWebhookPort
+	default:
+		panic("never reach here")
+	}
+}
+
+func externalEndpointPath(path string) string {
+	return lo.Ternary(path != "", path, "/")
+}
+
+// NewIngress creates a new Ingress for the given endpoint, either dashboard or webhook. It returns nil when the
+// endpoint isn't exposed with an Ingress.
+func (f *RisingWaveObjectFactory) NewIngress(endpoint string) *networkingv1.Ingress {
+	externalEndpoint := object.NewRisingWaveReader(f.risingwave).GetExternalEndpoint(endpoint)
+	if externalEndpoint == nil || externalEndpoint.Ingress == nil {
+		return nil
+	}
+
+	spec := externalEndpoint.Ingress
+	serviceName, port := f.externalEndpointBackend(endpoint)
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: f.getObjectMetaForGeneralResources(f.externalEndpointObjectName(endpoint), true),
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.IngressClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: spec.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     externalEndpointPath(spec.Path),
+									PathType: ptr.To(networkingv1.PathTypePrefix),
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: serviceName,
+											Port: networkingv1.ServiceBackendPort{
+												Number: port,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingress.Annotations = mergeMap(ingress.Annotations, spec.Annotations)
+
+	if spec.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      lo.Ternary(spec.Host != "", []string{spec.Host}, nil),
+				SecretName: spec.TLSSecretName,
+			},
+		}
+	}
+
+	return mustSetControllerReference(f.risingwave, ingress, f.scheme)
+}
+
+// NewHTTPRoute creates a new HTTPRoute for the given endpoint, either dashboard or webhook. It returns nil when the
+// endpoint isn't exposed with an HTTPRoute.
+func (f *RisingWaveObjectFactory) NewHTTPRoute(endpoint string) *gatewayv1.HTTPRoute {
+	externalEndpoint := object.NewRisingWaveReader(f.risingwave).GetExternalEndpoint(endpoint)
+	if externalEndpoint == nil || externalEndpoint.HTTPRoute == nil {
+		return nil
+	}
+
+	spec := externalEndpoint.HTTPRoute
+	serviceName, port := f.externalEndpointBackend(endpoint)
+
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: f.getObjectMetaForGeneralResources(f.externalEndpointObjectName(endpoint), true),
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: lo.Map(spec.ParentRefs, func(ref risingwavev1alpha1.RisingWaveGatewayReference, _ int) gatewayv1.ParentReference {
+					return gatewayv1.ParentReference{
+						Name:        gatewayv1.ObjectName(ref.Name),
+						Namespace:   lo.Ternary(ref.Namespace != "", ptr.To(gatewayv1.Namespace(ref.Namespace)), nil),
+						SectionName: lo.Ternary(ref.SectionName != "", ptr.To(gatewayv1.SectionName(ref.SectionName)), nil),
+					}
+				}),
+			},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1.HTTPPathMatch{
+								Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+								Value: ptr.To(externalEndpointPath(spec.Path)),
+							},
+						},
+					},
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name: gatewayv1.ObjectName(serviceName),
+									Port: ptr.To(gatewayv1.PortNumber(port)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	httpRoute.Annotations = mergeMap(httpRoute.Annotations, spec.Annotations)
+
+	if spec.Hostname != "" {
+		httpRoute.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(spec.Hostname)}
+	}
+
+	return mustSetControllerReference(f.risingwave, httpRoute, f.scheme)
+}
+
+// Paths of the data in the stores provisioned by the operator.
+const (
+	managedMinIODataPath      = "/data"
+	managedPostgreSQLDataPath = "/var/lib/postgresql/data"
+)
+
+func (f *RisingWaveObjectFactory) newManagedStoreSecret(component, usernameKey, passwordKey string) *corev1.Secret {
+	secretName := object.NewRisingWaveReader(f.risingwave).ManagedStoreSecretName(component)
+
+	// Never sync the Secret after it's created, otherwise the password would be regenerated.
+	secret := &corev1.Secret{
+		ObjectMeta: f.getObjectMetaForGeneralResources(secretName, false),
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			usernameKey: []byte(consts.ManagedStoreUsername),
+			passwordKey: []byte(lo.Must(utils.RandomHex(16))),
+		},
+	}
+	secret.Labels[consts.LabelRisingWaveComponent] = component
+
+	return mustSetControllerReference(f.risingwave, secret, f.scheme)
+}
+
+// ManagedStoreSecretsSelector selects the Secrets of the generated credentials for the managed stores.
+func ManagedStoreSecretsSelector() labels.Selector {
+	return labels.NewSelector().Add(
+		*lo.Must(labels.NewRequirement(consts.LabelRisingWaveName, selection.Exists, nil)),
+		*lo.Must(labels.NewRequirement(consts.LabelRisingWaveComponent, selection.In, []string{consts.ComponentMinIO, consts.ComponentPostgreSQL})),
+	)
+}
+
+// NewManagedMinIOSecret creates a new Secret of the generated credentials for the managed MinIO.
+func (f *RisingWaveObjectFactory) NewManagedMinIOSecret() *corev1.Secret {
+	return f.newManagedStoreSecret(consts.ComponentMinIO, consts.SecretKeyMinIOUsername, consts.SecretKeyMinIOPassword)
+}
+
+// NewManagedPostgreSQLSecret creates a new Secret of the generated credentials for the managed PostgreSQL.
+func (f *RisingWaveObjectFactory) NewManagedPostgreSQLSecret() *corev1.Secret {
+	return f.newManagedStoreSecret(consts.ComponentPostgreSQL, consts.SecretKeyPostgreSQLUsername, consts.SecretKeyPostgreSQLPassword)
+}
+
+// NewManagedMinIOService creates a new Service for the managed MinIO.
+func (f *RisingWaveObjectFactory) NewManagedMinIOService() *corev1.Service {
+	minIOSvc := f.newService(consts.ComponentMinIO, corev1.ServiceTypeClusterIP, []corev1.ServicePort{
+		{
+			Name:       consts.PortService,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       consts.MinIOServicePort,
+			TargetPort: intstr.FromString(consts.PortService),
+		},
+	})
+
+	return mustSetControllerReference(f.risingwave, minIOSvc, f.scheme)
+}
+
+// NewManagedPostgreSQLService creates a new Service for the managed PostgreSQL.
+func (f *RisingWaveObjectFactory) NewManagedPostgreSQLService() *corev1.Service {
+	postgreSQLSvc := f.newService(consts.ComponentPostgreSQL, corev1.ServiceTypeClusterIP, []corev1.ServicePort{
+		{
+			Name:       consts.PortService,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       consts.PostgreSQLServicePort,
+			TargetPort: intstr.FromString(consts.PortService),
+		},
+	})
+
+	return mustSetControllerReference(f.risingwave, postgreSQLSvc, f.scheme)
+}
+
+func buildManagedStoreVolumeClaimTemplate(storage *risingwavev1alpha1.RisingWaveManagedStoreStorage) corev1.PersistentVolumeClaim {
+	size := resource.MustParse(consts.DefaultManagedStoreStorageSize)
+	if storage.Size != nil {
+		size = *storage.Size
+	}
+
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "data",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: storage.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+}
+
+// newManagedStoreStatefulSet creates a single-replica StatefulSet running the container with the data persisted at
+// the path. The volumes are deleted with the StatefulSet.
+func (f *RisingWaveObjectFactory) newManagedStoreStatefulSet(component string, container corev1.Container, dataPath string,
+	storage *risingwavev1alpha1.RisingWaveManagedStoreStorage) *appsv1.StatefulSet {
+	volumeClaimTemplate := buildManagedStoreVolumeClaimTemplate(storage)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeClaimTemplate.Name,
+		MountPath: dataPath,
+	})
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: f.getObjectMetaForComponentLevelResources(component, true),
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: f.componentName(component, ""),
+			Replicas:    ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: f.podLabelsOrSelectorsForComponent(component),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: f.podLabelsOrSelectorsForComponent(component),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{volumeClaimTemplate},
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+
+	return mustSetControllerReference(f.risingwave, statefulSet, f.scheme)
+}
+
+func envFromManagedStoreSecret(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// NewManagedMinIOStatefulSet creates a new StatefulSet for the managed MinIO.
+func (f *RisingWaveObjectFactory) NewManagedMinIOStatefulSet() *appsv1.StatefulSet {
+	spec := f.risingwave.Spec.StateStore.ManagedMinIO
+	secretName := object.NewRisingWaveReader(f.risingwave).ManagedStoreSecretName(consts.ComponentMinIO)
+
+	container := corev1.Container{
+		Name:  consts.ComponentMinIO,
+		Image: lo.Ternary(spec.Image != "", spec.Image, consts.DefaultManagedMinIOImage),
+		Args:  []string{"server", managedMinIODataPath},
+		Env: []corev1.EnvVar{
+			envFromManagedStoreSecret("MINIO_ROOT_USER", secretName, consts.SecretKeyMinIOUsername),
+			envFromManagedStoreSecret("MINIO_ROOT_PASSWORD", secretName, consts.SecretKeyMinIOPassword),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          consts.PortService,
+				Protocol:      corev1.ProtocolTCP,
+				ContainerPort: consts.MinIOServicePort,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			PeriodSeconds: 10,
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/minio/health/ready",
+					Port: intstr.FromString(consts.PortService),
+				},
+			},
+		},
+		Resources: spec.Resources,
+	}
+
+	return f.newManagedStoreStatefulSet(consts.ComponentMinIO, container, managedMinIODataPath, &spec.Storage)
+}
+
+// NewManagedPostgreSQLStatefulSet creates a new StatefulSet for the managed PostgreSQL.
+func (f *RisingWaveObjectFactory) NewManagedPostgreSQLStatefulSet() *appsv1.StatefulSet {
+	spec := f.risingwave.Spec.MetaStore.ManagedPostgreSQL
+	secretName := object.NewRisingWaveReader(f.risingwave).ManagedStoreSecretName(consts.ComponentPostgreSQL)
+
+	container := corev1.Container{
+		Name:  consts.ComponentPostgreSQL,
+		Image: lo.Ternary(spec.Image != "", spec.Image, consts.DefaultManagedPostgreSQLImage),
+		Env: []corev1.EnvVar{
+			envFromManagedStoreSecret("POSTGRES_USER", secretName, consts.SecretKeyPostgreSQLUsername),
+			envFromManagedStoreSecret("POSTGRES_PASSWORD", secretName, consts.SecretKeyPostgreSQLPassword),
+			{
+				Name:  "POSTGRES_DB",
+				Value: lo.Ternary(spec.Database != "", spec.Database, consts.DefaultManagedPostgreSQLDatabase),
+			},
+			{
+				// Use a sub-directory since the root of the volume might contain lost+found.
+				Name:  "PGDATA",
+				Value: path.Join(managedPostgreSQLDataPath, "pgdata"),
+			},
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          consts.PortService,
+				Protocol:      corev1.ProtocolTCP,
+				ContainerPort: consts.PostgreSQLServicePort,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			PeriodSeconds: 10,
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromString(consts.PortService),
+				},
+			},
+		},
+		Resources: spec.Resources,
+	}
+
+	return f.newManagedStoreStatefulSet(consts.ComponentPostgreSQL, container, managedPostgreSQLDataPath, &spec.Storage)
 }
 
 func (f *RisingWaveObjectFactory) envsForHuaweiCloudOBS() []corev1.EnvVar {
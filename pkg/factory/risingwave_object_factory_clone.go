@@ -0,0 +1,192 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+)
+
+// CloneBackupDirectory returns the directory of the meta snapshots of the clone in the state store, which is next to
+// the data directory rather than in it, so that the snapshots are never vacuumed with the data.
+func (f *RisingWaveObjectFactory) CloneBackupDirectory() string {
+	return f.getDataDirectory() + "-backup"
+}
+
+// NewCloneRestoreJob creates the Job that restores the meta snapshot of the source into the MySQL or PostgreSQL meta
+// store of the clone with the restore tool of RisingWave. The snapshot is read from the backup directory of the source
+// in the same bucket, and the restored cluster is pointed at the data and the backup directories of the clone.
+func (f *RisingWaveObjectFactory) NewCloneRestoreJob(name string, snapshotID int64, sourceBackupDirectory string) *batchv1.Job {
+	storageURL := strings.TrimPrefix(f.hummockConnectionStr(), "hummock+")
+	dataDirectory := f.getDataDirectory()
+
+	container := corev1.Container{
+		Name:    "restore",
+		Image:   f.risingwave.Spec.Image,
+		Command: []string{risingwaveExecutablePath},
+		Env:     f.envsForStateStore(),
+	}
+
+	args := []string{"ctl", "meta", "restore-meta", "--meta-store-type", "sql"}
+	switch {
+	case f.isMetaStoreMySQL():
+		container.Env = append(container.Env, f.envsForMySQL()...)
+		args = append(args, "--sql-endpoint", f.mysqlEndpointURL())
+	case f.isMetaStorePostgreSQL():
+		container.Env = append(container.Env, f.envsForPostgreSQL()...)
+		args = append(args, "--sql-endpoint", f.postgreSQLEndpointURL())
+	default:
+		panic("unsupported meta store type")
+	}
+
+	container.Args = append(args,
+		"--meta-snapshot-id", strconv.FormatInt(snapshotID, 10),
+		"--backup-storage-url", storageURL,
+		"--backup-storage-directory", sourceBackupDirectory,
+		"--hummock-storage-url", storageURL,
+		"--hummock-storage-directory", dataDirectory,
+		"--overwrite-hummock-storage-directory", dataDirectory,
+		"--overwrite-backup-storage-directory", f.CloneBackupDirectory(),
+	)
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyNever,
+		ImagePullSecrets: f.imagePullSecretsForMeta(),
+	}
+	f.setupVolumeAndVolumeMountForMetaStoreTLS(&podSpec, &container)
+	podSpec.Containers = []corev1.Container{container}
+
+	labels := map[string]string{
+		consts.LabelRisingWaveName: f.risingwave.Name,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace(),
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			// Never retry, as the restore requires an empty meta store.
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+// bucketCredentialsSecretKeys returns the Secret and the keys of the access key ID and the secret access key of the
+// S3 compatible state store.
+func (f *RisingWaveObjectFactory) bucketCredentialsSecretKeys() (string, string, string) {
+	stateStore := &f.risingwave.Spec.StateStore
+	switch {
+	case f.isStateStoreMinIO():
+		creds := &object.NewRisingWaveReader(f.risingwave).GetMinIO().RisingWaveMinIOCredentials
+		return creds.SecretName, creds.UsernameKeyRef, creds.PasswordKeyRef
+	case f.isStateStoreS3() || f.isStateStoreS3Compatible():
+		creds := &stateStore.S3.RisingWaveS3Credentials
+		return creds.SecretName, creds.AccessKeyRef, creds.SecretAccessKeyRef
+	case f.isStateStoreAliyunOSS():
+		creds := &stateStore.AliyunOSS.RisingWaveAliyunOSSCredentials
+		return creds.SecretName, creds.AccessKeyIDRef, creds.AccessKeySecretRef
+	case f.isStateStoreHuaweiCloudOBS():
+		creds := &stateStore.HuaweiCloudOBS.RisingWaveHuaweiCloudOBSCredentials
+		return creds.SecretName, creds.AccessKeyIDRef, creds.AccessKeySecretRef
+	default:
+		panic("unsupported state store type")
+	}
+}
+
+// cloneCopyScript syncs the objects from the source directory to the target one on the server side. The objects
+// already copied are skipped, so that a retry resumes the copy.
+const cloneCopyScript = `aws configure set default.s3.addressing_style "${ADDRESSING_STYLE}" && ` +
+	`aws s3 sync "s3://${BUCKET}/${SOURCE_DIRECTORY}/" "s3://${BUCKET}/${TARGET_DIRECTORY}/" ` +
+	`--endpoint-url "${ENDPOINT_URL}" --only-show-errors`
+
+// NewCloneCopyJob creates the Job that copies the objects in the data directory of the source to the one of the clone
+// in the same bucket with the AWS CLI. The endpoint URL, the region and the addressing style are the ones resolved
+// for the bucket, and the credentials are read from the Secret of the state store.
+func (f *RisingWaveObjectFactory) NewCloneCopyJob(name, bucket, endpointURL, region, addressingStyle, sourceDirectory string) *batchv1.Job {
+	secretName, accessKeyIDKey, secretAccessKeyKey := f.bucketCredentialsSecretKeys()
+	secretRef := corev1.LocalObjectReference{Name: secretName}
+
+	container := corev1.Container{
+		Name:    "copy",
+		Image:   cmp.Or(f.risingwave.Spec.CloneFrom.CopyImage, consts.DefaultCloneCopyImage),
+		Command: []string{"/bin/sh", "-c", cloneCopyScript},
+		Env: []corev1.EnvVar{
+			{Name: "BUCKET", Value: bucket},
+			{Name: "SOURCE_DIRECTORY", Value: sourceDirectory},
+			{Name: "TARGET_DIRECTORY", Value: f.getDataDirectory()},
+			{Name: "ENDPOINT_URL", Value: endpointURL},
+			{Name: "ADDRESSING_STYLE", Value: addressingStyle},
+			{Name: "AWS_REGION", Value: region},
+			{
+				Name: "AWS_ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: accessKeyIDKey},
+				},
+			},
+			{
+				Name: "AWS_SECRET_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: secretAccessKeyKey},
+				},
+			},
+			// The S3 compatible services may not support the checksums added by default.
+			{Name: "AWS_REQUEST_CHECKSUM_CALCULATION", Value: "when_required"},
+			{Name: "AWS_RESPONSE_CHECKSUM_VALIDATION", Value: "when_required"},
+		},
+	}
+
+	labels := map[string]string{
+		consts.LabelRisingWaveName: f.risingwave.Name,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace(),
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			// The copy resumes on retries.
+			BackoffLimit: ptr.To[int32](3),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: f.imagePullSecretsForMeta(),
+					Containers:       []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"cmp"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory/envs"
+)
+
+// imagePullSecretsForMeta collects the image pull secrets of the meta Pods.
+func (f *RisingWaveObjectFactory) imagePullSecretsForMeta() []corev1.LocalObjectReference {
+	var secrets []corev1.LocalObjectReference
+	addSecrets := func(refs []corev1.LocalObjectReference) {
+		for _, ref := range refs {
+			secrets = mergeListWhenKeyEquals(secrets, ref, func(a, b *corev1.LocalObjectReference) bool {
+				return a.Name == b.Name
+			})
+		}
+	}
+
+	for _, group := range f.risingwave.Spec.Components.Meta.NodeGroups {
+		addSecrets(group.Template.Spec.ImagePullSecrets)
+	}
+	if standalone := f.risingwave.Spec.Components.Standalone; standalone != nil {
+		addSecrets(standalone.Template.Spec.ImagePullSecrets)
+	}
+
+	return secrets
+}
+
+// NewMetaStoreMigrationJob creates the Job that copies the metadata from the etcd meta store of the RisingWave to the
+// target MySQL or PostgreSQL meta store with the migration tool of RisingWave. The image defaults to the one of the
+// RisingWave, and the target is cleaned before the migration if forced.
+func (f *RisingWaveObjectFactory) NewMetaStoreMigrationJob(name, image string, target *risingwavev1alpha1.RisingWaveMetaStoreBackend, force bool) *batchv1.Job {
+	etcd := f.risingwave.Spec.MetaStore.Etcd
+
+	targetRisingWave := f.risingwave.DeepCopy()
+	targetRisingWave.Spec.MetaStore = *target.DeepCopy()
+	targetFactory := &RisingWaveObjectFactory{risingwave: targetRisingWave}
+
+	args := []string{"ctl", "meta", "migration", "--etcd-endpoints", etcd.Endpoint}
+	container := corev1.Container{
+		Name:    "migration",
+		Image:   cmp.Or(image, f.risingwave.Spec.Image),
+		Command: []string{risingwaveExecutablePath},
+		Env:     f.envsForEtcd(),
+	}
+
+	if credentials := etcd.RisingWaveEtcdCredentials; credentials != nil && credentials.SecretName != "" {
+		args = append(args,
+			"--etcd-username", "$("+envs.RWEtcdUsername+")",
+			"--etcd-password", "$("+envs.RWEtcdPassword+")")
+	}
+
+	switch {
+	case targetFactory.isMetaStoreMySQL():
+		container.Env = append(container.Env, targetFactory.envsForMySQL()...)
+		args = append(args, "--sql-endpoint", targetFactory.mysqlEndpointURL())
+	case targetFactory.isMetaStorePostgreSQL():
+		container.Env = append(container.Env, targetFactory.envsForPostgreSQL()...)
+		args = append(args, "--sql-endpoint", targetFactory.postgreSQLEndpointURL())
+	default:
+		panic("unsupported target meta store type")
+	}
+
+	if force {
+		args = append(args, "--force")
+	}
+	container.Args = args
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyNever,
+		ImagePullSecrets: f.imagePullSecretsForMeta(),
+	}
+	targetFactory.setupVolumeAndVolumeMountForMetaStoreTLS(&podSpec, &container)
+	podSpec.Containers = []corev1.Container{container}
+
+	labels := map[string]string{
+		consts.LabelRisingWaveName: f.risingwave.Name,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace(),
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			// Never retry, as a partial migration must be cleaned up before the next one.
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
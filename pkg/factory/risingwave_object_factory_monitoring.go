@@ -0,0 +1,409 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package factory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+)
+
+// Severities of the default alerts.
+const (
+	alertSeverityWarning  = "warning"
+	alertSeverityCritical = "critical"
+)
+
+// invalidPrometheusLabelChars matches the characters replaced with underscores when the Kubernetes labels are
+// converted into the Prometheus labels.
+var invalidPrometheusLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+func prometheusDuration(d time.Duration) prometheusv1.Duration {
+	if d%time.Second != 0 {
+		return prometheusv1.Duration(fmt.Sprintf("%dms", d.Milliseconds()))
+	}
+	return prometheusv1.Duration(fmt.Sprintf("%.0fs", d.Seconds()))
+}
+
+// PrometheusRuleName returns the name of the PrometheusRule of the RisingWave.
+func (f *RisingWaveObjectFactory) PrometheusRuleName() string {
+	return "risingwave-" + f.risingwave.Name
+}
+
+// GrafanaDashboardConfigMapName returns the name of the ConfigMap of the Grafana dashboard of the RisingWave.
+func (f *RisingWaveObjectFactory) GrafanaDashboardConfigMapName() string {
+	return "risingwave-" + f.risingwave.Name + "-grafana-dashboard"
+}
+
+// metricsSelector returns the selector of the metrics scraped from the RisingWave. The target labels of the
+// ServiceMonitor are used.
+func (f *RisingWaveObjectFactory) metricsSelector(extra ...string) string {
+	matchers := append([]string{
+		fmt.Sprintf(`namespace=%q`, f.namespace()),
+		fmt.Sprintf(`%s=%q`, invalidPrometheusLabelChars.ReplaceAllString(consts.LabelRisingWaveName, "_"), f.risingwave.Name),
+	}, extra...)
+
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+// podsSelector returns the selector of the metrics of the Pods of the RisingWave reported by kube-state-metrics and
+// cAdvisor, which don't carry the labels of the Pods.
+func (f *RisingWaveObjectFactory) podsSelector(extra ...string) string {
+	podNamePattern := regexp.QuoteMeta(f.risingwave.Name) + "-(meta|frontend|compute|compactor|standalone)(-.+)?"
+	matchers := append([]string{
+		fmt.Sprintf(`namespace=%q`, f.namespace()),
+		fmt.Sprintf(`pod=~%q`, podNamePattern),
+	}, extra...)
+
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+func (f *RisingWaveObjectFactory) defaultAlertRules(thresholds *risingwavev1alpha1.RisingWaveAlertThresholds) []prometheusv1.Rule {
+	barrierLatency := consts.DefaultAlertBarrierLatency
+	if thresholds.BarrierLatency != nil {
+		barrierLatency = thresholds.BarrierLatency.Duration
+	}
+	compactionPendingBytes := resource.MustParse(consts.DefaultAlertCompactionPendingBytes)
+	if thresholds.CompactionPendingBytes != nil {
+		compactionPendingBytes = *thresholds.CompactionPendingBytes
+	}
+	metaLeaderChanges := ptr.Deref(thresholds.MetaLeaderChanges, consts.DefaultAlertMetaLeaderChanges)
+	podNotReady := consts.DefaultAlertPodNotReady
+	if thresholds.PodNotReady != nil {
+		podNotReady = thresholds.PodNotReady.Duration
+	}
+
+	return []prometheusv1.Rule{
+		{
+			Alert: string(risingwavev1alpha1.RisingWaveAlertBarrierLatencyHigh),
+			Expr: intstr.FromString(fmt.Sprintf(
+				"histogram_quantile(0.99, sum by (le) (rate(meta_barrier_duration_seconds_bucket%s[5m]))) > %s",
+				f.metricsSelector(), strconv.FormatFloat(barrierLatency.Seconds(), 'f', -1, 64))),
+			For:    ptr.To(prometheusDuration(5 * time.Minute)),
+			Labels: map[string]string{"severity": alertSeverityWarning},
+			Annotations: map[string]string{
+				"summary":     "Barrier latency of RisingWave is high",
+				"description": fmt.Sprintf("The p99 barrier latency of RisingWave %s/%s is above %s.", f.namespace(), f.risingwave.Name, barrierLatency),
+			},
+		},
+		{
+			Alert: string(risingwavev1alpha1.RisingWaveAlertCompactionBacklogHigh),
+			Expr: intstr.FromString(fmt.Sprintf("sum(storage_compact_pending_bytes%s) > %d",
+				f.metricsSelector(), compactionPendingBytes.Value())),
+			For:    ptr.To(prometheusDuration(15 * time.Minute)),
+			Labels: map[string]string{"severity": alertSeverityWarning},
+			Annotations: map[string]string{
+				"summary":     "Compaction of RisingWave is falling behind",
+				"description": fmt.Sprintf("The data pending compaction of RisingWave %s/%s is above %s.", f.namespace(), f.risingwave.Name, compactionPendingBytes.String()),
+			},
+		},
+		{
+			// Every meta node that has been the leader within the last hour leaves a series.
+			Alert: string(risingwavev1alpha1.RisingWaveAlertMetaLeaderChurn),
+			Expr: intstr.FromString(fmt.Sprintf("count(count by (worker_addr) (count_over_time(meta_num%s[1h]))) - 1 >= %d",
+				f.metricsSelector(`role="leader"`), metaLeaderChanges)),
+			Labels: map[string]string{"severity": alertSeverityWarning},
+			Annotations: map[string]string{
+				"summary":     "Meta leader of RisingWave changes frequently",
+				"description": fmt.Sprintf("The meta leader of RisingWave %s/%s has changed at least %d times within an hour.", f.namespace(), f.risingwave.Name, metaLeaderChanges),
+			},
+		},
+		{
+			Alert: string(risingwavev1alpha1.RisingWaveAlertPodNotReady),
+			Expr: intstr.FromString(fmt.Sprintf("sum by (namespace, pod) (kube_pod_status_ready%s) > 0",
+				f.podsSelector(`condition="false"`))),
+			For:    ptr.To(prometheusDuration(podNotReady)),
+			Labels: map[string]string{"severity": alertSeverityCritical},
+			Annotations: map[string]string{
+				"summary":     "Pod of RisingWave is not ready",
+				"description": fmt.Sprintf("Pod {{ $labels.pod }} of RisingWave %s/%s has been not ready for more than %s.", f.namespace(), f.risingwave.Name, podNotReady),
+			},
+		},
+		{
+			Alert: string(risingwavev1alpha1.RisingWaveAlertContainerOOMKilled),
+			Expr: intstr.FromString(fmt.Sprintf(
+				"(kube_pod_container_status_last_terminated_reason%s == 1) and on (namespace, pod, container) (increase(kube_pod_container_status_restarts_total%s[10m]) > 0)",
+				f.podsSelector(`reason="OOMKilled"`), f.podsSelector())),
+			Labels: map[string]string{"severity": alertSeverityWarning},
+			Annotations: map[string]string{
+				"summary":     "Container of RisingWave is OOMKilled",
+				"description": fmt.Sprintf("Container {{ $labels.container }} in Pod {{ $labels.pod }} of RisingWave %s/%s has been OOMKilled recently.", f.namespace(), f.risingwave.Name),
+			},
+		},
+	}
+}
+
+// NewPrometheusRule creates a new PrometheusRule with the default alerts. It returns nil when it isn't enabled.
+func (f *RisingWaveObjectFactory) NewPrometheusRule() *prometheusv1.PrometheusRule {
+	spec := object.NewRisingWaveReader(f.risingwave).GetPrometheusRule()
+	if spec == nil {
+		return nil
+	}
+
+	rules := lo.Filter(f.defaultAlertRules(&spec.Thresholds), func(rule prometheusv1.Rule, _ int) bool {
+		return !lo.Contains(spec.DisabledAlerts, risingwavev1alpha1.RisingWaveAlert(rule.Alert))
+	})
+	for i := range rules {
+		rules[i].Labels = mergeMap(rules[i].Labels, map[string]string{
+			"namespace": f.namespace(),
+			invalidPrometheusLabelChars.ReplaceAllString(consts.LabelRisingWaveName, "_"): f.risingwave.Name,
+		})
+		rules[i].Labels = mergeMap(rules[i].Labels, spec.AlertLabels)
+	}
+
+	objectMeta := f.getObjectMetaForGeneralResources(f.PrometheusRuleName(), true)
+	objectMeta.Labels = mergeMap(spec.Labels, objectMeta.Labels)
+
+	prometheusRule := &prometheusv1.PrometheusRule{
+		ObjectMeta: objectMeta,
+		Spec: prometheusv1.PrometheusRuleSpec{
+			Groups: []prometheusv1.RuleGroup{
+				{
+					Name:  f.PrometheusRuleName(),
+					Rules: rules,
+				},
+			},
+		},
+	}
+
+	return mustSetControllerReference(f.risingwave, prometheusRule, f.scheme)
+}
+
+// Models of the Grafana dashboard, with only the fields in use.
+type (
+	grafanaDashboard struct {
+		UID           string            `json:"uid"`
+		Title         string            `json:"title"`
+		Tags          []string          `json:"tags"`
+		Editable      bool              `json:"editable"`
+		SchemaVersion int               `json:"schemaVersion"`
+		Time          grafanaTimeRange  `json:"time"`
+		Refresh       string            `json:"refresh"`
+		Templating    grafanaTemplating `json:"templating"`
+		Panels        []grafanaPanel    `json:"panels"`
+	}
+
+	grafanaTimeRange struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+
+	grafanaTemplating struct {
+		List []grafanaVariable `json:"list"`
+	}
+
+	grafanaVariable struct {
+		Name  string `json:"name"`
+		Label string `json:"label"`
+		Type  string `json:"type"`
+		Query string `json:"query"`
+	}
+
+	grafanaDatasource struct {
+		Type string `json:"type"`
+		UID  string `json:"uid"`
+	}
+
+	grafanaGridPos struct {
+		H int `json:"h"`
+		W int `json:"w"`
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	grafanaTarget struct {
+		RefID        string `json:"refId"`
+		Expr         string `json:"expr"`
+		LegendFormat string `json:"legendFormat"`
+	}
+
+	grafanaFieldConfig struct {
+		Defaults struct {
+			Unit string `json:"unit"`
+		} `json:"defaults"`
+	}
+
+	grafanaPanel struct {
+		ID          int                `json:"id"`
+		Type        string             `json:"type"`
+		Title       string             `json:"title"`
+		Datasource  grafanaDatasource  `json:"datasource"`
+		GridPos     grafanaGridPos     `json:"gridPos"`
+		FieldConfig grafanaFieldConfig `json:"fieldConfig"`
+		Targets     []grafanaTarget    `json:"targets"`
+	}
+)
+
+// grafanaDashboardPanel describes a time series panel in the dashboard.
+type grafanaDashboardPanel struct {
+	title   string
+	unit    string
+	exprs   []string
+	legends []string
+}
+
+func (f *RisingWaveObjectFactory) grafanaDashboardPanels() []grafanaDashboardPanel {
+	return []grafanaDashboardPanel{
+		{
+			title: "Barrier Latency",
+			unit:  "s",
+			exprs: []string{
+				fmt.Sprintf("histogram_quantile(0.5, sum by (le) (rate(meta_barrier_duration_seconds_bucket%s[$__rate_interval])))", f.metricsSelector()),
+				fmt.Sprintf("histogram_quantile(0.99, sum by (le) (rate(meta_barrier_duration_seconds_bucket%s[$__rate_interval])))", f.metricsSelector()),
+			},
+			legends: []string{"p50", "p99"},
+		},
+		{
+			title:   "Compaction Pending Bytes",
+			unit:    "bytes",
+			exprs:   []string{fmt.Sprintf("sum(storage_compact_pending_bytes%s)", f.metricsSelector())},
+			legends: []string{"pending"},
+		},
+		{
+			title:   "Meta Leader",
+			unit:    "none",
+			exprs:   []string{fmt.Sprintf("max by (worker_addr) (meta_num%s)", f.metricsSelector(`role="leader"`))},
+			legends: []string{"{{worker_addr}}"},
+		},
+		{
+			title:   "Pods Not Ready",
+			unit:    "none",
+			exprs:   []string{fmt.Sprintf("sum by (pod) (kube_pod_status_ready%s)", f.podsSelector(`condition="false"`))},
+			legends: []string{"{{pod}}"},
+		},
+		{
+			title:   "CPU Usage",
+			unit:    "none",
+			exprs:   []string{fmt.Sprintf("sum by (pod) (rate(container_cpu_usage_seconds_total%s[$__rate_interval]))", f.podsSelector(`container!=""`))},
+			legends: []string{"{{pod}}"},
+		},
+		{
+			title:   "Memory Usage",
+			unit:    "bytes",
+			exprs:   []string{fmt.Sprintf("sum by (pod) (container_memory_working_set_bytes%s)", f.podsSelector(`container!=""`))},
+			legends: []string{"{{pod}}"},
+		},
+		{
+			title:   "Container Restarts",
+			unit:    "none",
+			exprs:   []string{fmt.Sprintf("sum by (pod, container) (increase(kube_pod_container_status_restarts_total%s[1h]))", f.podsSelector())},
+			legends: []string{"{{pod}}/{{container}}"},
+		},
+		{
+			title:   "OOMKilled Containers",
+			unit:    "none",
+			exprs:   []string{fmt.Sprintf("sum by (pod, container) (kube_pod_container_status_last_terminated_reason%s)", f.podsSelector(`reason="OOMKilled"`))},
+			legends: []string{"{{pod}}/{{container}}"},
+		},
+	}
+}
+
+func (f *RisingWaveObjectFactory) grafanaDashboardJSON() string {
+	const (
+		panelWidth  = 12
+		panelHeight = 8
+	)
+
+	uid := sha256.Sum256([]byte(f.namespace() + "/" + f.risingwave.Name))
+	datasource := grafanaDatasource{Type: "prometheus", UID: "${datasource}"}
+
+	dashboard := grafanaDashboard{
+		UID:           "risingwave-" + hex.EncodeToString(uid[:8]),
+		Title:         fmt.Sprintf("RisingWave / %s / %s", f.namespace(), f.risingwave.Name),
+		Tags:          []string{"risingwave"},
+		Editable:      true,
+		SchemaVersion: 39,
+		Time:          grafanaTimeRange{From: "now-1h", To: "now"},
+		Refresh:       "30s",
+		Templating: grafanaTemplating{
+			List: []grafanaVariable{
+				{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+			},
+		},
+	}
+
+	for i, panel := range f.grafanaDashboardPanels() {
+		p := grafanaPanel{
+			ID:         i + 1,
+			Type:       "timeseries",
+			Title:      panel.title,
+			Datasource: datasource,
+			GridPos: grafanaGridPos{
+				H: panelHeight,
+				W: panelWidth,
+				X: (i % 2) * panelWidth,
+				Y: (i / 2) * panelHeight,
+			},
+		}
+		p.FieldConfig.Defaults.Unit = panel.unit
+		for j, expr := range panel.exprs {
+			p.Targets = append(p.Targets, grafanaTarget{
+				RefID:        string(rune('A' + j)),
+				Expr:         expr,
+				LegendFormat: panel.legends[j],
+			})
+		}
+		dashboard.Panels = append(dashboard.Panels, p)
+	}
+
+	return string(lo.Must(json.MarshalIndent(dashboard, "", "  ")))
+}
+
+// NewGrafanaDashboardConfigMap creates a new ConfigMap of the Grafana dashboard. It returns nil when it isn't
+// enabled.
+func (f *RisingWaveObjectFactory) NewGrafanaDashboardConfigMap() *corev1.ConfigMap {
+	spec := object.NewRisingWaveReader(f.risingwave).GetGrafanaDashboard()
+	if spec == nil {
+		return nil
+	}
+
+	labels := spec.Labels
+	if len(labels) == 0 {
+		labels = map[string]string{
+			consts.DefaultGrafanaDashboardLabelKey: consts.DefaultGrafanaDashboardLabelValue,
+		}
+	}
+
+	objectMeta := f.getObjectMetaForGeneralResources(f.GrafanaDashboardConfigMapName(), true)
+	objectMeta.Labels = mergeMap(labels, objectMeta.Labels)
+	objectMeta.Annotations = mergeMap(objectMeta.Annotations, spec.Annotations)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: objectMeta,
+		Data: map[string]string{
+			f.GrafanaDashboardConfigMapName() + ".json": f.grafanaDashboardJSON(),
+		},
+	}
+
+	return mustSetControllerReference(f.risingwave, configMap, f.scheme)
+}
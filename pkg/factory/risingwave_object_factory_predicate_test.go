@@ -295,7 +295,9 @@ func metaStatefulSetPredicates() []predicate[*appsv1.StatefulSet, metaStatefulSe
 			Name: "upgrade-strategy-match",
 			Fn: func(obj *appsv1.StatefulSet, tc metaStatefulSetTestCase) bool {
 				if tc.expectedUpgradeStrategy == nil {
-					return equality.Semantic.DeepEqual(obj.Spec.UpdateStrategy, appsv1.StatefulSetUpdateStrategy{})
+					return equality.Semantic.DeepEqual(obj.Spec.UpdateStrategy, appsv1.StatefulSetUpdateStrategy{
+						Type: appsv1.OnDeleteStatefulSetStrategyType,
+					})
 				}
 
 				return equality.Semantic.DeepEqual(obj.Spec.UpdateStrategy, *tc.expectedUpgradeStrategy)
@@ -0,0 +1,216 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"maps"
+	"path"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+)
+
+// Deadline of the Job probing the state store, which fails the Job if the Pod can't be scheduled or pulled.
+const stateStoreProbeDeadlineSeconds = 300
+
+// stateStoreProbePrelude defines the probe function, which runs the command and writes the output of the failed one
+// into the termination message of the container.
+const stateStoreProbePrelude = `probe() { op="$1"; shift; if ! out=$("$@" 2>&1 >/dev/null); then ` +
+	`printf 'unable to %s: %s' "${op}" "${out}" > /dev/termination-log; exit 1; fi; }; `
+
+// stateStoreProbeS3Script puts, lists and deletes the probe object in the bucket with the AWS CLI.
+const stateStoreProbeS3Script = stateStoreProbePrelude +
+	`aws configure set default.s3.addressing_style "${ADDRESSING_STYLE}" && ` +
+	`probe "put objects into ${LOCATION}" aws s3api put-object --bucket "${BUCKET}" --key "${PROBE_OBJECT}" --endpoint-url "${ENDPOINT_URL}" && ` +
+	`probe "list objects in ${LOCATION}" aws s3api list-objects-v2 --bucket "${BUCKET}" --prefix "${DIRECTORY}/" --max-items 1 --endpoint-url "${ENDPOINT_URL}" && ` +
+	`probe "delete objects in ${LOCATION}" aws s3api delete-object --bucket "${BUCKET}" --key "${PROBE_OBJECT}" --endpoint-url "${ENDPOINT_URL}"`
+
+// stateStoreProbeGCSScript puts, lists and deletes the probe object in the bucket with the Google Cloud CLI. The
+// credentials in the Secret are preferred to the workload identity if set.
+const stateStoreProbeGCSScript = stateStoreProbePrelude +
+	`if [ -n "${GCS_CREDENTIALS}" ]; then printf '%s' "${GCS_CREDENTIALS}" > /tmp/credentials.json && ` +
+	`export CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE=/tmp/credentials.json; fi && ` +
+	`probe "put objects into ${LOCATION}" sh -c 'printf "" | gcloud storage cp - "gs://${BUCKET}/${PROBE_OBJECT}"' && ` +
+	`probe "list objects in ${LOCATION}" gcloud storage ls "gs://${BUCKET}/${DIRECTORY}/" && ` +
+	`probe "delete objects in ${LOCATION}" gcloud storage rm "gs://${BUCKET}/${PROBE_OBJECT}"`
+
+// stateStoreProbeAzureBlobScript puts, lists and deletes the probe blob in the container with the Azure CLI. It logs
+// in with the federated token of the workload identity if there's no account key.
+const stateStoreProbeAzureBlobScript = stateStoreProbePrelude +
+	`AUTH_MODE=key; if [ -z "${AZURE_STORAGE_KEY}" ]; then AUTH_MODE=login && ` +
+	`probe "log in with the workload identity" sh -c 'az login --service-principal -u "${AZURE_CLIENT_ID}" -t "${AZURE_TENANT_ID}" --federated-token "$(cat "${AZURE_FEDERATED_TOKEN_FILE}")"'; fi && ` +
+	`probe "put objects into ${LOCATION}" az storage blob upload --blob-endpoint "${ENDPOINT_URL}" --auth-mode "${AUTH_MODE}" -c "${CONTAINER}" -n "${PROBE_OBJECT}" --data "" --overwrite && ` +
+	`probe "list objects in ${LOCATION}" az storage blob list --blob-endpoint "${ENDPOINT_URL}" --auth-mode "${AUTH_MODE}" -c "${CONTAINER}" --prefix "${DIRECTORY}/" --num-results 1 && ` +
+	`probe "delete objects in ${LOCATION}" az storage blob delete --blob-endpoint "${ENDPOINT_URL}" --auth-mode "${AUTH_MODE}" -c "${CONTAINER}" -n "${PROBE_OBJECT}"`
+
+// stateStoreProbeTemplate returns the Pod template of the compute nodes, or the standalone one in the standalone
+// mode, whose service account, labels and scheduling constraints the probe inherits.
+func (f *RisingWaveObjectFactory) stateStoreProbeTemplate() *risingwavev1alpha1.RisingWaveNodePodTemplate {
+	if object.NewRisingWaveReader(f.risingwave).IsStandaloneModeEnabled() {
+		return &f.convertStandaloneIntoNodeGroup().Template
+	}
+
+	nodeGroups := f.risingwave.Spec.Components.Compute.NodeGroups
+	if len(nodeGroups) == 0 {
+		return &risingwavev1alpha1.RisingWaveNodePodTemplate{}
+	}
+
+	return &nodeGroups[0].Template
+}
+
+// secretEnv returns the environment variable referring to the key of the Secret.
+func secretEnv(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// stateStoreProbeContainer returns the container that probes the state store with the CLI of the cloud.
+func (f *RisingWaveObjectFactory) stateStoreProbeContainer() corev1.Container {
+	stateStore := &f.risingwave.Spec.StateStore
+	location, _ := stores.DataLocationOf(f.risingwave)
+
+	env := []corev1.EnvVar{
+		{Name: "LOCATION", Value: location.Store + "/" + location.Directory},
+		{Name: "DIRECTORY", Value: location.Directory},
+		{Name: "PROBE_OBJECT", Value: path.Join(location.Directory, "risingwave-preflight-"+string(f.risingwave.UID))},
+	}
+
+	container := corev1.Container{
+		Name:                     "probe",
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+
+	switch {
+	case f.isStateStoreS3() || f.isStateStoreS3Compatible():
+		s3 := stateStore.S3
+		endpoint, secure, _ := stores.S3Endpoint(s3, s3.Region)
+		scheme, addressingStyle := "https://", "auto"
+		if !secure {
+			scheme = "http://"
+		}
+		if s3.ForcePathStyle {
+			addressingStyle = "path"
+		}
+
+		container.Image = consts.StateStoreProbeS3Image
+		container.Command = []string{"/bin/sh", "-c", stateStoreProbeS3Script}
+		env = append(env,
+			corev1.EnvVar{Name: "BUCKET", Value: s3.Bucket},
+			corev1.EnvVar{Name: "ENDPOINT_URL", Value: scheme + endpoint},
+			corev1.EnvVar{Name: "ADDRESSING_STYLE", Value: addressingStyle},
+			corev1.EnvVar{Name: "AWS_REGION", Value: s3.Region},
+		)
+		if !ptr.Deref(s3.UseServiceAccount, false) {
+			env = append(env,
+				secretEnv("AWS_ACCESS_KEY_ID", s3.SecretName, s3.AccessKeyRef),
+				secretEnv("AWS_SECRET_ACCESS_KEY", s3.SecretName, s3.SecretAccessKeyRef),
+			)
+		}
+	case f.isStateStoreGCS():
+		gcs := stateStore.GCS
+
+		container.Image = consts.StateStoreProbeGCSImage
+		container.Command = []string{"/bin/sh", "-c", stateStoreProbeGCSScript}
+		env = append(env, corev1.EnvVar{Name: "BUCKET", Value: gcs.Bucket})
+		if !ptr.Deref(gcs.UseWorkloadIdentity, false) {
+			env = append(env, secretEnv("GCS_CREDENTIALS", gcs.SecretName, gcs.ServiceAccountCredentialsKeyRef))
+		}
+	case f.isStateStoreAzureBlob():
+		azureBlob := stateStore.AzureBlob
+		endpoint := azureBlob.Endpoint
+		if !strings.HasPrefix(endpoint, "https://") && !strings.HasPrefix(endpoint, "http://") {
+			endpoint = "https://" + endpoint
+		}
+
+		container.Image = consts.StateStoreProbeAzureBlobImage
+		container.Command = []string{"/bin/sh", "-c", stateStoreProbeAzureBlobScript}
+		env = append(env,
+			corev1.EnvVar{Name: "CONTAINER", Value: azureBlob.Container},
+			corev1.EnvVar{Name: "ENDPOINT_URL", Value: endpoint},
+		)
+		if !ptr.Deref(azureBlob.UseServiceAccount, false) {
+			env = append(env,
+				secretEnv("AZURE_STORAGE_ACCOUNT", azureBlob.SecretName, azureBlob.AccountNameRef),
+				secretEnv("AZURE_STORAGE_KEY", azureBlob.SecretName, azureBlob.AccountKeyRef),
+			)
+		}
+	default:
+		panic("unsupported state store type")
+	}
+
+	container.Env = env
+
+	return container
+}
+
+// NewStateStoreProbeJob creates the Job that puts, lists and deletes a probe object in the data directory of the
+// state store with the CLI of the cloud. The Pod runs with the service account, the labels, the annotations and the
+// scheduling constraints of the compute nodes, so that it's granted the same workload identity or instance profile.
+// The reason of the failure is written into the termination message of the container.
+func (f *RisingWaveObjectFactory) NewStateStoreProbeJob(name string) *batchv1.Job {
+	template := f.stateStoreProbeTemplate()
+
+	labels := map[string]string{
+		consts.LabelRisingWaveName: f.risingwave.Name,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace(),
+			Name:      name,
+			Labels:    labels,
+			Annotations: map[string]string{
+				consts.AnnotationPreflightGeneration: strconv.FormatInt(f.risingwave.Generation, 10),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// Never retry, the preflight checks retry with a new Job.
+			BackoffLimit:          ptr.To[int32](0),
+			ActiveDeadlineSeconds: ptr.To[int64](stateStoreProbeDeadlineSeconds),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      mergeMap(maps.Clone(template.ObjectMeta.Labels), labels),
+					Annotations: template.ObjectMeta.Annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyNever,
+					EnableServiceLinks:           ptr.To(false),
+					ServiceAccountName:           template.Spec.ServiceAccountName,
+					AutomountServiceAccountToken: template.Spec.AutomountServiceAccountToken,
+					NodeSelector:                 template.Spec.NodeSelector,
+					Affinity:                     template.Spec.Affinity,
+					Tolerations:                  template.Spec.Tolerations,
+					Containers:                   []corev1.Container{f.stateStoreProbeContainer()},
+				},
+			},
+		},
+	}
+}
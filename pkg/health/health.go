@@ -0,0 +1,238 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health analyzes the conditions of the Pods and the workloads of the RisingWave, and finds the issues like
+// the crash loops, the OOM terminations, the image pull failures and the unschedulable Pods per node group.
+package health
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+const (
+	// MaxPodsPerIssue is the max number of the Pods listed in an issue.
+	MaxPodsPerIssue = 5
+
+	// OOMKilledWindow is how long an OOM termination is reported after it happens.
+	OOMKilledWindow = 30 * time.Minute
+
+	reasonCrashLoopBackOff         = "CrashLoopBackOff"
+	reasonOOMKilled                = "OOMKilled"
+	reasonImagePullBackOff         = "ImagePullBackOff"
+	reasonErrImagePull             = "ErrImagePull"
+	reasonInvalidImageName         = "InvalidImageName"
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+// Analyzer finds the health issues of the Pods and the workloads.
+type Analyzer struct {
+	// Now is the time of the analysis.
+	Now time.Time
+
+	// NodeGroupOf returns the node group of the group label of a Pod or a workload, e.g., the node group expanded
+	// into the zones. The group label is used as is if it's nil.
+	NodeGroupOf func(component, group string) string
+}
+
+type issueKey struct {
+	component string
+	group     string
+	reason    risingwavev1alpha1.RisingWaveHealthReason
+}
+
+type issueBuilder struct {
+	issues map[issueKey]*risingwavev1alpha1.RisingWaveHealthIssue
+}
+
+func (b *issueBuilder) add(key issueKey, pod string, message string) {
+	issue, ok := b.issues[key]
+	if !ok {
+		issue = &risingwavev1alpha1.RisingWaveHealthIssue{
+			Component: key.component,
+			Group:     key.group,
+			Reason:    key.reason,
+			Message:   message,
+		}
+		b.issues[key] = issue
+	}
+
+	if pod != "" {
+		issue.Count++
+		if len(issue.Pods) < MaxPodsPerIssue {
+			issue.Pods = append(issue.Pods, pod)
+		}
+	}
+}
+
+func (b *issueBuilder) build() []risingwavev1alpha1.RisingWaveHealthIssue {
+	issues := make([]risingwavev1alpha1.RisingWaveHealthIssue, 0, len(b.issues))
+	for _, issue := range b.issues {
+		issues = append(issues, *issue)
+	}
+
+	slices.SortFunc(issues, func(a, b risingwavev1alpha1.RisingWaveHealthIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.Component, b.Component),
+			cmp.Compare(a.Group, b.Group),
+			cmp.Compare(a.Reason, b.Reason),
+		)
+	})
+
+	return issues
+}
+
+func (a *Analyzer) keyOf(labels map[string]string, reason risingwavev1alpha1.RisingWaveHealthReason) issueKey {
+	component, group := labels[consts.LabelRisingWaveComponent], labels[consts.LabelRisingWaveGroup]
+	if a.NodeGroupOf != nil {
+		group = a.NodeGroupOf(component, group)
+	}
+
+	return issueKey{component: component, group: group, reason: reason}
+}
+
+// podIssues returns the messages of the issues of the Pod by the reasons. The first container with an issue wins.
+func (a *Analyzer) podIssues(pod *corev1.Pod) map[risingwavev1alpha1.RisingWaveHealthReason]string {
+	issues := make(map[risingwavev1alpha1.RisingWaveHealthReason]string)
+	addIssue := func(reason risingwavev1alpha1.RisingWaveHealthReason, message string) {
+		if _, ok := issues[reason]; !ok {
+			issues[reason] = message
+		}
+	}
+
+	if pod.Status.Phase == corev1.PodPending {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+				addIssue(risingwavev1alpha1.RisingWaveHealthReasonUnschedulable, cond.Message)
+			}
+		}
+	}
+
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for i := range statuses {
+			status := &statuses[i]
+			if waiting := status.State.Waiting; waiting != nil {
+				switch waiting.Reason {
+				case reasonCrashLoopBackOff:
+					addIssue(risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff, crashLoopMessage(status))
+				case reasonImagePullBackOff, reasonErrImagePull, reasonInvalidImageName:
+					addIssue(risingwavev1alpha1.RisingWaveHealthReasonImagePullBackOff,
+						fmt.Sprintf("container %s: %s", status.Name, cmp.Or(waiting.Message, waiting.Reason)))
+				}
+			}
+
+			for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+				if terminated != nil && terminated.Reason == reasonOOMKilled && a.Now.Sub(terminated.FinishedAt.Time) <= OOMKilledWindow {
+					addIssue(risingwavev1alpha1.RisingWaveHealthReasonOOMKilled, oomKilledMessage(pod, status, terminated))
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func crashLoopMessage(status *corev1.ContainerStatus) string {
+	message := fmt.Sprintf("container %s restarted %d times", status.Name, status.RestartCount)
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		message += fmt.Sprintf(", last exited with code %d", terminated.ExitCode)
+		if terminated.Reason != "" {
+			message += fmt.Sprintf(" (%s)", terminated.Reason)
+		}
+	}
+
+	return message
+}
+
+func oomKilledMessage(pod *corev1.Pod, status *corev1.ContainerStatus, terminated *corev1.ContainerStateTerminated) string {
+	message := fmt.Sprintf("container %s was OOMKilled at %s", status.Name, terminated.FinishedAt.UTC().Format(time.RFC3339))
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != status.Name {
+			continue
+		}
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			message += fmt.Sprintf(" with the memory limit %s", limit.String())
+		}
+	}
+
+	return message
+}
+
+// Analyze returns the health issues of the Pods and the Deployments, sorted by the components, the groups and the
+// reasons. The Pods being deleted are ignored.
+func (a *Analyzer) Analyze(pods []corev1.Pod, deployments []appsv1.Deployment) []risingwavev1alpha1.RisingWaveHealthIssue {
+	b := &issueBuilder{issues: make(map[issueKey]*risingwavev1alpha1.RisingWaveHealthIssue)}
+
+	pods = slices.Clone(pods)
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		for reason, message := range a.podIssues(pod) {
+			b.add(a.keyOf(pod.Labels, reason), pod.Name, message)
+		}
+	}
+
+	for i := range deployments {
+		deploy := &deployments[i]
+		for _, cond := range deploy.Status.Conditions {
+			if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == reasonProgressDeadlineExceeded {
+				b.add(a.keyOf(deploy.Labels, risingwavev1alpha1.RisingWaveHealthReasonProgressDeadlineExceeded), "",
+					fmt.Sprintf("Deployment %s: %s", deploy.Name, cond.Message))
+			}
+		}
+	}
+
+	return b.build()
+}
+
+// Summarize returns the summary of the issues of the reason, or empty if there's none.
+func Summarize(issues []risingwavev1alpha1.RisingWaveHealthIssue, reason risingwavev1alpha1.RisingWaveHealthReason) string {
+	var summaries []string
+	for _, issue := range issues {
+		if issue.Reason != reason {
+			continue
+		}
+
+		summary := issue.Component
+		if issue.Group != "" {
+			summary += "/" + issue.Group
+		}
+		if issue.Count > 0 {
+			summary += fmt.Sprintf(": %d Pod(s) (%s)", issue.Count, strings.Join(issue.Pods, ", "))
+		}
+		if issue.Message != "" {
+			summary += ": " + issue.Message
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return strings.Join(summaries, "; ")
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newPod(name, component, group string, mutate func(pod *corev1.Pod)) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				consts.LabelRisingWaveComponent: component,
+				consts.LabelRisingWaveGroup:     group,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: component,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: component, Ready: true}},
+		},
+	}
+	if mutate != nil {
+		mutate(&pod)
+	}
+
+	return pod
+}
+
+func crashLooping(pod *corev1.Pod) {
+	pod.Status.ContainerStatuses[0].RestartCount = 3
+	pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}
+}
+
+func oomKilledAt(t time.Time) func(pod *corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+			ExitCode:   137,
+			Reason:     "OOMKilled",
+			FinishedAt: metav1.NewTime(t),
+		}
+	}
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	var crashLoopingPods []corev1.Pod
+	for i := range MaxPodsPerIssue + 2 {
+		crashLoopingPods = append(crashLoopingPods, newPod(fmt.Sprintf("rw-compute-a-%d", i), consts.ComponentCompute, "a", crashLooping))
+	}
+
+	pods := append(crashLoopingPods,
+		newPod("rw-compute-b-0", consts.ComponentCompute, "b", oomKilledAt(now.Add(-time.Minute))),
+		newPod("rw-compute-b-1", consts.ComponentCompute, "b", oomKilledAt(now.Add(-time.Hour))),
+		newPod("rw-frontend-0", consts.ComponentFrontend, "", func(pod *corev1.Pod) {
+			pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: `Back-off pulling image "risingwavelabs/risingwave:v0.0.0"`,
+			}
+		}),
+		newPod("rw-meta-0", consts.ComponentMeta, "", func(pod *corev1.Pod) {
+			pod.Status.Phase = corev1.PodPending
+			pod.Status.ContainerStatuses = nil
+			pod.Status.Conditions = []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient memory.",
+				},
+			}
+		}),
+		newPod("rw-meta-1", consts.ComponentMeta, "", func(pod *corev1.Pod) {
+			crashLooping(pod)
+			pod.DeletionTimestamp = &metav1.Time{Time: now}
+		}),
+		newPod("rw-compactor-0", consts.ComponentCompactor, "", nil),
+	)
+
+	deployments := []appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "rw-compactor",
+				Labels: map[string]string{
+					consts.LabelRisingWaveComponent: consts.ComponentCompactor,
+					consts.LabelRisingWaveGroup:     "",
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{
+						Type:    appsv1.DeploymentProgressing,
+						Status:  corev1.ConditionFalse,
+						Reason:  "ProgressDeadlineExceeded",
+						Message: `ReplicaSet "rw-compactor-abc" has timed out progressing.`,
+					},
+				},
+			},
+		},
+	}
+
+	analyzer := &Analyzer{Now: now}
+	issues := analyzer.Analyze(pods, deployments)
+
+	assert.Equal(t, []risingwavev1alpha1.RisingWaveHealthIssue{
+		{
+			Component: consts.ComponentCompactor,
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonProgressDeadlineExceeded,
+			Message:   `Deployment rw-compactor: ReplicaSet "rw-compactor-abc" has timed out progressing.`,
+		},
+		{
+			Component: consts.ComponentCompute,
+			Group:     "a",
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff,
+			Count:     MaxPodsPerIssue + 2,
+			Pods:      []string{"rw-compute-a-0", "rw-compute-a-1", "rw-compute-a-2", "rw-compute-a-3", "rw-compute-a-4"},
+			Message:   "container compute restarted 3 times, last exited with code 1 (Error)",
+		},
+		{
+			Component: consts.ComponentCompute,
+			Group:     "b",
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonOOMKilled,
+			Count:     1,
+			Pods:      []string{"rw-compute-b-0"},
+			Message:   "container compute was OOMKilled at 2024-01-01T11:59:00Z with the memory limit 4Gi",
+		},
+		{
+			Component: consts.ComponentFrontend,
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonImagePullBackOff,
+			Count:     1,
+			Pods:      []string{"rw-frontend-0"},
+			Message:   `container frontend: Back-off pulling image "risingwavelabs/risingwave:v0.0.0"`,
+		},
+		{
+			Component: consts.ComponentMeta,
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonUnschedulable,
+			Count:     1,
+			Pods:      []string{"rw-meta-0"},
+			Message:   "0/3 nodes are available: 3 Insufficient memory.",
+		},
+	}, issues)
+}
+
+func TestAnalyzer_NodeGroupOf(t *testing.T) {
+	pods := []corev1.Pod{
+		newPod("rw-compute-a-z1-0", consts.ComponentCompute, "a-z1", crashLooping),
+		newPod("rw-compute-a-z2-0", consts.ComponentCompute, "a-z2", crashLooping),
+	}
+
+	analyzer := &Analyzer{
+		Now: now,
+		NodeGroupOf: func(component, group string) string {
+			return "a"
+		},
+	}
+	issues := analyzer.Analyze(pods, nil)
+
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, "a", issues[0].Group)
+		assert.Equal(t, int32(2), issues[0].Count)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	issues := []risingwavev1alpha1.RisingWaveHealthIssue{
+		{
+			Component: consts.ComponentCompute,
+			Group:     "a",
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff,
+			Count:     2,
+			Pods:      []string{"rw-compute-a-0", "rw-compute-a-1"},
+			Message:   "container compute restarted 3 times",
+		},
+		{
+			Component: consts.ComponentCompactor,
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonProgressDeadlineExceeded,
+			Message:   "Deployment rw-compactor: timed out",
+		},
+		{
+			Component: consts.ComponentFrontend,
+			Reason:    risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff,
+			Count:     1,
+			Pods:      []string{"rw-frontend-0"},
+			Message:   "container frontend restarted 1 times",
+		},
+	}
+
+	assert.Equal(t, "compute/a: 2 Pod(s) (rw-compute-a-0, rw-compute-a-1): container compute restarted 3 times; "+
+		"frontend: 1 Pod(s) (rw-frontend-0): container frontend restarted 1 times",
+		Summarize(issues, risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff))
+	assert.Equal(t, "compactor: Deployment rw-compactor: timed out",
+		Summarize(issues, risingwavev1alpha1.RisingWaveHealthReasonProgressDeadlineExceeded))
+	assert.Empty(t, Summarize(issues, risingwavev1alpha1.RisingWaveHealthReasonOOMKilled))
+}
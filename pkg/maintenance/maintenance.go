@@ -0,0 +1,218 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance decides when the disruptive changes of the workloads can be applied according to the
+// maintenance windows, and classifies the changes into disruptive and non-disruptive ones.
+package maintenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kruiseappsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruiseappsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	"github.com/robfig/cron/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+// window is a parsed maintenance window.
+type window struct {
+	schedule cron.Schedule
+	location *time.Location
+	duration time.Duration
+}
+
+func parseWindow(w *risingwavev1alpha1.RisingWaveMaintenanceWindow) (*window, error) {
+	// Empty is UTC.
+	location, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	if w.Duration.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+
+	return &window{schedule: schedule, location: location, duration: w.Duration.Duration}, nil
+}
+
+// ValidateWindow validates the schedule, the time zone and the duration of the window.
+func ValidateWindow(path *field.Path, w *risingwavev1alpha1.RisingWaveMaintenanceWindow) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	if _, err := cron.ParseStandard(w.Schedule); err != nil {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("schedule"), w.Schedule, err.Error()))
+	}
+	if _, err := time.LoadLocation(w.TimeZone); err != nil {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("timeZone"), w.TimeZone, err.Error()))
+	}
+	if w.Duration.Duration <= 0 {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("duration"), w.Duration.Duration.String(), "must be positive"))
+	}
+
+	return fieldErrs
+}
+
+// State is the state of the maintenance windows at a time.
+type State struct {
+	// Open is true if any window is open.
+	Open bool
+
+	// NextOpen is the time when the next window opens, if none is open.
+	NextOpen time.Time
+}
+
+// StateOf returns the state of the windows at the given time. The invalid windows are ignored.
+func StateOf(windows []risingwavev1alpha1.RisingWaveMaintenanceWindow, now time.Time) State {
+	var state State
+
+	for i := range windows {
+		w, err := parseWindow(&windows[i])
+		if err != nil {
+			continue
+		}
+
+		// The first opening after now - duration is either within the window, or the next one.
+		next := w.schedule.Next(now.Add(-w.duration).In(w.location))
+		if !next.After(now) {
+			return State{Open: true}
+		}
+
+		if state.NextOpen.IsZero() || next.Before(state.NextOpen) {
+			state.NextOpen = next
+		}
+	}
+
+	return state
+}
+
+// IsForced returns true if the pending changes of the RisingWave are forced to be applied immediately.
+func IsForced(risingwave *risingwavev1alpha1.RisingWave) bool {
+	return risingwave.Annotations[consts.AnnotationApplyPendingMaintenance] == "true"
+}
+
+// IsAllowed returns true if the disruptive changes of the RisingWave can be applied at the given time, i.e., there's
+// no maintenance window, any window is open, or the changes are forced. Otherwise, it also returns the time when the
+// next window opens.
+func IsAllowed(risingwave *risingwavev1alpha1.RisingWave, now time.Time) (bool, time.Time) {
+	if len(risingwave.Spec.MaintenanceWindows) == 0 || IsForced(risingwave) {
+		return true, time.Time{}
+	}
+
+	state := StateOf(risingwave.Spec.MaintenanceWindows, now)
+
+	// Don't hold the changes forever if none of the windows is valid.
+	return state.Open || state.NextOpen.IsZero(), state.NextOpen
+}
+
+// podTemplateOf returns the Pod template of the workload, or nil if it's not a workload.
+func podTemplateOf(obj client.Object) *corev1.PodTemplateSpec {
+	switch o := obj.(type) {
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template
+	case *appsv1.Deployment:
+		return &o.Spec.Template
+	case *kruiseappsv1beta1.StatefulSet:
+		return &o.Spec.Template
+	case *kruiseappsv1alpha1.CloneSet:
+		return &o.Spec.Template
+	default:
+		return nil
+	}
+}
+
+// IsWorkload returns true if the object has a Pod template.
+func IsWorkload(obj client.Object) bool {
+	return podTemplateOf(obj) != nil
+}
+
+// PodTemplateHash returns the hash of the Pod template of the workload built by the operator. The templates read from
+// the API server are defaulted, so the hash must be computed before the workload is created or updated.
+func PodTemplateHash(obj client.Object) string {
+	template := podTemplateOf(obj)
+	if template == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(template)
+	if err != nil {
+		panic(fmt.Sprintf("unable to marshal pod template: %v", err))
+	}
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// SetPodTemplateHash records the hash of the Pod template in the annotations of the workload to create or update.
+func SetPodTemplateHash(obj client.Object) {
+	if !IsWorkload(obj) {
+		return
+	}
+
+	setAnnotation(obj, consts.AnnotationPodTemplateHash, PodTemplateHash(obj))
+}
+
+// IsDisruptive returns true if updating the current workload to the expected one restarts the Pods, i.e., the Pod
+// template changes. The current workloads without the recorded hash, e.g., created by an older operator, are
+// considered changed as well.
+func IsDisruptive(current, expected client.Object) bool {
+	if !IsWorkload(expected) {
+		return false
+	}
+
+	return current.GetAnnotations()[consts.AnnotationPodTemplateHash] != PodTemplateHash(expected)
+}
+
+// IsPending returns true if the workload has the disruptive changes deferred.
+func IsPending(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[consts.AnnotationPendingMaintenance]
+
+	return ok
+}
+
+// Defer keeps the Pod template and its hash of the current workload in the expected one, so that only the
+// non-disruptive changes are applied, and marks the expected one as pending with the generation of the changes.
+func Defer(current, expected client.Object, generation int64) {
+	template := podTemplateOf(expected)
+	if template == nil {
+		return
+	}
+
+	*template = *podTemplateOf(current).DeepCopy()
+	setAnnotation(expected, consts.AnnotationPodTemplateHash, current.GetAnnotations()[consts.AnnotationPodTemplateHash])
+	setAnnotation(expected, consts.AnnotationPendingMaintenance, fmt.Sprintf("%d", generation))
+}
+
+func setAnnotation(obj client.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+// Saturday.
+var now = time.Date(2024, 1, 6, 3, 0, 0, 0, time.UTC)
+
+func newWindow(schedule string, duration time.Duration, timeZone string) risingwavev1alpha1.RisingWaveMaintenanceWindow {
+	return risingwavev1alpha1.RisingWaveMaintenanceWindow{
+		Schedule: schedule,
+		Duration: metav1.Duration{Duration: duration},
+		TimeZone: timeZone,
+	}
+}
+
+func TestStateOf(t *testing.T) {
+	testcases := map[string]struct {
+		windows []risingwavev1alpha1.RisingWaveMaintenanceWindow
+		state   State
+	}{
+		"open": {
+			windows: []risingwavev1alpha1.RisingWaveMaintenanceWindow{newWindow("0 2 * * 6", 2*time.Hour, "")},
+			state:   State{Open: true},
+		},
+		"closed-at-the-end": {
+			windows: []risingwavev1alpha1.RisingWaveMaintenanceWindow{newWindow("0 2 * * 6", time.Hour, "")},
+			state:   State{NextOpen: time.Date(2024, 1, 13, 2, 0, 0, 0, time.UTC)},
+		},
+		"time-zone": {
+			// 03:00 UTC is 11:00 in Shanghai.
+			windows: []risingwavev1alpha1.RisingWaveMaintenanceWindow{newWindow("0 2 * * 6", 2*time.Hour, "Asia/Shanghai")},
+			state:   State{NextOpen: time.Date(2024, 1, 12, 18, 0, 0, 0, time.UTC)},
+		},
+		"earliest-next-open": {
+			windows: []risingwavev1alpha1.RisingWaveMaintenanceWindow{
+				newWindow("0 2 * * 0", time.Hour, ""),
+				newWindow("0 4 * * *", time.Hour, ""),
+			},
+			state: State{NextOpen: time.Date(2024, 1, 6, 4, 0, 0, 0, time.UTC)},
+		},
+		"invalid-ignored": {
+			windows: []risingwavev1alpha1.RisingWaveMaintenanceWindow{
+				newWindow("invalid", time.Hour, ""),
+				newWindow("0 4 * * *", time.Hour, "Invalid/Zone"),
+			},
+			state: State{},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			state := StateOf(tc.windows, now)
+			assert.Equal(t, tc.state.Open, state.Open)
+			assert.True(t, tc.state.NextOpen.Equal(state.NextOpen), "next open: %s", state.NextOpen)
+		})
+	}
+}
+
+func TestIsAllowed(t *testing.T) {
+	risingwave := &risingwavev1alpha1.RisingWave{}
+
+	allowed, _ := IsAllowed(risingwave, now)
+	assert.True(t, allowed, "no window")
+
+	risingwave.Spec.MaintenanceWindows = []risingwavev1alpha1.RisingWaveMaintenanceWindow{newWindow("0 2 * * 0", time.Hour, "")}
+	allowed, nextOpen := IsAllowed(risingwave, now)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC), nextOpen.UTC())
+
+	risingwave.Annotations = map[string]string{consts.AnnotationApplyPendingMaintenance: "true"}
+	allowed, _ = IsAllowed(risingwave, now)
+	assert.True(t, allowed, "forced")
+}
+
+func TestValidateWindow(t *testing.T) {
+	path := field.NewPath("spec", "maintenanceWindows").Index(0)
+
+	window := newWindow("0 2 * * 6", time.Hour, "Asia/Shanghai")
+	assert.Empty(t, ValidateWindow(path, &window))
+
+	window = newWindow("0 2 * *", 0, "Invalid/Zone")
+	assert.Len(t, ValidateWindow(path, &window), 3)
+}
+
+func newStatefulSet(image string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "sts"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "risingwave", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestDefer(t *testing.T) {
+	current := newStatefulSet("v1")
+	SetPodTemplateHash(current)
+
+	// Only the replicas change.
+	expected := newStatefulSet("v1")
+	expected.Spec.Replicas = ptr.To[int32](3)
+	assert.False(t, IsDisruptive(current, expected))
+
+	// The image changes.
+	expected.Spec.Template.Spec.Containers[0].Image = "v2"
+	assert.True(t, IsDisruptive(current, expected))
+
+	Defer(current, expected, 5)
+	assert.Equal(t, "v1", expected.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, int32(3), *expected.Spec.Replicas)
+	assert.Equal(t, current.Annotations[consts.AnnotationPodTemplateHash], expected.Annotations[consts.AnnotationPodTemplateHash])
+	assert.Equal(t, "5", expected.Annotations[consts.AnnotationPendingMaintenance])
+	assert.True(t, IsPending(expected))
+
+	// Workloads without the hash are changed.
+	assert.True(t, IsDisruptive(newStatefulSet("v1"), newStatefulSet("v1")))
+
+	// Not workloads.
+	assert.False(t, IsDisruptive(&corev1.Service{}, &corev1.Service{}))
+}
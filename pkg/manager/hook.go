@@ -0,0 +1,106 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/risingwavelabs/ctrlkit"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ContextActionHook is a hook around the actions that derives the context the action runs with, e.g., to carry the
+// span of the action to the calls made by the action. PostRun is called with the derived context. Unlike the
+// ctrlkit.ActionHook, it's run by the wrapper of WithContextActionHooks instead of the generated managers.
+type ContextActionHook interface {
+	// PreRun runs before the action and returns the context of the action.
+	PreRun(ctx context.Context, logger logr.Logger, action string) context.Context
+
+	// PostRun runs after the action.
+	PostRun(ctx context.Context, logger logr.Logger, action string, result ctrl.Result, err error)
+}
+
+// actionGroup is the group of actions in the workflow, e.g., the joins and the sequentials.
+type actionGroup interface {
+	Children() []ctrlkit.Action
+	SetChildren([]ctrlkit.Action)
+}
+
+// actionDecorator is the action that decorates another one, e.g., the timeouts, the retries and the shared ones.
+type actionDecorator interface {
+	Inner() ctrlkit.Action
+	SetInner(ctrlkit.Action)
+}
+
+// hookedAction runs the hooks around the action.
+type hookedAction struct {
+	inner ctrlkit.Action
+	hooks []ContextActionHook
+}
+
+// Description implements the Action.
+func (act *hookedAction) Description() string {
+	return act.inner.Description()
+}
+
+// Run implements the Action.
+func (act *hookedAction) Run(ctx context.Context) (result ctrl.Result, err error) {
+	description := act.inner.Description()
+	logger := log.FromContext(ctx).WithValues("action", description)
+
+	// Run the hooks one by one, and then in the reversed order after the action.
+	for _, h := range act.hooks {
+		ctx = h.PreRun(ctx, logger, description)
+	}
+	defer func() {
+		for i := len(act.hooks) - 1; i >= 0; i-- {
+			act.hooks[i].PostRun(ctx, logger, description, result, err)
+		}
+	}()
+
+	return act.inner.Run(ctx)
+}
+
+// WithContextActionHooks wraps every action in the workflow with the hooks, except for the groups and the decorators
+// of the actions, and returns the workflow.
+func WithContextActionHooks(workflow ctrlkit.Action, hooks ...ContextActionHook) ctrlkit.Action {
+	switch act := workflow.(type) {
+	case *hookedAction:
+		// Shared actions may be reached more than once.
+		return act
+	case actionGroup:
+		children := act.Children()
+		for i := range children {
+			children[i] = WithContextActionHooks(children[i], hooks...)
+		}
+		act.SetChildren(children)
+
+		return workflow
+	case actionDecorator:
+		act.SetInner(WithContextActionHooks(act.Inner(), hooks...))
+
+		return workflow
+	default:
+		if workflow == ctrlkit.Nop {
+			return workflow
+		}
+
+		return &hookedAction{inner: workflow, hooks: hooks}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/risingwavelabs/ctrlkit"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type hookContextKey struct{}
+
+type recordingHook struct {
+	mu                          sync.Mutex
+	value                       string
+	preRunValues, postRunValues []any
+	postRunActions              []string
+}
+
+func (h *recordingHook) PreRun(ctx context.Context, logger logr.Logger, action string) context.Context {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.preRunValues = append(h.preRunValues, ctx.Value(hookContextKey{}))
+	if h.value != "" {
+		return context.WithValue(ctx, hookContextKey{}, h.value+"/"+action)
+	}
+
+	return ctx
+}
+
+func (h *recordingHook) PostRun(ctx context.Context, logger logr.Logger, action string, result ctrl.Result, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.postRunValues = append(h.postRunValues, ctx.Value(hookContextKey{}))
+	h.postRunActions = append(h.postRunActions, action)
+}
+
+func TestWithContextActionHooks(t *testing.T) {
+	first, second := &recordingHook{value: "first"}, &recordingHook{value: "second"}
+
+	// The hooks run around the generated actions, no matter how they're composed.
+	m := NewRisingWaveControllerManager(RisingWaveControllerManagerState{}, nil, logr.Discard())
+	var mu sync.Mutex
+	actionValues := make(map[string]any)
+	newAction := func(name string) ctrlkit.Action {
+		return m.NewAction(name, func(ctx context.Context, logger logr.Logger) (ctrl.Result, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			actionValues[name] = ctx.Value(hookContextKey{})
+
+			return ctrlkit.Continue()
+		})
+	}
+	shared := ctrlkit.Shared(newAction("Shared"))
+	workflow := ctrlkit.OptimizeWorkflow(ctrlkit.SequentialJoin(
+		ctrlkit.ParallelJoin(newAction("A"), ctrlkit.Timeout(time.Minute, newAction("B")), shared),
+		ctrlkit.If(false, newAction("Skipped")),
+		shared,
+	))
+
+	if _, err := WithContextActionHooks(workflow, first, second).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"A", "B", "Shared"} {
+		if actionValues[name] != "second/"+name {
+			t.Errorf("action %s should run with the derived context, got %v", name, actionValues[name])
+		}
+	}
+	if len(first.postRunActions) != 3 || len(second.postRunActions) != 3 {
+		t.Errorf("hooks should run once for each action, got %v and %v", first.postRunActions, second.postRunActions)
+	}
+	for i := range first.preRunValues {
+		if value, _ := second.preRunValues[i].(string); first.preRunValues[i] != nil || !strings.HasPrefix(value, "first/") {
+			t.Errorf("the derived context should be passed on to the following hooks, got %v and %v", first.preRunValues, second.preRunValues)
+		}
+	}
+	for _, h := range []*recordingHook{first, second} {
+		for i, action := range h.postRunActions {
+			if h.postRunValues[i] != "second/"+action {
+				t.Errorf("post run should be called with the derived context, got %v", h.postRunValues)
+			}
+		}
+	}
+}
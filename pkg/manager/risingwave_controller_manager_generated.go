@@ -32,11 +32,13 @@ import (
 	"github.com/risingwavelabs/ctrlkit"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 )
@@ -376,6 +378,205 @@ func (s *RisingWaveControllerManagerState) GetFrontendStatefulSets(ctx context.C
 	return validated, nil
 }
 
+// GetGrafanaDashboardConfigMap gets grafanaDashboardConfigMap with name equals to risingwave-${target.Name}-grafana-dashboard.
+func (s *RisingWaveControllerManagerState) GetGrafanaDashboardConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	var grafanaDashboardConfigMap corev1.ConfigMap
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      "risingwave-" + s.target.Name + "-grafana-dashboard",
+	}, &grafanaDashboardConfigMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'grafanaDashboardConfigMap': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&grafanaDashboardConfigMap, s.target) {
+		return nil, fmt.Errorf("unable to get state 'grafanaDashboardConfigMap': object not owned by target")
+	}
+
+	return &grafanaDashboardConfigMap, nil
+}
+
+// GetHttpRoutes lists httpRoutes with the following selectors:
+//   - labels/risingwave/name=${target.Name}
+//   - owned
+func (s *RisingWaveControllerManagerState) GetHttpRoutes(ctx context.Context) ([]gatewayv1.HTTPRoute, error) {
+	var httpRoutesList gatewayv1.HTTPRouteList
+
+	matchingLabels := map[string]string{
+		"risingwave/name": s.target.Name,
+	}
+
+	err := s.List(ctx, &httpRoutesList, client.InNamespace(s.target.Namespace),
+		client.MatchingLabels(matchingLabels))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get state 'httpRoutes': %w", err)
+	}
+
+	var validated []gatewayv1.HTTPRoute
+	for _, obj := range httpRoutesList.Items {
+		if ctrlkit.ValidateOwnership(&obj, s.target) {
+			validated = append(validated, obj)
+		}
+	}
+
+	return validated, nil
+}
+
+// GetIngresses lists ingresses with the following selectors:
+//   - labels/risingwave/name=${target.Name}
+//   - owned
+func (s *RisingWaveControllerManagerState) GetIngresses(ctx context.Context) ([]networkingv1.Ingress, error) {
+	var ingressesList networkingv1.IngressList
+
+	matchingLabels := map[string]string{
+		"risingwave/name": s.target.Name,
+	}
+
+	err := s.List(ctx, &ingressesList, client.InNamespace(s.target.Namespace),
+		client.MatchingLabels(matchingLabels))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get state 'ingresses': %w", err)
+	}
+
+	var validated []networkingv1.Ingress
+	for _, obj := range ingressesList.Items {
+		if ctrlkit.ValidateOwnership(&obj, s.target) {
+			validated = append(validated, obj)
+		}
+	}
+
+	return validated, nil
+}
+
+// GetManagedMinIOSecret gets managedMinIOSecret with name equals to ${target.Name}-minio-credentials.
+func (s *RisingWaveControllerManagerState) GetManagedMinIOSecret(ctx context.Context) (*corev1.Secret, error) {
+	var managedMinIOSecret corev1.Secret
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-minio-credentials",
+	}, &managedMinIOSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'managedMinIOSecret': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&managedMinIOSecret, s.target) {
+		return nil, fmt.Errorf("unable to get state 'managedMinIOSecret': object not owned by target")
+	}
+
+	return &managedMinIOSecret, nil
+}
+
+// GetManagedMinIOService gets managedMinIOService with name equals to ${target.Name}-minio.
+func (s *RisingWaveControllerManagerState) GetManagedMinIOService(ctx context.Context) (*corev1.Service, error) {
+	var managedMinIOService corev1.Service
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-minio",
+	}, &managedMinIOService)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'managedMinIOService': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&managedMinIOService, s.target) {
+		return nil, fmt.Errorf("unable to get state 'managedMinIOService': object not owned by target")
+	}
+
+	return &managedMinIOService, nil
+}
+
+// GetManagedMinIOStatefulSet gets managedMinIOStatefulSet with name equals to ${target.Name}-minio.
+func (s *RisingWaveControllerManagerState) GetManagedMinIOStatefulSet(ctx context.Context) (*appsv1.StatefulSet, error) {
+	var managedMinIOStatefulSet appsv1.StatefulSet
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-minio",
+	}, &managedMinIOStatefulSet)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'managedMinIOStatefulSet': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&managedMinIOStatefulSet, s.target) {
+		return nil, fmt.Errorf("unable to get state 'managedMinIOStatefulSet': object not owned by target")
+	}
+
+	return &managedMinIOStatefulSet, nil
+}
+
+// GetManagedPostgreSQLSecret gets managedPostgreSQLSecret with name equals to ${target.Name}-postgresql-credentials.
+func (s *RisingWaveControllerManagerState) GetManagedPostgreSQLSecret(ctx context.Context) (*corev1.Secret, error) {
+	var managedPostgreSQLSecret corev1.Secret
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-postgresql-credentials",
+	}, &managedPostgreSQLSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'managedPostgreSQLSecret': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&managedPostgreSQLSecret, s.target) {
+		return nil, fmt.Errorf("unable to get state 'managedPostgreSQLSecret': object not owned by target")
+	}
+
+	return &managedPostgreSQLSecret, nil
+}
+
+// GetManagedPostgreSQLService gets managedPostgreSQLService with name equals to ${target.Name}-postgresql.
+func (s *RisingWaveControllerManagerState) GetManagedPostgreSQLService(ctx context.Context) (*corev1.Service, error) {
+	var managedPostgreSQLService corev1.Service
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-postgresql",
+	}, &managedPostgreSQLService)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'managedPostgreSQLService': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&managedPostgreSQLService, s.target) {
+		return nil, fmt.Errorf("unable to get state 'managedPostgreSQLService': object not owned by target")
+	}
+
+	return &managedPostgreSQLService, nil
+}
+
+// GetManagedPostgreSQLStatefulSet gets managedPostgreSQLStatefulSet with name equals to ${target.Name}-postgresql.
+func (s *RisingWaveControllerManagerState) GetManagedPostgreSQLStatefulSet(ctx context.Context) (*appsv1.StatefulSet, error) {
+	var managedPostgreSQLStatefulSet appsv1.StatefulSet
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-postgresql",
+	}, &managedPostgreSQLStatefulSet)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'managedPostgreSQLStatefulSet': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&managedPostgreSQLStatefulSet, s.target) {
+		return nil, fmt.Errorf("unable to get state 'managedPostgreSQLStatefulSet': object not owned by target")
+	}
+
+	return &managedPostgreSQLStatefulSet, nil
+}
+
 // GetMetaAdvancedStatefulSets lists metaAdvancedStatefulSets with the following selectors:
 //   - labels/risingwave/component=meta
 //   - labels/risingwave/name=${target.Name}
@@ -453,6 +654,92 @@ func (s *RisingWaveControllerManagerState) GetMetaStatefulSets(ctx context.Conte
 	return validated, nil
 }
 
+// GetNetworkPolicies lists networkPolicies with the following selectors:
+//   - labels/risingwave/name=${target.Name}
+//   - owned
+func (s *RisingWaveControllerManagerState) GetNetworkPolicies(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+	var networkPoliciesList networkingv1.NetworkPolicyList
+
+	matchingLabels := map[string]string{
+		"risingwave/name": s.target.Name,
+	}
+
+	err := s.List(ctx, &networkPoliciesList, client.InNamespace(s.target.Namespace),
+		client.MatchingLabels(matchingLabels))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get state 'networkPolicies': %w", err)
+	}
+
+	var validated []networkingv1.NetworkPolicy
+	for _, obj := range networkPoliciesList.Items {
+		if ctrlkit.ValidateOwnership(&obj, s.target) {
+			validated = append(validated, obj)
+		}
+	}
+
+	return validated, nil
+}
+
+// GetPodMonitor gets podMonitor with name equals to risingwave-${target.Name}.
+func (s *RisingWaveControllerManagerState) GetPodMonitor(ctx context.Context) (*monitoringv1.PodMonitor, error) {
+	var podMonitor monitoringv1.PodMonitor
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      "risingwave-" + s.target.Name,
+	}, &podMonitor)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'podMonitor': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&podMonitor, s.target) {
+		return nil, fmt.Errorf("unable to get state 'podMonitor': object not owned by target")
+	}
+
+	return &podMonitor, nil
+}
+
+// GetPods lists pods with the following selectors:
+//   - labels/risingwave/name=${target.Name}
+func (s *RisingWaveControllerManagerState) GetPods(ctx context.Context) ([]corev1.Pod, error) {
+	var podsList corev1.PodList
+
+	matchingLabels := map[string]string{
+		"risingwave/name": s.target.Name,
+	}
+
+	err := s.List(ctx, &podsList, client.InNamespace(s.target.Namespace),
+		client.MatchingLabels(matchingLabels))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get state 'pods': %w", err)
+	}
+
+	return podsList.Items, nil
+}
+
+// GetPrometheusRule gets prometheusRule with name equals to risingwave-${target.Name}.
+func (s *RisingWaveControllerManagerState) GetPrometheusRule(ctx context.Context) (*monitoringv1.PrometheusRule, error) {
+	var prometheusRule monitoringv1.PrometheusRule
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      "risingwave-" + s.target.Name,
+	}, &prometheusRule)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'prometheusRule': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&prometheusRule, s.target) {
+		return nil, fmt.Errorf("unable to get state 'prometheusRule': object not owned by target")
+	}
+
+	return &prometheusRule, nil
+}
+
 // GetServiceMonitor gets serviceMonitor with name equals to risingwave-${target.Name}.
 func (s *RisingWaveControllerManagerState) GetServiceMonitor(ctx context.Context) (*monitoringv1.ServiceMonitor, error) {
 	var serviceMonitor monitoringv1.ServiceMonitor
@@ -495,6 +782,27 @@ func (s *RisingWaveControllerManagerState) GetStandaloneAdvancedStatefulSet(ctx
 	return &standaloneAdvancedStatefulSet, nil
 }
 
+// GetStandaloneHeadlessService gets standaloneHeadlessService with name equals to ${target.Name}-standalone-headless.
+func (s *RisingWaveControllerManagerState) GetStandaloneHeadlessService(ctx context.Context) (*corev1.Service, error) {
+	var standaloneHeadlessService corev1.Service
+
+	err := s.Get(ctx, types.NamespacedName{
+		Namespace: s.target.Namespace,
+		Name:      s.target.Name + "-standalone-headless",
+	}, &standaloneHeadlessService)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get state 'standaloneHeadlessService': %w", err)
+	}
+	if !ctrlkit.ValidateOwnership(&standaloneHeadlessService, s.target) {
+		return nil, fmt.Errorf("unable to get state 'standaloneHeadlessService': object not owned by target")
+	}
+
+	return &standaloneHeadlessService, nil
+}
+
 // GetStandaloneService gets standaloneService with name equals to ${target.Name}-standalone.
 func (s *RisingWaveControllerManagerState) GetStandaloneService(ctx context.Context) (*corev1.Service, error) {
 	var standaloneService corev1.Service
@@ -631,6 +939,10 @@ type RisingWaveControllerManagerImpl interface {
 	// SyncStandaloneService creates or updates the service for standalone RisingWave.
 	SyncStandaloneService(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service) (ctrl.Result, error)
 
+	// SyncStandaloneHeadlessService creates or updates the headless service for the standalone nodes in the high
+	// availability mode, and deletes it otherwise.
+	SyncStandaloneHeadlessService(ctx context.Context, logger logr.Logger, standaloneHeadlessService *corev1.Service) (ctrl.Result, error)
+
 	// SyncStandaloneStatefulSet creates or updates the StatefulSet for standalone RisingWave.
 	SyncStandaloneStatefulSet(ctx context.Context, logger logr.Logger, standaloneStatefulSet *appsv1.StatefulSet) (ctrl.Result, error)
 
@@ -643,18 +955,67 @@ type RisingWaveControllerManagerImpl interface {
 	// WaitBeforeStandaloneAdvancedStatefulSetReady waits (aborts the workflow) before the standalone advanced StatefulSet is ready.
 	WaitBeforeStandaloneAdvancedStatefulSetReady(ctx context.Context, logger logr.Logger, standaloneAdvancedStatefulSet *appsv1beta1.StatefulSet) (ctrl.Result, error)
 
-	// SyncServiceMonitor creates or updates the service monitor for RisingWave.
+	// StopStandaloneForMigration stops the standalone Pods before the meta nodes are brought up when migrating from
+	// the standalone mode, and records the progress of the migration in the status.
+	StopStandaloneForMigration(ctx context.Context, logger logr.Logger, standaloneStatefulSet *appsv1.StatefulSet, standaloneAdvancedStatefulSet *appsv1beta1.StatefulSet, pods []corev1.Pod) (ctrl.Result, error)
+
+	// SyncResourceRecommendations collects the resource usage and OOM terminations of the Pods, updates the
+	// resource recommendations in the status, and applies them when the node group opts in.
+	SyncResourceRecommendations(ctx context.Context, logger logr.Logger, pods []corev1.Pod) (ctrl.Result, error)
+
+	// CollectResourceGroupsAndSyncStatus collects the workers and streaming jobs of the resource groups from the
+	// meta service and sync them into the status.
+	CollectResourceGroupsAndSyncStatus(ctx context.Context, logger logr.Logger, pods []corev1.Pod) (ctrl.Result, error)
+
+	// SyncNetworkPolicies creates, updates or deletes the NetworkPolicies for the RisingWave components.
+	SyncNetworkPolicies(ctx context.Context, logger logr.Logger, networkPolicies []networkingv1.NetworkPolicy) (ctrl.Result, error)
+
+	// SyncIngresses creates, updates or deletes the Ingresses exposing the dashboard and the webhook listener,
+	// and sync the external URLs into the status.
+	SyncIngresses(ctx context.Context, logger logr.Logger, ingresses []networkingv1.Ingress) (ctrl.Result, error)
+
+	// SyncServiceMonitor creates, updates or deletes the service monitor for RisingWave.
 	SyncServiceMonitor(ctx context.Context, logger logr.Logger, serviceMonitor *monitoringv1.ServiceMonitor) (ctrl.Result, error)
 
+	// SyncPodMonitor creates, updates or deletes the pod monitor for RisingWave.
+	SyncPodMonitor(ctx context.Context, logger logr.Logger, podMonitor *monitoringv1.PodMonitor) (ctrl.Result, error)
+
+	// SyncHTTPRoutes creates, updates or deletes the HTTPRoutes exposing the dashboard and the webhook listener,
+	// and sync the external URLs into the status.
+	SyncHTTPRoutes(ctx context.Context, logger logr.Logger, httpRoutes []gatewayv1.HTTPRoute) (ctrl.Result, error)
+
+	// SyncPrometheusRule creates, updates or deletes the PrometheusRule for RisingWave.
+	SyncPrometheusRule(ctx context.Context, logger logr.Logger, prometheusRule *monitoringv1.PrometheusRule) (ctrl.Result, error)
+
+	// SyncGrafanaDashboardConfigMap creates, updates or deletes the ConfigMap of the Grafana dashboard for RisingWave.
+	SyncGrafanaDashboardConfigMap(ctx context.Context, logger logr.Logger, grafanaDashboardConfigMap *corev1.ConfigMap) (ctrl.Result, error)
+
+	// SyncManagedMinIO creates or updates the Secret, the Service and the StatefulSet for the managed MinIO, or
+	// deletes them if the state store isn't the managed MinIO.
+	SyncManagedMinIO(ctx context.Context, logger logr.Logger, managedMinIOSecret *corev1.Secret, managedMinIOService *corev1.Service, managedMinIOStatefulSet *appsv1.StatefulSet) (ctrl.Result, error)
+
+	// SyncManagedPostgreSQL creates or updates the Secret, the Service and the StatefulSet for the managed
+	// PostgreSQL, or deletes them if the meta store isn't the managed PostgreSQL.
+	SyncManagedPostgreSQL(ctx context.Context, logger logr.Logger, managedPostgreSQLSecret *corev1.Secret, managedPostgreSQLService *corev1.Service, managedPostgreSQLStatefulSet *appsv1.StatefulSet) (ctrl.Result, error)
+
+	// WaitBeforeManagedStoresReady waits (aborts the workflow) before the managed stores are ready.
+	WaitBeforeManagedStoresReady(ctx context.Context, logger logr.Logger, managedMinIOStatefulSet *appsv1.StatefulSet, managedPostgreSQLStatefulSet *appsv1.StatefulSet) (ctrl.Result, error)
+
 	// CollectRunningStatisticsAndSyncStatus collects running statistics and sync them into the status.
-	CollectRunningStatisticsAndSyncStatus(ctx context.Context, logger logr.Logger, frontendService *corev1.Service, frontendHeadlessService *corev1.Service, metaService *corev1.Service, computeService *corev1.Service, compactorService *corev1.Service, metaStatefulSets []appsv1.StatefulSet, frontendDeployments []appsv1.Deployment, frontendStatefulSets []appsv1.StatefulSet, computeStatefulSets []appsv1.StatefulSet, compactorDeployments []appsv1.Deployment, configConfigMap *corev1.ConfigMap) (ctrl.Result, error)
+	CollectRunningStatisticsAndSyncStatus(ctx context.Context, logger logr.Logger, frontendService *corev1.Service, frontendHeadlessService *corev1.Service, metaService *corev1.Service, computeService *corev1.Service, compactorService *corev1.Service, metaStatefulSets []appsv1.StatefulSet, frontendDeployments []appsv1.Deployment, frontendStatefulSets []appsv1.StatefulSet, computeStatefulSets []appsv1.StatefulSet, compactorDeployments []appsv1.Deployment, configConfigMap *corev1.ConfigMap, pods []corev1.Pod) (ctrl.Result, error)
 
-	CollectOpenKruiseRunningStatisticsAndSyncStatus(ctx context.Context, logger logr.Logger, frontendService *corev1.Service, frontendHeadlessService *corev1.Service, metaService *corev1.Service, computeService *corev1.Service, compactorService *corev1.Service, metaAdvancedStatefulSets []appsv1beta1.StatefulSet, frontendCloneSets []appsv1alpha1.CloneSet, frontendAdvancedStatefulSets []appsv1beta1.StatefulSet, computeAdvancedStatefulSets []appsv1beta1.StatefulSet, compactorCloneSets []appsv1alpha1.CloneSet, configConfigMap *corev1.ConfigMap) (ctrl.Result, error)
+	CollectOpenKruiseRunningStatisticsAndSyncStatus(ctx context.Context, logger logr.Logger, frontendService *corev1.Service, frontendHeadlessService *corev1.Service, metaService *corev1.Service, computeService *corev1.Service, compactorService *corev1.Service, metaAdvancedStatefulSets []appsv1beta1.StatefulSet, frontendCloneSets []appsv1alpha1.CloneSet, frontendAdvancedStatefulSets []appsv1beta1.StatefulSet, computeAdvancedStatefulSets []appsv1beta1.StatefulSet, compactorCloneSets []appsv1alpha1.CloneSet, configConfigMap *corev1.ConfigMap, pods []corev1.Pod) (ctrl.Result, error)
 
 	// CollectRunningStatisticsAndSyncStatusForStandalone collects running statistics and sync them into the status.
-	CollectRunningStatisticsAndSyncStatusForStandalone(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service, standaloneStatefulSet *appsv1.StatefulSet, configConfigMap *corev1.ConfigMap) (ctrl.Result, error)
+	CollectRunningStatisticsAndSyncStatusForStandalone(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service, standaloneStatefulSet *appsv1.StatefulSet, configConfigMap *corev1.ConfigMap, pods []corev1.Pod) (ctrl.Result, error)
+
+	CollectOpenKruiseRunningStatisticsAndSyncStatusForStandalone(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service, standaloneAdvancedStatefulSet *appsv1beta1.StatefulSet, configConfigMap *corev1.ConfigMap, pods []corev1.Pod) (ctrl.Result, error)
 
-	CollectOpenKruiseRunningStatisticsAndSyncStatusForStandalone(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service, standaloneAdvancedStatefulSet *appsv1beta1.StatefulSet, configConfigMap *corev1.ConfigMap) (ctrl.Result, error)
+	// RemediateStuckPods deletes the Pods of the stateful components that get stuck in a rollout within the budget
+	// of the remediation policy, and records the interventions in the status.
+	RemediateStuckPods(ctx context.Context, logger logr.Logger, metaStatefulSets []appsv1.StatefulSet, frontendStatefulSets []appsv1.StatefulSet, computeStatefulSets []appsv1.StatefulSet, standaloneStatefulSet *appsv1.StatefulSet, pods []corev1.Pod) (ctrl.Result, error)
+
+	RemediateOpenKruiseStuckPods(ctx context.Context, logger logr.Logger, metaAdvancedStatefulSets []appsv1beta1.StatefulSet, frontendAdvancedStatefulSets []appsv1beta1.StatefulSet, computeAdvancedStatefulSets []appsv1beta1.StatefulSet, standaloneAdvancedStatefulSet *appsv1beta1.StatefulSet, pods []corev1.Pod) (ctrl.Result, error)
 }
 
 // Pre-defined actions in RisingWaveControllerManager.
@@ -687,15 +1048,30 @@ const (
 	RisingWaveAction_WaitBeforeCompactorCloneSetsReady                            = "WaitBeforeCompactorCloneSetsReady"
 	RisingWaveAction_SyncConfigConfigMap                                          = "SyncConfigConfigMap"
 	RisingWaveAction_SyncStandaloneService                                        = "SyncStandaloneService"
+	RisingWaveAction_SyncStandaloneHeadlessService                                = "SyncStandaloneHeadlessService"
 	RisingWaveAction_SyncStandaloneStatefulSet                                    = "SyncStandaloneStatefulSet"
 	RisingWaveAction_SyncStandaloneAdvancedStatefulSet                            = "SyncStandaloneAdvancedStatefulSet"
 	RisingWaveAction_WaitBeforeStandaloneStatefulSetReady                         = "WaitBeforeStandaloneStatefulSetReady"
 	RisingWaveAction_WaitBeforeStandaloneAdvancedStatefulSetReady                 = "WaitBeforeStandaloneAdvancedStatefulSetReady"
+	RisingWaveAction_StopStandaloneForMigration                                   = "StopStandaloneForMigration"
+	RisingWaveAction_SyncResourceRecommendations                                  = "SyncResourceRecommendations"
+	RisingWaveAction_CollectResourceGroupsAndSyncStatus                           = "CollectResourceGroupsAndSyncStatus"
+	RisingWaveAction_SyncNetworkPolicies                                          = "SyncNetworkPolicies"
+	RisingWaveAction_SyncIngresses                                                = "SyncIngresses"
 	RisingWaveAction_SyncServiceMonitor                                           = "SyncServiceMonitor"
+	RisingWaveAction_SyncPodMonitor                                               = "SyncPodMonitor"
+	RisingWaveAction_SyncHTTPRoutes                                               = "SyncHTTPRoutes"
+	RisingWaveAction_SyncPrometheusRule                                           = "SyncPrometheusRule"
+	RisingWaveAction_SyncGrafanaDashboardConfigMap                                = "SyncGrafanaDashboardConfigMap"
+	RisingWaveAction_SyncManagedMinIO                                             = "SyncManagedMinIO"
+	RisingWaveAction_SyncManagedPostgreSQL                                        = "SyncManagedPostgreSQL"
+	RisingWaveAction_WaitBeforeManagedStoresReady                                 = "WaitBeforeManagedStoresReady"
 	RisingWaveAction_CollectRunningStatisticsAndSyncStatus                        = "CollectRunningStatisticsAndSyncStatus"
 	RisingWaveAction_CollectOpenKruiseRunningStatisticsAndSyncStatus              = "CollectOpenKruiseRunningStatisticsAndSyncStatus"
 	RisingWaveAction_CollectRunningStatisticsAndSyncStatusForStandalone           = "CollectRunningStatisticsAndSyncStatusForStandalone"
 	RisingWaveAction_CollectOpenKruiseRunningStatisticsAndSyncStatusForStandalone = "CollectOpenKruiseRunningStatisticsAndSyncStatusForStandalone"
+	RisingWaveAction_RemediateStuckPods                                           = "RemediateStuckPods"
+	RisingWaveAction_RemediateOpenKruiseStuckPods                                 = "RemediateOpenKruiseStuckPods"
 )
 
 // RisingWaveControllerManager encapsulates the states and actions used by RisingWaveController.
@@ -1370,6 +1746,29 @@ func (m *RisingWaveControllerManager) SyncStandaloneService() ctrlkit.Action {
 	})
 }
 
+// SyncStandaloneHeadlessService generates the action of "SyncStandaloneHeadlessService".
+func (m *RisingWaveControllerManager) SyncStandaloneHeadlessService() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncStandaloneHeadlessService, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncStandaloneHeadlessService)
+
+		// Get states.
+		standaloneHeadlessService, err := m.state.GetStandaloneHeadlessService(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncStandaloneHeadlessService, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncStandaloneHeadlessService, map[string]runtime.Object{
+				"standaloneHeadlessService": standaloneHeadlessService,
+			})
+		}
+
+		return m.impl.SyncStandaloneHeadlessService(ctx, logger, standaloneHeadlessService)
+	})
+}
+
 // SyncStandaloneStatefulSet generates the action of "SyncStandaloneStatefulSet".
 func (m *RisingWaveControllerManager) SyncStandaloneStatefulSet() ctrlkit.Action {
 	return ctrlkit.NewAction(RisingWaveAction_SyncStandaloneStatefulSet, func(ctx context.Context) (result ctrl.Result, err error) {
@@ -1466,6 +1865,133 @@ func (m *RisingWaveControllerManager) WaitBeforeStandaloneAdvancedStatefulSetRea
 	})
 }
 
+// StopStandaloneForMigration generates the action of "StopStandaloneForMigration".
+func (m *RisingWaveControllerManager) StopStandaloneForMigration() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_StopStandaloneForMigration, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_StopStandaloneForMigration)
+
+		// Get states.
+		standaloneStatefulSet, err := m.state.GetStandaloneStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		standaloneAdvancedStatefulSet, err := m.state.GetStandaloneAdvancedStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_StopStandaloneForMigration, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_StopStandaloneForMigration, map[string]runtime.Object{
+				"standaloneStatefulSet":         standaloneStatefulSet,
+				"standaloneAdvancedStatefulSet": standaloneAdvancedStatefulSet,
+				"pods":                          &corev1.PodList{Items: pods},
+			})
+		}
+
+		return m.impl.StopStandaloneForMigration(ctx, logger, standaloneStatefulSet, standaloneAdvancedStatefulSet, pods)
+	})
+}
+
+// SyncResourceRecommendations generates the action of "SyncResourceRecommendations".
+func (m *RisingWaveControllerManager) SyncResourceRecommendations() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncResourceRecommendations, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncResourceRecommendations)
+
+		// Get states.
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncResourceRecommendations, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncResourceRecommendations, map[string]runtime.Object{
+				"pods": &corev1.PodList{Items: pods},
+			})
+		}
+
+		return m.impl.SyncResourceRecommendations(ctx, logger, pods)
+	})
+}
+
+// CollectResourceGroupsAndSyncStatus generates the action of "CollectResourceGroupsAndSyncStatus".
+func (m *RisingWaveControllerManager) CollectResourceGroupsAndSyncStatus() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_CollectResourceGroupsAndSyncStatus, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_CollectResourceGroupsAndSyncStatus)
+
+		// Get states.
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_CollectResourceGroupsAndSyncStatus, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_CollectResourceGroupsAndSyncStatus, map[string]runtime.Object{
+				"pods": &corev1.PodList{Items: pods},
+			})
+		}
+
+		return m.impl.CollectResourceGroupsAndSyncStatus(ctx, logger, pods)
+	})
+}
+
+// SyncNetworkPolicies generates the action of "SyncNetworkPolicies".
+func (m *RisingWaveControllerManager) SyncNetworkPolicies() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncNetworkPolicies, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncNetworkPolicies)
+
+		// Get states.
+		networkPolicies, err := m.state.GetNetworkPolicies(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncNetworkPolicies, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncNetworkPolicies, map[string]runtime.Object{
+				"networkPolicies": &networkingv1.NetworkPolicyList{Items: networkPolicies},
+			})
+		}
+
+		return m.impl.SyncNetworkPolicies(ctx, logger, networkPolicies)
+	})
+}
+
+// SyncIngresses generates the action of "SyncIngresses".
+func (m *RisingWaveControllerManager) SyncIngresses() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncIngresses, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncIngresses)
+
+		// Get states.
+		ingresses, err := m.state.GetIngresses(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncIngresses, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncIngresses, map[string]runtime.Object{
+				"ingresses": &networkingv1.IngressList{Items: ingresses},
+			})
+		}
+
+		return m.impl.SyncIngresses(ctx, logger, ingresses)
+	})
+}
+
 // SyncServiceMonitor generates the action of "SyncServiceMonitor".
 func (m *RisingWaveControllerManager) SyncServiceMonitor() ctrlkit.Action {
 	return ctrlkit.NewAction(RisingWaveAction_SyncServiceMonitor, func(ctx context.Context) (result ctrl.Result, err error) {
@@ -1489,6 +2015,197 @@ func (m *RisingWaveControllerManager) SyncServiceMonitor() ctrlkit.Action {
 	})
 }
 
+// SyncPodMonitor generates the action of "SyncPodMonitor".
+func (m *RisingWaveControllerManager) SyncPodMonitor() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncPodMonitor, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncPodMonitor)
+
+		// Get states.
+		podMonitor, err := m.state.GetPodMonitor(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncPodMonitor, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncPodMonitor, map[string]runtime.Object{
+				"podMonitor": podMonitor,
+			})
+		}
+
+		return m.impl.SyncPodMonitor(ctx, logger, podMonitor)
+	})
+}
+
+// SyncHTTPRoutes generates the action of "SyncHTTPRoutes".
+func (m *RisingWaveControllerManager) SyncHTTPRoutes() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncHTTPRoutes, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncHTTPRoutes)
+
+		// Get states.
+		httpRoutes, err := m.state.GetHttpRoutes(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncHTTPRoutes, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncHTTPRoutes, map[string]runtime.Object{
+				"httpRoutes": &gatewayv1.HTTPRouteList{Items: httpRoutes},
+			})
+		}
+
+		return m.impl.SyncHTTPRoutes(ctx, logger, httpRoutes)
+	})
+}
+
+// SyncPrometheusRule generates the action of "SyncPrometheusRule".
+func (m *RisingWaveControllerManager) SyncPrometheusRule() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncPrometheusRule, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncPrometheusRule)
+
+		// Get states.
+		prometheusRule, err := m.state.GetPrometheusRule(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncPrometheusRule, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncPrometheusRule, map[string]runtime.Object{
+				"prometheusRule": prometheusRule,
+			})
+		}
+
+		return m.impl.SyncPrometheusRule(ctx, logger, prometheusRule)
+	})
+}
+
+// SyncGrafanaDashboardConfigMap generates the action of "SyncGrafanaDashboardConfigMap".
+func (m *RisingWaveControllerManager) SyncGrafanaDashboardConfigMap() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncGrafanaDashboardConfigMap, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncGrafanaDashboardConfigMap)
+
+		// Get states.
+		grafanaDashboardConfigMap, err := m.state.GetGrafanaDashboardConfigMap(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncGrafanaDashboardConfigMap, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncGrafanaDashboardConfigMap, map[string]runtime.Object{
+				"grafanaDashboardConfigMap": grafanaDashboardConfigMap,
+			})
+		}
+
+		return m.impl.SyncGrafanaDashboardConfigMap(ctx, logger, grafanaDashboardConfigMap)
+	})
+}
+
+// SyncManagedMinIO generates the action of "SyncManagedMinIO".
+func (m *RisingWaveControllerManager) SyncManagedMinIO() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncManagedMinIO, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncManagedMinIO)
+
+		// Get states.
+		managedMinIOSecret, err := m.state.GetManagedMinIOSecret(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		managedMinIOService, err := m.state.GetManagedMinIOService(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		managedMinIOStatefulSet, err := m.state.GetManagedMinIOStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncManagedMinIO, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncManagedMinIO, map[string]runtime.Object{
+				"managedMinIOSecret":      managedMinIOSecret,
+				"managedMinIOService":     managedMinIOService,
+				"managedMinIOStatefulSet": managedMinIOStatefulSet,
+			})
+		}
+
+		return m.impl.SyncManagedMinIO(ctx, logger, managedMinIOSecret, managedMinIOService, managedMinIOStatefulSet)
+	})
+}
+
+// SyncManagedPostgreSQL generates the action of "SyncManagedPostgreSQL".
+func (m *RisingWaveControllerManager) SyncManagedPostgreSQL() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_SyncManagedPostgreSQL, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_SyncManagedPostgreSQL)
+
+		// Get states.
+		managedPostgreSQLSecret, err := m.state.GetManagedPostgreSQLSecret(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		managedPostgreSQLService, err := m.state.GetManagedPostgreSQLService(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		managedPostgreSQLStatefulSet, err := m.state.GetManagedPostgreSQLStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_SyncManagedPostgreSQL, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_SyncManagedPostgreSQL, map[string]runtime.Object{
+				"managedPostgreSQLSecret":      managedPostgreSQLSecret,
+				"managedPostgreSQLService":     managedPostgreSQLService,
+				"managedPostgreSQLStatefulSet": managedPostgreSQLStatefulSet,
+			})
+		}
+
+		return m.impl.SyncManagedPostgreSQL(ctx, logger, managedPostgreSQLSecret, managedPostgreSQLService, managedPostgreSQLStatefulSet)
+	})
+}
+
+// WaitBeforeManagedStoresReady generates the action of "WaitBeforeManagedStoresReady".
+func (m *RisingWaveControllerManager) WaitBeforeManagedStoresReady() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_WaitBeforeManagedStoresReady, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_WaitBeforeManagedStoresReady)
+
+		// Get states.
+		managedMinIOStatefulSet, err := m.state.GetManagedMinIOStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		managedPostgreSQLStatefulSet, err := m.state.GetManagedPostgreSQLStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_WaitBeforeManagedStoresReady, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_WaitBeforeManagedStoresReady, map[string]runtime.Object{
+				"managedMinIOStatefulSet":      managedMinIOStatefulSet,
+				"managedPostgreSQLStatefulSet": managedPostgreSQLStatefulSet,
+			})
+		}
+
+		return m.impl.WaitBeforeManagedStoresReady(ctx, logger, managedMinIOStatefulSet, managedPostgreSQLStatefulSet)
+	})
+}
+
 // CollectRunningStatisticsAndSyncStatus generates the action of "CollectRunningStatisticsAndSyncStatus".
 func (m *RisingWaveControllerManager) CollectRunningStatisticsAndSyncStatus() ctrlkit.Action {
 	return ctrlkit.NewAction(RisingWaveAction_CollectRunningStatisticsAndSyncStatus, func(ctx context.Context) (result ctrl.Result, err error) {
@@ -1550,6 +2267,11 @@ func (m *RisingWaveControllerManager) CollectRunningStatisticsAndSyncStatus() ct
 			return ctrlkit.RequeueIfError(err)
 		}
 
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
 		// Invoke action.
 		if m.hook != nil {
 			defer func() {
@@ -1567,10 +2289,11 @@ func (m *RisingWaveControllerManager) CollectRunningStatisticsAndSyncStatus() ct
 				"computeStatefulSets":     &appsv1.StatefulSetList{Items: computeStatefulSets},
 				"compactorDeployments":    &appsv1.DeploymentList{Items: compactorDeployments},
 				"configConfigMap":         configConfigMap,
+				"pods":                    &corev1.PodList{Items: pods},
 			})
 		}
 
-		return m.impl.CollectRunningStatisticsAndSyncStatus(ctx, logger, frontendService, frontendHeadlessService, metaService, computeService, compactorService, metaStatefulSets, frontendDeployments, frontendStatefulSets, computeStatefulSets, compactorDeployments, configConfigMap)
+		return m.impl.CollectRunningStatisticsAndSyncStatus(ctx, logger, frontendService, frontendHeadlessService, metaService, computeService, compactorService, metaStatefulSets, frontendDeployments, frontendStatefulSets, computeStatefulSets, compactorDeployments, configConfigMap, pods)
 	})
 }
 
@@ -1635,6 +2358,11 @@ func (m *RisingWaveControllerManager) CollectOpenKruiseRunningStatisticsAndSyncS
 			return ctrlkit.RequeueIfError(err)
 		}
 
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
 		// Invoke action.
 		if m.hook != nil {
 			defer func() {
@@ -1652,10 +2380,11 @@ func (m *RisingWaveControllerManager) CollectOpenKruiseRunningStatisticsAndSyncS
 				"computeAdvancedStatefulSets":  &appsv1beta1.StatefulSetList{Items: computeAdvancedStatefulSets},
 				"compactorCloneSets":           &appsv1alpha1.CloneSetList{Items: compactorCloneSets},
 				"configConfigMap":              configConfigMap,
+				"pods":                         &corev1.PodList{Items: pods},
 			})
 		}
 
-		return m.impl.CollectOpenKruiseRunningStatisticsAndSyncStatus(ctx, logger, frontendService, frontendHeadlessService, metaService, computeService, compactorService, metaAdvancedStatefulSets, frontendCloneSets, frontendAdvancedStatefulSets, computeAdvancedStatefulSets, compactorCloneSets, configConfigMap)
+		return m.impl.CollectOpenKruiseRunningStatisticsAndSyncStatus(ctx, logger, frontendService, frontendHeadlessService, metaService, computeService, compactorService, metaAdvancedStatefulSets, frontendCloneSets, frontendAdvancedStatefulSets, computeAdvancedStatefulSets, compactorCloneSets, configConfigMap, pods)
 	})
 }
 
@@ -1680,6 +2409,11 @@ func (m *RisingWaveControllerManager) CollectRunningStatisticsAndSyncStatusForSt
 			return ctrlkit.RequeueIfError(err)
 		}
 
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
 		// Invoke action.
 		if m.hook != nil {
 			defer func() {
@@ -1689,10 +2423,11 @@ func (m *RisingWaveControllerManager) CollectRunningStatisticsAndSyncStatusForSt
 				"standaloneService":     standaloneService,
 				"standaloneStatefulSet": standaloneStatefulSet,
 				"configConfigMap":       configConfigMap,
+				"pods":                  &corev1.PodList{Items: pods},
 			})
 		}
 
-		return m.impl.CollectRunningStatisticsAndSyncStatusForStandalone(ctx, logger, standaloneService, standaloneStatefulSet, configConfigMap)
+		return m.impl.CollectRunningStatisticsAndSyncStatusForStandalone(ctx, logger, standaloneService, standaloneStatefulSet, configConfigMap, pods)
 	})
 }
 
@@ -1717,6 +2452,11 @@ func (m *RisingWaveControllerManager) CollectOpenKruiseRunningStatisticsAndSyncS
 			return ctrlkit.RequeueIfError(err)
 		}
 
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
 		// Invoke action.
 		if m.hook != nil {
 			defer func() {
@@ -1726,10 +2466,105 @@ func (m *RisingWaveControllerManager) CollectOpenKruiseRunningStatisticsAndSyncS
 				"standaloneService":             standaloneService,
 				"standaloneAdvancedStatefulSet": standaloneAdvancedStatefulSet,
 				"configConfigMap":               configConfigMap,
+				"pods":                          &corev1.PodList{Items: pods},
+			})
+		}
+
+		return m.impl.CollectOpenKruiseRunningStatisticsAndSyncStatusForStandalone(ctx, logger, standaloneService, standaloneAdvancedStatefulSet, configConfigMap, pods)
+	})
+}
+
+// RemediateStuckPods generates the action of "RemediateStuckPods".
+func (m *RisingWaveControllerManager) RemediateStuckPods() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_RemediateStuckPods, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_RemediateStuckPods)
+
+		// Get states.
+		metaStatefulSets, err := m.state.GetMetaStatefulSets(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		frontendStatefulSets, err := m.state.GetFrontendStatefulSets(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		computeStatefulSets, err := m.state.GetComputeStatefulSets(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		standaloneStatefulSet, err := m.state.GetStandaloneStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_RemediateStuckPods, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_RemediateStuckPods, map[string]runtime.Object{
+				"metaStatefulSets":      &appsv1.StatefulSetList{Items: metaStatefulSets},
+				"frontendStatefulSets":  &appsv1.StatefulSetList{Items: frontendStatefulSets},
+				"computeStatefulSets":   &appsv1.StatefulSetList{Items: computeStatefulSets},
+				"standaloneStatefulSet": standaloneStatefulSet,
+				"pods":                  &corev1.PodList{Items: pods},
+			})
+		}
+
+		return m.impl.RemediateStuckPods(ctx, logger, metaStatefulSets, frontendStatefulSets, computeStatefulSets, standaloneStatefulSet, pods)
+	})
+}
+
+// RemediateOpenKruiseStuckPods generates the action of "RemediateOpenKruiseStuckPods".
+func (m *RisingWaveControllerManager) RemediateOpenKruiseStuckPods() ctrlkit.Action {
+	return ctrlkit.NewAction(RisingWaveAction_RemediateOpenKruiseStuckPods, func(ctx context.Context) (result ctrl.Result, err error) {
+		logger := m.logger.WithValues("action", RisingWaveAction_RemediateOpenKruiseStuckPods)
+
+		// Get states.
+		metaAdvancedStatefulSets, err := m.state.GetMetaAdvancedStatefulSets(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		frontendAdvancedStatefulSets, err := m.state.GetFrontendAdvancedStatefulSets(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		computeAdvancedStatefulSets, err := m.state.GetComputeAdvancedStatefulSets(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		standaloneAdvancedStatefulSet, err := m.state.GetStandaloneAdvancedStatefulSet(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		pods, err := m.state.GetPods(ctx)
+		if err != nil {
+			return ctrlkit.RequeueIfError(err)
+		}
+
+		// Invoke action.
+		if m.hook != nil {
+			defer func() { m.hook.PostRun(ctx, logger, RisingWaveAction_RemediateOpenKruiseStuckPods, result, err) }()
+			m.hook.PreRun(ctx, logger, RisingWaveAction_RemediateOpenKruiseStuckPods, map[string]runtime.Object{
+				"metaAdvancedStatefulSets":      &appsv1beta1.StatefulSetList{Items: metaAdvancedStatefulSets},
+				"frontendAdvancedStatefulSets":  &appsv1beta1.StatefulSetList{Items: frontendAdvancedStatefulSets},
+				"computeAdvancedStatefulSets":   &appsv1beta1.StatefulSetList{Items: computeAdvancedStatefulSets},
+				"standaloneAdvancedStatefulSet": standaloneAdvancedStatefulSet,
+				"pods":                          &corev1.PodList{Items: pods},
 			})
 		}
 
-		return m.impl.CollectOpenKruiseRunningStatisticsAndSyncStatusForStandalone(ctx, logger, standaloneService, standaloneAdvancedStatefulSet, configConfigMap)
+		return m.impl.RemediateOpenKruiseStuckPods(ctx, logger, metaAdvancedStatefulSets, frontendAdvancedStatefulSets, computeAdvancedStatefulSets, standaloneAdvancedStatefulSet, pods)
 	})
 }
 
@@ -18,11 +18,18 @@ package manager
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	kruiseappsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
@@ -31,13 +38,17 @@ import (
 	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/risingwavelabs/risingwave-operator/pkg/event"
 
@@ -45,17 +56,33 @@ import (
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	pb "github.com/risingwavelabs/risingwave-operator/pkg/controller/proto"
 	"github.com/risingwavelabs/risingwave-operator/pkg/factory"
+	"github.com/risingwavelabs/risingwave-operator/pkg/health"
+	"github.com/risingwavelabs/risingwave-operator/pkg/maintenance"
+	"github.com/risingwavelabs/risingwave-operator/pkg/meta"
 	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/recommender"
+	"github.com/risingwavelabs/risingwave-operator/pkg/remediation"
 	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
 )
 
 type risingWaveControllerManagerImpl struct {
-	client             client.Client
-	risingwaveManager  *object.RisingWaveManager
-	objectFactory      *factory.RisingWaveObjectFactory
-	eventMessageStore  *event.MessageStore
-	forceUpdateEnabled bool
+	client                    client.Client
+	risingwaveManager         *object.RisingWaveManager
+	objectFactory             *factory.RisingWaveObjectFactory
+	eventMessageStore         *event.MessageStore
+	forceUpdateEnabled        bool
+	inPlacePodResizeAvailable bool
+	operatorNamespace         string
+
+	mu sync.Mutex
+
+	// pendingMaintenance are the workloads with the disruptive changes deferred in this round.
+	pendingMaintenance []string
+
+	// collectResourceGroups collects the resource groups from the meta service at the given address.
+	collectResourceGroups func(ctx context.Context, addr string) ([]risingwavev1alpha1.RisingWaveResourceGroupStatus, error)
 }
 
 func getStandaloneStatusUtil(rw *risingwavev1alpha1.RisingWave, logger logr.Logger, readyReplicas int32) risingwavev1alpha1.ComponentReplicasStatus {
@@ -153,6 +180,113 @@ func buildNodeGroupStatus[T any, TP ptrAsObject[T], G any](groups []G, nameAndRe
 	return status
 }
 
+// reportComputeRoles sets the roles of the compute node groups in the status. Groups not found in the spec are skipped.
+func (mgr *risingWaveControllerManagerImpl) reportComputeRoles(status risingwavev1alpha1.ComponentReplicasStatus) risingwavev1alpha1.ComponentReplicasStatus {
+	for i := range status.Groups {
+		if nodeGroup := mgr.risingwaveManager.GetNodeGroup(consts.ComponentCompute, status.Groups[i].Name); nodeGroup != nil {
+			status.Groups[i].Role = mgr.risingwaveManager.GetComputeRole(nodeGroup)
+		}
+	}
+
+	return status
+}
+
+// healthEventTypes are the event types of the health reasons.
+var healthEventTypes = map[risingwavev1alpha1.RisingWaveHealthReason]consts.RisingWaveEventType{
+	risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff:         consts.RisingWaveEventTypeCrashLoopBackOff,
+	risingwavev1alpha1.RisingWaveHealthReasonOOMKilled:                consts.RisingWaveEventTypeOOMKilled,
+	risingwavev1alpha1.RisingWaveHealthReasonImagePullBackOff:         consts.RisingWaveEventTypeImagePullBackOff,
+	risingwavev1alpha1.RisingWaveHealthReasonUnschedulable:            consts.RisingWaveEventTypeUnschedulable,
+	risingwavev1alpha1.RisingWaveHealthReasonProgressDeadlineExceeded: consts.RisingWaveEventTypeProgressDeadlineExceeded,
+}
+
+// reportHealth analyzes the Pods and the Deployments, syncs the health issues into the status, and sets the messages
+// of the warning events of the issues found.
+func (mgr *risingWaveControllerManagerImpl) reportHealth(pods []corev1.Pod, deployments []appsv1.Deployment) {
+	analyzer := &health.Analyzer{
+		Now: time.Now(),
+		// Pods of the node groups expanded into zones are labeled with the groups of the zones.
+		NodeGroupOf: func(component, group string) string {
+			for _, nodeGroup := range mgr.risingwaveManager.GetNodeGroups(component) {
+				for _, g := range mgr.risingwaveManager.ExpandNodeGroup(&nodeGroup) {
+					if g.Name == group {
+						return nodeGroup.Name
+					}
+				}
+			}
+
+			return group
+		},
+	}
+	issues := analyzer.Analyze(pods, deployments)
+
+	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+		status.Health = issues
+	})
+
+	for reason, eventType := range healthEventTypes {
+		if summary := health.Summarize(issues, reason); summary != "" {
+			mgr.eventMessageStore.SetMessage(eventType.Name, summary)
+		}
+	}
+}
+
+// reportZones folds the groups of the zones back into the node groups that are expanded into zones, and reports the
+// replicas of each zone.
+func (mgr *risingWaveControllerManagerImpl) reportZones(component string, status risingwavev1alpha1.ComponentReplicasStatus) risingwavev1alpha1.ComponentReplicasStatus {
+	zoneOfGroup := make(map[string]lo.Tuple2[string, string])
+	for _, nodeGroup := range mgr.risingwaveManager.GetNodeGroups(component) {
+		_, zones := mgr.risingwaveManager.GetZones(&nodeGroup)
+		for _, zone := range zones {
+			zoneOfGroup[object.ZonedNodeGroupName(nodeGroup.Name, zone)] = lo.T2(nodeGroup.Name, zone)
+		}
+	}
+	if len(zoneOfGroup) == 0 {
+		return status
+	}
+
+	groups := make([]risingwavev1alpha1.ComponentGroupReplicasStatus, 0, len(status.Groups))
+	zonedGroups := make(map[string]int)
+	for _, group := range status.Groups {
+		ref, ok := zoneOfGroup[group.Name]
+		if !ok {
+			groups = append(groups, group)
+
+			continue
+		}
+
+		nodeGroup, zone := ref.Unpack()
+		idx, found := zonedGroups[nodeGroup]
+		if !found {
+			idx = len(groups)
+			zonedGroups[nodeGroup] = idx
+			groups = append(groups, risingwavev1alpha1.ComponentGroupReplicasStatus{
+				Name:   nodeGroup,
+				Exists: true,
+			})
+		}
+
+		g := &groups[idx]
+		g.Target += group.Target
+		g.Running += group.Running
+		// The group is missing if any of its zones is missing.
+		g.Exists = g.Exists && group.Exists
+		g.Zones = append(g.Zones, risingwavev1alpha1.ComponentZoneReplicasStatus{
+			Name:    zone,
+			Target:  group.Target,
+			Running: group.Running,
+			Exists:  group.Exists,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	status.Groups = groups
+
+	return status
+}
+
 func isGroupMissing(group risingwavev1alpha1.ComponentGroupReplicasStatus) bool {
 	return !group.Exists
 }
@@ -202,7 +336,7 @@ func buildMetaStoreType(metaStore *risingwavev1alpha1.RisingWaveMetaStoreBackend
 		return risingwavev1alpha1.RisingWaveMetaStoreBackendTypeSQLite
 	case metaStore.MySQL != nil:
 		return risingwavev1alpha1.RisingWaveMetaStoreBackendTypeMySQL
-	case metaStore.PostgreSQL != nil:
+	case metaStore.PostgreSQL != nil, metaStore.ManagedPostgreSQL != nil:
 		return risingwavev1alpha1.RisingWaveMetaStoreBackendTypePostgreSQL
 	default:
 		return risingwavev1alpha1.RisingWaveMetaStoreBackendTypeUnknown
@@ -213,7 +347,7 @@ func buildStateStoreType(stateStore *risingwavev1alpha1.RisingWaveStateStoreBack
 	switch {
 	case ptr.Deref(stateStore.Memory, false):
 		return risingwavev1alpha1.RisingWaveStateStoreBackendTypeMemory
-	case stateStore.MinIO != nil:
+	case stateStore.MinIO != nil, stateStore.ManagedMinIO != nil:
 		return risingwavev1alpha1.RisingWaveStateStoreBackendTypeMinIO
 	case stateStore.S3 != nil:
 		return risingwavev1alpha1.RisingWaveStateStoreBackendTypeS3
@@ -251,11 +385,10 @@ func (mgr *risingWaveControllerManagerImpl) CollectOpenKruiseRunningStatisticsAn
 	computeStatefulSets []kruiseappsv1beta1.StatefulSet,
 	compactorCloneSets []kruiseappsv1alpha1.CloneSet,
 	configConfigMap *corev1.ConfigMap,
+	pods []corev1.Pod,
 ) (reconcile.Result, error) {
 	risingwave := mgr.risingwaveManager.RisingWave()
 
-	componentsSpec := &risingwave.Spec.Components
-
 	// Update the replicas and storage status.
 	getNameAndReplicasFromNodeGroup := func(g *risingwavev1alpha1.RisingWaveNodeGroup) (string, int32) {
 		return g.Name, g.Replicas
@@ -268,15 +401,15 @@ func (mgr *risingWaveControllerManagerImpl) CollectOpenKruiseRunningStatisticsAn
 	}
 	var frontendReplicas risingwavev1alpha1.ComponentReplicasStatus
 	if frontendStatefulSetEnabled(risingwave) {
-		frontendReplicas = buildNodeGroupStatus(componentsSpec.Frontend.NodeGroups, getNameAndReplicasFromNodeGroup, frontendAdvancedStatefulSets, getGroupAndReadyReplicasForStatefulSet)
+		frontendReplicas = mgr.reportZones(consts.ComponentFrontend, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentFrontend), getNameAndReplicasFromNodeGroup, frontendAdvancedStatefulSets, getGroupAndReadyReplicasForStatefulSet))
 	} else {
-		frontendReplicas = buildNodeGroupStatus(componentsSpec.Frontend.NodeGroups, getNameAndReplicasFromNodeGroup, frontendCloneSets, getGroupAndReadyReplicasForCloneSets)
+		frontendReplicas = mgr.reportZones(consts.ComponentFrontend, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentFrontend), getNameAndReplicasFromNodeGroup, frontendCloneSets, getGroupAndReadyReplicasForCloneSets))
 	}
 	componentReplicas := risingwavev1alpha1.RisingWaveComponentsReplicasStatus{
-		Meta:       buildNodeGroupStatus(componentsSpec.Meta.NodeGroups, getNameAndReplicasFromNodeGroup, metaAdvancedStatefulSets, getGroupAndReadyReplicasForStatefulSet),
+		Meta:       mgr.reportZones(consts.ComponentMeta, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentMeta), getNameAndReplicasFromNodeGroup, metaAdvancedStatefulSets, getGroupAndReadyReplicasForStatefulSet)),
 		Frontend:   frontendReplicas,
-		Compactor:  buildNodeGroupStatus(componentsSpec.Compactor.NodeGroups, getNameAndReplicasFromNodeGroup, compactorCloneSets, getGroupAndReadyReplicasForCloneSets),
-		Compute:    buildNodeGroupStatus(componentsSpec.Compute.NodeGroups, getNameAndReplicasFromNodeGroup, computeStatefulSets, getGroupAndReadyReplicasForStatefulSet),
+		Compactor:  mgr.reportZones(consts.ComponentCompactor, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentCompactor), getNameAndReplicasFromNodeGroup, compactorCloneSets, getGroupAndReadyReplicasForCloneSets)),
+		Compute:    mgr.reportComputeRoles(mgr.reportZones(consts.ComponentCompute, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentCompute), getNameAndReplicasFromNodeGroup, computeStatefulSets, getGroupAndReadyReplicasForStatefulSet))),
 		Standalone: risingwavev1alpha1.ComponentReplicasStatus{Target: 0, Running: 0},
 	}
 
@@ -300,6 +433,9 @@ func (mgr *risingWaveControllerManagerImpl) CollectOpenKruiseRunningStatisticsAn
 		status.ComponentReplicas = componentReplicas
 	})
 
+	// Report the health issues. CloneSets have no progress deadlines.
+	mgr.reportHealth(pods, nil)
+
 	// If any of these states is missing or any of the groups is missing, turn the condition Running to false.
 	recoverConditionAndReasons := []struct {
 		cond      bool
@@ -384,11 +520,10 @@ func (mgr *risingWaveControllerManagerImpl) CollectRunningStatisticsAndSyncStatu
 	computeStatefulSets []appsv1.StatefulSet,
 	compactorDeployments []appsv1.Deployment,
 	configConfigMap *corev1.ConfigMap,
+	pods []corev1.Pod,
 ) (reconcile.Result, error) {
 	risingwave := mgr.risingwaveManager.RisingWave()
 
-	componentsSpec := &risingwave.Spec.Components
-
 	getNameAndReplicasFromNodeGroup := func(g *risingwavev1alpha1.RisingWaveNodeGroup) (string, int32) {
 		return g.Name, g.Replicas
 	}
@@ -400,15 +535,15 @@ func (mgr *risingWaveControllerManagerImpl) CollectRunningStatisticsAndSyncStatu
 	}
 	var frontendReplicas risingwavev1alpha1.ComponentReplicasStatus
 	if frontendStatefulSetEnabled(risingwave) {
-		frontendReplicas = buildNodeGroupStatus(componentsSpec.Frontend.NodeGroups, getNameAndReplicasFromNodeGroup, frontendStatefulSets, getGroupAndReadyReplicasForStatefulSet)
+		frontendReplicas = mgr.reportZones(consts.ComponentFrontend, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentFrontend), getNameAndReplicasFromNodeGroup, frontendStatefulSets, getGroupAndReadyReplicasForStatefulSet))
 	} else {
-		frontendReplicas = buildNodeGroupStatus(componentsSpec.Frontend.NodeGroups, getNameAndReplicasFromNodeGroup, frontendDeployments, getGroupAndReadyReplicasForDeployment)
+		frontendReplicas = mgr.reportZones(consts.ComponentFrontend, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentFrontend), getNameAndReplicasFromNodeGroup, frontendDeployments, getGroupAndReadyReplicasForDeployment))
 	}
 	componentReplicas := risingwavev1alpha1.RisingWaveComponentsReplicasStatus{
-		Meta:       buildNodeGroupStatus(componentsSpec.Meta.NodeGroups, getNameAndReplicasFromNodeGroup, metaStatefulSets, getGroupAndReadyReplicasForStatefulSet),
+		Meta:       mgr.reportZones(consts.ComponentMeta, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentMeta), getNameAndReplicasFromNodeGroup, metaStatefulSets, getGroupAndReadyReplicasForStatefulSet)),
 		Frontend:   frontendReplicas,
-		Compactor:  buildNodeGroupStatus(componentsSpec.Compactor.NodeGroups, getNameAndReplicasFromNodeGroup, compactorDeployments, getGroupAndReadyReplicasForDeployment),
-		Compute:    buildNodeGroupStatus(componentsSpec.Compute.NodeGroups, getNameAndReplicasFromNodeGroup, computeStatefulSets, getGroupAndReadyReplicasForStatefulSet),
+		Compactor:  mgr.reportZones(consts.ComponentCompactor, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentCompactor), getNameAndReplicasFromNodeGroup, compactorDeployments, getGroupAndReadyReplicasForDeployment)),
+		Compute:    mgr.reportComputeRoles(mgr.reportZones(consts.ComponentCompute, buildNodeGroupStatus(mgr.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentCompute), getNameAndReplicasFromNodeGroup, computeStatefulSets, getGroupAndReadyReplicasForStatefulSet))),
 		Standalone: risingwavev1alpha1.ComponentReplicasStatus{Target: 0, Running: 0},
 	}
 
@@ -432,6 +567,9 @@ func (mgr *risingWaveControllerManagerImpl) CollectRunningStatisticsAndSyncStatu
 		status.ComponentReplicas = componentReplicas
 	})
 
+	// Report the health issues. Only the Deployments have the progress deadlines.
+	mgr.reportHealth(pods, slices.Concat(frontendDeployments, compactorDeployments))
+
 	// If any of these states is missing or any of the groups is missing, turn the condition Running to false.
 	recoverConditionAndReasons := []struct {
 		cond      bool
@@ -520,7 +658,7 @@ func syncComponentGroupWorkloads[T any, TP ptrAsObject[T]](
 
 	var expectedGroupSet map[string]int
 	if enabled {
-		expectedGroupSet = buildKeyMapFromList(mgr.risingwaveManager.GetNodeGroups(component), getNameFromNodeGroup)
+		expectedGroupSet = buildKeyMapFromList(mgr.risingwaveManager.GetWorkloadNodeGroups(component), getNameFromNodeGroup)
 	}
 	// Decide to delete or to sync.
 	observedGroupSet := make(map[string]int)
@@ -728,7 +866,7 @@ func waitComponentGroupWorkloadsReady[T any, TP ptrAsObject[T]](ctx context.Cont
 }
 
 func (mgr *risingWaveControllerManagerImpl) buildExpectedGroupSet(component string) map[string]int {
-	return buildKeyMapFromList(mgr.risingwaveManager.GetNodeGroups(component), getNameFromNodeGroup)
+	return buildKeyMapFromList(mgr.risingwaveManager.GetWorkloadNodeGroups(component), getNameFromNodeGroup)
 }
 
 // WaitBeforeCompactorDeploymentsReady implements RisingWaveControllerManagerImpl.
@@ -860,7 +998,53 @@ func (mgr *risingWaveControllerManagerImpl) isObjectSynced(obj client.Object) bo
 
 	// Use larger than to avoid cases that we observed an old RisingWave object and
 	// a newer object.
-	return observedGeneration >= currentGeneration
+	if observedGeneration < currentGeneration {
+		return false
+	}
+
+	// The applied resource recommendations are kept in the status and don't bump the generation.
+	if maintenance.IsWorkload(obj) && obj.GetAnnotations()[consts.AnnotationAppliedResourcesHash] !=
+		mgr.appliedResourceRecommendationsHash(obj.GetLabels()[consts.LabelRisingWaveComponent]) {
+		return false
+	}
+
+	// Apply the deferred changes once they are allowed.
+	return !maintenance.IsPending(obj) || !mgr.isMaintenanceAllowed()
+}
+
+// isMaintenanceAllowed returns true if the disruptive changes of the workloads can be applied now.
+func (mgr *risingWaveControllerManagerImpl) isMaintenanceAllowed() bool {
+	allowed, _ := maintenance.IsAllowed(mgr.risingwaveManager.RisingWave(), time.Now())
+
+	return allowed
+}
+
+// deferDisruptiveChanges keeps the Pod template of the current workload in the new one if the changes are disruptive
+// and not allowed now, and reports the workload in the PendingMaintenance condition. It returns true if deferred.
+func (mgr *risingWaveControllerManagerImpl) deferDisruptiveChanges(obj, newObj client.Object, kind string) bool {
+	risingwave := mgr.risingwaveManager.RisingWave()
+
+	allowed, nextOpen := maintenance.IsAllowed(risingwave, time.Now())
+	if allowed || !maintenance.IsDisruptive(obj, newObj) {
+		return false
+	}
+
+	maintenance.Defer(obj, newObj, risingwave.Generation)
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	mgr.pendingMaintenance = append(mgr.pendingMaintenance, kind+"/"+newObj.GetName())
+	slices.Sort(mgr.pendingMaintenance)
+	mgr.risingwaveManager.UpdateCondition(risingwavev1alpha1.RisingWaveCondition{
+		Type:   risingwavev1alpha1.RisingWaveConditionPendingMaintenance,
+		Status: metav1.ConditionTrue,
+		Reason: "OutsideMaintenanceWindows",
+		Message: fmt.Sprintf("Disruptive changes of %s are deferred until the next maintenance window at %s",
+			strings.Join(mgr.pendingMaintenance, ", "), nextOpen.UTC().Format(time.RFC3339)),
+	})
+
+	return true
 }
 
 //nolint:gocritic
@@ -908,6 +1092,8 @@ func (mgr *risingWaveControllerManagerImpl) syncObject(ctx context.Context, obj
 		}
 
 		newObj = ensureTheSameObject(obj, newObj)
+		mgr.setAppliedResourceRecommendationsHash(newObj)
+		maintenance.SetPodTemplateHash(newObj)
 
 		gvk, err := apiutil.GVKForObject(newObj, scheme)
 		if err != nil {
@@ -933,6 +1119,12 @@ func (mgr *risingWaveControllerManagerImpl) syncObject(ctx context.Context, obj
 		}
 
 		newObj = ensureTheSameObject(obj, newObj)
+		mgr.setAppliedResourceRecommendationsHash(newObj)
+		maintenance.SetPodTemplateHash(newObj)
+		if mgr.deferDisruptiveChanges(obj, newObj, gvk.Kind) {
+			logger.Info("Defer the disruptive changes of "+gvk.Kind+" until the maintenance window", "object", utils.GetNamespacedName(newObj))
+		}
+
 		// Set the resource version for update.
 		newObj.SetResourceVersion(obj.GetResourceVersion())
 		logger.Info("Update the object of "+gvk.Kind, "object", utils.GetNamespacedName(newObj),
@@ -1086,11 +1278,372 @@ func (mgr *risingWaveControllerManagerImpl) SyncConfigConfigMap(ctx context.Cont
 
 // SyncServiceMonitor implements RisingWaveControllerManagerImpl.
 func (mgr *risingWaveControllerManagerImpl) SyncServiceMonitor(ctx context.Context, logger logr.Logger, serviceMonitor *monitoringv1.ServiceMonitor) (reconcile.Result, error) {
+	if mgr.risingwaveManager.GetMonitorKind() != risingwavev1alpha1.RisingWaveMonitorKindServiceMonitor {
+		if serviceMonitor != nil {
+			logger.Info("Delete the ServiceMonitor", "servicemonitor", serviceMonitor.Name)
+			err := mgr.client.Delete(ctx, serviceMonitor, client.Preconditions{UID: &serviceMonitor.UID})
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete service monitor", client.IgnoreNotFound(err))
+		}
+
+		return ctrlkit.Continue()
+	}
+
 	err := syncObject(mgr, ctx, serviceMonitor, mgr.objectFactory.NewServiceMonitor, logger)
 
 	return ctrlkit.RequeueIfErrorAndWrap("unable to sync service monitor", err)
 }
 
+// SyncPodMonitor implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncPodMonitor(ctx context.Context, logger logr.Logger, podMonitor *monitoringv1.PodMonitor) (reconcile.Result, error) {
+	if mgr.risingwaveManager.GetMonitorKind() != risingwavev1alpha1.RisingWaveMonitorKindPodMonitor {
+		if podMonitor != nil {
+			logger.Info("Delete the PodMonitor", "podmonitor", podMonitor.Name)
+			err := mgr.client.Delete(ctx, podMonitor, client.Preconditions{UID: &podMonitor.UID})
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete pod monitor", client.IgnoreNotFound(err))
+		}
+
+		return ctrlkit.Continue()
+	}
+
+	err := syncObject(mgr, ctx, podMonitor, mgr.objectFactory.NewPodMonitor, logger)
+
+	return ctrlkit.RequeueIfErrorAndWrap("unable to sync pod monitor", err)
+}
+
+// SyncNetworkPolicies implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncNetworkPolicies(ctx context.Context, logger logr.Logger, networkPolicies []networkingv1.NetworkPolicy) (reconcile.Result, error) {
+	expectedNetworkPolicies := mgr.objectFactory.NewNetworkPolicies(mgr.operatorNamespace)
+
+	// Delete the ones not expected.
+	for i := range networkPolicies {
+		networkPolicy := &networkPolicies[i]
+		if lo.ContainsBy(expectedNetworkPolicies, func(np *networkingv1.NetworkPolicy) bool { return np.Name == networkPolicy.Name }) {
+			continue
+		}
+
+		logger.Info("Delete the NetworkPolicy", "networkpolicy", networkPolicy.Name)
+		if err := mgr.client.Delete(ctx, networkPolicy, client.Preconditions{UID: &networkPolicy.UID}); client.IgnoreNotFound(err) != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete network policy", err)
+		}
+	}
+
+	for _, expected := range expectedNetworkPolicies {
+		var networkPolicy *networkingv1.NetworkPolicy
+		if np, ok := lo.Find(networkPolicies, func(np networkingv1.NetworkPolicy) bool { return np.Name == expected.Name }); ok {
+			networkPolicy = &np
+		}
+
+		if err := syncObject(mgr, ctx, networkPolicy, func() *networkingv1.NetworkPolicy { return expected }, logger); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync network policy", err)
+		}
+	}
+
+	return ctrlkit.Continue()
+}
+
+// externalEndpoints are the endpoints that can be exposed outside the cluster.
+var externalEndpoints = []string{consts.ExternalEndpointDashboard, consts.ExternalEndpointWebhook}
+
+// Kinds of the objects exposing the endpoints, reported in the status.
+const (
+	externalEndpointKindIngress   = "Ingress"
+	externalEndpointKindHTTPRoute = "HTTPRoute"
+)
+
+// httpRoutesSyncInterval is the interval to check the HTTPRoutes that haven't been accepted by the Gateways, since
+// the HTTPRoutes aren't watched.
+const httpRoutesSyncInterval = 30 * time.Second
+
+func externalURL(scheme, host, path string) string {
+	if host == "" {
+		return ""
+	}
+
+	return scheme + "://" + host + path
+}
+
+// ingressURL returns the external URL of the Ingress and whether it has been admitted by the Ingress controller, i.e.,
+// the load balancer is assigned. The address of the load balancer is used when there's no host in the rule.
+func ingressURL(ingress *networkingv1.Ingress) (string, bool) {
+	if ingress == nil || len(ingress.Status.LoadBalancer.Ingress) == 0 || len(ingress.Spec.Rules) == 0 {
+		return "", false
+	}
+
+	rule := ingress.Spec.Rules[0]
+	host := rule.Host
+	if host == "" {
+		lb := ingress.Status.LoadBalancer.Ingress[0]
+		host = lo.Ternary(lb.Hostname != "", lb.Hostname, lb.IP)
+	}
+
+	path := "/"
+	if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+		path = rule.HTTP.Paths[0].Path
+	}
+
+	return externalURL(lo.Ternary(len(ingress.Spec.TLS) > 0, "https", "http"), host, path), true
+}
+
+// httpRouteURL returns the external URL of the HTTPRoute and whether it has been accepted by any of the Gateways.
+// The URL is empty when there's no hostname in the HTTPRoute.
+func httpRouteURL(httpRoute *gatewayv1.HTTPRoute, scheme string) (string, bool) {
+	if httpRoute == nil || !lo.ContainsBy(httpRoute.Status.Parents, func(p gatewayv1.RouteParentStatus) bool {
+		return apimeta.IsStatusConditionTrue(p.Conditions, string(gatewayv1.RouteConditionAccepted))
+	}) {
+		return "", false
+	}
+
+	host := ""
+	if len(httpRoute.Spec.Hostnames) > 0 {
+		host = string(httpRoute.Spec.Hostnames[0])
+	}
+
+	path := "/"
+	if len(httpRoute.Spec.Rules) > 0 && len(httpRoute.Spec.Rules[0].Matches) > 0 && httpRoute.Spec.Rules[0].Matches[0].Path != nil {
+		path = ptr.Deref(httpRoute.Spec.Rules[0].Matches[0].Path.Value, "/")
+	}
+
+	return externalURL(lo.Ternary(scheme != "", scheme, "https"), host, path), true
+}
+
+// updateExternalEndpointsStatus replaces the endpoints of the given kind in the status.
+func (mgr *risingWaveControllerManagerImpl) updateExternalEndpointsStatus(kind string, endpoints []risingwavev1alpha1.RisingWaveExternalEndpointStatus) {
+	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+		externalEndpoints := append(lo.Filter(status.ExternalEndpoints, func(e risingwavev1alpha1.RisingWaveExternalEndpointStatus, _ int) bool {
+			return e.Kind != kind
+		}), endpoints...)
+		if len(externalEndpoints) == 0 {
+			status.ExternalEndpoints = nil
+
+			return
+		}
+
+		sort.Slice(externalEndpoints, func(i, j int) bool {
+			if externalEndpoints[i].Name != externalEndpoints[j].Name {
+				return externalEndpoints[i].Name < externalEndpoints[j].Name
+			}
+
+			return externalEndpoints[i].Kind < externalEndpoints[j].Kind
+		})
+		status.ExternalEndpoints = externalEndpoints
+	})
+}
+
+// SyncIngresses implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncIngresses(ctx context.Context, logger logr.Logger, ingresses []networkingv1.Ingress) (reconcile.Result, error) {
+	var expectedNames []string
+	var endpoints []risingwavev1alpha1.RisingWaveExternalEndpointStatus
+	for _, endpoint := range externalEndpoints {
+		expected := mgr.objectFactory.NewIngress(endpoint)
+		if expected == nil {
+			continue
+		}
+		expectedNames = append(expectedNames, expected.Name)
+
+		var ingress *networkingv1.Ingress
+		if ing, ok := lo.Find(ingresses, func(ing networkingv1.Ingress) bool { return ing.Name == expected.Name }); ok {
+			ingress = &ing
+		}
+
+		if err := syncObject(mgr, ctx, ingress, func() *networkingv1.Ingress { return expected }, logger); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync ingress", err)
+		}
+
+		// The Ingresses are watched, so the URL will be reported once the load balancer is assigned.
+		url, _ := ingressURL(ingress)
+		endpoints = append(endpoints, risingwavev1alpha1.RisingWaveExternalEndpointStatus{
+			Name: endpoint,
+			Kind: externalEndpointKindIngress,
+			URL:  url,
+		})
+	}
+
+	// Delete the ones not expected.
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		if lo.Contains(expectedNames, ingress.Name) {
+			continue
+		}
+
+		logger.Info("Delete the Ingress", "ingress", ingress.Name)
+		if err := mgr.client.Delete(ctx, ingress, client.Preconditions{UID: &ingress.UID}); client.IgnoreNotFound(err) != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete ingress", err)
+		}
+	}
+
+	mgr.updateExternalEndpointsStatus(externalEndpointKindIngress, endpoints)
+
+	return ctrlkit.Continue()
+}
+
+// SyncHTTPRoutes implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncHTTPRoutes(ctx context.Context, logger logr.Logger, httpRoutes []gatewayv1.HTTPRoute) (reconcile.Result, error) {
+	var expectedNames []string
+	var endpoints []risingwavev1alpha1.RisingWaveExternalEndpointStatus
+	allAccepted := true
+	for _, endpoint := range externalEndpoints {
+		expected := mgr.objectFactory.NewHTTPRoute(endpoint)
+		if expected == nil {
+			continue
+		}
+		expectedNames = append(expectedNames, expected.Name)
+
+		var httpRoute *gatewayv1.HTTPRoute
+		if route, ok := lo.Find(httpRoutes, func(route gatewayv1.HTTPRoute) bool { return route.Name == expected.Name }); ok {
+			httpRoute = &route
+		}
+
+		if err := syncObject(mgr, ctx, httpRoute, func() *gatewayv1.HTTPRoute { return expected }, logger); err != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to sync http route", err)
+		}
+
+		url, accepted := httpRouteURL(httpRoute, mgr.risingwaveManager.GetExternalEndpoint(endpoint).HTTPRoute.Scheme)
+		allAccepted = allAccepted && accepted
+		endpoints = append(endpoints, risingwavev1alpha1.RisingWaveExternalEndpointStatus{
+			Name: endpoint,
+			Kind: externalEndpointKindHTTPRoute,
+			URL:  url,
+		})
+	}
+
+	// Delete the ones not expected.
+	for i := range httpRoutes {
+		httpRoute := &httpRoutes[i]
+		if lo.Contains(expectedNames, httpRoute.Name) {
+			continue
+		}
+
+		logger.Info("Delete the HTTPRoute", "httproute", httpRoute.Name)
+		if err := mgr.client.Delete(ctx, httpRoute, client.Preconditions{UID: &httpRoute.UID}); client.IgnoreNotFound(err) != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete http route", err)
+		}
+	}
+
+	mgr.updateExternalEndpointsStatus(externalEndpointKindHTTPRoute, endpoints)
+
+	if !allAccepted {
+		return ctrlkit.RequeueAfter(httpRoutesSyncInterval)
+	}
+
+	return ctrlkit.Continue()
+}
+
+// SyncPrometheusRule implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncPrometheusRule(ctx context.Context, logger logr.Logger, prometheusRule *monitoringv1.PrometheusRule) (reconcile.Result, error) {
+	expected := mgr.objectFactory.NewPrometheusRule()
+	if expected == nil {
+		if prometheusRule != nil {
+			logger.Info("Delete the PrometheusRule", "prometheusrule", prometheusRule.Name)
+			err := mgr.client.Delete(ctx, prometheusRule, client.Preconditions{UID: &prometheusRule.UID})
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete prometheus rule", client.IgnoreNotFound(err))
+		}
+
+		return ctrlkit.Continue()
+	}
+
+	err := syncObject(mgr, ctx, prometheusRule, func() *monitoringv1.PrometheusRule { return expected }, logger)
+
+	return ctrlkit.RequeueIfErrorAndWrap("unable to sync prometheus rule", err)
+}
+
+// SyncGrafanaDashboardConfigMap implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncGrafanaDashboardConfigMap(ctx context.Context, logger logr.Logger, grafanaDashboardConfigMap *corev1.ConfigMap) (reconcile.Result, error) {
+	expected := mgr.objectFactory.NewGrafanaDashboardConfigMap()
+	if expected == nil {
+		if grafanaDashboardConfigMap != nil {
+			logger.Info("Delete the ConfigMap of the Grafana dashboard", "configmap", grafanaDashboardConfigMap.Name)
+			err := mgr.client.Delete(ctx, grafanaDashboardConfigMap, client.Preconditions{UID: &grafanaDashboardConfigMap.UID})
+
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete grafana dashboard configmap", client.IgnoreNotFound(err))
+		}
+
+		return ctrlkit.Continue()
+	}
+
+	err := syncObject(mgr, ctx, grafanaDashboardConfigMap, func() *corev1.ConfigMap { return expected }, logger)
+
+	return ctrlkit.RequeueIfErrorAndWrap("unable to sync grafana dashboard configmap", err)
+}
+
+// deleteManagedStoreObjects deletes the existing objects of a store provisioned by the operator.
+func (mgr *risingWaveControllerManagerImpl) deleteManagedStoreObjects(ctx context.Context, logger logr.Logger, objs ...client.Object) error {
+	for _, obj := range objs {
+		if isObjectNil(obj) {
+			continue
+		}
+
+		uid := obj.GetUID()
+		logger.Info("Delete the object of the managed store", "object", utils.GetNamespacedName(obj))
+		if err := mgr.client.Delete(ctx, obj, client.Preconditions{UID: &uid}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SyncManagedMinIO implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncManagedMinIO(ctx context.Context, logger logr.Logger, managedMinIOSecret *corev1.Secret, managedMinIOService *corev1.Service, managedMinIOStatefulSet *appsv1.StatefulSet) (reconcile.Result, error) {
+	if mgr.risingwaveManager.RisingWave().Spec.StateStore.ManagedMinIO == nil {
+		err := mgr.deleteManagedStoreObjects(ctx, logger, managedMinIOStatefulSet, managedMinIOService, managedMinIOSecret)
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to delete managed minio", err)
+	}
+
+	if err := syncObject(mgr, ctx, managedMinIOSecret, mgr.objectFactory.NewManagedMinIOSecret, logger); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync managed minio secret", err)
+	}
+	if err := syncObject(mgr, ctx, managedMinIOService, mgr.objectFactory.NewManagedMinIOService, logger); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync managed minio service", err)
+	}
+	err := syncObject(mgr, ctx, managedMinIOStatefulSet, mgr.objectFactory.NewManagedMinIOStatefulSet, logger)
+
+	return ctrlkit.RequeueIfErrorAndWrap("unable to sync managed minio statefulset", err)
+}
+
+// SyncManagedPostgreSQL implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncManagedPostgreSQL(ctx context.Context, logger logr.Logger, managedPostgreSQLSecret *corev1.Secret, managedPostgreSQLService *corev1.Service, managedPostgreSQLStatefulSet *appsv1.StatefulSet) (reconcile.Result, error) {
+	if mgr.risingwaveManager.RisingWave().Spec.MetaStore.ManagedPostgreSQL == nil {
+		err := mgr.deleteManagedStoreObjects(ctx, logger, managedPostgreSQLStatefulSet, managedPostgreSQLService, managedPostgreSQLSecret)
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to delete managed postgresql", err)
+	}
+
+	if err := syncObject(mgr, ctx, managedPostgreSQLSecret, mgr.objectFactory.NewManagedPostgreSQLSecret, logger); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync managed postgresql secret", err)
+	}
+	if err := syncObject(mgr, ctx, managedPostgreSQLService, mgr.objectFactory.NewManagedPostgreSQLService, logger); err != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync managed postgresql service", err)
+	}
+	err := syncObject(mgr, ctx, managedPostgreSQLStatefulSet, mgr.objectFactory.NewManagedPostgreSQLStatefulSet, logger)
+
+	return ctrlkit.RequeueIfErrorAndWrap("unable to sync managed postgresql statefulset", err)
+}
+
+// WaitBeforeManagedStoresReady implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) WaitBeforeManagedStoresReady(ctx context.Context, logger logr.Logger, managedMinIOStatefulSet *appsv1.StatefulSet, managedPostgreSQLStatefulSet *appsv1.StatefulSet) (reconcile.Result, error) {
+	risingwave := mgr.risingwaveManager.RisingWave()
+
+	if risingwave.Spec.StateStore.ManagedMinIO != nil &&
+		!(mgr.isObjectSynced(managedMinIOStatefulSet) && utils.IsStatefulSetRolledOut(managedMinIOStatefulSet)) {
+		logger.Info("Managed MinIO hasn't been ready!")
+
+		return ctrlkit.Exit()
+	}
+
+	if risingwave.Spec.MetaStore.ManagedPostgreSQL != nil &&
+		!(mgr.isObjectSynced(managedPostgreSQLStatefulSet) && utils.IsStatefulSetRolledOut(managedPostgreSQLStatefulSet)) {
+		logger.Info("Managed PostgreSQL hasn't been ready!")
+
+		return ctrlkit.Exit()
+	}
+
+	return ctrlkit.NoRequeue()
+}
+
 // SyncStandaloneService implements RisingWaveControllerManagerImpl.
 func (mgr *risingWaveControllerManagerImpl) SyncStandaloneService(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service) (ctrl.Result, error) {
 	if mgr.risingwaveManager.IsStandaloneModeEnabled() {
@@ -1109,6 +1662,28 @@ func (mgr *risingWaveControllerManagerImpl) SyncStandaloneService(ctx context.Co
 	return ctrlkit.NoRequeue()
 }
 
+// SyncStandaloneHeadlessService implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncStandaloneHeadlessService(ctx context.Context, logger logr.Logger, standaloneHeadlessService *corev1.Service) (ctrl.Result, error) {
+	if mgr.risingwaveManager.IsStandaloneHAEnabled() {
+		err := syncObject(mgr, ctx, standaloneHeadlessService, mgr.objectFactory.NewStandaloneHeadlessService, logger)
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync standalone headless service", err)
+	}
+
+	if standaloneHeadlessService != nil {
+		if mgr.isObjectSynced(standaloneHeadlessService) {
+			logger.Info("Skip deleting object from newer generation", "object", standaloneHeadlessService.GetName())
+			return ctrlkit.NoRequeue()
+		}
+
+		err := client.IgnoreNotFound(mgr.client.Delete(ctx, standaloneHeadlessService, client.Preconditions{UID: &standaloneHeadlessService.UID}))
+
+		return ctrlkit.RequeueIfErrorAndWrap("unable to sync standalone headless service", err)
+	}
+
+	return ctrlkit.NoRequeue()
+}
+
 // SyncStandaloneStatefulSet implements RisingWaveControllerManagerImpl.
 func (mgr *risingWaveControllerManagerImpl) SyncStandaloneStatefulSet(ctx context.Context, logger logr.Logger, standaloneStatefulSet *appsv1.StatefulSet) (ctrl.Result, error) {
 	if mgr.risingwaveManager.IsStandaloneModeEnabled() && !mgr.risingwaveManager.IsOpenKruiseEnabled() {
@@ -1183,8 +1758,64 @@ func (mgr *risingWaveControllerManagerImpl) WaitBeforeStandaloneAdvancedStateful
 	return ctrlkit.NoRequeue()
 }
 
+// standaloneMigrationRetryInterval is the interval to check again if the standalone Pods have stopped.
+const standaloneMigrationRetryInterval = 5 * time.Second
+
+// StopStandaloneForMigration implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) StopStandaloneForMigration(ctx context.Context, logger logr.Logger, standaloneStatefulSet *appsv1.StatefulSet, standaloneAdvancedStatefulSet *kruiseappsv1beta1.StatefulSet, pods []corev1.Pod) (ctrl.Result, error) {
+	if !mgr.risingwaveManager.IsStandaloneMigrationInProgress() {
+		return ctrlkit.NoRequeue()
+	}
+
+	// The standalone Pods have already stopped.
+	if status := mgr.risingwaveManager.RisingWave().Status.StandaloneMigration; status != nil &&
+		(status.Phase == risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStartingMeta ||
+			status.Phase == risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStartingComponents) {
+		return ctrlkit.NoRequeue()
+	}
+
+	// Keep the standalone Pods running if the meta store can't be carried over.
+	if err := mgr.risingwaveManager.CheckStandaloneMigration(); err != nil {
+		logger.Info("Migration from the standalone mode is blocked", "reason", err.Error())
+		mgr.risingwaveManager.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhasePending, err.Error())
+
+		return ctrlkit.Exit()
+	}
+
+	mgr.risingwaveManager.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStoppingStandalone,
+		"Stopping the standalone Pods")
+
+	for _, obj := range []client.Object{standaloneStatefulSet, standaloneAdvancedStatefulSet} {
+		if isObjectNil(obj) || utils.IsDeleted(obj) {
+			continue
+		}
+
+		logger.Info("Delete the standalone workload for migration", "kind", reflect.TypeOf(obj).Elem().Name(), "name", obj.GetName())
+
+		uid := obj.GetUID()
+		if err := mgr.client.Delete(ctx, obj, client.Preconditions{UID: &uid}); client.IgnoreNotFound(err) != nil {
+			return ctrlkit.RequeueIfErrorAndWrap("unable to delete the standalone workload", err)
+		}
+	}
+
+	// Wait until all the standalone Pods are gone, so that the meta store is never served by two meta services.
+	standalonePods := lo.Filter(pods, func(pod corev1.Pod, _ int) bool {
+		return pod.Labels[consts.LabelRisingWaveComponent] == consts.ComponentStandalone
+	})
+	if len(standalonePods) > 0 {
+		logger.Info("Waiting for the standalone Pods to stop", "pods", utils.MapObjectsToNames[corev1.Pod, *corev1.Pod](standalonePods))
+
+		return ctrlkit.RequeueAfter(standaloneMigrationRetryInterval)
+	}
+
+	mgr.risingwaveManager.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStartingMeta,
+		"Bringing up the meta nodes on the meta store")
+
+	return ctrlkit.NoRequeue()
+}
+
 // CollectRunningStatisticsAndSyncStatusForStandalone implements RisingWaveControllerManagerImpl.
-func (mgr *risingWaveControllerManagerImpl) CollectRunningStatisticsAndSyncStatusForStandalone(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service, standaloneStatefulSet *appsv1.StatefulSet, configConfigMap *corev1.ConfigMap) (ctrl.Result, error) {
+func (mgr *risingWaveControllerManagerImpl) CollectRunningStatisticsAndSyncStatusForStandalone(ctx context.Context, logger logr.Logger, standaloneService *corev1.Service, standaloneStatefulSet *appsv1.StatefulSet, configConfigMap *corev1.ConfigMap, pods []corev1.Pod) (ctrl.Result, error) {
 	risingwave := mgr.risingwaveManager.RisingWave()
 
 	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
@@ -1207,8 +1838,14 @@ func (mgr *risingWaveControllerManagerImpl) CollectRunningStatisticsAndSyncStatu
 		status.ComponentReplicas = risingwavev1alpha1.RisingWaveComponentsReplicasStatus{
 			Standalone: getStandaloneStatus(risingwave, standaloneStatefulSet, logger),
 		}
+
+		// Forget the previous migration, so that it can be done again.
+		status.StandaloneMigration = nil
 	})
 
+	// Report the health issues.
+	mgr.reportHealth(pods, nil)
+
 	recoverConditionAndReasons := []struct {
 		cond      bool
 		component string
@@ -1255,7 +1892,8 @@ func (mgr *risingWaveControllerManagerImpl) CollectOpenKruiseRunningStatisticsAn
 	logger logr.Logger,
 	standaloneService *corev1.Service,
 	standaloneAdvancedStatefulSet *kruiseappsv1beta1.StatefulSet,
-	configConfigMap *corev1.ConfigMap) (ctrl.Result, error) {
+	configConfigMap *corev1.ConfigMap,
+	pods []corev1.Pod) (ctrl.Result, error) {
 	risingwave := mgr.risingwaveManager.RisingWave()
 
 	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
@@ -1280,6 +1918,9 @@ func (mgr *risingWaveControllerManagerImpl) CollectOpenKruiseRunningStatisticsAn
 		}
 	})
 
+	// Report the health issues.
+	mgr.reportHealth(pods, nil)
+
 	recoverConditionAndReasons := []struct {
 		cond      bool
 		component string
@@ -1320,17 +1961,470 @@ func (mgr *risingWaveControllerManagerImpl) CollectOpenKruiseRunningStatisticsAn
 	return ctrlkit.Continue()
 }
 
-func newRisingWaveControllerManagerImpl(client client.Client, risingwaveManager *object.RisingWaveManager, messageStore *event.MessageStore, forceUpdateEnabled bool, operatorVersion string) *risingWaveControllerManagerImpl {
+func newRisingWaveControllerManagerImpl(client client.Client, risingwaveManager *object.RisingWaveManager, messageStore *event.MessageStore, forceUpdateEnabled, inPlacePodResizeAvailable bool, operatorVersion, operatorNamespace string) *risingWaveControllerManagerImpl {
 	return &risingWaveControllerManagerImpl{
-		client:             client,
-		risingwaveManager:  risingwaveManager,
-		objectFactory:      factory.NewRisingWaveObjectFactory(risingwaveManager.RisingWave(), client.Scheme(), operatorVersion),
-		eventMessageStore:  messageStore,
-		forceUpdateEnabled: forceUpdateEnabled,
+		client:                    client,
+		risingwaveManager:         risingwaveManager,
+		objectFactory:             factory.NewRisingWaveObjectFactory(risingwaveManager.RisingWave(), client.Scheme(), operatorVersion),
+		eventMessageStore:         messageStore,
+		forceUpdateEnabled:        forceUpdateEnabled,
+		inPlacePodResizeAvailable: inPlacePodResizeAvailable,
+		operatorNamespace:         operatorNamespace,
+		collectResourceGroups:     collectResourceGroupsFromMeta,
 	}
 }
 
 // NewRisingWaveControllerManagerImpl creates an object that implements the RisingWaveControllerManagerImpl.
-func NewRisingWaveControllerManagerImpl(client client.Client, risingwaveManager *object.RisingWaveManager, messageStore *event.MessageStore, forceUpdateEnabled bool, operatorVersion string) RisingWaveControllerManagerImpl {
-	return newRisingWaveControllerManagerImpl(client, risingwaveManager, messageStore, forceUpdateEnabled, operatorVersion)
+func NewRisingWaveControllerManagerImpl(client client.Client, risingwaveManager *object.RisingWaveManager, messageStore *event.MessageStore, forceUpdateEnabled, inPlacePodResizeAvailable bool, operatorVersion, operatorNamespace string) RisingWaveControllerManagerImpl {
+	return newRisingWaveControllerManagerImpl(client, risingwaveManager, messageStore, forceUpdateEnabled, inPlacePodResizeAvailable, operatorVersion, operatorNamespace)
+}
+
+// resourceRecommendationInterval is the interval to sample the resource usage of the Pods.
+const resourceRecommendationInterval = time.Minute
+
+func (mgr *risingWaveControllerManagerImpl) getContainerUsages(ctx context.Context, logger logr.Logger) recommender.ContainerUsages {
+	risingwave := mgr.risingwaveManager.RisingWave()
+
+	var podMetricsList unstructured.UnstructuredList
+	podMetricsList.SetGroupVersionKind(recommender.PodMetricsListGVK)
+
+	err := mgr.client.List(ctx, &podMetricsList, client.InNamespace(risingwave.Namespace),
+		client.MatchingLabels{consts.LabelRisingWaveName: risingwave.Name})
+	if err != nil {
+		// The metrics server is optional. Only the OOM terminations are tracked without it.
+		logger.V(1).Info("Pod metrics are unavailable", "error", err.Error())
+
+		return nil
+	}
+
+	return recommender.ContainerUsagesFromPodMetrics(podMetricsList.Items)
+}
+
+func findResourceRecommendation(recommendations []risingwavev1alpha1.RisingWaveResourceRecommendation, component, group string) *risingwavev1alpha1.RisingWaveResourceRecommendation {
+	for i := range recommendations {
+		if recommendations[i].Component == component && recommendations[i].Group == group {
+			return &recommendations[i]
+		}
+	}
+
+	return nil
+}
+
+// appliedResourceRecommendationsHash returns the hash of the resource recommendations applied to the node groups of
+// the component, or an empty string if there's none.
+func (mgr *risingWaveControllerManagerImpl) appliedResourceRecommendationsHash(component string) string {
+	applied := lo.Filter(mgr.risingwaveManager.RisingWave().Status.ResourceRecommendations, func(r risingwavev1alpha1.RisingWaveResourceRecommendation, _ int) bool {
+		return r.Component == component && len(r.Applied) > 0
+	})
+	if len(applied) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, r := range applied {
+		b, err := json.Marshal(r.Applied)
+		if err != nil {
+			panic(fmt.Sprintf("unable to marshal applied resources: %v", err))
+		}
+		_, _ = fmt.Fprintf(h, "%s=%s;", r.Group, b)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// setAppliedResourceRecommendationsHash records the hash of the applied resource recommendations in the annotations
+// of the workload to create or update, so that the workload is synced once they change.
+func (mgr *risingWaveControllerManagerImpl) setAppliedResourceRecommendationsHash(obj client.Object) {
+	if !maintenance.IsWorkload(obj) {
+		return
+	}
+
+	hash := mgr.appliedResourceRecommendationsHash(obj.GetLabels()[consts.LabelRisingWaveComponent])
+	annotations := obj.GetAnnotations()
+	if hash == "" {
+		delete(annotations, consts.AnnotationAppliedResourcesHash)
+		return
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[consts.AnnotationAppliedResourcesHash] = hash
+	obj.SetAnnotations(annotations)
+}
+
+func isResourceRecommendationAutoApplied(nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) bool {
+	return nodeGroup.ResourceRecommendation != nil &&
+		nodeGroup.ResourceRecommendation.Mode == risingwavev1alpha1.RisingWaveResourceRecommendationModeAuto
+}
+
+// resizePodsInPlace resizes the RisingWave container of the Pods to the target through the resize subresource.
+func (mgr *risingWaveControllerManagerImpl) resizePodsInPlace(ctx context.Context, logger logr.Logger, component string, pods []corev1.Pod, target corev1.ResourceList) error {
+	for _, pod := range pods {
+		if utils.IsDeleted(&pod) || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		_, i, found := lo.FindIndexOf(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == component })
+		if !found || !recommender.IsSignificantlyDifferent(pod.Spec.Containers[i].Resources.Limits, target) {
+			continue
+		}
+
+		resized := pod.DeepCopy()
+		recommender.ApplyTarget(&resized.Spec.Containers[i].Resources, target)
+
+		logger.Info("Resize the pod in place", "pod", pod.Name, "target", target)
+		if err := mgr.client.SubResource("resize").Update(ctx, resized); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to resize pod %s: %w", pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyResourceRecommendation applies the target of the recommendation to the Pods of the node group in the Auto mode.
+// The Pods are resized in place when the cluster supports it. Otherwise, the target is recorded as the applied one in
+// the status, and the workloads are synced by upgrading, where the object factory builds the Pods with it, which rolls
+// the Pods. The spec is never changed, so that the recommender doesn't fight with the owner of the RisingWave, e.g.,
+// kubectl apply or a GitOps tool.
+func (mgr *risingWaveControllerManagerImpl) applyResourceRecommendation(ctx context.Context, logger logr.Logger,
+	nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup, r *risingwavev1alpha1.RisingWaveResourceRecommendation, pods []corev1.Pod) error {
+	if !isResourceRecommendationAutoApplied(nodeGroup) || len(r.Target) == 0 {
+		return nil
+	}
+
+	if mgr.inPlacePodResizeAvailable {
+		return mgr.resizePodsInPlace(ctx, logger, r.Component, pods, r.Target)
+	}
+
+	current := r.Applied
+	if len(current) == 0 {
+		current = nodeGroup.Template.Spec.Resources.Limits
+	}
+	if recommender.IsSignificantlyDifferent(current, r.Target) {
+		logger.Info("Apply the resource recommendation to the node group", "target", r.Target)
+		r.Applied = r.Target.DeepCopy()
+
+		mgr.risingwaveManager.UpdateCondition(risingwavev1alpha1.RisingWaveCondition{
+			Type:   risingwavev1alpha1.RisingWaveConditionUpgrading,
+			Status: metav1.ConditionTrue,
+		})
+	}
+
+	return nil
+}
+
+// SyncResourceRecommendations implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) SyncResourceRecommendations(ctx context.Context, logger logr.Logger, pods []corev1.Pod) (reconcile.Result, error) {
+	risingwave := mgr.risingwaveManager.RisingWave()
+
+	type nodeGroupRef struct {
+		component string
+		nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup
+	}
+
+	var nodeGroups []nodeGroupRef
+	if !mgr.risingwaveManager.IsStandaloneModeEnabled() {
+		for _, component := range []string{consts.ComponentMeta, consts.ComponentFrontend, consts.ComponentCompute, consts.ComponentCompactor} {
+			for _, nodeGroup := range mgr.risingwaveManager.GetNodeGroups(component) {
+				if nodeGroup.ResourceRecommendation != nil {
+					nodeGroups = append(nodeGroups, nodeGroupRef{component: component, nodeGroup: nodeGroup.DeepCopy()})
+				}
+			}
+		}
+	}
+
+	if len(nodeGroups) == 0 {
+		mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+			status.ResourceRecommendations = nil
+		})
+
+		return ctrlkit.Continue()
+	}
+
+	// Pods of the node groups expanded into zones are labeled with the groups of the zones.
+	podsOfGroup := func(component string, nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) []corev1.Pod {
+		groups := lo.Map(mgr.risingwaveManager.ExpandNodeGroup(nodeGroup), func(g risingwavev1alpha1.RisingWaveNodeGroup, _ int) string {
+			return g.Name
+		})
+
+		return lo.Filter(pods, func(pod corev1.Pod, _ int) bool {
+			return pod.Labels[consts.LabelRisingWaveComponent] == component && lo.Contains(groups, pod.Labels[consts.LabelRisingWaveGroup])
+		})
+	}
+
+	// Update the recommendations.
+	usages := mgr.getContainerUsages(ctx, logger)
+	now := metav1.Now()
+	recommendations := make([]risingwavev1alpha1.RisingWaveResourceRecommendation, 0, len(nodeGroups))
+
+	for _, ref := range nodeGroups {
+		// The RisingWave container is named after the component.
+		prev := findResourceRecommendation(risingwave.Status.ResourceRecommendations, ref.component, ref.nodeGroup.Name)
+		r := recommender.Recommend(prev, ref.component, ref.nodeGroup.Name, ref.component,
+			ref.nodeGroup.ResourceRecommendation, podsOfGroup(ref.component, ref.nodeGroup), usages, now)
+
+		// Keep the applied target unless the recommendations are no longer applied by rolling the Pods.
+		if prev != nil && isResourceRecommendationAutoApplied(ref.nodeGroup) && !mgr.inPlacePodResizeAvailable {
+			r.Applied = prev.Applied.DeepCopy()
+		}
+		recommendations = append(recommendations, r)
+	}
+
+	// Apply the recommendations only when the RisingWave is running, so that they never interfere with the
+	// initialization or an ongoing upgrade.
+	if mgr.risingwaveManager.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionRunning, true) &&
+		!mgr.risingwaveManager.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionUpgrading, true) {
+		for i, ref := range nodeGroups {
+			if err := mgr.applyResourceRecommendation(ctx, logger.WithValues("component", ref.component, "group", ref.nodeGroup.Name),
+				ref.nodeGroup, &recommendations[i], podsOfGroup(ref.component, ref.nodeGroup)); err != nil {
+				mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+					status.ResourceRecommendations = recommendations
+				})
+				return ctrlkit.RequeueIfErrorAndWrap("unable to apply resource recommendations", err)
+			}
+		}
+	}
+
+	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+		status.ResourceRecommendations = recommendations
+	})
+
+	return ctrlkit.RequeueAfter(resourceRecommendationInterval)
+}
+
+// resourceGroupsSyncInterval is the interval to collect the resource groups when there are non-default ones.
+const resourceGroupsSyncInterval = 30 * time.Second
+
+// collectResourceGroupsFromMeta lists the compute nodes and streaming jobs from the meta service at the given address
+// and aggregates them by resource groups.
+func collectResourceGroupsFromMeta(ctx context.Context, addr string) ([]risingwavev1alpha1.RisingWaveResourceGroupStatus, error) {
+	metaClient, err := meta.NewClient(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer metaClient.Close() //nolint:errcheck
+
+	nodes, err := metaClient.ListComputeNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := metaClient.ListStreamingJobStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildResourceGroupsStatus(nodes, jobs), nil
+}
+
+func buildResourceGroupsStatus(nodes []*pb.WorkerNode, jobs []*pb.ListStreamingJobStatesResponse_StreamingJobState) []risingwavev1alpha1.RisingWaveResourceGroupStatus {
+	groups := make(map[string]*risingwavev1alpha1.RisingWaveResourceGroupStatus)
+	groupOf := func(name string) *risingwavev1alpha1.RisingWaveResourceGroupStatus {
+		// Nodes and jobs without a resource group belong to the default one.
+		if name == "" {
+			name = consts.DefaultResourceGroup
+		}
+		if _, ok := groups[name]; !ok {
+			groups[name] = &risingwavev1alpha1.RisingWaveResourceGroupStatus{Name: name}
+		}
+
+		return groups[name]
+	}
+
+	for _, node := range nodes {
+		groupOf(node.GetProperty().GetResourceGroup()).Workers++
+	}
+	for _, job := range jobs {
+		groupOf(job.GetResourceGroup()).StreamingJobs++
+	}
+
+	status := lo.Map(lo.Values(groups), func(g *risingwavev1alpha1.RisingWaveResourceGroupStatus, _ int) risingwavev1alpha1.RisingWaveResourceGroupStatus {
+		return *g
+	})
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Name < status[j].Name
+	})
+
+	return status
+}
+
+// FindMetaPod returns a running Pod serving the meta service, preferring the leader. It returns nil if there's none.
+func FindMetaPod(pods []corev1.Pod, component string) *corev1.Pod {
+	candidates := lo.Filter(pods, func(pod corev1.Pod, _ int) bool {
+		return pod.Labels[consts.LabelRisingWaveComponent] == component && pod.Status.PodIP != "" &&
+			!utils.IsDeleted(&pod) && utils.IsPodRunning(&pod)
+	})
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if leader, ok := lo.Find(candidates, func(pod corev1.Pod) bool {
+		return pod.Labels[consts.LabelRisingWaveMetaRole] == consts.MetaRoleLeader
+	}); ok {
+		return &leader
+	}
+
+	return &candidates[0]
+}
+
+// CollectResourceGroupsAndSyncStatus implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) CollectResourceGroupsAndSyncStatus(ctx context.Context, logger logr.Logger, pods []corev1.Pod) (reconcile.Result, error) {
+	if !mgr.risingwaveManager.DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionRunning, true) {
+		return ctrlkit.Continue()
+	}
+
+	metaComponent := lo.If(mgr.risingwaveManager.IsStandaloneModeEnabled(), consts.ComponentStandalone).Else(consts.ComponentMeta)
+	metaPod := FindMetaPod(pods, metaComponent)
+	if metaPod == nil {
+		return ctrlkit.Continue()
+	}
+
+	// Refresh periodically only when there are non-default resource groups, since the status is used to protect
+	// the resource groups with streaming jobs from being deleted.
+	periodic := !mgr.risingwaveManager.IsStandaloneModeEnabled() &&
+		lo.ContainsBy(mgr.risingwaveManager.GetNodeGroups(consts.ComponentCompute), func(g risingwavev1alpha1.RisingWaveNodeGroup) bool {
+			return mgr.risingwaveManager.GetResourceGroup(&g) != consts.DefaultResourceGroup
+		})
+	result := func() (reconcile.Result, error) {
+		if periodic {
+			return ctrlkit.RequeueAfter(resourceGroupsSyncInterval)
+		}
+
+		return ctrlkit.Continue()
+	}
+
+	resourceGroups, err := func() ([]risingwavev1alpha1.RisingWaveResourceGroupStatus, error) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		return mgr.collectResourceGroups(ctx, net.JoinHostPort(metaPod.Status.PodIP, strconv.Itoa(int(consts.MetaServicePort))))
+	}()
+	if err != nil {
+		// Keep the last reported status.
+		logger.Info("Failed to collect resource groups from the meta service", "pod", metaPod.Name, "error", err.Error())
+
+		return result()
+	}
+
+	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+		status.ResourceGroups = resourceGroups
+	})
+
+	return result()
+}
+
+// remediationBudgetRecheckInterval is the interval to recheck the stuck Pods withheld for the exhausted budget.
+const remediationBudgetRecheckInterval = time.Minute
+
+// RemediateStuckPods implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) RemediateStuckPods(ctx context.Context, logger logr.Logger, metaStatefulSets []appsv1.StatefulSet, frontendStatefulSets []appsv1.StatefulSet, computeStatefulSets []appsv1.StatefulSet, standaloneStatefulSet *appsv1.StatefulSet, pods []corev1.Pod) (reconcile.Result, error) {
+	var workloads []remediation.Workload
+	for _, stsList := range [][]appsv1.StatefulSet{metaStatefulSets, frontendStatefulSets, computeStatefulSets} {
+		for i := range stsList {
+			workloads = append(workloads, remediation.WorkloadOfStatefulSet(&stsList[i]))
+		}
+	}
+	if standaloneStatefulSet != nil {
+		workloads = append(workloads, remediation.WorkloadOfStatefulSet(standaloneStatefulSet))
+	}
+
+	return mgr.remediateStuckPods(ctx, logger, workloads, pods)
+}
+
+// RemediateOpenKruiseStuckPods implements RisingWaveControllerManagerImpl.
+func (mgr *risingWaveControllerManagerImpl) RemediateOpenKruiseStuckPods(ctx context.Context, logger logr.Logger, metaAdvancedStatefulSets []kruiseappsv1beta1.StatefulSet, frontendAdvancedStatefulSets []kruiseappsv1beta1.StatefulSet, computeAdvancedStatefulSets []kruiseappsv1beta1.StatefulSet, standaloneAdvancedStatefulSet *kruiseappsv1beta1.StatefulSet, pods []corev1.Pod) (reconcile.Result, error) {
+	var workloads []remediation.Workload
+	for _, stsList := range [][]kruiseappsv1beta1.StatefulSet{metaAdvancedStatefulSets, frontendAdvancedStatefulSets, computeAdvancedStatefulSets} {
+		for i := range stsList {
+			workloads = append(workloads, remediation.WorkloadOfAdvancedStatefulSet(&stsList[i]))
+		}
+	}
+	if standaloneAdvancedStatefulSet != nil {
+		workloads = append(workloads, remediation.WorkloadOfAdvancedStatefulSet(standaloneAdvancedStatefulSet))
+	}
+
+	return mgr.remediateStuckPods(ctx, logger, workloads, pods)
+}
+
+// remediateStuckPods deletes the stuck Pods of the workloads planned within the budget, so that they are recreated
+// by the StatefulSets on the update revision. The interventions are recorded in the status and as the events.
+func (mgr *risingWaveControllerManagerImpl) remediateStuckPods(ctx context.Context, logger logr.Logger, workloads []remediation.Workload, pods []corev1.Pod) (reconcile.Result, error) {
+	risingwave := mgr.risingwaveManager.RisingWave()
+
+	policy := risingwave.Spec.Remediation
+	if policy == nil {
+		mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+			status.Remediation = nil
+		})
+
+		return ctrlkit.Continue()
+	}
+
+	var prev risingwavev1alpha1.RisingWaveRemediationStatus
+	if risingwave.Status.Remediation != nil {
+		prev = *risingwave.Status.Remediation.DeepCopy()
+	}
+
+	now := metav1.Now()
+	planner := &remediation.Planner{Now: now.Time, Policy: policy, History: prev.Interventions}
+	plan := planner.Plan(workloads, pods)
+
+	var records []risingwavev1alpha1.RisingWaveRemediationIntervention
+	var deleteErr error
+	for _, intervention := range plan.Interventions {
+		pod := intervention.Pod
+		logger.Info("Delete the stuck pod", "pod", pod.Name, "reason", intervention.Reason, "message", intervention.Message)
+
+		if err := mgr.client.Delete(ctx, pod, client.Preconditions{UID: &pod.UID}); client.IgnoreNotFound(err) != nil {
+			deleteErr = fmt.Errorf("unable to delete pod %s: %w", pod.Name, err)
+
+			break
+		}
+		records = append(records, intervention.Record(now))
+	}
+
+	// Record the interventions made even if some deletion fails.
+	mgr.risingwaveManager.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+		status.Remediation = &risingwavev1alpha1.RisingWaveRemediationStatus{
+			Interventions:      planner.AppendHistory(records...),
+			TotalInterventions: prev.TotalInterventions + int64(len(records)),
+			Withheld:           plan.Withheld,
+		}
+	})
+
+	if len(records) > 0 {
+		mgr.eventMessageStore.SetMessage(consts.RisingWaveEventTypeRemediated.Name, summarizeInterventions(records))
+	}
+	if len(plan.Withheld) > 0 {
+		mgr.eventMessageStore.SetMessage(consts.RisingWaveEventTypeRemediationBudgetExhausted.Name,
+			fmt.Sprintf("Stuck Pod(s) left as is for the exhausted budget: %s", strings.Join(plan.Withheld, ", ")))
+	}
+
+	if deleteErr != nil {
+		return ctrlkit.RequeueIfErrorAndWrap("unable to remediate stuck pods", deleteErr)
+	}
+
+	switch {
+	case len(plan.Withheld) > 0:
+		return ctrlkit.RequeueAfter(remediationBudgetRecheckInterval)
+	case plan.RecheckAfter > 0:
+		return ctrlkit.RequeueAfter(plan.RecheckAfter)
+	default:
+		return ctrlkit.Continue()
+	}
+}
+
+// summarizeInterventions returns the message of the event of the interventions.
+func summarizeInterventions(records []risingwavev1alpha1.RisingWaveRemediationIntervention) string {
+	summaries := make([]string, 0, len(records))
+	for _, record := range records {
+		nodeGroup := record.Component
+		if record.Group != "" {
+			nodeGroup += "/" + record.Group
+		}
+		summaries = append(summaries, fmt.Sprintf("%s: deleted Pod %s (%s): %s", nodeGroup, record.Pod, record.Reason, record.Message))
+	}
+
+	return strings.Join(summaries, "; ")
 }
@@ -18,10 +18,12 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"slices"
 	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	kruiseappsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
@@ -30,13 +32,17 @@ import (
 	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/risingwavelabs/risingwave-operator/pkg/event"
 
@@ -44,6 +50,8 @@ import (
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	pb "github.com/risingwavelabs/risingwave-operator/pkg/controller/proto"
+	"github.com/risingwavelabs/risingwave-operator/pkg/maintenance"
 	"github.com/risingwavelabs/risingwave-operator/pkg/object"
 	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
 )
@@ -56,7 +64,7 @@ func newRisingWaveControllerManagerImplForTest(risingwave *risingwavev1alpha1.Ri
 		Build()
 	risingwaveManager := object.NewRisingWaveManager(fakeClient, risingwave.DeepCopy(), false)
 
-	return newRisingWaveControllerManagerImpl(fakeClient, risingwaveManager, event.NewMessageStore(), false, "")
+	return newRisingWaveControllerManagerImpl(fakeClient, risingwaveManager, event.NewMessageStore(), false, false, "", "")
 }
 
 func newRisingWaveControllerManagerImplOpenKruiseAvailableForTest(risingwave *risingwavev1alpha1.RisingWave, objects ...client.Object) *risingWaveControllerManagerImpl {
@@ -67,7 +75,7 @@ func newRisingWaveControllerManagerImplOpenKruiseAvailableForTest(risingwave *ri
 		Build()
 	risingwaveManager := object.NewRisingWaveManager(fakeClient, risingwave.DeepCopy(), true)
 
-	return newRisingWaveControllerManagerImpl(fakeClient, risingwaveManager, event.NewMessageStore(), false, "")
+	return newRisingWaveControllerManagerImpl(fakeClient, risingwaveManager, event.NewMessageStore(), false, false, "", "")
 }
 
 func fakeRisingWaveWithFrontendStatefulSet(openKruise bool) *risingwavev1alpha1.RisingWave {
@@ -401,6 +409,443 @@ func TestRisingWaveControllerManagerImpl_SyncServiceMonitor(t *testing.T) {
 	)
 }
 
+func TestRisingWaveControllerManagerImpl_SyncNetworkPolicies(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{Enabled: ptr.To(true)}
+	})
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	listNetworkPolicies := func() []networkingv1.NetworkPolicy {
+		var networkPolicies networkingv1.NetworkPolicyList
+		if err := managerImpl.client.List(context.Background(), &networkPolicies, client.InNamespace(risingwave.Namespace)); err != nil {
+			t.Fatal(err)
+		}
+
+		return networkPolicies.Items
+	}
+
+	r, err := managerImpl.SyncNetworkPolicies(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	networkPolicies := listNetworkPolicies()
+	if len(networkPolicies) != 4 {
+		t.Fatalf("unexpected number of network policies: %d", len(networkPolicies))
+	}
+
+	// Switching to the standalone mode removes the policies of the components.
+	risingwave.Spec.EnableStandaloneMode = ptr.To(true)
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, lo.Map(networkPolicies, func(np networkingv1.NetworkPolicy, _ int) client.Object {
+		np.ResourceVersion = ""
+
+		return &np
+	})...)
+
+	r, err = managerImpl.SyncNetworkPolicies(context.Background(), logr.Discard(), networkPolicies)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	networkPolicies = listNetworkPolicies()
+	if len(networkPolicies) != 1 || networkPolicies[0].Name != risingwave.Name+"-standalone" {
+		t.Fatalf("unexpected network policies: %v", lo.Map(networkPolicies, func(np networkingv1.NetworkPolicy, _ int) string { return np.Name }))
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncManagedMinIO(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+			ManagedMinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendManagedMinIO{},
+		}
+	})
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	getObjects := func() (*corev1.Secret, *corev1.Service, *appsv1.StatefulSet) {
+		secret, svc, sts := &corev1.Secret{}, &corev1.Service{}, &appsv1.StatefulSet{}
+		for obj, name := range map[client.Object]string{
+			secret: risingwave.Name + "-minio-credentials",
+			svc:    risingwave.Name + "-minio",
+			sts:    risingwave.Name + "-minio",
+		} {
+			err := managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: name}, obj)
+			if apierrors.IsNotFound(err) {
+				return nil, nil, nil
+			} else if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return secret, svc, sts
+	}
+
+	r, err := managerImpl.SyncManagedMinIO(context.Background(), logr.Discard(), nil, nil, nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	secret, svc, sts := getObjects()
+	if secret == nil {
+		t.Fatal("managed minio not created")
+	}
+	password := string(secret.Data[consts.SecretKeyMinIOPassword])
+
+	// Abort the workflow before the StatefulSet is ready.
+	if _, err = managerImpl.WaitBeforeManagedStoresReady(context.Background(), logr.Discard(), sts, nil); err != ctrlkit.ErrExit {
+		t.Fatalf("expected ErrExit, got %v", err)
+	}
+
+	// The credentials are kept on the following syncs.
+	r, err = managerImpl.SyncManagedMinIO(context.Background(), logr.Discard(), secret, svc, sts)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+	if secret, _, _ = getObjects(); string(secret.Data[consts.SecretKeyMinIOPassword]) != password {
+		t.Fatal("password of the managed minio changed")
+	}
+
+	// Switching to another state store deletes the managed MinIO.
+	risingwave.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+		Memory: ptr.To(true),
+	}
+	secret.ResourceVersion, svc.ResourceVersion, sts.ResourceVersion = "", "", ""
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, secret, svc, sts)
+
+	r, err = managerImpl.SyncManagedMinIO(context.Background(), logr.Discard(), secret, svc, sts)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+	if secret, _, _ = getObjects(); secret != nil {
+		t.Fatal("managed minio not deleted")
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncPrometheusRuleAndGrafanaDashboard(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+			PrometheusRule: &risingwavev1alpha1.RisingWavePrometheusRule{
+				Enabled: ptr.To(true),
+			},
+			GrafanaDashboard: &risingwavev1alpha1.RisingWaveGrafanaDashboard{
+				Enabled: ptr.To(true),
+			},
+		}
+	})
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	getObjects := func() (*monitoringv1.PrometheusRule, *corev1.ConfigMap) {
+		prometheusRule, configMap := &monitoringv1.PrometheusRule{}, &corev1.ConfigMap{}
+
+		err := managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: "risingwave-" + risingwave.Name}, prometheusRule)
+		if apierrors.IsNotFound(err) {
+			prometheusRule = nil
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		err = managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: "risingwave-" + risingwave.Name + "-grafana-dashboard"}, configMap)
+		if apierrors.IsNotFound(err) {
+			configMap = nil
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		return prometheusRule, configMap
+	}
+
+	r, err := managerImpl.SyncPrometheusRule(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+	r, err = managerImpl.SyncGrafanaDashboardConfigMap(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	prometheusRule, configMap := getObjects()
+	if prometheusRule == nil || configMap == nil {
+		t.Fatal("prometheus rule or grafana dashboard not created")
+	}
+
+	// Disabling them deletes the objects.
+	risingwave.Spec.Monitoring.PrometheusRule.Enabled = ptr.To(false)
+	risingwave.Spec.Monitoring.GrafanaDashboard = nil
+	prometheusRule.ResourceVersion, configMap.ResourceVersion = "", ""
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, prometheusRule, configMap)
+
+	r, err = managerImpl.SyncPrometheusRule(context.Background(), logr.Discard(), prometheusRule)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+	r, err = managerImpl.SyncGrafanaDashboardConfigMap(context.Background(), logr.Discard(), configMap)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	if prometheusRule, configMap = getObjects(); prometheusRule != nil || configMap != nil {
+		t.Fatal("prometheus rule or grafana dashboard not deleted")
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncServiceMonitorAndPodMonitor(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	getObjects := func() (*monitoringv1.ServiceMonitor, *monitoringv1.PodMonitor) {
+		serviceMonitor, podMonitor := &monitoringv1.ServiceMonitor{}, &monitoringv1.PodMonitor{}
+
+		err := managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: "risingwave-" + risingwave.Name}, serviceMonitor)
+		if apierrors.IsNotFound(err) {
+			serviceMonitor = nil
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		err = managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: "risingwave-" + risingwave.Name}, podMonitor)
+		if apierrors.IsNotFound(err) {
+			podMonitor = nil
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		return serviceMonitor, podMonitor
+	}
+
+	r, err := managerImpl.SyncServiceMonitor(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+	r, err = managerImpl.SyncPodMonitor(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	serviceMonitor, podMonitor := getObjects()
+	if serviceMonitor == nil || podMonitor != nil {
+		t.Fatal("only the service monitor is expected by default")
+	}
+
+	// Switching to the PodMonitor deletes the ServiceMonitor.
+	risingwave.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+		MonitorKind: risingwavev1alpha1.RisingWaveMonitorKindPodMonitor,
+	}
+	serviceMonitor.ResourceVersion = ""
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, serviceMonitor)
+
+	r, err = managerImpl.SyncServiceMonitor(context.Background(), logr.Discard(), serviceMonitor)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+	r, err = managerImpl.SyncPodMonitor(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	if serviceMonitor, podMonitor = getObjects(); serviceMonitor != nil || podMonitor == nil {
+		t.Fatal("only the pod monitor is expected after switching the kind")
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncIngresses(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+			Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+				Ingress: &risingwavev1alpha1.RisingWaveIngress{
+					Host:          "dashboard.example.com",
+					TLSSecretName: "dashboard-tls",
+				},
+			},
+		}
+		rw.Status.ExternalEndpoints = []risingwavev1alpha1.RisingWaveExternalEndpointStatus{
+			{Name: consts.ExternalEndpointWebhook, Kind: externalEndpointKindIngress, URL: "http://stale"},
+			{Name: consts.ExternalEndpointWebhook, Kind: externalEndpointKindHTTPRoute, URL: "https://webhook.example.com/"},
+		}
+	})
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	r, err := managerImpl.SyncIngresses(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	var ingress networkingv1.Ingress
+	if err := managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: risingwave.Name + "-dashboard"}, &ingress); err != nil {
+		t.Fatal(err)
+	}
+
+	// The URL isn't reported before the load balancer is assigned, and the HTTPRoutes are kept.
+	if status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ExternalEndpoints; !equality.Semantic.DeepEqual(status, []risingwavev1alpha1.RisingWaveExternalEndpointStatus{
+		{Name: consts.ExternalEndpointDashboard, Kind: externalEndpointKindIngress},
+		{Name: consts.ExternalEndpointWebhook, Kind: externalEndpointKindHTTPRoute, URL: "https://webhook.example.com/"},
+	}) {
+		t.Fatalf("unexpected external endpoints: %v", status)
+	}
+
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: "1.2.3.4"}}
+	ingress.ResourceVersion = ""
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, &ingress)
+
+	r, err = managerImpl.SyncIngresses(context.Background(), logr.Discard(), []networkingv1.Ingress{ingress})
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	if status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ExternalEndpoints; !equality.Semantic.DeepEqual(status, []risingwavev1alpha1.RisingWaveExternalEndpointStatus{
+		{Name: consts.ExternalEndpointDashboard, Kind: externalEndpointKindIngress, URL: "https://dashboard.example.com/"},
+		{Name: consts.ExternalEndpointWebhook, Kind: externalEndpointKindHTTPRoute, URL: "https://webhook.example.com/"},
+	}) {
+		t.Fatalf("unexpected external endpoints: %v", status)
+	}
+
+	// Removing the external access deletes the Ingress.
+	risingwave.Spec.ExternalAccess = nil
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, &ingress)
+
+	r, err = managerImpl.SyncIngresses(context.Background(), logr.Discard(), []networkingv1.Ingress{ingress})
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	var ingresses networkingv1.IngressList
+	if err := managerImpl.client.List(context.Background(), &ingresses, client.InNamespace(risingwave.Namespace)); err != nil {
+		t.Fatal(err)
+	}
+	if len(ingresses.Items) != 0 {
+		t.Fatalf("unexpected ingresses: %d", len(ingresses.Items))
+	}
+}
+
+func Test_ingressURL(t *testing.T) {
+	newIngress := func(host string, tls bool, lb ...networkingv1.IngressLoadBalancerIngress) *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{
+					{
+						Host: host,
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{{Path: "/rw"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		if tls {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{SecretName: "tls"}}
+		}
+		ingress.Status.LoadBalancer.Ingress = lb
+
+		return ingress
+	}
+
+	testcases := map[string]struct {
+		ingress  *networkingv1.Ingress
+		url      string
+		admitted bool
+	}{
+		"nil": {
+			ingress: nil,
+		},
+		"not-admitted": {
+			ingress: newIngress("example.com", true),
+		},
+		"host": {
+			ingress:  newIngress("example.com", true, networkingv1.IngressLoadBalancerIngress{IP: "1.2.3.4"}),
+			url:      "https://example.com/rw",
+			admitted: true,
+		},
+		"lb-hostname": {
+			ingress:  newIngress("", false, networkingv1.IngressLoadBalancerIngress{Hostname: "lb.example.com", IP: "1.2.3.4"}),
+			url:      "http://lb.example.com/rw",
+			admitted: true,
+		},
+		"lb-ip": {
+			ingress:  newIngress("", false, networkingv1.IngressLoadBalancerIngress{IP: "1.2.3.4"}),
+			url:      "http://1.2.3.4/rw",
+			admitted: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			url, admitted := ingressURL(tc.ingress)
+			if url != tc.url || admitted != tc.admitted {
+				t.Fatalf("unexpected result: %s, %v", url, admitted)
+			}
+		})
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncHTTPRoutes(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+			Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+				HTTPRoute: &risingwavev1alpha1.RisingWaveHTTPRoute{
+					ParentRefs: []risingwavev1alpha1.RisingWaveGatewayReference{{Name: "gateway"}},
+					Hostname:   "dashboard.example.com",
+					Scheme:     "http",
+				},
+			},
+		}
+	})
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	// Requeue before the HTTPRoute is accepted, since it isn't watched.
+	r, err := managerImpl.SyncHTTPRoutes(context.Background(), logr.Discard(), nil)
+	if err != nil || r.RequeueAfter != httpRoutesSyncInterval {
+		t.Fatal("unexpected result", r, err)
+	}
+
+	var httpRoute gatewayv1.HTTPRoute
+	if err := managerImpl.client.Get(context.Background(), types.NamespacedName{Namespace: risingwave.Namespace, Name: risingwave.Name + "-dashboard"}, &httpRoute); err != nil {
+		t.Fatal(err)
+	}
+
+	httpRoute.Status.Parents = []gatewayv1.RouteParentStatus{
+		{
+			ParentRef:      httpRoute.Spec.ParentRefs[0],
+			ControllerName: "example.com/gateway-controller",
+			Conditions: []metav1.Condition{
+				{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	httpRoute.ResourceVersion = ""
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, &httpRoute)
+
+	r, err = managerImpl.SyncHTTPRoutes(context.Background(), logr.Discard(), []gatewayv1.HTTPRoute{httpRoute})
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	if status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ExternalEndpoints; !equality.Semantic.DeepEqual(status, []risingwavev1alpha1.RisingWaveExternalEndpointStatus{
+		{Name: consts.ExternalEndpointDashboard, Kind: externalEndpointKindHTTPRoute, URL: "http://dashboard.example.com/"},
+	}) {
+		t.Fatalf("unexpected external endpoints: %v", status)
+	}
+
+	// Removing the external access deletes the HTTPRoute and clears the status.
+	risingwave.Spec.ExternalAccess = nil
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, &httpRoute)
+
+	r, err = managerImpl.SyncHTTPRoutes(context.Background(), logr.Discard(), []gatewayv1.HTTPRoute{httpRoute})
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("sync failed", r, err)
+	}
+
+	var httpRoutes gatewayv1.HTTPRouteList
+	if err := managerImpl.client.List(context.Background(), &httpRoutes, client.InNamespace(risingwave.Namespace)); err != nil {
+		t.Fatal(err)
+	}
+	if len(httpRoutes.Items) != 0 {
+		t.Fatalf("unexpected http routes: %d", len(httpRoutes.Items))
+	}
+	if status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ExternalEndpoints; status != nil {
+		t.Fatalf("unexpected external endpoints: %v", status)
+	}
+}
+
 func TestRisingWaveControllerManagerImpl_SyncMetaService(t *testing.T) {
 	fakeRisingwave := testutils.FakeRisingWave()
 
@@ -497,6 +942,53 @@ func TestRisingWaveControllerManagerImpl_SyncFrontendHeadlessService(t *testing.
 	})
 }
 
+func TestRisingWaveControllerManagerImpl_SyncStandaloneHeadlessService(t *testing.T) {
+	t.Run("create-when-ha", func(t *testing.T) {
+		risingwave := testutils.FakeRisingWaveStandaloneHA()
+		key := types.NamespacedName{Namespace: risingwave.Namespace, Name: risingwave.Name + "-standalone-headless"}
+		managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+		r, err := managerImpl.SyncStandaloneHeadlessService(context.Background(), logr.Discard(), nil)
+		if ctrlkit.NeedsRequeue(r, err) {
+			t.Fatal("sync failed", r, err)
+		}
+
+		var current corev1.Service
+		if err := managerImpl.client.Get(context.Background(), key, &current); err != nil {
+			t.Fatal(err)
+		}
+
+		if current.Spec.ClusterIP != corev1.ClusterIPNone || !current.Spec.PublishNotReadyAddresses {
+			t.Fatal("standalone headless service isn't headless or doesn't publish the addresses not ready")
+		}
+	})
+
+	t.Run("delete-when-single-replica", func(t *testing.T) {
+		risingwave := testutils.FakeRisingWaveStandaloneHA()
+		risingwave.Spec.Components.Standalone.Replicas = 1
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      risingwave.Name + "-standalone-headless",
+				Namespace: risingwave.Namespace,
+				Labels: map[string]string{
+					consts.LabelRisingWaveGeneration: strconv.FormatInt(risingwave.Generation-1, 10),
+				},
+			},
+		}
+		managerImpl := newRisingWaveControllerManagerImplForTest(risingwave, service)
+
+		r, err := managerImpl.SyncStandaloneHeadlessService(context.Background(), logr.Discard(), service)
+		if ctrlkit.NeedsRequeue(r, err) {
+			t.Fatal("sync failed", r, err)
+		}
+
+		var current corev1.Service
+		if err := managerImpl.client.Get(context.Background(), types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, &current); err == nil {
+			t.Fatal("standalone headless service still exists after delete")
+		}
+	})
+}
+
 func TestRisingWaveControllerManagerImpl_SyncComputeService(t *testing.T) {
 	fakeRisingwave := testutils.FakeRisingWave()
 
@@ -1446,6 +1938,7 @@ func TestRisingWaveControllerManagerImpl_CollectRunningStatisticsAndSyncStatus(t
 				[]appsv1.StatefulSet{computeStatefulSet},
 				[]appsv1.Deployment{compactorDeployment},
 				&corev1.ConfigMap{},
+				nil,
 			)
 			if ctrlkit.NeedsRequeue(r, err) {
 				t.Fatalf("unexpected requeue: %v %v", r, err)
@@ -1465,6 +1958,86 @@ func TestRisingWaveControllerManagerImpl_CollectRunningStatisticsAndSyncStatus(t
 	}
 }
 
+func TestRisingWaveControllerManagerImpl_CollectRunningStatisticsAndSyncStatus_Health(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	compactorDeployment := newReadyDeployment(risingwave, risingwave.Name+"-compactor", "")
+	compactorDeployment.Labels[consts.LabelRisingWaveComponent] = consts.ComponentCompactor
+	compactorDeployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ProgressDeadlineExceeded",
+			Message: "ReplicaSet has timed out progressing.",
+		},
+	}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: risingwave.Name + "-compute-0",
+				Labels: map[string]string{
+					consts.LabelRisingWaveComponent: consts.ComponentCompute,
+					consts.LabelRisingWaveGroup:     "",
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:         "compute",
+						RestartCount: 5,
+						State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					},
+				},
+			},
+		},
+	}
+
+	r, err := managerImpl.CollectRunningStatisticsAndSyncStatus(
+		context.Background(),
+		logr.Discard(),
+		&corev1.Service{},
+		nil,
+		&corev1.Service{},
+		&corev1.Service{},
+		&corev1.Service{},
+		[]appsv1.StatefulSet{newReadyStatefulSet(risingwave, risingwave.Name+"-meta", "")},
+		[]appsv1.Deployment{newReadyDeployment(risingwave, risingwave.Name+"-frontend", "")},
+		nil,
+		[]appsv1.StatefulSet{newReadyStatefulSet(risingwave, risingwave.Name+"-compute", "")},
+		[]appsv1.Deployment{compactorDeployment},
+		&corev1.ConfigMap{},
+		pods,
+	)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatalf("unexpected requeue: %v %v", r, err)
+	}
+
+	health := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.Health
+	if len(health) != 2 {
+		t.Fatalf("unexpected health issues: %+v", health)
+	}
+	if health[0].Component != consts.ComponentCompactor || health[0].Reason != risingwavev1alpha1.RisingWaveHealthReasonProgressDeadlineExceeded {
+		t.Fatalf("unexpected health issue: %+v", health[0])
+	}
+	if health[1].Component != consts.ComponentCompute || health[1].Reason != risingwavev1alpha1.RisingWaveHealthReasonCrashLoopBackOff ||
+		health[1].Count != 1 || health[1].Pods[0] != risingwave.Name+"-compute-0" {
+		t.Fatalf("unexpected health issue: %+v", health[1])
+	}
+
+	for _, event := range []consts.RisingWaveEventType{consts.RisingWaveEventTypeCrashLoopBackOff, consts.RisingWaveEventTypeProgressDeadlineExceeded} {
+		if !managerImpl.eventMessageStore.IsMessageSet(event.Name) {
+			t.Fatalf("message of %s not set", event.Name)
+		}
+	}
+	if managerImpl.eventMessageStore.IsMessageSet(consts.RisingWaveEventTypeOOMKilled.Name) {
+		t.Fatal("unexpected message of OOMKilled")
+	}
+	if msg := managerImpl.eventMessageStore.MessageFor(consts.RisingWaveEventTypeCrashLoopBackOff.Name); msg != "compute: 1 Pod(s) (fake-risingwave-compute-0): container compute restarted 5 times" {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+}
+
 func TestRisingWaveControllerManagerImpl_CollectOpenKruiseRunningStatisticsAndSyncStatus(t *testing.T) {
 	testcases := map[string]struct {
 		risingwave                   *risingwavev1alpha1.RisingWave
@@ -1511,6 +2084,7 @@ func TestRisingWaveControllerManagerImpl_CollectOpenKruiseRunningStatisticsAndSy
 				[]kruiseappsv1beta1.StatefulSet{computeAdvancedStatefulSet},
 				[]kruiseappsv1alpha1.CloneSet{compactorCloneSet},
 				&corev1.ConfigMap{},
+				nil,
 			)
 			if ctrlkit.NeedsRequeue(r, err) {
 				t.Fatalf("unexpected requeue: %v %v", r, err)
@@ -1581,3 +2155,606 @@ func TestRisingWaveControllerManagerImpl_ExpectedFrontendWorkloadFamily(t *testi
 		})
 	}
 }
+
+func TestRisingWaveControllerManagerImpl_SyncResourceRecommendations(t *testing.T) {
+	oomTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+
+	testcases := map[string]struct {
+		mode        risingwavev1alpha1.RisingWaveResourceRecommendationMode
+		running     bool
+		wantApplied bool
+	}{
+		"recommend": {
+			mode:    risingwavev1alpha1.RisingWaveResourceRecommendationModeRecommend,
+			running: true,
+		},
+		"auto-not-running": {
+			mode: risingwavev1alpha1.RisingWaveResourceRecommendationModeAuto,
+		},
+		"auto-running": {
+			mode:        risingwavev1alpha1.RisingWaveResourceRecommendationModeAuto,
+			running:     true,
+			wantApplied: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+				rw.Spec.Components.Compute.NodeGroups[0].ResourceRecommendation = &risingwavev1alpha1.RisingWaveResourceRecommendationPolicy{
+					Mode: tc.mode,
+				}
+				rw.ResourceVersion = "1"
+				rw.Status.Conditions = []risingwavev1alpha1.RisingWaveCondition{
+					{Type: risingwavev1alpha1.RisingWaveConditionRunning, Status: lo.If(tc.running, metav1.ConditionTrue).Else(metav1.ConditionFalse)},
+				}
+				// Published by the previous reconciliation.
+				rw.Status.ResourceRecommendations = []risingwavev1alpha1.RisingWaveResourceRecommendation{
+					{
+						Component: consts.ComponentCompute,
+						Group:     "",
+						Target:    corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+					},
+				}
+			})
+
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-risingwave-compute-0",
+					Namespace: risingwave.Namespace,
+					Labels: map[string]string{
+						consts.LabelRisingWaveName:      risingwave.Name,
+						consts.LabelRisingWaveComponent: consts.ComponentCompute,
+						consts.LabelRisingWaveGroup:     "",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: consts.ComponentCompute,
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: consts.ComponentCompute,
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: oomTime},
+							},
+						},
+					},
+				},
+			}
+
+			managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+			r, err := managerImpl.SyncResourceRecommendations(context.Background(), logr.Discard(), []corev1.Pod{pod})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.RequeueAfter != resourceRecommendationInterval {
+				t.Fatalf("unexpected result: %v", r)
+			}
+
+			recommendations := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ResourceRecommendations
+			if len(recommendations) != 1 {
+				t.Fatalf("unexpected recommendations: %v", recommendations)
+			}
+			if recommendations[0].OOMKills != 1 {
+				t.Fatalf("oom kill not tracked: %v", recommendations[0])
+			}
+			// The previous target is larger than the bumped limit.
+			if target := recommendations[0].Target[corev1.ResourceMemory]; target.Cmp(resource.MustParse("2Gi")) != 0 {
+				t.Fatalf("unexpected target: %s", target.String())
+			}
+
+			applied := recommendations[0].Applied[corev1.ResourceMemory]
+			if isApplied := applied.Cmp(resource.MustParse("2Gi")) == 0; isApplied != tc.wantApplied {
+				t.Fatalf("unexpected applied memory: %s", applied.String())
+			}
+
+			// The workloads are synced by upgrading once the recommendation is applied.
+			upgrading := object.NewRisingWaveReader(managerImpl.risingwaveManager.RisingWaveAfterImage()).
+				DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionUpgrading, true)
+			if upgrading != tc.wantApplied {
+				t.Fatalf("unexpected upgrading condition: %v", upgrading)
+			}
+
+			// The spec is never changed.
+			var obj risingwavev1alpha1.RisingWave
+			if err := managerImpl.client.Get(context.Background(), client.ObjectKeyFromObject(risingwave), &obj); err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(obj.Spec, risingwave.Spec) {
+				t.Fatal("spec changed")
+			}
+
+			// The workloads are built with the applied limits.
+			nodeGroup := object.NewRisingWaveReader(managerImpl.risingwaveManager.RisingWaveAfterImage()).GetWorkloadNodeGroup(consts.ComponentCompute, "")
+			memoryLimit := nodeGroup.Template.Spec.Resources.Limits[corev1.ResourceMemory]
+			if isApplied := memoryLimit.Cmp(resource.MustParse("2Gi")) == 0; isApplied != tc.wantApplied {
+				t.Fatalf("unexpected memory limit of the workload: %s", memoryLimit.String())
+			}
+		})
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncComputeStatefulSets_AppliedResourceRecommendation(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.Components.Compute.NodeGroups[0].ResourceRecommendation = &risingwavev1alpha1.RisingWaveResourceRecommendationPolicy{
+			Mode: risingwavev1alpha1.RisingWaveResourceRecommendationModeAuto,
+		}
+	})
+
+	// Create the StatefulSet before any recommendation is applied.
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+	if _, err := managerImpl.SyncComputeStatefulSets(context.Background(), logr.Discard(), nil); err != nil {
+		t.Fatal(err)
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := managerImpl.client.List(context.Background(), &statefulSets); err != nil {
+		t.Fatal(err)
+	}
+	if len(statefulSets.Items) != 1 {
+		t.Fatalf("unexpected statefulsets: %d", len(statefulSets.Items))
+	}
+
+	// The recommendation is applied without bumping the generation.
+	risingwave.Status.ResourceRecommendations = []risingwavev1alpha1.RisingWaveResourceRecommendation{
+		{
+			Component: consts.ComponentCompute,
+			Group:     "",
+			Applied:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+	}
+	managerImpl = newRisingWaveControllerManagerImplForTest(risingwave, &statefulSets.Items[0])
+	if managerImpl.isObjectSynced(&statefulSets.Items[0]) {
+		t.Fatal("statefulset synced before the applied recommendation is rolled out")
+	}
+	if _, err := managerImpl.SyncComputeStatefulSets(context.Background(), logr.Discard(), statefulSets.Items); err != nil {
+		t.Fatal(err)
+	}
+
+	var statefulSet appsv1.StatefulSet
+	if err := managerImpl.client.Get(context.Background(), client.ObjectKeyFromObject(&statefulSets.Items[0]), &statefulSet); err != nil {
+		t.Fatal(err)
+	}
+	if memoryLimit := statefulSet.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory]; memoryLimit.Cmp(resource.MustParse("2Gi")) != 0 {
+		t.Fatalf("applied recommendation not rolled out: %s", memoryLimit.String())
+	}
+	if !managerImpl.isObjectSynced(&statefulSet) {
+		t.Fatal("statefulset not synced after the applied recommendation is rolled out")
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncResourceRecommendations_Disabled(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Status.ResourceRecommendations = []risingwavev1alpha1.RisingWaveResourceRecommendation{
+			{Component: consts.ComponentCompute},
+		}
+	})
+
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+	r, err := managerImpl.SyncResourceRecommendations(context.Background(), logr.Discard(), nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatalf("unexpected requeue: %v %v", r, err)
+	}
+
+	if recommendations := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ResourceRecommendations; recommendations != nil {
+		t.Fatalf("recommendations not cleared: %v", recommendations)
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_ReportComputeRoles(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.EnableEmbeddedServingMode = ptr.To(true)
+		rw.Spec.Components.Compute.NodeGroups = append(rw.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+			Name:        "serving",
+			Replicas:    1,
+			ComputeRole: risingwavev1alpha1.RisingWaveComputeRoleServing,
+		})
+	})
+
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+	status := managerImpl.reportComputeRoles(risingwavev1alpha1.ComponentReplicasStatus{
+		Groups: []risingwavev1alpha1.ComponentGroupReplicasStatus{
+			{Name: ""},
+			{Name: "serving"},
+			{Name: "removed(-)"},
+		},
+	})
+
+	expected := []risingwavev1alpha1.RisingWaveComputeRole{
+		risingwavev1alpha1.RisingWaveComputeRoleStreaming,
+		risingwavev1alpha1.RisingWaveComputeRoleServing,
+		"",
+	}
+	for i, g := range status.Groups {
+		if g.Role != expected[i] {
+			t.Fatalf("unexpected role of group %s: %s", g.Name, g.Role)
+		}
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_ReportZones(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+		rw.Spec.Components.Compute.NodeGroups = append(rw.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+			Name:     "zoned",
+			Replicas: 3,
+			Zones: &risingwavev1alpha1.RisingWaveNodeGroupZones{
+				Values: []string{"z1", "z2"},
+			},
+		})
+	})
+
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+	status := managerImpl.reportZones(consts.ComponentCompute, buildNodeGroupStatus(
+		managerImpl.risingwaveManager.GetWorkloadNodeGroups(consts.ComponentCompute),
+		func(g *risingwavev1alpha1.RisingWaveNodeGroup) (string, int32) { return g.Name, g.Replicas },
+		[]appsv1.StatefulSet{
+			newStatefulSetWithGroupAndReadyReplicas("", 1),
+			newStatefulSetWithGroupAndReadyReplicas("zoned-z1", 1),
+		},
+		func(t *appsv1.StatefulSet) (string, int32) {
+			return t.Labels[consts.LabelRisingWaveGroup], t.Status.ReadyReplicas
+		},
+	))
+
+	expected := []risingwavev1alpha1.ComponentGroupReplicasStatus{
+		{Name: "", Target: 1, Running: 1, Exists: true},
+		{Name: "zoned", Target: 3, Running: 1, Exists: false, Zones: []risingwavev1alpha1.ComponentZoneReplicasStatus{
+			{Name: "z1", Target: 2, Running: 1, Exists: true},
+			{Name: "z2", Target: 1, Running: 0, Exists: false},
+		}},
+	}
+	if !equality.Semantic.DeepEqual(expected, status.Groups) {
+		t.Fatalf("unexpected groups: %v", status.Groups)
+	}
+	if status.Target != 4 || status.Running != 2 {
+		t.Fatalf("unexpected replicas: %d/%d", status.Running, status.Target)
+	}
+}
+
+func newStatefulSetWithGroupAndReadyReplicas(group string, readyReplicas int32) appsv1.StatefulSet {
+	return appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{consts.LabelRisingWaveGroup: group},
+		},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: readyReplicas},
+	}
+}
+
+func TestBuildResourceGroupsStatus(t *testing.T) {
+	nodes := []*pb.WorkerNode{
+		{Id: 1, Property: &pb.WorkerNode_Property{}},
+		{Id: 2, Property: &pb.WorkerNode_Property{ResourceGroup: ptr.To("default")}},
+		{Id: 3, Property: &pb.WorkerNode_Property{ResourceGroup: ptr.To("analytics")}},
+	}
+	jobs := []*pb.ListStreamingJobStatesResponse_StreamingJobState{
+		{TableId: 1, ResourceGroup: "default"},
+		{TableId: 2, ResourceGroup: "analytics"},
+		{TableId: 3, ResourceGroup: "analytics"},
+		{TableId: 4, ResourceGroup: "orphan"},
+	}
+
+	expected := []risingwavev1alpha1.RisingWaveResourceGroupStatus{
+		{Name: "analytics", Workers: 1, StreamingJobs: 2},
+		{Name: "default", Workers: 2, StreamingJobs: 1},
+		{Name: "orphan", Workers: 0, StreamingJobs: 1},
+	}
+	if status := buildResourceGroupsStatus(nodes, jobs); !equality.Semantic.DeepEqual(status, expected) {
+		t.Fatalf("unexpected status: %v", status)
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_CollectResourceGroupsAndSyncStatus(t *testing.T) {
+	newMetaPod := func(name, ip, role string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					consts.LabelRisingWaveComponent: consts.ComponentMeta,
+					consts.LabelRisingWaveMetaRole:  role,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				PodIP:      ip,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	testcases := map[string]struct {
+		nodeGroups   []risingwavev1alpha1.RisingWaveNodeGroup
+		pods         []corev1.Pod
+		wantAddr     string
+		wantRequeue  bool
+		collectError error
+	}{
+		"no-meta-pods": {},
+		"leader-preferred": {
+			pods: []corev1.Pod{
+				newMetaPod("meta-0", "10.0.0.1", consts.MetaRoleFollower),
+				newMetaPod("meta-1", "10.0.0.2", consts.MetaRoleLeader),
+			},
+			wantAddr: "10.0.0.2:5690",
+		},
+		"non-default-groups-requeue": {
+			nodeGroups: []risingwavev1alpha1.RisingWaveNodeGroup{
+				{Name: "", Replicas: 1},
+				{Name: "analytics", Replicas: 1},
+			},
+			pods:        []corev1.Pod{newMetaPod("meta-0", "10.0.0.1", consts.MetaRoleLeader)},
+			wantAddr:    "10.0.0.1:5690",
+			wantRequeue: true,
+		},
+		"collect-error": {
+			pods:         []corev1.Pod{newMetaPod("meta-0", "10.0.0.1", consts.MetaRoleLeader)},
+			wantAddr:     "10.0.0.1:5690",
+			collectError: errors.New("unavailable"),
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := testutils.FakeRisingWaveWithMutate(func(rw *risingwavev1alpha1.RisingWave) {
+				if tc.nodeGroups != nil {
+					rw.Spec.Components.Compute.NodeGroups = tc.nodeGroups
+				}
+				rw.Status.ResourceGroups = []risingwavev1alpha1.RisingWaveResourceGroupStatus{{Name: "stale"}}
+			})
+
+			managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+			collected := []risingwavev1alpha1.RisingWaveResourceGroupStatus{{Name: "default", Workers: 1}}
+			var gotAddr string
+			managerImpl.collectResourceGroups = func(ctx context.Context, addr string) ([]risingwavev1alpha1.RisingWaveResourceGroupStatus, error) {
+				gotAddr = addr
+
+				return collected, tc.collectError
+			}
+
+			r, err := managerImpl.CollectResourceGroupsAndSyncStatus(context.Background(), logr.Discard(), tc.pods)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (r.RequeueAfter > 0) != tc.wantRequeue {
+				t.Fatalf("unexpected result: %v", r)
+			}
+			if gotAddr != tc.wantAddr {
+				t.Fatalf("unexpected meta address: %s", gotAddr)
+			}
+
+			expected := []risingwavev1alpha1.RisingWaveResourceGroupStatus{{Name: "stale"}}
+			if tc.wantAddr != "" && tc.collectError == nil {
+				expected = collected
+			}
+			if status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.ResourceGroups; !equality.Semantic.DeepEqual(status, expected) {
+				t.Fatalf("unexpected status: %v", status)
+			}
+		})
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_RemediateStuckPods(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Spec.Remediation = &risingwavev1alpha1.RisingWaveRemediationPolicy{
+		StuckThreshold:   &metav1.Duration{Duration: 5 * time.Minute},
+		MaxInterventions: ptr.To[int32](1),
+	}
+
+	computeStatefulSet := newReadyStatefulSet(risingwave, risingwave.Name+"-compute", "")
+	computeStatefulSet.UID = "compute"
+	computeStatefulSet.Labels[consts.LabelRisingWaveComponent] = consts.ComponentCompute
+	computeStatefulSet.Status.CurrentRevision, computeStatefulSet.Status.UpdateRevision = "old", "new"
+	metaStatefulSet := newReadyStatefulSet(risingwave, risingwave.Name+"-meta", "")
+	metaStatefulSet.UID = "meta"
+	metaStatefulSet.Labels[consts.LabelRisingWaveComponent] = consts.ComponentMeta
+	metaStatefulSet.Status.CurrentRevision, metaStatefulSet.Status.UpdateRevision = "old", "new"
+
+	newStuckPod := func(name string, owner types.UID, notReadyFor time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: risingwave.Namespace,
+				UID:       types.UID(name),
+				Labels:    map[string]string{appsv1.StatefulSetRevisionLabel: "old"},
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "StatefulSet", Name: name, UID: owner, Controller: ptr.To(true)},
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodReady,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-notReadyFor)),
+					},
+				},
+			},
+		}
+	}
+	computePod := newStuckPod(risingwave.Name+"-compute-0", "compute", time.Hour)
+	metaPod := newStuckPod(risingwave.Name+"-meta-0", "meta", 10*time.Minute)
+
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave, computePod, metaPod)
+
+	r, err := managerImpl.RemediateStuckPods(context.Background(), logr.Discard(),
+		[]appsv1.StatefulSet{metaStatefulSet}, nil, []appsv1.StatefulSet{computeStatefulSet}, nil,
+		[]corev1.Pod{*computePod, *metaPod})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.RequeueAfter != remediationBudgetRecheckInterval {
+		t.Fatalf("unexpected result: %v", r)
+	}
+
+	// The longest stuck Pod is deleted within the budget, and the other is withheld.
+	if err := managerImpl.client.Get(context.Background(), client.ObjectKeyFromObject(computePod), &corev1.Pod{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("compute pod not deleted: %v", err)
+	}
+	if err := managerImpl.client.Get(context.Background(), client.ObjectKeyFromObject(metaPod), &corev1.Pod{}); err != nil {
+		t.Fatalf("meta pod deleted: %v", err)
+	}
+
+	status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.Remediation
+	if status == nil || len(status.Interventions) != 1 || status.TotalInterventions != 1 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if record := status.Interventions[0]; record.Pod != computePod.Name || record.Component != consts.ComponentCompute ||
+		record.Reason != risingwavev1alpha1.RisingWaveRemediationReasonOutdated {
+		t.Fatalf("unexpected intervention: %+v", record)
+	}
+	if !slices.Equal(status.Withheld, []string{metaPod.Name}) {
+		t.Fatalf("unexpected withheld pods: %v", status.Withheld)
+	}
+
+	for _, event := range []consts.RisingWaveEventType{consts.RisingWaveEventTypeRemediated, consts.RisingWaveEventTypeRemediationBudgetExhausted} {
+		if !managerImpl.eventMessageStore.IsMessageSet(event.Name) {
+			t.Fatalf("message of %s not set", event.Name)
+		}
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_RemediateStuckPods_Disabled(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Status.Remediation = &risingwavev1alpha1.RisingWaveRemediationStatus{TotalInterventions: 1}
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave)
+
+	r, err := managerImpl.RemediateStuckPods(context.Background(), logr.Discard(), nil, nil, nil, nil, nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatalf("unexpected requeue: %v %v", r, err)
+	}
+	if status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.Remediation; status != nil {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_SyncObject_MaintenanceWindows(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	// A window that isn't open for years.
+	risingwave.Spec.MaintenanceWindows = []risingwavev1alpha1.RisingWaveMaintenanceWindow{
+		{Schedule: "0 0 29 2 *", Duration: metav1.Duration{Duration: time.Minute}},
+	}
+
+	newStatefulSet := func(generation int64, image string, replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: risingwave.Namespace,
+				Name:      risingwave.Name + "-compute",
+				Labels:    map[string]string{consts.LabelRisingWaveGeneration: strconv.FormatInt(generation, 10)},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "risingwave", Image: image}}},
+				},
+			},
+		}
+	}
+
+	current := newStatefulSet(risingwave.Generation-1, "v1", 1)
+	current.Annotations = map[string]string{consts.AnnotationPodTemplateHash: maintenance.PodTemplateHash(current)}
+
+	sync := func(mgr *risingWaveControllerManagerImpl, obj *appsv1.StatefulSet) *appsv1.StatefulSet {
+		if err := syncObject(mgr, context.Background(), obj, func() *appsv1.StatefulSet {
+			return newStatefulSet(risingwave.Generation, "v2", 3)
+		}, logr.Discard()); err != nil {
+			t.Fatal(err)
+		}
+
+		var sts appsv1.StatefulSet
+		if err := mgr.client.Get(context.Background(), client.ObjectKeyFromObject(obj), &sts); err != nil {
+			t.Fatal(err)
+		}
+
+		return &sts
+	}
+
+	// The replicas are changed, while the image is deferred.
+	mgr := newRisingWaveControllerManagerImplForTest(risingwave, current)
+	sts := sync(mgr, current)
+	if *sts.Spec.Replicas != 3 || sts.Spec.Template.Spec.Containers[0].Image != "v1" || !maintenance.IsPending(sts) {
+		t.Fatalf("unexpected statefulset: replicas %d, image %s", *sts.Spec.Replicas, sts.Spec.Template.Spec.Containers[0].Image)
+	}
+	if !object.NewRisingWaveReader(mgr.risingwaveManager.RisingWaveAfterImage()).DoesConditionExistAndEqual(risingwavev1alpha1.RisingWaveConditionPendingMaintenance, true) {
+		t.Fatal("pending maintenance should be reported")
+	}
+	if !mgr.isObjectSynced(sts) {
+		t.Fatal("deferred object should be synced outside the windows")
+	}
+
+	// Forced.
+	risingwave.Annotations = map[string]string{consts.AnnotationApplyPendingMaintenance: "true"}
+	mgr = newRisingWaveControllerManagerImplForTest(risingwave, sts)
+	if mgr.isObjectSynced(sts) {
+		t.Fatal("deferred object should be synced once allowed")
+	}
+	sts = sync(mgr, sts)
+	if sts.Spec.Template.Spec.Containers[0].Image != "v2" || maintenance.IsPending(sts) {
+		t.Fatalf("deferred changes should be applied, image %s", sts.Spec.Template.Spec.Containers[0].Image)
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_StopStandaloneForMigration(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveMigratingFromStandalone()
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: risingwave.Namespace, Name: risingwave.Name + "-standalone", UID: "standalone-uid"},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: risingwave.Namespace,
+			Name:      risingwave.Name + "-standalone-0",
+			Labels:    map[string]string{consts.LabelRisingWaveComponent: consts.ComponentStandalone},
+		},
+	}
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave, sts)
+
+	// The StatefulSet is deleted, and it waits for the Pod to stop.
+	r, err := managerImpl.StopStandaloneForMigration(context.Background(), logr.Discard(), sts, nil, []corev1.Pod{pod})
+	if err != nil || r.RequeueAfter == 0 {
+		t.Fatal("expected requeue", r, err)
+	}
+	if err := managerImpl.client.Get(context.Background(), client.ObjectKeyFromObject(sts), &appsv1.StatefulSet{}); !apierrors.IsNotFound(err) {
+		t.Fatal("standalone statefulset not deleted", err)
+	}
+	status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.StandaloneMigration
+	if status == nil || status.Phase != risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStoppingStandalone {
+		t.Fatalf("unexpected status: %v", status)
+	}
+
+	// Continue to bring up the meta nodes once the Pod is gone.
+	r, err = managerImpl.StopStandaloneForMigration(context.Background(), logr.Discard(), nil, nil, nil)
+	if ctrlkit.NeedsRequeue(r, err) {
+		t.Fatal("expected continue", r, err)
+	}
+	status = managerImpl.risingwaveManager.RisingWaveAfterImage().Status.StandaloneMigration
+	if status.Phase != risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStartingMeta {
+		t.Fatalf("unexpected status: %v", status)
+	}
+}
+
+func TestRisingWaveControllerManagerImpl_StopStandaloneForMigration_Blocked(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveMigratingFromStandalone()
+	risingwave.Spec.MetaStore.SQLite.Path = "/tmp/meta.db"
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: risingwave.Namespace, Name: risingwave.Name + "-standalone", UID: "standalone-uid"},
+	}
+	managerImpl := newRisingWaveControllerManagerImplForTest(risingwave, sts)
+
+	if _, err := managerImpl.StopStandaloneForMigration(context.Background(), logr.Discard(), sts, nil, nil); err != ctrlkit.ErrExit {
+		t.Fatalf("expected ErrExit, got %v", err)
+	}
+	if err := managerImpl.client.Get(context.Background(), client.ObjectKeyFromObject(sts), &appsv1.StatefulSet{}); err != nil {
+		t.Fatal("standalone statefulset deleted", err)
+	}
+	status := managerImpl.risingwaveManager.RisingWaveAfterImage().Status.StandaloneMigration
+	if status == nil || status.Phase != risingwavev1alpha1.RisingWaveStandaloneMigrationPhasePending {
+		t.Fatalf("unexpected status: %v", status)
+	}
+}
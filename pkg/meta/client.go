@@ -0,0 +1,151 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package meta provides a minimal client of the RisingWave meta service.
+package meta
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/risingwavelabs/risingwave-operator/pkg/controller/proto"
+)
+
+// Client is a client of the RisingWave meta service. It must be closed after use.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// ListComputeNodes lists the running compute nodes registered in the cluster.
+func (c *Client) ListComputeNodes(ctx context.Context) ([]*pb.WorkerNode, error) {
+	workerType := pb.WorkerType_WORKER_TYPE_COMPUTE_NODE
+
+	resp, err := pb.NewClusterServiceClient(c.conn).ListAllNodes(ctx, &pb.ListAllNodesRequest{
+		WorkerType: &workerType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list compute nodes: %w", err)
+	}
+
+	return resp.GetNodes(), nil
+}
+
+// ListStreamingJobStates lists the states of the streaming jobs in the cluster.
+func (c *Client) ListStreamingJobStates(ctx context.Context) ([]*pb.ListStreamingJobStatesResponse_StreamingJobState, error) {
+	resp, err := pb.NewStreamManagerServiceClient(c.conn).ListStreamingJobStates(ctx, &pb.ListStreamingJobStatesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list streaming jobs: %w", err)
+	}
+
+	return resp.GetStates(), nil
+}
+
+// GetSystemParams gets the system parameters of the cluster.
+func (c *Client) GetSystemParams(ctx context.Context) (*pb.SystemParams, error) {
+	resp, err := pb.NewSystemParamsServiceClient(c.conn).GetSystemParams(ctx, &pb.GetSystemParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get system params: %w", err)
+	}
+
+	return resp.GetParams(), nil
+}
+
+// SetSystemParam sets the system parameter of the cluster to the value.
+func (c *Client) SetSystemParam(ctx context.Context, param, value string) error {
+	_, err := pb.NewSystemParamsServiceClient(c.conn).SetSystemParam(ctx, &pb.SetSystemParamRequest{
+		Param: param,
+		Value: &value,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set system param %s: %w", param, err)
+	}
+
+	return nil
+}
+
+// Pause pauses the streaming jobs of the cluster. It's a no-op if they are paused already. The request and the
+// response carry no field used by the operator, so they are sent and received as empty messages.
+func (c *Client) Pause(ctx context.Context) error {
+	if err := c.conn.Invoke(ctx, "/meta.StreamManagerService/Pause", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		return fmt.Errorf("unable to pause: %w", err)
+	}
+
+	return nil
+}
+
+// Resume resumes the streaming jobs of the cluster. It's a no-op if they aren't paused. The request and the response
+// are sent and received as empty messages like the ones of Pause.
+func (c *Client) Resume(ctx context.Context) error {
+	if err := c.conn.Invoke(ctx, "/meta.StreamManagerService/Resume", &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		return fmt.Errorf("unable to resume: %w", err)
+	}
+
+	return nil
+}
+
+// BackupMeta starts a job to take a snapshot of the metadata, and returns the ID of the job, which is also the ID of
+// the snapshot once the job succeeds.
+func (c *Client) BackupMeta(ctx context.Context) (uint64, error) {
+	resp, err := pb.NewBackupServiceClient(c.conn).BackupMeta(ctx, &pb.BackupMetaRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("unable to backup meta: %w", err)
+	}
+
+	return resp.GetJobId(), nil
+}
+
+// GetBackupJobStatus gets the status of the backup job and the message of it.
+func (c *Client) GetBackupJobStatus(ctx context.Context, jobID uint64) (pb.BackupJobStatus, string, error) {
+	resp, err := pb.NewBackupServiceClient(c.conn).GetBackupJobStatus(ctx, &pb.GetBackupJobStatusRequest{JobId: jobID})
+	if err != nil {
+		return pb.BackupJobStatus_UNSPECIFIED, "", fmt.Errorf("unable to get backup job status: %w", err)
+	}
+
+	return resp.GetJobStatus(), resp.GetMessage(), nil
+}
+
+// DeleteMetaSnapshots deletes the snapshots of the metadata.
+func (c *Client) DeleteMetaSnapshots(ctx context.Context, snapshotIDs ...uint64) error {
+	_, err := pb.NewBackupServiceClient(c.conn).DeleteMetaSnapshot(ctx, &pb.DeleteMetaSnapshotRequest{SnapshotIds: snapshotIDs})
+	if err != nil {
+		return fmt.Errorf("unable to delete meta snapshots: %w", err)
+	}
+
+	return nil
+}
+
+// NewClient creates a client of the meta service at the given address (host:port). The connection is established
+// lazily on the first request.
+func NewClient(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		var d net.Dialer
+
+		return d.DialContext(ctx, "tcp", s)
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect: %w", err)
+	}
+
+	return &Client{conn: conn}, nil
+}
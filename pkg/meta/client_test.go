@@ -0,0 +1,263 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/utils/ptr"
+
+	pb "github.com/risingwavelabs/risingwave-operator/pkg/controller/proto"
+)
+
+type fakeMetaServer struct {
+	pb.UnimplementedClusterServiceServer
+	pb.UnimplementedStreamManagerServiceServer
+	pb.UnimplementedSystemParamsServiceServer
+	pb.UnimplementedBackupServiceServer
+
+	params   pb.SystemParams
+	paused   bool
+	jobs     map[uint64]pb.BackupJobStatus
+	nextJob  uint64
+	snapshot []uint64
+}
+
+func (s *fakeMetaServer) ListAllNodes(_ context.Context, req *pb.ListAllNodesRequest) (*pb.ListAllNodesResponse, error) {
+	nodes := []*pb.WorkerNode{
+		{Id: 1, Type: pb.WorkerType_WORKER_TYPE_COMPUTE_NODE, Property: &pb.WorkerNode_Property{ResourceGroup: ptr.To("default")}},
+		{Id: 2, Type: pb.WorkerType_WORKER_TYPE_FRONTEND},
+	}
+
+	var filtered []*pb.WorkerNode
+	for _, n := range nodes {
+		if req.WorkerType == nil || n.GetType() == req.GetWorkerType() {
+			filtered = append(filtered, n)
+		}
+	}
+
+	return &pb.ListAllNodesResponse{Nodes: filtered}, nil
+}
+
+func (s *fakeMetaServer) ListStreamingJobStates(context.Context, *pb.ListStreamingJobStatesRequest) (*pb.ListStreamingJobStatesResponse, error) {
+	return &pb.ListStreamingJobStatesResponse{
+		States: []*pb.ListStreamingJobStatesResponse_StreamingJobState{
+			{TableId: 1, Name: "mv", ResourceGroup: "default"},
+		},
+	}, nil
+}
+
+func (s *fakeMetaServer) GetSystemParams(context.Context, *pb.GetSystemParamsRequest) (*pb.GetSystemParamsResponse, error) {
+	return &pb.GetSystemParamsResponse{Params: &s.params}, nil
+}
+
+func (s *fakeMetaServer) SetSystemParam(_ context.Context, req *pb.SetSystemParamRequest) (*pb.SetSystemParamResponse, error) {
+	if req.GetParam() != "pause_on_next_bootstrap" {
+		return nil, status.Errorf(codes.InvalidArgument, "unrecognized system param %q", req.GetParam())
+	}
+	s.params.PauseOnNextBootstrap = ptr.To(req.GetValue() == "true")
+
+	return &pb.SetSystemParamResponse{Params: &s.params}, nil
+}
+
+func (s *fakeMetaServer) BackupMeta(context.Context, *pb.BackupMetaRequest) (*pb.BackupMetaResponse, error) {
+	s.nextJob++
+	s.jobs[s.nextJob] = pb.BackupJobStatus_SUCCEEDED
+	s.snapshot = append(s.snapshot, s.nextJob)
+
+	return &pb.BackupMetaResponse{JobId: s.nextJob}, nil
+}
+
+func (s *fakeMetaServer) GetBackupJobStatus(_ context.Context, req *pb.GetBackupJobStatusRequest) (*pb.GetBackupJobStatusResponse, error) {
+	jobStatus, ok := s.jobs[req.GetJobId()]
+	if !ok {
+		jobStatus = pb.BackupJobStatus_NOT_FOUND
+	}
+
+	return &pb.GetBackupJobStatusResponse{JobId: req.GetJobId(), JobStatus: jobStatus}, nil
+}
+
+func (s *fakeMetaServer) DeleteMetaSnapshot(_ context.Context, req *pb.DeleteMetaSnapshotRequest) (*pb.DeleteMetaSnapshotResponse, error) {
+	s.snapshot = slices.DeleteFunc(s.snapshot, func(id uint64) bool {
+		return slices.Contains(req.GetSnapshotIds(), id)
+	})
+
+	return &pb.DeleteMetaSnapshotResponse{}, nil
+}
+
+func startFakeMetaServer(t *testing.T) string {
+	t.Helper()
+
+	addr, _ := startFakeMetaServerWithState(t)
+
+	return addr
+}
+
+func startFakeMetaServerWithState(t *testing.T) (string, *fakeMetaServer) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeMetaServer{
+		params: pb.SystemParams{
+			StateStore:             ptr.To("hummock+s3://hummock001"),
+			DataDirectory:          ptr.To("hummock"),
+			BackupStorageUrl:       ptr.To("s3://hummock001"),
+			BackupStorageDirectory: ptr.To("backup"),
+			PauseOnNextBootstrap:   ptr.To(false),
+		},
+		jobs: make(map[uint64]pb.BackupJobStatus),
+	}
+
+	// Pause isn't in the generated services, so it's served as an unknown method.
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		if method, _ := grpc.MethodFromServerStream(stream); method != "/meta.StreamManagerService/Pause" {
+			return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+		}
+		if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+			return err
+		}
+		fake.paused = true
+
+		return stream.SendMsg(&emptypb.Empty{})
+	}))
+	pb.RegisterClusterServiceServer(server, fake)
+	pb.RegisterStreamManagerServiceServer(server, fake)
+	pb.RegisterSystemParamsServiceServer(server, fake)
+	pb.RegisterBackupServiceServer(server, fake)
+
+	go server.Serve(lis) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String(), fake
+}
+
+func TestClient(t *testing.T) {
+	client, err := NewClient(startFakeMetaServer(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close() //nolint:errcheck
+
+	nodes, err := client.ListComputeNodes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].GetId() != 1 || nodes[0].GetProperty().GetResourceGroup() != "default" {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	}
+
+	jobs, err := client.ListStreamingJobStates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].GetName() != "mv" || jobs[0].GetResourceGroup() != "default" {
+		t.Fatalf("unexpected jobs: %v", jobs)
+	}
+}
+
+func TestClient_SystemParams(t *testing.T) {
+	client, err := NewClient(startFakeMetaServer(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close() //nolint:errcheck
+
+	params, err := client.GetSystemParams(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.GetDataDirectory() != "hummock" || params.GetBackupStorageDirectory() != "backup" || params.GetPauseOnNextBootstrap() {
+		t.Fatalf("unexpected params: %v", params)
+	}
+
+	if err := client.SetSystemParam(context.Background(), "pause_on_next_bootstrap", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetSystemParam(context.Background(), "unknown", "true"); err == nil {
+		t.Fatal("unknown param should be rejected")
+	}
+
+	params, err = client.GetSystemParams(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !params.GetPauseOnNextBootstrap() {
+		t.Fatalf("param not set: %v", params)
+	}
+}
+
+func TestClient_Pause(t *testing.T) {
+	addr, server := startFakeMetaServerWithState(t)
+	client, err := NewClient(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close() //nolint:errcheck
+
+	if err := client.Pause(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !server.paused {
+		t.Fatal("cluster not paused")
+	}
+}
+
+func TestClient_BackupMeta(t *testing.T) {
+	addr, server := startFakeMetaServerWithState(t)
+	client, err := NewClient(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close() //nolint:errcheck
+
+	jobID, err := client.BackupMeta(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobStatus, _, err := client.GetBackupJobStatus(context.Background(), jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobStatus != pb.BackupJobStatus_SUCCEEDED {
+		t.Fatalf("unexpected job status: %s", jobStatus)
+	}
+
+	jobStatus, _, err = client.GetBackupJobStatus(context.Background(), jobID+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobStatus != pb.BackupJobStatus_NOT_FOUND {
+		t.Fatalf("unexpected job status of an unknown job: %s", jobStatus)
+	}
+
+	if err := client.DeleteMetaSnapshots(context.Background(), jobID); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.snapshot) != 0 {
+		t.Fatalf("snapshots not deleted: %v", server.snapshot)
+	}
+}
@@ -246,6 +246,11 @@ func ResetMetrics() {
 	webhookRequestPanicCount.Reset()
 	webhookRequestPassCount.Reset()
 	webhookRequestRejectCount.Reset()
+
+	for _, vec := range risingwaveMetricVecs() {
+		vec.Reset()
+	}
+	risingwaveControllerActionDuration.Reset()
 }
 
 // InitMetrics registers custom metrics with the global prometheus registry.
@@ -260,4 +265,9 @@ func InitMetrics() {
 	metrics.Registry.MustRegister(webhookRequestPanicCount)
 	metrics.Registry.MustRegister(webhookRequestPassCount)
 	metrics.Registry.MustRegister(webhookRequestRejectCount)
+
+	for _, vec := range risingwaveMetricVecs() {
+		metrics.Registry.MustRegister(vec)
+	}
+	metrics.Registry.MustRegister(risingwaveControllerActionDuration)
 }
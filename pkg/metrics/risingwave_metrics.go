@@ -0,0 +1,177 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/risingwavelabs/ctrlkit"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+// Results of the actions, reported in the action duration histogram.
+const (
+	ActionResultContinue = "continue"
+	ActionResultRequeue  = "requeue"
+	ActionResultExit     = "exit"
+	ActionResultError    = "error"
+)
+
+// Values of the status label of the condition gauge.
+var conditionStatusValues = []string{"true", "false", "unknown"}
+
+var (
+	// RisingWave metrics vectors have the following attributes:
+	// namespace: The namespace of the RisingWave, e.g., default
+	// name: The name of the RisingWave
+	// They reflect the status of the RisingWave observed by the operator, and are removed once the RisingWave
+	// is deleted.
+	risingwaveStatusCondition = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "risingwave_status_condition",
+			Help: "Value is 1 if the condition of the RisingWave is in the status, 0 otherwise",
+		},
+		[]string{"namespace", "name", "type", "status"},
+	)
+	risingwaveComponentTargetReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "risingwave_component_target_replicas",
+			Help: "Target replicas of the group of the RisingWave component",
+		},
+		[]string{"namespace", "name", "component", "group"},
+	)
+	risingwaveComponentRunningReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "risingwave_component_running_replicas",
+			Help: "Running replicas of the group of the RisingWave component",
+		},
+		[]string{"namespace", "name", "component", "group"},
+	)
+	risingwaveScaleViewLockedReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "risingwave_scale_view_locked_replicas",
+			Help: "Replicas of the group locked by the RisingWaveScaleView",
+		},
+		[]string{"namespace", "name", "scale_view", "component", "group"},
+	)
+	risingwaveObservedGenerationLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "risingwave_observed_generation_lag",
+			Help: "Difference between the generation and the observed generation of the RisingWave",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// Action metrics vectors have the following attributes:
+	// action: The name of the action in the workflow of the controller, e.g., SyncMetaService
+	// result: The result of the action, the value should be one of "continue", "requeue", "exit" and "error".
+	risingwaveControllerActionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "risingwave_controller_action_duration_seconds",
+			Help:    "Length of time per action in the workflow of the RisingWave controller",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 16), // from 1ms to about 33s
+		},
+		[]string{"action", "result"},
+	)
+)
+
+func risingwaveMetricVecs() []*prometheus.GaugeVec {
+	return []*prometheus.GaugeVec{
+		risingwaveStatusCondition,
+		risingwaveComponentTargetReplicas,
+		risingwaveComponentRunningReplicas,
+		risingwaveScaleViewLockedReplicas,
+		risingwaveObservedGenerationLag,
+	}
+}
+
+// DeleteRisingWaveMetrics removes the metrics of the given RisingWave.
+func DeleteRisingWaveMetrics(target types.NamespacedName) {
+	for _, vec := range risingwaveMetricVecs() {
+		vec.DeletePartialMatch(prometheus.Labels{"namespace": target.Namespace, "name": target.Name})
+	}
+}
+
+// UpdateRisingWaveMetrics updates the metrics of the RisingWave with its status. The metrics of the groups and the
+// scale views no longer in the status are removed.
+func UpdateRisingWaveMetrics(risingwave *risingwavev1alpha1.RisingWave) {
+	namespace, name := risingwave.Namespace, risingwave.Name
+	DeleteRisingWaveMetrics(types.NamespacedName{Namespace: namespace, Name: name})
+
+	for _, cond := range risingwave.Status.Conditions {
+		for _, status := range conditionStatusValues {
+			value := 0.0
+			if strings.EqualFold(string(cond.Status), status) {
+				value = 1.0
+			}
+			risingwaveStatusCondition.WithLabelValues(namespace, name, string(cond.Type), status).Set(value)
+		}
+	}
+
+	componentReplicas := &risingwave.Status.ComponentReplicas
+	for component, replicas := range map[string]*risingwavev1alpha1.ComponentReplicasStatus{
+		consts.ComponentMeta:       &componentReplicas.Meta,
+		consts.ComponentFrontend:   &componentReplicas.Frontend,
+		consts.ComponentCompute:    &componentReplicas.Compute,
+		consts.ComponentCompactor:  &componentReplicas.Compactor,
+		consts.ComponentStandalone: &componentReplicas.Standalone,
+	} {
+		for _, group := range replicas.Groups {
+			risingwaveComponentTargetReplicas.WithLabelValues(namespace, name, component, group.Name).Set(float64(group.Target))
+			risingwaveComponentRunningReplicas.WithLabelValues(namespace, name, component, group.Name).Set(float64(group.Running))
+		}
+
+		// Components without groups, e.g., the standalone one.
+		if len(replicas.Groups) == 0 && (replicas.Target > 0 || replicas.Running > 0) {
+			risingwaveComponentTargetReplicas.WithLabelValues(namespace, name, component, "").Set(float64(replicas.Target))
+			risingwaveComponentRunningReplicas.WithLabelValues(namespace, name, component, "").Set(float64(replicas.Running))
+		}
+	}
+
+	for _, scaleView := range risingwave.Status.ScaleViews {
+		for _, groupLock := range scaleView.GroupLocks {
+			risingwaveScaleViewLockedReplicas.WithLabelValues(namespace, name, scaleView.Name, scaleView.Component, groupLock.Name).
+				Set(float64(groupLock.Replicas))
+		}
+	}
+
+	risingwaveObservedGenerationLag.WithLabelValues(namespace, name).Set(float64(risingwave.Generation - risingwave.Status.ObservedGeneration))
+}
+
+// ActionResult returns the result label of the action with the given result and error.
+func ActionResult(result ctrl.Result, err error) string {
+	switch {
+	case errors.Is(err, ctrlkit.ErrExit):
+		return ActionResultExit
+	case err != nil:
+		return ActionResultError
+	case ctrlkit.NeedsRequeue(result, nil):
+		return ActionResultRequeue
+	default:
+		return ActionResultContinue
+	}
+}
+
+// ObserveRisingWaveControllerActionDuration updates the action duration histogram with the given duration.
+func ObserveRisingWaveControllerActionDuration(action, result string, duration time.Duration) {
+	risingwaveControllerActionDuration.WithLabelValues(action, result).Observe(duration.Seconds())
+}
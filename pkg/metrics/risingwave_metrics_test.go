@@ -0,0 +1,92 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/risingwavelabs/ctrlkit"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+func Test_UpdateRisingWaveMetrics(t *testing.T) {
+	ResetMetrics()
+	defer ResetMetrics()
+
+	risingwave := &risingwavev1alpha1.RisingWave{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rw", Generation: 3},
+		Status: risingwavev1alpha1.RisingWaveStatus{
+			ObservedGeneration: 2,
+			Conditions: []risingwavev1alpha1.RisingWaveCondition{
+				{Type: risingwavev1alpha1.RisingWaveConditionRunning, Status: metav1.ConditionFalse},
+			},
+			ComponentReplicas: risingwavev1alpha1.RisingWaveComponentsReplicasStatus{
+				Compute: risingwavev1alpha1.ComponentReplicasStatus{
+					Target:  3,
+					Running: 2,
+					Groups: []risingwavev1alpha1.ComponentGroupReplicasStatus{
+						{Name: "a", Target: 2, Running: 1},
+						{Name: "b", Target: 1, Running: 1},
+					},
+				},
+				Standalone: risingwavev1alpha1.ComponentReplicasStatus{Target: 1, Running: 1},
+			},
+			ScaleViews: []risingwavev1alpha1.RisingWaveScaleViewLock{
+				{
+					Name:       "sv",
+					Component:  consts.ComponentCompute,
+					GroupLocks: []risingwavev1alpha1.RisingWaveScaleViewLockGroupLock{{Name: "a", Replicas: 2}},
+				},
+			},
+		},
+	}
+
+	UpdateRisingWaveMetrics(risingwave)
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(risingwaveStatusCondition.WithLabelValues("default", "rw", "Running", "true")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(risingwaveStatusCondition.WithLabelValues("default", "rw", "Running", "false")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(risingwaveComponentTargetReplicas.WithLabelValues("default", "rw", consts.ComponentCompute, "a")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(risingwaveComponentRunningReplicas.WithLabelValues("default", "rw", consts.ComponentCompute, "a")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(risingwaveComponentTargetReplicas.WithLabelValues("default", "rw", consts.ComponentStandalone, "")))
+	assert.Equal(t, 3, testutil.CollectAndCount(risingwaveComponentTargetReplicas), "empty components not reported")
+	assert.Equal(t, 2.0, testutil.ToFloat64(risingwaveScaleViewLockedReplicas.WithLabelValues("default", "rw", "sv", consts.ComponentCompute, "a")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(risingwaveObservedGenerationLag.WithLabelValues("default", "rw")))
+
+	// Groups no longer in the status are removed.
+	risingwave.Status.ComponentReplicas.Compute.Groups = risingwave.Status.ComponentReplicas.Compute.Groups[:1]
+	UpdateRisingWaveMetrics(risingwave)
+	assert.Equal(t, 2, testutil.CollectAndCount(risingwaveComponentTargetReplicas))
+
+	DeleteRisingWaveMetrics(types.NamespacedName{Namespace: "default", Name: "rw"})
+	for _, vec := range risingwaveMetricVecs() {
+		assert.Equal(t, 0, testutil.CollectAndCount(vec))
+	}
+}
+
+func Test_ActionResult(t *testing.T) {
+	assert.Equal(t, ActionResultContinue, ActionResult(ctrl.Result{}, nil))
+	assert.Equal(t, ActionResultRequeue, ActionResult(ctrl.Result{RequeueAfter: time.Second}, nil))
+	assert.Equal(t, ActionResultExit, ActionResult(ctrlkit.Exit()))
+	assert.Equal(t, ActionResultError, ActionResult(ctrl.Result{}, errors.New("error")))
+}
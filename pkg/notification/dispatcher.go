@@ -0,0 +1,322 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+const (
+	queueSize          = 256
+	requestTimeout     = 10 * time.Second
+	defaultMaxAttempts = 3
+	defaultBackoff     = time.Second
+	maxMessageLength   = 256
+)
+
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwavenotifications,verbs=get;list;watch
+// +kubebuilder:rbac:groups=risingwave.risingwavelabs.com,resources=risingwavenotifications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// Dispatcher is a Notifier that delivers the notifications to the sinks of the matching RisingWaveNotifications in the
+// background. It must be added to the manager to run.
+type Dispatcher struct {
+	client client.Client
+	// reader reads the Secrets of the headers from the API server without the cache.
+	reader     client.Reader
+	httpClient *http.Client
+	queue      chan Notification
+
+	mu sync.Mutex
+	// lastWarnings are the messages of the warning events last sent for the RisingWaves.
+	lastWarnings map[types.NamespacedName]map[string]string
+}
+
+// NewDispatcher creates a Dispatcher.
+func NewDispatcher(client client.Client, reader client.Reader) *Dispatcher {
+	return &Dispatcher{
+		client:       client,
+		reader:       reader,
+		httpClient:   &http.Client{Timeout: requestTimeout},
+		queue:        make(chan Notification, queueSize),
+		lastWarnings: make(map[types.NamespacedName]map[string]string),
+	}
+}
+
+// isDuplicate tells if the notification is a warning event that repeats the last one of the same event, e.g., the
+// Unhealthy events recorded in each reconciliation. The warnings are forgotten once a normal event, e.g., Running,
+// happens, so they're sent again if they come back.
+func (d *Dispatcher) isDuplicate(n Notification) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := types.NamespacedName{Namespace: n.Namespace, Name: n.Name}
+	if n.Type != corev1.EventTypeWarning {
+		delete(d.lastWarnings, key)
+		return false
+	}
+
+	warnings, ok := d.lastWarnings[key]
+	if !ok {
+		warnings = make(map[string]string)
+		d.lastWarnings[key] = warnings
+	}
+	if message, ok := warnings[n.Event]; ok && message == n.Message {
+		return true
+	}
+	warnings[n.Event] = n.Message
+
+	return false
+}
+
+// Notify implements the Notifier interface. The notification is dropped when the queue is full.
+func (d *Dispatcher) Notify(n Notification) {
+	if d.isDuplicate(n) {
+		return
+	}
+
+	select {
+	case d.queue <- n:
+	default:
+		log.Log.WithName("notification").Info("Queue is full, drop the notification",
+			"namespace", n.Namespace, "name", n.Name, "event", n.Event)
+	}
+}
+
+// Forget implements the Notifier interface. It forgets the warnings sent for the RisingWave.
+func (d *Dispatcher) Forget(target types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.lastWarnings, target)
+}
+
+// Start implements the manager.Runnable interface. It delivers the notifications in order until the context is done.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-d.queue:
+			d.dispatch(ctx, n)
+		}
+	}
+}
+
+func (d *Dispatcher) matches(notification *risingwavev1alpha1.RisingWaveNotification, n Notification) (bool, error) {
+	if ptr.Deref(notification.Spec.Suspend, false) {
+		return false, nil
+	}
+
+	if len(notification.Spec.Events) > 0 &&
+		!slices.Contains(notification.Spec.Events, risingwavev1alpha1.RisingWaveNotificationEvent(n.Event)) {
+		return false, nil
+	}
+
+	if notification.Spec.Selector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(notification.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(n.Labels)), nil
+}
+
+// dispatch delivers the notification to the sinks of all the matching RisingWaveNotifications in parallel.
+func (d *Dispatcher) dispatch(ctx context.Context, n Notification) {
+	logger := log.FromContext(ctx).WithName("notification").WithValues("namespace", n.Namespace, "name", n.Name, "event", n.Event)
+
+	var notificationList risingwavev1alpha1.RisingWaveNotificationList
+	if err := d.client.List(ctx, &notificationList, client.InNamespace(n.Namespace)); err != nil {
+		logger.Error(err, "Failed to list the RisingWaveNotifications")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range notificationList.Items {
+		notification := &notificationList.Items[i]
+
+		ok, err := d.matches(notification, n)
+		if err != nil {
+			logger.Error(err, "Invalid selector", "notification", notification.Name)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		wg.Go(func() {
+			d.deliverAndRecord(ctx, logger.WithValues("notification", notification.Name), notification, n)
+		})
+	}
+	wg.Wait()
+}
+
+func (d *Dispatcher) deliverAndRecord(ctx context.Context, logger logr.Logger, notification *risingwavev1alpha1.RisingWaveNotification, n Notification) {
+	attempts, err := d.deliver(ctx, notification, n)
+
+	delivery := risingwavev1alpha1.RisingWaveNotificationDelivery{
+		RisingWave: n.Name,
+		Event:      risingwavev1alpha1.RisingWaveNotificationEvent(n.Event),
+		Time:       metav1.Now(),
+		Attempts:   attempts,
+		Succeeded:  err == nil,
+		Message:    "Delivered",
+	}
+	if err != nil {
+		logger.Error(err, "Failed to deliver the notification", "attempts", attempts)
+		delivery.Message = truncate(err.Error(), maxMessageLength)
+	}
+
+	if err := d.recordDelivery(ctx, client.ObjectKeyFromObject(notification), delivery); err != nil {
+		logger.Error(err, "Failed to update the status of the RisingWaveNotification")
+	}
+}
+
+func (d *Dispatcher) recordDelivery(ctx context.Context, key client.ObjectKey, delivery risingwavev1alpha1.RisingWaveNotificationDelivery) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var notification risingwavev1alpha1.RisingWaveNotification
+		if err := d.client.Get(ctx, key, &notification); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		status := &notification.Status
+		status.LastDelivery = &delivery
+		if delivery.Succeeded {
+			status.Delivered++
+		} else {
+			status.Failed++
+			status.LastFailure = &delivery
+		}
+
+		return d.client.Status().Update(ctx, &notification)
+	})
+}
+
+func (d *Dispatcher) headers(ctx context.Context, notification *risingwavev1alpha1.RisingWaveNotification) (map[string]string, error) {
+	sink := &notification.Spec.Sink
+
+	headers := make(map[string]string, len(sink.Headers))
+	for k, v := range sink.Headers {
+		headers[k] = v
+	}
+
+	if sink.HeadersFrom != nil {
+		var secret corev1.Secret
+		if err := d.reader.Get(ctx, types.NamespacedName{Namespace: notification.Namespace, Name: sink.HeadersFrom.SecretName}, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the secret of the headers: %w", err)
+		}
+		for k, v := range secret.Data {
+			headers[k] = string(v)
+		}
+	}
+
+	return headers, nil
+}
+
+// deliver sends the notification to the sink, and retries on the retryable failures with exponential backoff. It
+// returns the number of the attempts made.
+func (d *Dispatcher) deliver(ctx context.Context, notification *risingwavev1alpha1.RisingWaveNotification, n Notification) (int32, error) {
+	body, contentType, err := render(&notification.Spec.Sink, n)
+	if err != nil {
+		return 0, err
+	}
+
+	headers, err := d.headers(ctx, notification)
+	if err != nil {
+		return 0, err
+	}
+
+	maxAttempts := ptr.Deref(notification.Spec.Retry.MaxAttempts, defaultMaxAttempts)
+	backoff := defaultBackoff
+	if notification.Spec.Retry.Backoff != nil {
+		backoff = notification.Spec.Retry.Backoff.Duration
+	}
+
+	var attempt int32
+	for {
+		attempt++
+
+		retryable, err := d.post(ctx, notification.Spec.Sink.URL, contentType, headers, body)
+		if err == nil || !retryable || attempt >= maxAttempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends the body to the URL, and tells if it should be retried on failure.
+func (d *Dispatcher) post(ctx context.Context, url, contentType string, headers map[string]string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxMessageLength))
+
+	return isRetryable(resp.StatusCode), fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(respBody))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,309 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+type sink struct {
+	mu       sync.Mutex
+	requests []request
+	failures int32
+}
+
+// newSink starts a local HTTP server, which fails the first failures requests with 503.
+func newSink(t *testing.T, failures int32) (*sink, *httptest.Server) {
+	s := &sink{failures: failures}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, request{header: r.Header.Clone(), body: body})
+		if atomic.AddInt32(&s.failures, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return s, server
+}
+
+func (s *sink) Requests() []request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func newNotification(name, url string, spec func(spec *risingwavev1alpha1.RisingWaveNotificationSpec)) *risingwavev1alpha1.RisingWaveNotification {
+	notification := &risingwavev1alpha1.RisingWaveNotification{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: risingwavev1alpha1.RisingWaveNotificationSpec{
+			Sink: risingwavev1alpha1.RisingWaveNotificationSink{URL: url},
+			Retry: risingwavev1alpha1.RisingWaveNotificationRetry{
+				Backoff: &metav1.Duration{Duration: time.Millisecond},
+			},
+		},
+	}
+	if spec != nil {
+		spec(&notification.Spec)
+	}
+
+	return notification
+}
+
+func newDispatcherForTest(objs ...client.Object) (*Dispatcher, client.Client) {
+	c := fake.NewClientBuilder().
+		WithScheme(testutils.Scheme).
+		WithStatusSubresource(&risingwavev1alpha1.RisingWaveNotification{}).
+		WithObjects(objs...).
+		Build()
+
+	return NewDispatcher(c, c), c
+}
+
+func getStatus(t *testing.T, c client.Client, name string) risingwavev1alpha1.RisingWaveNotificationStatus {
+	var notification risingwavev1alpha1.RisingWaveNotification
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, &notification); err != nil {
+		t.Fatal(err)
+	}
+
+	return notification.Status
+}
+
+var testNotification = Notification{
+	Namespace:  "default",
+	Name:       "rw",
+	Labels:     map[string]string{"env": "prod"},
+	Event:      "Unhealthy",
+	Type:       corev1.EventTypeWarning,
+	Message:    "meta is not ready",
+	Generation: 2,
+	Time:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestDispatcher_CloudEvents(t *testing.T) {
+	s, server := newSink(t, 0)
+	d, c := newDispatcherForTest(newNotification("ce", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+		spec.Sink.Headers = map[string]string{"X-Token": "a"}
+		spec.Sink.HeadersFrom = &risingwavev1alpha1.RisingWaveNotificationHeadersSource{SecretName: "headers"}
+	}), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "headers"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer b")},
+	})
+
+	d.dispatch(context.Background(), testNotification)
+
+	requests := s.Requests()
+	if !assert.Len(t, requests, 1) {
+		return
+	}
+	assert.Equal(t, cloudEventsContentType, requests[0].header.Get("Content-Type"))
+	assert.Equal(t, "a", requests[0].header.Get("X-Token"))
+	assert.Equal(t, "Bearer b", requests[0].header.Get("Authorization"))
+
+	var event map[string]any
+	if err := json.Unmarshal(requests[0].body, &event); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1.0", event["specversion"])
+	assert.Equal(t, "com.risingwavelabs.risingwave.unhealthy", event["type"])
+	assert.Equal(t, "/apis/risingwave.risingwavelabs.com/v1alpha1/namespaces/default/risingwaves/rw", event["source"])
+	assert.NotEmpty(t, event["id"])
+	assert.Equal(t, map[string]any{
+		"namespace":  "default",
+		"name":       "rw",
+		"event":      "Unhealthy",
+		"type":       "Warning",
+		"message":    "meta is not ready",
+		"generation": 2.0,
+		"time":       "2023-01-01T00:00:00Z",
+	}, event["data"])
+
+	status := getStatus(t, c, "ce")
+	assert.Equal(t, int64(1), status.Delivered)
+	assert.Equal(t, int64(0), status.Failed)
+	assert.True(t, status.LastDelivery.Succeeded)
+	assert.Equal(t, int32(1), status.LastDelivery.Attempts)
+	assert.Nil(t, status.LastFailure)
+}
+
+func TestDispatcher_JSON(t *testing.T) {
+	s, server := newSink(t, 0)
+	d, _ := newDispatcherForTest(
+		newNotification("slack", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+			spec.Sink.Format = risingwavev1alpha1.RisingWaveNotificationFormatJSON
+		}),
+		newNotification("custom", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+			spec.Sink.Format = risingwavev1alpha1.RisingWaveNotificationFormatJSON
+			spec.Sink.Template = `{"instance": {{ .Name | json }}, "event": {{ .Event | json }}}`
+		}),
+	)
+
+	d.dispatch(context.Background(), testNotification)
+
+	var bodies []string
+	for _, r := range s.Requests() {
+		assert.Equal(t, jsonContentType, r.header.Get("Content-Type"))
+		bodies = append(bodies, string(r.body))
+	}
+	assert.ElementsMatch(t, []string{
+		`{"text": "[Warning] RisingWave default/rw is Unhealthy: meta is not ready"}`,
+		`{"instance": "rw", "event": "Unhealthy"}`,
+	}, bodies)
+}
+
+func TestDispatcher_Filters(t *testing.T) {
+	s, server := newSink(t, 0)
+	d, _ := newDispatcherForTest(
+		newNotification("other-events", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+			spec.Events = []risingwavev1alpha1.RisingWaveNotificationEvent{"Running"}
+		}),
+		newNotification("other-instances", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+			spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}
+		}),
+		newNotification("suspended", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+			spec.Suspend = ptr.To(true)
+		}),
+		newNotification("matched", server.URL, func(spec *risingwavev1alpha1.RisingWaveNotificationSpec) {
+			spec.Events = []risingwavev1alpha1.RisingWaveNotificationEvent{"Unhealthy"}
+			spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+		}),
+	)
+
+	d.dispatch(context.Background(), testNotification)
+
+	assert.Len(t, s.Requests(), 1)
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	s, server := newSink(t, 2)
+	d, c := newDispatcherForTest(
+		newNotification("retried", server.URL, nil),
+	)
+
+	d.dispatch(context.Background(), testNotification)
+
+	assert.Len(t, s.Requests(), 3)
+	status := getStatus(t, c, "retried")
+	assert.Equal(t, int64(1), status.Delivered)
+	assert.Equal(t, int32(3), status.LastDelivery.Attempts)
+
+	// Fails after all the attempts.
+	atomic.StoreInt32(&s.failures, 2)
+	var notification risingwavev1alpha1.RisingWaveNotification
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "retried"}, &notification); err != nil {
+		t.Fatal(err)
+	}
+	notification.Spec.Retry.MaxAttempts = ptr.To[int32](2)
+	if err := c.Update(context.Background(), &notification); err != nil {
+		t.Fatal(err)
+	}
+
+	d.dispatch(context.Background(), testNotification)
+
+	assert.Len(t, s.Requests(), 5)
+	status = getStatus(t, c, "retried")
+	assert.Equal(t, int64(1), status.Failed)
+	assert.False(t, status.LastDelivery.Succeeded)
+	assert.Equal(t, int32(2), status.LastFailure.Attempts)
+	assert.Contains(t, status.LastFailure.Message, "503")
+}
+
+func TestDispatcher_NotRetryable(t *testing.T) {
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	d, c := newDispatcherForTest(newNotification("bad", server.URL, nil))
+
+	d.dispatch(context.Background(), testNotification)
+
+	assert.Equal(t, int32(1), count.Load())
+	assert.Equal(t, int64(1), getStatus(t, c, "bad").Failed)
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	s, server := newSink(t, 0)
+	d, _ := newDispatcherForTest(newNotification("all", server.URL, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = d.Start(ctx) }()
+
+	running := testNotification
+	running.Event, running.Type, running.Message = "Running", corev1.EventTypeNormal, ""
+
+	crashLoop := testNotification
+	crashLoop.Event, crashLoop.Message = "CrashLoopBackOff", "compute: 1 Pod(s)"
+
+	// The repeated warnings are dropped until the RisingWave is running again.
+	d.Notify(testNotification)
+	d.Notify(crashLoop)
+	d.Notify(testNotification)
+	d.Notify(crashLoop)
+	d.Notify(running)
+	d.Notify(testNotification)
+
+	assert.Eventually(t, func() bool { return len(s.Requests()) == 4 }, 5*time.Second, 10*time.Millisecond)
+
+	// Forgotten once the RisingWave is deleted.
+	d.Forget(types.NamespacedName{Namespace: "default", Name: "rw"})
+	d.Notify(testNotification)
+	assert.Eventually(t, func() bool { return len(s.Requests()) == 5 }, 5*time.Second, 10*time.Millisecond)
+
+	// Events are delivered in order.
+	var events []string
+	for _, r := range s.Requests() {
+		var event cloudEvent
+		if err := json.Unmarshal(r.body, &event); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, event.Data.Event)
+	}
+	assert.Equal(t, []string{"Unhealthy", "CrashLoopBackOff", "Running", "Unhealthy", "Unhealthy"}, events)
+}
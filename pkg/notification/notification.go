@@ -0,0 +1,155 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notification delivers the lifecycle events of the RisingWaves to the HTTP endpoints declared by the
+// RisingWaveNotifications, as CloudEvents or the templated JSON.
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/types"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsTypePrefix  = "com.risingwavelabs.risingwave."
+	cloudEventsContentType = "application/cloudevents+json; charset=utf-8"
+	jsonContentType        = "application/json; charset=utf-8"
+)
+
+// DefaultTemplate is the template of the JSON format when it isn't specified. It's compatible with the Slack incoming
+// webhooks.
+const DefaultTemplate = `{"text": {{ if .Message }}` +
+	`{{ printf "[%s] RisingWave %s/%s is %s: %s" .Type .Namespace .Name .Event .Message | json }}` +
+	`{{ else }}` +
+	`{{ printf "[%s] RisingWave %s/%s is %s" .Type .Namespace .Name .Event | json }}` +
+	`{{ end }}}`
+
+// Notification is a lifecycle event of a RisingWave.
+type Notification struct {
+	// Namespace of the RisingWave.
+	Namespace string `json:"namespace"`
+
+	// Name of the RisingWave.
+	Name string `json:"name"`
+
+	// Labels of the RisingWave, to match the selectors.
+	Labels map[string]string `json:"-"`
+
+	// Event is the name of the event, e.g., Running.
+	Event string `json:"event"`
+
+	// Type is the type of the event, either Normal or Warning.
+	Type string `json:"type"`
+
+	// Message of the event.
+	Message string `json:"message,omitempty"`
+
+	// Generation of the RisingWave when the event happens.
+	Generation int64 `json:"generation"`
+
+	// Time of the event.
+	Time time.Time `json:"time"`
+}
+
+// Notifier notifies about the lifecycle events of the RisingWaves. Implementations must not block.
+type Notifier interface {
+	// Notify notifies about the event.
+	Notify(n Notification)
+
+	// Forget drops the states of the RisingWave once it's deleted.
+	Forget(target types.NamespacedName)
+}
+
+// cloudEvent is a CloudEvent in the structured content mode.
+type cloudEvent struct {
+	SpecVersion     string       `json:"specversion"`
+	ID              string       `json:"id"`
+	Source          string       `json:"source"`
+	Type            string       `json:"type"`
+	Subject         string       `json:"subject"`
+	Time            time.Time    `json:"time"`
+	DataContentType string       `json:"datacontenttype"`
+	Data            Notification `json:"data"`
+}
+
+func newCloudEvent(n Notification) cloudEvent {
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          fmt.Sprintf("/apis/%s/namespaces/%s/risingwaves/%s", risingwavev1alpha1.GroupVersion.String(), n.Namespace, n.Name),
+		Type:            cloudEventsTypePrefix + strings.ToLower(n.Event),
+		Subject:         n.Name,
+		Time:            n.Time,
+		DataContentType: "application/json",
+		Data:            n,
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// render renders the body of the notification in the format of the sink, and returns it with the content type.
+func render(sink *risingwavev1alpha1.RisingWaveNotificationSink, n Notification) ([]byte, string, error) {
+	switch sink.Format {
+	case risingwavev1alpha1.RisingWaveNotificationFormatJSON:
+		text := sink.Template
+		if text == "" {
+			text = DefaultTemplate
+		}
+
+		tmpl, err := template.New("notification").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid template: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, n); err != nil {
+			return nil, "", fmt.Errorf("unable to render template: %w", err)
+		}
+		if !json.Valid(buf.Bytes()) {
+			return nil, "", fmt.Errorf("template renders invalid JSON: %s", buf.String())
+		}
+
+		return buf.Bytes(), jsonContentType, nil
+	case risingwavev1alpha1.RisingWaveNotificationFormatCloudEvents, "":
+		body, err := json.Marshal(newCloudEvent(n))
+		if err != nil {
+			return nil, "", err
+		}
+
+		return body, cloudEventsContentType, nil
+	default:
+		return nil, "", fmt.Errorf("unsupported format %q", sink.Format)
+	}
+}
+
+// isRetryable tells if the delivery should be retried with the status code of the response.
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
@@ -18,9 +18,15 @@ package object
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -28,6 +34,8 @@ import (
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object/scaleview"
+	"github.com/risingwavelabs/risingwave-operator/pkg/recommender"
 )
 
 // RisingWaveReader is a reader for RisingWave object.
@@ -66,6 +74,61 @@ func (r *RisingWaveReader) StateStoreRootPath() string {
 	}
 }
 
+// ManagedStoreName returns the name of the Service and the StatefulSet of the store provisioned by the operator.
+func (r *RisingWaveReader) ManagedStoreName(component string) string {
+	return r.risingwave.Name + "-" + component
+}
+
+// ManagedStoreSecretName returns the name of the Secret of the generated credentials of the store provisioned by the
+// operator.
+func (r *RisingWaveReader) ManagedStoreSecretName(component string) string {
+	return r.ManagedStoreName(component) + "-credentials"
+}
+
+// managedStoreHost returns the host of the store provisioned by the operator, which is accessible across namespaces.
+func (r *RisingWaveReader) managedStoreHost(component string) string {
+	return fmt.Sprintf("%s.%s.svc", r.ManagedStoreName(component), r.risingwave.Namespace)
+}
+
+// GetMinIO returns the MinIO state store, or nil if the state store isn't MinIO. The managed MinIO is resolved into
+// the address of its Service and the Secret of the generated credentials.
+func (r *RisingWaveReader) GetMinIO() *risingwavev1alpha1.RisingWaveStateStoreBackendMinIO {
+	stateStore := &r.risingwave.Spec.StateStore
+	if stateStore.ManagedMinIO == nil {
+		return stateStore.MinIO
+	}
+
+	return &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+		RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+			SecretName:     r.ManagedStoreSecretName(consts.ComponentMinIO),
+			UsernameKeyRef: consts.SecretKeyMinIOUsername,
+			PasswordKeyRef: consts.SecretKeyMinIOPassword,
+		},
+		Endpoint: fmt.Sprintf("%s:%d", r.managedStoreHost(consts.ComponentMinIO), consts.MinIOServicePort),
+		Bucket:   lo.Ternary(stateStore.ManagedMinIO.Bucket != "", stateStore.ManagedMinIO.Bucket, consts.DefaultManagedMinIOBucket),
+	}
+}
+
+// GetPostgreSQL returns the PostgreSQL meta store, or nil if the meta store isn't PostgreSQL. The managed PostgreSQL
+// is resolved into the address of its Service and the Secret of the generated credentials.
+func (r *RisingWaveReader) GetPostgreSQL() *risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL {
+	metaStore := &r.risingwave.Spec.MetaStore
+	if metaStore.ManagedPostgreSQL == nil {
+		return metaStore.PostgreSQL
+	}
+
+	return &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+		RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+			SecretName:     r.ManagedStoreSecretName(consts.ComponentPostgreSQL),
+			UsernameKeyRef: consts.SecretKeyPostgreSQLUsername,
+			PasswordKeyRef: consts.SecretKeyPostgreSQLPassword,
+		},
+		Host:     r.managedStoreHost(consts.ComponentPostgreSQL),
+		Port:     uint32(consts.PostgreSQLServicePort),
+		Database: lo.Ternary(metaStore.ManagedPostgreSQL.Database != "", metaStore.ManagedPostgreSQL.Database, consts.DefaultManagedPostgreSQLDatabase),
+	}
+}
+
 // IsObservedGenerationOutdated tells whether the observed generation is outdated.
 func (r *RisingWaveReader) IsObservedGenerationOutdated() bool {
 	return r.risingwave.Status.ObservedGeneration < r.risingwave.Generation
@@ -199,6 +262,27 @@ func (mgr *RisingWaveManager) UpdateStatus(f func(*risingwavev1alpha1.RisingWave
 	f(&mgr.mutableRisingWave.Status)
 }
 
+// UpdateStandaloneMigrationPhase updates the phase and the message of the migration from the standalone mode in the
+// mutable copy. The start time is recorded when the migration starts, and the completion time when it completes.
+func (mgr *RisingWaveManager) UpdateStandaloneMigrationPhase(phase risingwavev1alpha1.RisingWaveStandaloneMigrationPhase, message string) {
+	mgr.UpdateStatus(func(status *risingwavev1alpha1.RisingWaveStatus) {
+		if status.StandaloneMigration == nil {
+			status.StandaloneMigration = &risingwavev1alpha1.RisingWaveStandaloneMigrationStatus{}
+		}
+
+		migration := status.StandaloneMigration
+		migration.Phase, migration.Message = phase, message
+
+		now := metav1.Now()
+		if migration.StartTime == nil && phase != risingwavev1alpha1.RisingWaveStandaloneMigrationPhasePending {
+			migration.StartTime = &now
+		}
+		if migration.CompletionTime == nil && phase == risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseCompleted {
+			migration.CompletionTime = &now
+		}
+	})
+}
+
 // UpdateRemoteRisingWaveStatus updates the remote RisingWave object with the mutable copy.
 func (mgr *RisingWaveManager) UpdateRemoteRisingWaveStatus(ctx context.Context) error {
 	mgr.mu.RLock()
@@ -229,6 +313,354 @@ func (r *RisingWaveReader) IsStandaloneModeEnabled() bool {
 	return ptr.Deref(r.risingwave.Spec.EnableStandaloneMode, false)
 }
 
+// IsStandaloneHAEnabled returns true when the standalone mode runs more than one replica, where the standalone Pods
+// advertise their own addresses and elect the meta leader among themselves.
+func (r *RisingWaveReader) IsStandaloneHAEnabled() bool {
+	standalone := r.risingwave.Spec.Components.Standalone
+
+	return r.IsStandaloneModeEnabled() && standalone != nil && standalone.Replicas > 1
+}
+
+// IsStandaloneMigrationEnabled returns true when the RisingWave is set to migrate from the standalone mode to the
+// distributed mode.
+func (r *RisingWaveReader) IsStandaloneMigrationEnabled() bool {
+	return !r.IsStandaloneModeEnabled() && r.risingwave.Spec.StandaloneMigration != nil
+}
+
+// IsStandaloneMigrationInProgress returns true when the migration from the standalone mode is enabled and hasn't
+// completed yet.
+func (r *RisingWaveReader) IsStandaloneMigrationInProgress() bool {
+	status := r.risingwave.Status.StandaloneMigration
+
+	return r.IsStandaloneMigrationEnabled() &&
+		(status == nil || status.Phase != risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseCompleted)
+}
+
+// GetStandaloneSQLiteVolume returns the volume of the standalone Pod that holds the SQLite DB file of the meta store
+// and how it's mounted, so that the meta store can be carried over to the meta Pod when migrating from the standalone
+// mode. A volume claim template is turned into the volume of the PVC of the standalone Pod. Nil is returned if the
+// meta store isn't SQLite or the DB file isn't on a persistent volume.
+func (r *RisingWaveReader) GetStandaloneSQLiteVolume() (*corev1.Volume, *corev1.VolumeMount) {
+	sqlite, standalone := r.risingwave.Spec.MetaStore.SQLite, r.risingwave.Spec.Components.Standalone
+	if sqlite == nil || standalone == nil {
+		return nil, nil
+	}
+
+	isParentDir := func(dir, path string) bool {
+		dir = strings.TrimSuffix(dir, "/")
+
+		return dir != "" && strings.HasPrefix(path, dir+"/")
+	}
+
+	// Find the mount on the closest parent directory, or the specified one.
+	migration := r.risingwave.Spec.StandaloneMigration
+
+	var mount *corev1.VolumeMount
+	for i, m := range standalone.Template.Spec.VolumeMounts {
+		if !isParentDir(m.MountPath, sqlite.Path) {
+			continue
+		}
+		if migration != nil && migration.SQLiteVolume != "" && migration.SQLiteVolume != m.Name {
+			continue
+		}
+		if mount == nil || len(m.MountPath) > len(mount.MountPath) {
+			mount = &standalone.Template.Spec.VolumeMounts[i]
+		}
+	}
+	if mount == nil {
+		return nil, nil
+	}
+
+	// The PVC created from the template for the only standalone Pod.
+	if lo.ContainsBy(standalone.VolumeClaimTemplates, func(pvc risingwavev1alpha1.PersistentVolumeClaim) bool {
+		return pvc.Name == mount.Name
+	}) {
+		return &corev1.Volume{
+			Name: mount.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: fmt.Sprintf("%s-%s-%s-0", mount.Name, r.risingwave.Name, consts.ComponentStandalone),
+				},
+			},
+		}, mount.DeepCopy()
+	}
+
+	volume, ok := lo.Find(standalone.Template.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == mount.Name
+	})
+	if !ok || volume.PersistentVolumeClaim == nil {
+		return nil, nil
+	}
+
+	return volume.DeepCopy(), mount.DeepCopy()
+}
+
+// CheckStandaloneMigration returns an error if the meta store can't be carried over when migrating from the standalone
+// mode, i.e., it's the memory, or the SQLite DB file isn't on a persistent volume of the standalone Pod that survives
+// the deletion of the StatefulSet or isn't carried over to exactly one meta Pod.
+func (r *RisingWaveReader) CheckStandaloneMigration() error {
+	metaStore := r.risingwave.Spec.MetaStore
+
+	switch {
+	case ptr.Deref(metaStore.Memory, false):
+		return errors.New("the memory meta store can't be carried over")
+	case metaStore.SQLite != nil:
+		volume, _ := r.GetStandaloneSQLiteVolume()
+		if volume == nil {
+			return fmt.Errorf("no persistent volume of the standalone Pod holds the SQLite DB file %s", metaStore.SQLite.Path)
+		}
+
+		// The PVCs from the templates are deleted together with the standalone StatefulSet with this policy.
+		standalone := r.risingwave.Spec.Components.Standalone
+		isFromTemplate := lo.ContainsBy(standalone.VolumeClaimTemplates, func(pvc risingwavev1alpha1.PersistentVolumeClaim) bool {
+			return pvc.Name == volume.Name
+		})
+		policy := standalone.PersistentVolumeClaimRetentionPolicy
+		if isFromTemplate && policy != nil && policy.WhenDeleted == appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+			return errors.New("the PVCs of the standalone Pod are deleted with the StatefulSet, set whenDeleted to Retain")
+		}
+
+		replicas := lo.SumBy(r.GetWorkloadNodeGroups(consts.ComponentMeta), func(ng risingwavev1alpha1.RisingWaveNodeGroup) int32 {
+			return ng.Replicas
+		})
+		if replicas != 1 {
+			return fmt.Errorf("the SQLite DB file can only be carried over to exactly one meta Pod, but there are %d", replicas)
+		}
+	}
+
+	return nil
+}
+
+// GetComputeRole returns the effective role of the compute nodes in the given node group. It defaults to streaming
+// when the embedded serving mode is enabled, and both otherwise.
+func (r *RisingWaveReader) GetComputeRole(nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) risingwavev1alpha1.RisingWaveComputeRole {
+	if nodeGroup.ComputeRole != "" {
+		return nodeGroup.ComputeRole
+	}
+
+	if ptr.Deref(r.risingwave.Spec.EnableEmbeddedServingMode, false) {
+		return risingwavev1alpha1.RisingWaveComputeRoleStreaming
+	}
+
+	return risingwavev1alpha1.RisingWaveComputeRoleBoth
+}
+
+// GetResourceGroup returns the effective resource group of the compute nodes in the given node group. It defaults to
+// the node group name, or the default resource group if the name is empty.
+func (r *RisingWaveReader) GetResourceGroup(nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) string {
+	if nodeGroup.ResourceGroup != "" {
+		return nodeGroup.ResourceGroup
+	}
+
+	if nodeGroup.Name != "" {
+		return nodeGroup.Name
+	}
+
+	return consts.DefaultResourceGroup
+}
+
+func zoneTopologyKey(zones *risingwavev1alpha1.RisingWaveNodeGroupZones) string {
+	return lo.Ternary(zones.TopologyKey != "", zones.TopologyKey, consts.DefaultZoneTopologyKey)
+}
+
+// GetZones returns the topology key and the sorted zones that the given node group is expanded into. The zones are
+// taken from the spec, or from the zones discovered in the status if none is specified. It returns an empty key when
+// the node group isn't expanded into zones.
+func (r *RisingWaveReader) GetZones(nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) (string, []string) {
+	if nodeGroup.Zones == nil {
+		return "", nil
+	}
+
+	topologyKey := zoneTopologyKey(nodeGroup.Zones)
+
+	zones := nodeGroup.Zones.Values
+	if len(zones) == 0 {
+		if discovered, ok := lo.Find(r.risingwave.Status.TopologyZones, func(z risingwavev1alpha1.RisingWaveTopologyZones) bool {
+			return z.TopologyKey == topologyKey
+		}); ok {
+			zones = discovered.Values
+		}
+	}
+
+	zones = lo.Uniq(zones)
+	slices.Sort(zones)
+
+	return topologyKey, zones
+}
+
+func (r *RisingWaveReader) isTopologyKeyDiscovered(topologyKey string) bool {
+	return lo.ContainsBy(r.risingwave.Status.TopologyZones, func(z risingwavev1alpha1.RisingWaveTopologyZones) bool {
+		return z.TopologyKey == topologyKey
+	})
+}
+
+// GetTopologyKeysToDiscover returns the sorted topology keys of the node groups that are expanded into zones without
+// specifying the zones, i.e., the zones must be discovered from the labels of the Kubernetes nodes.
+func (r *RisingWaveReader) GetTopologyKeysToDiscover() []string {
+	if r.IsStandaloneModeEnabled() {
+		return nil
+	}
+
+	var topologyKeys []string
+	for _, component := range []string{consts.ComponentMeta, consts.ComponentFrontend, consts.ComponentCompute, consts.ComponentCompactor} {
+		for _, nodeGroup := range r.GetNodeGroups(component) {
+			if nodeGroup.Zones != nil && len(nodeGroup.Zones.Values) == 0 {
+				topologyKeys = append(topologyKeys, zoneTopologyKey(nodeGroup.Zones))
+			}
+		}
+	}
+
+	topologyKeys = lo.Uniq(topologyKeys)
+	slices.Sort(topologyKeys)
+
+	return topologyKeys
+}
+
+// ZonedNodeGroupName returns the name of the node group expanded from the given group for the zone.
+func ZonedNodeGroupName(group, zone string) string {
+	if group == "" {
+		return zone
+	}
+
+	return group + "-" + zone
+}
+
+// ExpandNodeGroup expands the given node group into the node groups of the workloads. A node group that isn't
+// expanded into zones is returned as is. There's none for the one whose zones are yet to be discovered, so that no
+// workload is created without being pinned to a zone, and the one with no node labeled with the topology key is
+// returned as is. Otherwise, there's one node group per zone with the replicas split evenly, and the Pods are pinned
+// to the zone with a node selector.
+func (r *RisingWaveReader) ExpandNodeGroup(nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) []risingwavev1alpha1.RisingWaveNodeGroup {
+	topologyKey, zones := r.GetZones(nodeGroup)
+	if topologyKey == "" {
+		return []risingwavev1alpha1.RisingWaveNodeGroup{*nodeGroup.DeepCopy()}
+	}
+	if len(zones) == 0 {
+		if !r.isTopologyKeyDiscovered(topologyKey) {
+			return nil
+		}
+
+		return []risingwavev1alpha1.RisingWaveNodeGroup{*nodeGroup.DeepCopy()}
+	}
+
+	replicas := scaleview.SplitReplicasEvenly(nodeGroup.Replicas, len(zones))
+	nodeGroups := make([]risingwavev1alpha1.RisingWaveNodeGroup, 0, len(zones))
+	for i, zone := range zones {
+		g := nodeGroup.DeepCopy()
+		g.Name = ZonedNodeGroupName(nodeGroup.Name, zone)
+		g.Replicas = replicas[i]
+		g.Zones = nil
+		// Keep the resource group of the original node group.
+		g.ResourceGroup = r.GetResourceGroup(nodeGroup)
+
+		if g.Template.ObjectMeta.Labels == nil {
+			g.Template.ObjectMeta.Labels = make(map[string]string)
+		}
+		g.Template.ObjectMeta.Labels[consts.LabelRisingWaveZone] = zone
+
+		if g.Template.Spec.NodeSelector == nil {
+			g.Template.Spec.NodeSelector = make(map[string]string)
+		}
+		g.Template.Spec.NodeSelector[topologyKey] = zone
+
+		nodeGroups = append(nodeGroups, *g)
+	}
+
+	return nodeGroups
+}
+
+// applyResourceRecommendation returns a copy of the node group with the applied resource recommendation in the status
+// set on the resources of the template. The node group is returned as is if there's none or it isn't in the Auto mode.
+func (r *RisingWaveReader) applyResourceRecommendation(component string, nodeGroup *risingwavev1alpha1.RisingWaveNodeGroup) *risingwavev1alpha1.RisingWaveNodeGroup {
+	if nodeGroup.ResourceRecommendation == nil || nodeGroup.ResourceRecommendation.Mode != risingwavev1alpha1.RisingWaveResourceRecommendationModeAuto {
+		return nodeGroup
+	}
+
+	for _, rec := range r.risingwave.Status.ResourceRecommendations {
+		if rec.Component == component && rec.Group == nodeGroup.Name && len(rec.Applied) > 0 {
+			g := nodeGroup.DeepCopy()
+			recommender.ApplyTarget(&g.Template.Spec.Resources, rec.Applied)
+			return g
+		}
+	}
+
+	return nodeGroup
+}
+
+// GetWorkloadNodeGroups gets the node groups of the workloads of the given component, i.e., the node groups with
+// the ones expanded into zones replaced by the node groups of each zone, and the applied resource recommendations
+// set on the templates. It panics when the component is unknown.
+func (r *RisingWaveReader) GetWorkloadNodeGroups(component string) []risingwavev1alpha1.RisingWaveNodeGroup {
+	nodeGroups := r.GetNodeGroups(component)
+
+	workloadNodeGroups := make([]risingwavev1alpha1.RisingWaveNodeGroup, 0, len(nodeGroups))
+	for i := range nodeGroups {
+		workloadNodeGroups = append(workloadNodeGroups, r.ExpandNodeGroup(r.applyResourceRecommendation(component, &nodeGroups[i]))...)
+	}
+
+	return workloadNodeGroups
+}
+
+// GetWorkloadNodeGroup gets the node group of the workload of the given component and group.
+// It panics when the component is unknown and returns nil when the node group isn't found.
+func (r *RisingWaveReader) GetWorkloadNodeGroup(component, group string) *risingwavev1alpha1.RisingWaveNodeGroup {
+	for _, nodeGroup := range r.GetWorkloadNodeGroups(component) {
+		if nodeGroup.Name == group {
+			return &nodeGroup
+		}
+	}
+
+	return nil
+}
+
+// GetExternalEndpoint gets the external access configuration of the given endpoint, either dashboard or webhook.
+// It returns nil when the endpoint isn't exposed and panics when the endpoint is unknown.
+func (r *RisingWaveReader) GetExternalEndpoint(endpoint string) *risingwavev1alpha1.RisingWaveExternalEndpoint {
+	externalAccess := r.risingwave.Spec.ExternalAccess
+	if externalAccess == nil {
+		return nil
+	}
+
+	switch endpoint {
+	case consts.ExternalEndpointDashboard:
+		return externalAccess.Dashboard
+	case consts.ExternalEndpointWebhook:
+		return externalAccess.Webhook
+	default:
+		panic("unknown endpoint: " + endpoint)
+	}
+}
+
+// GetMonitorKind gets the kind of the object to scrape the metrics. It's ServiceMonitor by default.
+func (r *RisingWaveReader) GetMonitorKind() risingwavev1alpha1.RisingWaveMonitorKind {
+	monitoring := r.risingwave.Spec.Monitoring
+	if monitoring == nil || monitoring.MonitorKind == "" {
+		return risingwavev1alpha1.RisingWaveMonitorKindServiceMonitor
+	}
+
+	return monitoring.MonitorKind
+}
+
+// GetPrometheusRule gets the configuration of the PrometheusRule. It returns nil when it isn't enabled.
+func (r *RisingWaveReader) GetPrometheusRule() *risingwavev1alpha1.RisingWavePrometheusRule {
+	monitoring := r.risingwave.Spec.Monitoring
+	if monitoring == nil || monitoring.PrometheusRule == nil || !ptr.Deref(monitoring.PrometheusRule.Enabled, false) {
+		return nil
+	}
+
+	return monitoring.PrometheusRule
+}
+
+// GetGrafanaDashboard gets the configuration of the Grafana dashboard. It returns nil when it isn't enabled.
+func (r *RisingWaveReader) GetGrafanaDashboard() *risingwavev1alpha1.RisingWaveGrafanaDashboard {
+	monitoring := r.risingwave.Spec.Monitoring
+	if monitoring == nil || monitoring.GrafanaDashboard == nil || !ptr.Deref(monitoring.GrafanaDashboard.Enabled, false) {
+		return nil
+	}
+
+	return monitoring.GrafanaDashboard
+}
+
 // IsAdvertisingWithIP returns true when the advertising with IP is enabled.
 func (r *RisingWaveReader) IsAdvertisingWithIP() bool {
 	return ptr.Deref(r.risingwave.Spec.EnableAdvertisingWithIP, false)
@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -197,3 +198,154 @@ func Test_RisingWaveManager_UpdateMemoryAndGet(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_RisingWaveReader_ExpandNodeGroup(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Spec.Components.Compute.NodeGroups[0].Replicas = 3
+	risingwave.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+		Values: []string{"b", "a"},
+	}
+	risingwave.Spec.Components.Compute.NodeGroups = append(risingwave.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+		Name:     "racks",
+		Replicas: 2,
+		Zones: &risingwavev1alpha1.RisingWaveNodeGroupZones{
+			TopologyKey: "example.com/rack",
+		},
+	})
+	risingwave.Status.TopologyZones = []risingwavev1alpha1.RisingWaveTopologyZones{
+		{TopologyKey: "example.com/rack", Values: []string{"r1", "r2", "r3"}},
+	}
+
+	reader := NewRisingWaveReader(risingwave)
+
+	if keys := reader.GetTopologyKeysToDiscover(); len(keys) != 1 || keys[0] != "example.com/rack" {
+		t.Fatalf("unexpected topology keys to discover: %v", keys)
+	}
+
+	type expectedGroup struct {
+		name, zone, topologyKey, resourceGroup string
+		replicas                               int32
+	}
+	expected := []expectedGroup{
+		{name: "a", zone: "a", topologyKey: "topology.kubernetes.io/zone", resourceGroup: "default", replicas: 2},
+		{name: "b", zone: "b", topologyKey: "topology.kubernetes.io/zone", resourceGroup: "default", replicas: 1},
+		{name: "racks-r1", zone: "r1", topologyKey: "example.com/rack", resourceGroup: "racks", replicas: 1},
+		{name: "racks-r2", zone: "r2", topologyKey: "example.com/rack", resourceGroup: "racks", replicas: 1},
+		{name: "racks-r3", zone: "r3", topologyKey: "example.com/rack", resourceGroup: "racks", replicas: 0},
+	}
+
+	nodeGroups := reader.GetWorkloadNodeGroups("compute")
+	if len(nodeGroups) != len(expected) {
+		t.Fatalf("unexpected number of node groups: %d", len(nodeGroups))
+	}
+
+	for i, e := range expected {
+		g := nodeGroups[i]
+		if g.Name != e.name || g.Replicas != e.replicas || g.ResourceGroup != e.resourceGroup || g.Zones != nil {
+			t.Fatalf("unexpected node group %d: %s, %d, %s", i, g.Name, g.Replicas, g.ResourceGroup)
+		}
+		if g.Template.Spec.NodeSelector[e.topologyKey] != e.zone || g.Template.ObjectMeta.Labels["risingwave/zone"] != e.zone {
+			t.Fatalf("node group %s isn't pinned to zone %s", g.Name, e.zone)
+		}
+	}
+
+	if reader.GetWorkloadNodeGroup("compute", "racks-r2") == nil || reader.GetWorkloadNodeGroup("compute", "racks") != nil {
+		t.Fatal("unexpected workload node group lookup")
+	}
+
+	// The spec is untouched.
+	if risingwave.Spec.Components.Compute.NodeGroups[0].Template.Spec.NodeSelector != nil ||
+		risingwave.Spec.Components.Compute.NodeGroups[0].Zones.Values[0] != "b" {
+		t.Fatal("spec is modified")
+	}
+}
+
+func Test_RisingWaveReader_ExpandNodeGroup_NoZonesDiscovered(t *testing.T) {
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Spec.Components.Compute.NodeGroups[0].Replicas = 3
+	risingwave.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+		TopologyKey: "example.com/rack",
+	}
+
+	// No workload is created before the zones are discovered.
+	if nodeGroups := NewRisingWaveReader(risingwave).GetWorkloadNodeGroups("compute"); len(nodeGroups) != 0 {
+		t.Fatalf("unexpected node groups: %v", nodeGroups)
+	}
+
+	// The node group isn't expanded when no node is labeled with the topology key.
+	risingwave.Status.TopologyZones = []risingwavev1alpha1.RisingWaveTopologyZones{
+		{TopologyKey: "example.com/rack"},
+	}
+	nodeGroups := NewRisingWaveReader(risingwave).GetWorkloadNodeGroups("compute")
+	if len(nodeGroups) != 1 || nodeGroups[0].Name != "" || nodeGroups[0].Replicas != 3 {
+		t.Fatalf("unexpected node groups: %v", nodeGroups)
+	}
+	if nodeGroups[0].Template.Spec.NodeSelector != nil {
+		t.Fatal("node group is pinned to a zone")
+	}
+}
+
+func Test_RisingWaveReader_GetStandaloneSQLiteVolume(t *testing.T) {
+	risingwave := testutils.FakeRisingWaveMigratingFromStandalone()
+
+	// The PVC of the standalone Pod created from the template.
+	volume, mount := NewRisingWaveReader(risingwave).GetStandaloneSQLiteVolume()
+	if volume == nil || mount == nil {
+		t.Fatal("volume not found")
+	}
+	if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != "data-"+risingwave.Name+"-standalone-0" {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+	if mount.MountPath != "/risingwave/data" {
+		t.Fatalf("unexpected mount: %v", mount)
+	}
+
+	// An existing PVC mounted on a closer parent directory.
+	risingwave.Spec.Components.Standalone.Template.Spec.VolumeMounts = append(risingwave.Spec.Components.Standalone.Template.Spec.VolumeMounts,
+		corev1.VolumeMount{Name: "meta", MountPath: "/risingwave/data/"})
+	risingwave.Spec.Components.Standalone.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "meta",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "meta-pvc"},
+			},
+		},
+	}
+	volume, _ = NewRisingWaveReader(risingwave).GetStandaloneSQLiteVolume()
+	if volume == nil || volume.PersistentVolumeClaim.ClaimName != "meta-pvc" {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+
+	// The specified volume.
+	risingwave.Spec.StandaloneMigration.SQLiteVolume = "data"
+	volume, _ = NewRisingWaveReader(risingwave).GetStandaloneSQLiteVolume()
+	if volume == nil || volume.Name != "data" {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+
+	// Not on a persistent volume.
+	risingwave.Spec.MetaStore.SQLite.Path = "/tmp/meta.db"
+	if volume, _ = NewRisingWaveReader(risingwave).GetStandaloneSQLiteVolume(); volume != nil {
+		t.Fatalf("unexpected volume: %v", volume)
+	}
+	if NewRisingWaveReader(risingwave).CheckStandaloneMigration() == nil {
+		t.Fatal("expect an error")
+	}
+}
+
+func Test_RisingWaveManager_UpdateStandaloneMigrationPhase(t *testing.T) {
+	mgr := NewRisingWaveManager(nil, testutils.FakeRisingWaveMigratingFromStandalone(), false)
+
+	mgr.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhasePending, "blocked")
+	status := mgr.RisingWaveAfterImage().Status.StandaloneMigration
+	if status == nil || status.StartTime != nil || status.Message != "blocked" {
+		t.Fatalf("unexpected status: %v", status)
+	}
+
+	mgr.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseStoppingStandalone, "")
+	mgr.UpdateStandaloneMigrationPhase(risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseCompleted, "")
+	status = mgr.RisingWaveAfterImage().Status.StandaloneMigration
+	if status.StartTime == nil || status.CompletionTime == nil {
+		t.Fatalf("unexpected status: %v", status)
+	}
+}
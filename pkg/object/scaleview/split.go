@@ -124,3 +124,18 @@ func SplitReplicas(sv *risingwavev1alpha1.RisingWaveScaleView) map[string]int32
 
 	return replicas
 }
+
+// SplitReplicasEvenly splits the total replicas into n parts as evenly as possible. The remainders go to the leading
+// parts. It must be a stable function.
+func SplitReplicasEvenly(total int32, n int) []int32 {
+	replicas := make([]int32, n)
+
+	totalLeft := int(total)
+	for i := range replicas {
+		taken := split(totalLeft, n-i)
+		replicas[i] = int32(taken)
+		totalLeft -= taken
+	}
+
+	return replicas
+}
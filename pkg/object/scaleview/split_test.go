@@ -207,3 +207,40 @@ func TestScaleViewLockManager_splitReplicasIntoGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitReplicasEvenly(t *testing.T) {
+	testcases := map[string]struct {
+		total    int32
+		n        int
+		expected []int32
+	}{
+		"even": {
+			total:    6,
+			n:        3,
+			expected: []int32{2, 2, 2},
+		},
+		"uneven": {
+			total:    7,
+			n:        3,
+			expected: []int32{3, 2, 2},
+		},
+		"less-than-parts": {
+			total:    2,
+			n:        3,
+			expected: []int32{1, 1, 0},
+		},
+		"zero": {
+			total:    0,
+			n:        2,
+			expected: []int32{0, 0},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			if r := SplitReplicasEvenly(tc.total, tc.n); !reflect.DeepEqual(r, tc.expected) {
+				t.Fatalf("unexpected replicas, expected %v, got %v", tc.expected, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+)
+
+// Error numbers and codes of the SQL databases.
+const (
+	mysqlErrAccessDenied = 1045
+	mysqlErrBadDB        = 1049
+
+	pgErrInvalidPassword      = "28P01"
+	pgErrInvalidAuthorization = "28000"
+	pgErrInvalidCatalogName   = "3D000"
+)
+
+func (c *Checker) checkMetaStore(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	metaStore := &risingwave.Spec.MetaStore
+
+	switch {
+	case metaStore.Etcd != nil:
+		return c.checkEtcd(ctx, risingwave.Namespace, metaStore.Etcd)
+	case metaStore.MySQL != nil:
+		return c.checkMySQL(ctx, risingwave.Namespace, metaStore.MySQL)
+	case metaStore.PostgreSQL != nil, metaStore.ManagedPostgreSQL != nil:
+		return c.checkPostgreSQL(ctx, risingwave.Namespace, object.NewRisingWaveReader(risingwave).GetPostgreSQL())
+	default:
+		// Memory and SQLite are local to the meta nodes, nothing to check.
+		return nil
+	}
+}
+
+func (c *Checker) checkEtcd(ctx context.Context, namespace string, etcd *risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd) error {
+	var endpoints []string
+	for _, endpoint := range strings.Split(etcd.Endpoint, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		addr, _ := stores.TrimScheme(endpoint)
+		if err := c.checkTCP(ctx, hostPort(addr, "2379")); err != nil {
+			return err
+		}
+	}
+
+	secretName, usernameKey, passwordKey := etcd.Secret, "username", "password"
+	if etcd.RisingWaveEtcdCredentials != nil {
+		secretName = etcd.SecretName
+		usernameKey, passwordKey = etcd.UsernameKeyRef, etcd.PasswordKeyRef
+	}
+
+	// Empty secret indicates no authentication.
+	if secretName == "" || len(endpoints) == 0 {
+		return nil
+	}
+
+	values, err := stores.SecretValues(ctx, c.reader, namespace, secretName, usernameKey, passwordKey)
+	if err != nil {
+		return err
+	}
+
+	return c.authenticateEtcd(ctx, endpoints[0], values[0], values[1], secretName)
+}
+
+// authenticateEtcd authenticates with the etcd through the gRPC gateway.
+func (c *Checker) authenticateEtcd(ctx context.Context, endpoint, username, password, secretName string) error {
+	addr, secure := stores.TrimScheme(endpoint)
+	scheme := "http"
+	if secure {
+		scheme = "https"
+	}
+
+	body, err := json.Marshal(map[string]string{"name": username, "password": password})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s://%s/v3/auth/authenticate", scheme, hostPort(addr, "2379")), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to authenticate with etcd: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+
+		reason := failure.Message
+		if reason == "" {
+			reason = failure.Error
+		}
+		if reason == "" {
+			reason = resp.Status
+		}
+
+		return fmt.Errorf("unable to authenticate with etcd, check the credentials in secret %q: %s", secretName, reason)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkMySQL(ctx context.Context, namespace string, mysqlSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL) error {
+	if err := c.checkTCP(ctx, stores.MySQLAddr(mysqlSpec)); err != nil {
+		return err
+	}
+
+	cfg, err := stores.MySQLConfig(ctx, c.reader, namespace, mysqlSpec)
+	if err != nil {
+		return err
+	}
+	cfg.DialFunc = c.dial
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid MySQL configuration: %w", err)
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) {
+			switch mysqlErr.Number {
+			case mysqlErrAccessDenied:
+				return fmt.Errorf("access denied for user %q, check the credentials in secret %q", cfg.User, mysqlSpec.SecretName)
+			case mysqlErrBadDB:
+				return fmt.Errorf("database %q does not exist", mysqlSpec.Database)
+			}
+		}
+
+		return fmt.Errorf("unable to connect to MySQL: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkPostgreSQL(ctx context.Context, namespace string, pgSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL) error {
+	if err := c.checkTCP(ctx, stores.PostgreSQLAddr(pgSpec)); err != nil {
+		return err
+	}
+
+	cfg, err := stores.PostgreSQLConfig(ctx, c.reader, namespace, pgSpec)
+	if err != nil {
+		return err
+	}
+	cfg.DialFunc = c.dial
+
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgErrInvalidPassword, pgErrInvalidAuthorization:
+				return fmt.Errorf("authentication failed for user %q, check the credentials in secret %q", cfg.User, pgSpec.SecretName)
+			case pgErrInvalidCatalogName:
+				return fmt.Errorf("database %q does not exist", pgSpec.Database)
+			}
+		}
+
+		return fmt.Errorf("unable to connect to PostgreSQL: %w", err)
+	}
+
+	return conn.Close(ctx)
+}
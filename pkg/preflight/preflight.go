@@ -0,0 +1,110 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package preflight verifies the meta store and the state store of a RisingWave before the components are rolled out.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+// DefaultTimeout is the default timeout of checking a single store.
+const DefaultTimeout = 10 * time.Second
+
+// Checker checks if the meta store and the state store of a RisingWave are reachable with the configured credentials.
+type Checker struct {
+	reader  client.Reader
+	dial    func(ctx context.Context, network, addr string) (net.Conn, error)
+	timeout time.Duration
+}
+
+// Check runs the checks against the meta store and the state store. The returned error describes all the failures
+// and is meant to be shown to the users.
+func (c *Checker) Check(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	var failures []string
+
+	if err := c.runWithTimeout(ctx, risingwave, c.checkMetaStore); err != nil {
+		failures = append(failures, "meta store: "+err.Error())
+	}
+
+	if err := c.runWithTimeout(ctx, risingwave, c.checkStateStore); err != nil {
+		failures = append(failures, "state store: "+err.Error())
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(failures, "; "))
+}
+
+func (c *Checker) runWithTimeout(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave,
+	check func(context.Context, *risingwavev1alpha1.RisingWave) error) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return check(ctx, risingwave)
+}
+
+// checkTCP verifies that the address is reachable.
+func (c *Checker) checkTCP(ctx context.Context, addr string) error {
+	conn, err := c.dial(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to connect to %s, check the address and the network policies: %w", addr, err)
+	}
+
+	return conn.Close()
+}
+
+// httpClient returns an HTTP client that dials with the checker's dial function.
+func (c *Checker) httpClient() *http.Client {
+	return &http.Client{Transport: c.transport()}
+}
+
+func (c *Checker) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = c.dial
+
+	return transport
+}
+
+// hostPort returns the address with the default port if the port isn't specified.
+func hostPort(addr, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+
+	return net.JoinHostPort(addr, defaultPort)
+}
+
+// NewChecker creates a new Checker that reads the credentials with the reader.
+func NewChecker(reader client.Reader) *Checker {
+	return &Checker{
+		reader:  reader,
+		dial:    (&net.Dialer{}).DialContext,
+		timeout: DefaultTimeout,
+	}
+}
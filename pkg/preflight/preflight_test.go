@@ -0,0 +1,588 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package preflight
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgproto3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+func newTestChecker(objects ...client.Object) *Checker {
+	c := NewChecker(fake.NewClientBuilder().WithScheme(testutils.Scheme).WithObjects(objects...).Build())
+	c.timeout = 5 * time.Second
+
+	return c
+}
+
+func newTestSecret(name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Data: map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+
+	return secret
+}
+
+// closedAddr returns an address that refuses connections.
+func closedAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	return addr
+}
+
+func splitHostPort(t *testing.T, addr string) (string, uint32) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := strconv.ParseUint(port, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return host, uint32(p)
+}
+
+// fakePostgreSQL is a stand-in of PostgreSQL that accepts or rejects all the connections.
+func fakePostgreSQL(t *testing.T, errorCode string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				backend := pgproto3.NewBackend(conn, conn)
+				for {
+					msg, err := backend.ReceiveStartupMessage()
+					if err != nil {
+						return
+					}
+					if _, ok := msg.(*pgproto3.SSLRequest); ok {
+						_, _ = conn.Write([]byte("N"))
+
+						continue
+					}
+
+					break
+				}
+
+				if errorCode != "" {
+					backend.Send(&pgproto3.ErrorResponse{Severity: "FATAL", Code: errorCode, Message: "rejected"})
+				} else {
+					backend.Send(&pgproto3.AuthenticationOk{})
+					backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+				}
+				_ = backend.Flush()
+
+				_, _ = backend.Receive()
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+// fakeS3 is a stand-in of S3 that records the requests and rejects the ones of the given method.
+type fakeS3 struct {
+	mu       sync.Mutex
+	requests []string
+	deny     string
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	s.mu.Unlock()
+
+	if r.Method == s.deny {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Query().Has("location") {
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+
+			return
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><KeyCount>0</KeyCount><MaxKeys>1</MaxKeys><IsTruncated>false</IsTruncated></ListBucketResult>`))
+	case http.MethodPut:
+		w.Header().Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestRisingWave(patch func(r *risingwavev1alpha1.RisingWave)) *risingwavev1alpha1.RisingWave {
+	risingwave := &risingwavev1alpha1.RisingWave{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "example",
+			UID:       "uid",
+		},
+		Spec: risingwavev1alpha1.RisingWaveSpec{
+			MetaStore: risingwavev1alpha1.RisingWaveMetaStoreBackend{
+				Memory: ptr.To(true),
+			},
+			StateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{
+				DataDirectory: "hummock",
+				Memory:        ptr.To(true),
+			},
+		},
+	}
+	patch(risingwave)
+
+	return risingwave
+}
+
+func TestChecker_MetaStore(t *testing.T) {
+	pgCredentials := risingwavev1alpha1.RisingWaveDBCredentials{
+		SecretName:     "pg",
+		UsernameKeyRef: "username",
+		PasswordKeyRef: "password",
+	}
+	pgSecret := newTestSecret("pg", map[string]string{"username": "root", "password": "pass"})
+
+	etcdServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/auth/authenticate" && strings.Contains(r.Header.Get("Content-Type"), "json") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"etcdserver: authentication failed, invalid user ID or password","code":3}`))
+
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer etcdServer.Close()
+	etcdAddr := etcdServer.Listener.Addr().String()
+
+	testcases := map[string]struct {
+		objects []client.Object
+		patch   func(r *risingwavev1alpha1.RisingWave)
+		errMsg  string
+	}{
+		"memory": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {},
+		},
+		"etcd-unreachable": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: closedAddr(t),
+					},
+				}
+			},
+			errMsg: "meta store: unable to connect to",
+		},
+		"etcd-without-auth": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: etcdAddr,
+					},
+				}
+			},
+		},
+		"etcd-auth-failed": {
+			objects: []client.Object{newTestSecret("etcd", map[string]string{"username": "root", "password": "wrong"})},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: etcdAddr,
+						RisingWaveEtcdCredentials: &risingwavev1alpha1.RisingWaveEtcdCredentials{
+							SecretName:     "etcd",
+							UsernameKeyRef: "username",
+							PasswordKeyRef: "password",
+						},
+					},
+				}
+			},
+			errMsg: `check the credentials in secret "etcd": etcdserver: authentication failed`,
+		},
+		"postgresql-unreachable": {
+			objects: []client.Object{pgSecret},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, closedAddr(t))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+					},
+				}
+			},
+			errMsg: "meta store: unable to connect to",
+		},
+		"postgresql-secret-not-found": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, fakePostgreSQL(t, ""))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+					},
+				}
+			},
+			errMsg: `meta store: secret "pg" not found`,
+		},
+		"postgresql-key-not-found": {
+			objects: []client.Object{newTestSecret("pg", map[string]string{"username": "root"})},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, fakePostgreSQL(t, ""))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+					},
+				}
+			},
+			errMsg: `meta store: key "password" not found in secret "pg"`,
+		},
+		"postgresql-auth-failed": {
+			objects: []client.Object{pgSecret},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, fakePostgreSQL(t, pgErrInvalidPassword))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+						Options:                 map[string]string{"sslmode": "disable"},
+					},
+				}
+			},
+			errMsg: `meta store: authentication failed for user "root", check the credentials in secret "pg"`,
+		},
+		"postgresql-database-not-exist": {
+			objects: []client.Object{pgSecret},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, fakePostgreSQL(t, pgErrInvalidCatalogName))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+					},
+				}
+			},
+			errMsg: `meta store: database "risingwave" does not exist`,
+		},
+		"postgresql-pass": {
+			objects: []client.Object{pgSecret},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, fakePostgreSQL(t, ""))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+					},
+				}
+			},
+		},
+		"mysql-unreachable": {
+			objects: []client.Object{pgSecret},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				host, port := splitHostPort(t, closedAddr(t))
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					MySQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL{
+						RisingWaveDBCredentials: pgCredentials,
+						Host:                    host,
+						Port:                    port,
+						Database:                "risingwave",
+					},
+				}
+			},
+			errMsg: "meta store: unable to connect to",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			err := newTestChecker(tc.objects...).Check(context.Background(), newTestRisingWave(tc.patch))
+			if tc.errMsg == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Fatalf("expect error containing %q, but got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestChecker_StateStore(t *testing.T) {
+	s3Secret := newTestSecret("s3", map[string]string{"AccessKeyID": "ak", "SecretAccessKey": "sk"})
+	minioSecret := newTestSecret("minio", map[string]string{"username": "ak", "password": "sk"})
+
+	testcases := map[string]struct {
+		objects  []client.Object
+		deny     string
+		patch    func(r *risingwavev1alpha1.RisingWave, endpoint string)
+		requests []string
+		errMsg   string
+	}{
+		"memory": {
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {},
+		},
+		"s3-pass": {
+			objects: []client.Object{s3Secret},
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.S3 = &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+					RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
+						SecretName:         "s3",
+						AccessKeyRef:       "AccessKeyID",
+						SecretAccessKeyRef: "SecretAccessKey",
+					},
+					Bucket:         "bucket",
+					Region:         "us-east-1",
+					Endpoint:       "http://" + endpoint,
+					ForcePathStyle: true,
+				}
+			},
+			requests: []string{
+				"GET /bucket/",
+				"PUT /bucket/hummock/risingwave-preflight-uid",
+				"DELETE /bucket/hummock/risingwave-preflight-uid",
+			},
+		},
+		"s3-access-denied": {
+			objects: []client.Object{s3Secret},
+			deny:    http.MethodPut,
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.S3 = &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+					RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
+						SecretName:         "s3",
+						AccessKeyRef:       "AccessKeyID",
+						SecretAccessKeyRef: "SecretAccessKey",
+					},
+					Bucket:         "bucket",
+					Region:         "us-east-1",
+					Endpoint:       "http://" + endpoint,
+					ForcePathStyle: true,
+				}
+			},
+			errMsg: `state store: access denied to put objects in bucket "bucket"`,
+		},
+		"s3-secret-not-found": {
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.S3 = &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+					RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
+						SecretName:         "s3",
+						AccessKeyRef:       "AccessKeyID",
+						SecretAccessKeyRef: "SecretAccessKey",
+					},
+					Bucket:   "bucket",
+					Region:   "us-east-1",
+					Endpoint: "http://" + endpoint,
+				}
+			},
+			errMsg: `state store: secret "s3" not found`,
+		},
+		"minio-pass": {
+			objects: []client.Object{minioSecret},
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.MinIO = &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+					RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+						SecretName:     "minio",
+						UsernameKeyRef: "username",
+						PasswordKeyRef: "password",
+					},
+					Endpoint: endpoint,
+					Bucket:   "bucket",
+				}
+			},
+			requests: []string{
+				"GET /bucket/",
+				"GET /bucket/",
+				"PUT /bucket/hummock/risingwave-preflight-uid",
+				"DELETE /bucket/hummock/risingwave-preflight-uid",
+			},
+		},
+		"minio-list-denied": {
+			objects: []client.Object{minioSecret},
+			deny:    http.MethodGet,
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.MinIO = &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+					RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+						SecretName:     "minio",
+						UsernameKeyRef: "username",
+						PasswordKeyRef: "password",
+					},
+					Endpoint: endpoint,
+					Bucket:   "bucket",
+				}
+			},
+			errMsg: `state store: access denied to list objects in bucket "bucket"`,
+		},
+		"hdfs-unreachable": {
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.HDFS = &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{
+					NameNode: "hdfs://" + closedAddr(t),
+				}
+			},
+			errMsg: "state store: unable to connect to",
+		},
+		"webhdfs-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave, endpoint string) {
+				r.Spec.StateStore.Memory = nil
+				r.Spec.StateStore.WebHDFS = &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{
+					NameNode: endpoint,
+				}
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			s3 := &fakeS3{deny: tc.deny}
+			server := httptest.NewServer(s3)
+			defer server.Close()
+
+			risingwave := newTestRisingWave(func(r *risingwavev1alpha1.RisingWave) {
+				tc.patch(r, server.Listener.Addr().String())
+			})
+
+			err := newTestChecker(tc.objects...).Check(context.Background(), risingwave)
+			if tc.errMsg == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Fatalf("expect error containing %q, but got %v", tc.errMsg, err)
+			}
+
+			if tc.requests != nil && strings.Join(s3.requests, ",") != strings.Join(tc.requests, ",") {
+				t.Fatalf("unexpected requests: %v, expected: %v", s3.requests, tc.requests)
+			}
+		})
+	}
+}
+
+func TestRequiresProbeJob(t *testing.T) {
+	testcases := map[string]struct {
+		stateStore risingwavev1alpha1.RisingWaveStateStoreBackend
+		expected   bool
+	}{
+		"s3-secret": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{
+				S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+					RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{SecretName: "s3-creds"},
+				},
+			},
+			expected: false,
+		},
+		"s3-service-account": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{
+				S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+					RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{UseServiceAccount: ptr.To(true)},
+				},
+			},
+			expected: true,
+		},
+		"gcs": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{}},
+			expected:   true,
+		},
+		"azure-blob": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{AzureBlob: &risingwavev1alpha1.RisingWaveStateStoreBackendAzureBlob{}},
+			expected:   true,
+		},
+		"minio": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{}},
+			expected:   false,
+		},
+		"hdfs": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{HDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{}},
+			expected:   false,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := testutils.FakeRisingWave()
+			risingwave.Spec.StateStore = tc.stateStore
+
+			if got := RequiresProbeJob(risingwave); got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
+)
+
+// Prefix of the probe object's name.
+const probeObjectPrefix = "risingwave-preflight-"
+
+func (c *Checker) checkStateStore(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
+	stateStore := &risingwave.Spec.StateStore
+
+	switch {
+	case stores.IsBucketSupported(stateStore):
+		b, err := stores.ResolveBucket(ctx, c.reader, risingwave)
+		if err != nil {
+			return err
+		}
+
+		location, _ := stores.DataLocationOf(risingwave)
+
+		return c.probeBucket(ctx, b, location.Directory, path.Join(location.Directory, probeObjectPrefix+string(risingwave.UID)))
+	case stateStore.S3 != nil:
+		// The credentials are only available in the pods, check the reachability only. The permissions are probed
+		// in a Job, see RequiresProbeJob.
+		endpoint, secure, _ := stores.S3Endpoint(stateStore.S3, stateStore.S3.Region)
+
+		return c.checkTCP(ctx, hostPort(endpoint, defaultPort(secure)))
+	case stateStore.GCS != nil:
+		// GCS isn't S3 compatible with the service account credentials, check the reachability only. The permissions
+		// are probed in a Job, see RequiresProbeJob.
+		return c.checkTCP(ctx, "storage.googleapis.com:443")
+	case stateStore.AzureBlob != nil:
+		endpoint := stateStore.AzureBlob.Endpoint
+		if !strings.HasPrefix(endpoint, "https://") {
+			endpoint = "https://" + endpoint
+		}
+
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid Azure Blob endpoint %q: %w", stateStore.AzureBlob.Endpoint, err)
+		}
+
+		return c.checkTCP(ctx, hostPort(u.Host, "443"))
+	case stateStore.HDFS != nil:
+		return c.checkNameNode(ctx, stateStore.HDFS.NameNode, "8020")
+	case stateStore.WebHDFS != nil:
+		return c.checkNameNode(ctx, stateStore.WebHDFS.NameNode, "9870")
+	default:
+		// Memory and local disk are local to the nodes, nothing to check.
+		return nil
+	}
+}
+
+// RequiresProbeJob tells if the permissions on the state store can only be probed from a Pod running with the
+// workload identity of the RisingWave or with the CLI of the cloud, rather than by the checker.
+func RequiresProbeJob(risingwave *risingwavev1alpha1.RisingWave) bool {
+	stateStore := &risingwave.Spec.StateStore
+
+	return (stateStore.S3 != nil && !stores.IsBucketSupported(stateStore)) || stateStore.GCS != nil || stateStore.AzureBlob != nil
+}
+
+func (c *Checker) checkNameNode(ctx context.Context, nameNode, port string) error {
+	// The default name node is resolved from the Hadoop configurations in the pods.
+	if nameNode == "default" {
+		return nil
+	}
+
+	addr := strings.TrimPrefix(strings.TrimPrefix(nameNode, "hdfs://"), "webhdfs://")
+	addr, _, _ = strings.Cut(addr, "/")
+
+	return c.checkTCP(ctx, hostPort(addr, port))
+}
+
+func defaultPort(secure bool) string {
+	if secure {
+		return "443"
+	}
+
+	return "80"
+}
+
+// probeBucket lists the objects under the directory, then puts and deletes the probe object to verify that the
+// credentials have the permissions that RisingWave requires.
+func (c *Checker) probeBucket(ctx context.Context, b *stores.Bucket, dir, probeObject string) error {
+	if err := c.checkTCP(ctx, hostPort(b.Endpoint, defaultPort(b.Secure))); err != nil {
+		return err
+	}
+
+	client, err := b.NewClient(c.transport())
+	if err != nil {
+		return err
+	}
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for object := range client.ListObjects(listCtx, b.Name, minio.ListObjectsOptions{Prefix: dir + "/", MaxKeys: 1}) {
+		if object.Err != nil {
+			return bucketError("list", b, object.Err)
+		}
+
+		break
+	}
+
+	if _, err := client.PutObject(ctx, b.Name, probeObject, strings.NewReader(""), 0, minio.PutObjectOptions{}); err != nil {
+		return bucketError("put", b, err)
+	}
+
+	if err := client.RemoveObject(ctx, b.Name, probeObject, minio.RemoveObjectOptions{}); err != nil {
+		return bucketError("delete", b, err)
+	}
+
+	return nil
+}
+
+// bucketError translates the error returned by the bucket into an actionable one.
+func bucketError(op string, b *stores.Bucket, err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchBucket":
+		return fmt.Errorf("bucket %q does not exist", b.Name)
+	case "AccessDenied":
+		return fmt.Errorf("access denied to %s objects in bucket %q, check the permissions of the credentials", op, b.Name)
+	case "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return fmt.Errorf("invalid credentials for bucket %q, check the access key and the secret key", b.Name)
+	default:
+		return fmt.Errorf("unable to %s objects in bucket %q: %w", op, b.Name, err)
+	}
+}
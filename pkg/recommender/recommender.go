@@ -0,0 +1,338 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recommender provides a vertical resource recommender for the RisingWave node groups. It keeps the peak
+// usage and the OOM terminations observed on the Pods and derives the recommended cpu and memory limits from them.
+package recommender
+
+import (
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+const (
+	// SafetyMarginFraction is the fraction added on top of the peak usage. It's kept below the
+	// ApplyToleranceFraction, as the usage observed on the Pods is capped by their limits, so that the limits of the
+	// Pods running at their limits aren't raised round after round.
+	SafetyMarginFraction = 0.05
+
+	// CPUPeakHalfLife is the time for the peak cpu usage to decay by half, so that the recommended cpu limit goes
+	// down after the load drops.
+	CPUPeakHalfLife = 24 * time.Hour
+
+	// OOMBumpUpRatio is the ratio to bump the memory limit by after an OOM termination.
+	OOMBumpUpRatio = 1.2
+
+	// ApplyToleranceFraction is the relative difference between the current limit and the recommendation
+	// below which the recommendation isn't applied, to avoid resizing the Pods for negligible changes.
+	ApplyToleranceFraction = 0.1
+
+	reasonOOMKilled = "OOMKilled"
+)
+
+// OOMMinBumpUp is the minimum increase of the memory limit after an OOM termination.
+var OOMMinBumpUp = resource.MustParse("100Mi")
+
+// PodMetricsListGVK is the GroupVersionKind of the PodMetricsList served by the metrics server.
+var PodMetricsListGVK = schema.GroupVersionKind{
+	Group:   "metrics.k8s.io",
+	Version: "v1beta1",
+	Kind:    "PodMetricsList",
+}
+
+// ContainerUsages is the observed resource usage keyed by the Pod name and then the container name.
+type ContainerUsages map[string]map[string]corev1.ResourceList
+
+// ContainerUsagesFromPodMetrics parses the PodMetrics objects returned by the metrics server.
+// Malformed entries are ignored.
+func ContainerUsagesFromPodMetrics(podMetrics []unstructured.Unstructured) ContainerUsages {
+	usages := make(ContainerUsages)
+
+	for _, pm := range podMetrics {
+		containers, _, _ := unstructured.NestedSlice(pm.Object, "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			name, _, _ := unstructured.NestedString(container, "name")
+			usage, _, _ := unstructured.NestedStringMap(container, "usage")
+
+			list := make(corev1.ResourceList)
+			for _, r := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				if q, err := resource.ParseQuantity(usage[string(r)]); err == nil {
+					list[r] = q
+				}
+			}
+
+			if usages[pm.GetName()] == nil {
+				usages[pm.GetName()] = make(map[string]corev1.ResourceList)
+			}
+			usages[pm.GetName()][name] = list
+		}
+	}
+
+	return usages
+}
+
+func maxQuantity(a, b resource.Quantity) resource.Quantity {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+
+	return b
+}
+
+func withMargin(q resource.Quantity, fraction float64) resource.Quantity {
+	return *resource.NewMilliQuantity(int64(math.Ceil(float64(q.MilliValue())*(1+fraction))), q.Format)
+}
+
+// roundUpCPU rounds the cpu up to the multiple of 100m, so that the decay of the peak doesn't change the
+// recommendation every round.
+func roundUpCPU(q resource.Quantity) resource.Quantity {
+	const granularity = 100
+
+	milli := (q.MilliValue() + granularity - 1) / granularity * granularity
+
+	return *resource.NewMilliQuantity(milli, resource.DecimalSI)
+}
+
+func roundUpMemory(q resource.Quantity) resource.Quantity {
+	const mebibyte = 1 << 20
+
+	mib := (q.Value() + mebibyte - 1) / mebibyte
+
+	return *resource.NewQuantity(mib*mebibyte, resource.BinarySI)
+}
+
+func clamp(name corev1.ResourceName, q resource.Quantity, policy *risingwavev1alpha1.RisingWaveResourceRecommendationPolicy) resource.Quantity {
+	if lower, ok := policy.MinAllowed[name]; ok && q.Cmp(lower) < 0 {
+		q = lower.DeepCopy()
+	}
+	if upper, ok := policy.MaxAllowed[name]; ok && q.Cmp(upper) > 0 {
+		q = upper.DeepCopy()
+	}
+
+	return q
+}
+
+func findContainer[T any](containers []T, name string, nameOf func(*T) string) *T {
+	for i := range containers {
+		if nameOf(&containers[i]) == name {
+			return &containers[i]
+		}
+	}
+
+	return nil
+}
+
+// Recommend updates the recommendation of a node group with the Pods of the group and their observed usages. The
+// container is the name of the RisingWave container in the Pods. The previous recommendation could be nil.
+//
+// Memory is recommended as the peak usage plus a safety margin, and bumped by OOMBumpUpRatio of the limit that the
+// container was OOM killed with. It never goes down. CPU is recommended as the peak usage plus a safety margin, where
+// the peak decays by half every CPUPeakHalfLife. The results are bounded by the MinAllowed and MaxAllowed of the
+// policy.
+func Recommend(prev *risingwavev1alpha1.RisingWaveResourceRecommendation, component, group, container string,
+	policy *risingwavev1alpha1.RisingWaveResourceRecommendationPolicy, pods []corev1.Pod, usages ContainerUsages,
+	now metav1.Time) risingwavev1alpha1.RisingWaveResourceRecommendation {
+	r := risingwavev1alpha1.RisingWaveResourceRecommendation{
+		Component: component,
+		Group:     group,
+		PeakUsage: make(corev1.ResourceList),
+		Target:    make(corev1.ResourceList),
+	}
+	if prev != nil {
+		prev = prev.DeepCopy()
+		r.PeakUsage = orEmpty(prev.PeakUsage)
+		r.Target = orEmpty(prev.Target)
+		r.OOMKills = prev.OOMKills
+		r.LastOOMTime = prev.LastOOMTime
+		r.LastUpdateTime = prev.LastUpdateTime
+		r.LastSampleTime = prev.LastSampleTime
+	}
+
+	// Decay the peak cpu usage since the last sample.
+	if peak, ok := r.PeakUsage[corev1.ResourceCPU]; ok && r.LastSampleTime != nil && now.After(r.LastSampleTime.Time) {
+		decay := math.Pow(0.5, float64(now.Sub(r.LastSampleTime.Time))/float64(CPUPeakHalfLife))
+		r.PeakUsage[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(float64(peak.MilliValue())*decay), peak.Format)
+	}
+
+	// Track the peak usage.
+	for _, pod := range pods {
+		usage, ok := usages[pod.Name][container]
+		if !ok {
+			continue
+		}
+
+		for name, q := range usage {
+			if peak, ok := r.PeakUsage[name]; ok {
+				r.PeakUsage[name] = maxQuantity(peak, q)
+			} else {
+				r.PeakUsage[name] = q.DeepCopy()
+			}
+		}
+	}
+	r.LastSampleTime = now.DeepCopy()
+
+	// Track the OOM terminations that haven't been observed.
+	var oomBumpedMemory *resource.Quantity
+	lastOOMTime := r.LastOOMTime
+
+	for _, pod := range pods {
+		status := findContainer(pod.Status.ContainerStatuses, container, func(s *corev1.ContainerStatus) string { return s.Name })
+		if status == nil || status.LastTerminationState.Terminated == nil {
+			continue
+		}
+
+		terminated := status.LastTerminationState.Terminated
+		if terminated.Reason != reasonOOMKilled || (lastOOMTime != nil && !terminated.FinishedAt.After(lastOOMTime.Time)) {
+			continue
+		}
+
+		r.OOMKills++
+		if r.LastOOMTime == nil || terminated.FinishedAt.After(r.LastOOMTime.Time) {
+			r.LastOOMTime = terminated.FinishedAt.DeepCopy()
+		}
+
+		spec := findContainer(pod.Spec.Containers, container, func(c *corev1.Container) string { return c.Name })
+		if spec == nil {
+			continue
+		}
+
+		// Prefer the resized limits reported in the status if there are any.
+		limit, ok := spec.Resources.Limits[corev1.ResourceMemory]
+		if status.Resources != nil {
+			if resized, found := status.Resources.Limits[corev1.ResourceMemory]; found {
+				limit, ok = resized, true
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		bumped := withMargin(limit, OOMBumpUpRatio-1)
+		minBumped := limit.DeepCopy()
+		minBumped.Add(OOMMinBumpUp)
+		bumped = maxQuantity(bumped, minBumped)
+
+		if oomBumpedMemory == nil || bumped.Cmp(*oomBumpedMemory) > 0 {
+			oomBumpedMemory = &bumped
+		}
+	}
+
+	// Derive the targets.
+	target := make(corev1.ResourceList)
+	if peak, ok := r.PeakUsage[corev1.ResourceCPU]; ok {
+		target[corev1.ResourceCPU] = clamp(corev1.ResourceCPU, roundUpCPU(withMargin(peak, SafetyMarginFraction)), policy)
+	}
+
+	var memory *resource.Quantity
+	if peak, ok := r.PeakUsage[corev1.ResourceMemory]; ok {
+		memory = ptr.To(withMargin(peak, SafetyMarginFraction))
+	}
+	if oomBumpedMemory != nil && (memory == nil || oomBumpedMemory.Cmp(*memory) > 0) {
+		memory = oomBumpedMemory
+	}
+	if prevMemory, ok := r.Target[corev1.ResourceMemory]; ok && (memory == nil || prevMemory.Cmp(*memory) > 0) {
+		memory = &prevMemory
+	}
+	if memory != nil {
+		target[corev1.ResourceMemory] = clamp(corev1.ResourceMemory, roundUpMemory(*memory), policy)
+	}
+
+	if !isResourceListEqual(target, r.Target) {
+		r.Target = target
+		r.LastUpdateTime = now
+	}
+
+	return r
+}
+
+func orEmpty(l corev1.ResourceList) corev1.ResourceList {
+	if l == nil {
+		return make(corev1.ResourceList)
+	}
+
+	return l
+}
+
+func isResourceListEqual(a, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, q := range a {
+		if other, ok := b[name]; !ok || q.Cmp(other) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSignificantlyDifferent tells if the target differs from the current limits by more than the
+// ApplyToleranceFraction on any resource. Resources that aren't in the target are ignored.
+func IsSignificantlyDifferent(current, target corev1.ResourceList) bool {
+	for name, t := range target {
+		c, ok := current[name]
+		if !ok || c.IsZero() {
+			return true
+		}
+
+		diff := math.Abs(t.AsApproximateFloat64()-c.AsApproximateFloat64()) / c.AsApproximateFloat64()
+		if diff > ApplyToleranceFraction {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApplyTarget sets the limits of the resources in the target. The memory request is set to the target as well since
+// RisingWave sizes its memory usage by the limit, and the cpu request is capped at the new limit.
+func ApplyTarget(resources *corev1.ResourceRequirements, target corev1.ResourceList) {
+	if len(target) == 0 {
+		return
+	}
+
+	if resources.Limits == nil {
+		resources.Limits = make(corev1.ResourceList)
+	}
+	if resources.Requests == nil {
+		resources.Requests = make(corev1.ResourceList)
+	}
+
+	if cpu, ok := target[corev1.ResourceCPU]; ok {
+		resources.Limits[corev1.ResourceCPU] = cpu.DeepCopy()
+		if req, found := resources.Requests[corev1.ResourceCPU]; !found || req.Cmp(cpu) > 0 {
+			resources.Requests[corev1.ResourceCPU] = cpu.DeepCopy()
+		}
+	}
+
+	if memory, ok := target[corev1.ResourceMemory]; ok {
+		resources.Limits[corev1.ResourceMemory] = memory.DeepCopy()
+		resources.Requests[corev1.ResourceMemory] = memory.DeepCopy()
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+func newPod(name string, memoryLimit string, oomKilledAt *time.Time) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "compute",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse(memoryLimit),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "compute"},
+			},
+		},
+	}
+
+	if oomKilledAt != nil {
+		pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+			Reason:     "OOMKilled",
+			FinishedAt: metav1.NewTime(*oomKilledAt),
+		}
+	}
+
+	return pod
+}
+
+func usageOf(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func assertQuantity(t *testing.T, list corev1.ResourceList, name corev1.ResourceName, expected string) {
+	t.Helper()
+
+	q, ok := list[name]
+	if !ok {
+		t.Fatalf("%s not found in %v", name, list)
+	}
+	if q.Cmp(resource.MustParse(expected)) != 0 {
+		t.Fatalf("unexpected %s, expected %s, got %s", name, expected, q.String())
+	}
+}
+
+func TestContainerUsagesFromPodMetrics(t *testing.T) {
+	podMetrics := []unstructured.Unstructured{
+		{
+			Object: map[string]any{
+				"metadata": map[string]any{"name": "pod-0"},
+				"containers": []any{
+					map[string]any{
+						"name":  "compute",
+						"usage": map[string]any{"cpu": "1500m", "memory": "2Gi"},
+					},
+					"malformed",
+				},
+			},
+		},
+	}
+
+	usages := ContainerUsagesFromPodMetrics(podMetrics)
+	assertQuantity(t, usages["pod-0"]["compute"], corev1.ResourceCPU, "1500m")
+	assertQuantity(t, usages["pod-0"]["compute"], corev1.ResourceMemory, "2Gi")
+}
+
+func TestRecommend_PeakUsage(t *testing.T) {
+	policy := &risingwavev1alpha1.RisingWaveResourceRecommendationPolicy{}
+	pods := []corev1.Pod{newPod("pod-0", "4Gi", nil), newPod("pod-1", "4Gi", nil)}
+	now := metav1.Now()
+
+	r := Recommend(nil, "compute", "", "compute", policy, pods, ContainerUsages{
+		"pod-0": {"compute": usageOf("1", "2Gi")},
+		"pod-1": {"compute": usageOf("2", "1Gi")},
+	}, now)
+
+	assertQuantity(t, r.PeakUsage, corev1.ResourceCPU, "2")
+	assertQuantity(t, r.PeakUsage, corev1.ResourceMemory, "2Gi")
+	assertQuantity(t, r.Target, corev1.ResourceCPU, "2100m")
+	// 2Gi * 1.05 rounded up to MiB.
+	assertQuantity(t, r.Target, corev1.ResourceMemory, "2151Mi")
+	if !r.LastUpdateTime.Equal(&now) {
+		t.Fatal("last update time should be set")
+	}
+
+	// The peak is kept when the usage drops, and the update time is unchanged.
+	later := metav1.NewTime(now.Add(time.Minute))
+	r = Recommend(&r, "compute", "", "compute", policy, pods, ContainerUsages{
+		"pod-0": {"compute": usageOf("500m", "1Gi")},
+	}, later)
+	assertQuantity(t, r.PeakUsage, corev1.ResourceMemory, "2Gi")
+	if !r.LastUpdateTime.Equal(&now) {
+		t.Fatal("last update time should be unchanged")
+	}
+}
+
+func TestRecommend_PodsAtLimits(t *testing.T) {
+	policy := &risingwavev1alpha1.RisingWaveResourceRecommendationPolicy{
+		MaxAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+	}
+	pod := newPod("pod-0", "4Gi", nil)
+	pod.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU] = resource.MustParse("2")
+	now := time.Now()
+
+	// Run the rounds of the recommender against the Pod with its limits applied the way the Auto mode does, where
+	// the observed cpu usage is capped at the limit.
+	var r *risingwavev1alpha1.RisingWaveResourceRecommendation
+	runRounds := func(rounds int, cpu string) {
+		for range rounds {
+			limits := pod.Spec.Containers[0].Resources.Limits
+			usage := usageOf(cpu, "1Gi")
+			if usage.Cpu().Cmp(*limits.Cpu()) > 0 {
+				usage[corev1.ResourceCPU] = limits.Cpu().DeepCopy()
+			}
+
+			now = now.Add(time.Hour)
+			next := Recommend(r, "compute", "", "compute", policy, []corev1.Pod{pod}, ContainerUsages{
+				"pod-0": {"compute": usage},
+			}, metav1.NewTime(now))
+			r = &next
+
+			target := corev1.ResourceList{corev1.ResourceCPU: r.Target[corev1.ResourceCPU]}
+			if IsSignificantlyDifferent(limits, target) {
+				ApplyTarget(&pod.Spec.Containers[0].Resources, target)
+			}
+		}
+	}
+
+	// The limit isn't raised round after round.
+	runRounds(24, "4")
+	assertQuantity(t, pod.Spec.Containers[0].Resources.Limits, corev1.ResourceCPU, "2")
+
+	// The limit goes down after the load drops.
+	runRounds(72, "500m")
+	if limit := pod.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU]; limit.Cmp(resource.MustParse("1")) >= 0 {
+		t.Fatalf("cpu limit not lowered: %s", limit.String())
+	}
+	if peak := r.PeakUsage[corev1.ResourceCPU]; peak.Cmp(resource.MustParse("500m")) < 0 {
+		t.Fatalf("peak cpu usage decays below the usage: %s", peak.String())
+	}
+}
+
+func TestRecommend_OOMKilled(t *testing.T) {
+	policy := &risingwavev1alpha1.RisingWaveResourceRecommendationPolicy{}
+	oomTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	pods := []corev1.Pod{newPod("pod-0", "1Gi", &oomTime)}
+
+	r := Recommend(nil, "compute", "", "compute", policy, pods, nil, metav1.Now())
+	if r.OOMKills != 1 || r.LastOOMTime == nil || !r.LastOOMTime.Time.Equal(oomTime) {
+		t.Fatalf("unexpected oom tracking: %d, %v", r.OOMKills, r.LastOOMTime)
+	}
+	// 1Gi * 1.2 rounded up to MiB.
+	assertQuantity(t, r.Target, corev1.ResourceMemory, "1229Mi")
+
+	// The same OOM termination is counted only once.
+	r = Recommend(&r, "compute", "", "compute", policy, pods, nil, metav1.Now())
+	if r.OOMKills != 1 {
+		t.Fatalf("oom counted twice: %d", r.OOMKills)
+	}
+	assertQuantity(t, r.Target, corev1.ResourceMemory, "1229Mi")
+
+	// The minimum bump applies to small limits.
+	pods = []corev1.Pod{newPod("pod-0", "128Mi", &oomTime)}
+	r = Recommend(nil, "compute", "", "compute", policy, pods, nil, metav1.Now())
+	assertQuantity(t, r.Target, corev1.ResourceMemory, "228Mi")
+}
+
+func TestRecommend_Bounds(t *testing.T) {
+	policy := &risingwavev1alpha1.RisingWaveResourceRecommendationPolicy{
+		MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+	pods := []corev1.Pod{newPod("pod-0", "8Gi", nil)}
+
+	r := Recommend(nil, "compute", "", "compute", policy, pods, ContainerUsages{
+		"pod-0": {"compute": usageOf("100m", "6Gi")},
+	}, metav1.Now())
+
+	assertQuantity(t, r.Target, corev1.ResourceCPU, "1")
+	assertQuantity(t, r.Target, corev1.ResourceMemory, "4Gi")
+}
+
+func TestIsSignificantlyDifferent(t *testing.T) {
+	testcases := map[string]struct {
+		current  corev1.ResourceList
+		target   corev1.ResourceList
+		expected bool
+	}{
+		"within-tolerance": {
+			current:  usageOf("2", "4Gi"),
+			target:   usageOf("2100m", "4200Mi"),
+			expected: false,
+		},
+		"memory-out-of-tolerance": {
+			current:  usageOf("2", "4Gi"),
+			target:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("5Gi")},
+			expected: true,
+		},
+		"missing-limit": {
+			current:  corev1.ResourceList{},
+			target:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			expected: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			if IsSignificantlyDifferent(tc.current, tc.target) != tc.expected {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestApplyTarget(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Requests: usageOf("4", "1Gi"),
+	}
+
+	ApplyTarget(&resources, usageOf("2", "4Gi"))
+
+	assertQuantity(t, resources.Limits, corev1.ResourceCPU, "2")
+	assertQuantity(t, resources.Limits, corev1.ResourceMemory, "4Gi")
+	assertQuantity(t, resources.Requests, corev1.ResourceCPU, "2")
+	assertQuantity(t, resources.Requests, corev1.ResourceMemory, "4Gi")
+}
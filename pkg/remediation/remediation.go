@@ -0,0 +1,303 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remediation finds the Pods of the StatefulSets that get stuck in a rollout, and plans the interventions
+// within the budget of the remediation policy.
+package remediation
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	kruiseappsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+const (
+	// DefaultStuckThreshold is the default threshold of a Pod being not ready before it's considered stuck.
+	DefaultStuckThreshold = 10 * time.Minute
+
+	// DefaultMaxInterventions is the default max number of interventions within the budget window.
+	DefaultMaxInterventions = 3
+
+	// DefaultBudgetWindow is the default sliding window of the budget.
+	DefaultBudgetWindow = time.Hour
+
+	// MaxInterventionsKept is the max number of interventions kept in the status, except for the ones within the
+	// budget window, which are always kept as the budget is counted from them.
+	MaxInterventionsKept = 20
+)
+
+// Workload is the rollout state of a StatefulSet, either a native or an advanced one.
+type Workload struct {
+	// UID of the workload, which is the controller of its Pods.
+	UID types.UID
+
+	// Component and Group of the workload.
+	Component string
+	Group     string
+
+	// Updating is true when the workload has observed its latest generation and is rolling the Pods.
+	Updating bool
+
+	// UpdateRevision is the revision the Pods are rolled to.
+	UpdateRevision string
+}
+
+// WorkloadOfStatefulSet returns the workload of the StatefulSet.
+func WorkloadOfStatefulSet(sts *appsv1.StatefulSet) Workload {
+	return Workload{
+		UID:       sts.UID,
+		Component: sts.Labels[consts.LabelRisingWaveComponent],
+		Group:     sts.Labels[consts.LabelRisingWaveGroup],
+		Updating: sts.Generation == sts.Status.ObservedGeneration &&
+			(sts.Status.UpdateRevision != sts.Status.CurrentRevision || sts.Status.UpdatedReplicas < sts.Status.Replicas),
+		UpdateRevision: sts.Status.UpdateRevision,
+	}
+}
+
+// WorkloadOfAdvancedStatefulSet returns the workload of the advanced StatefulSet.
+func WorkloadOfAdvancedStatefulSet(sts *kruiseappsv1beta1.StatefulSet) Workload {
+	return Workload{
+		UID:       sts.UID,
+		Component: sts.Labels[consts.LabelRisingWaveComponent],
+		Group:     sts.Labels[consts.LabelRisingWaveGroup],
+		Updating: sts.Generation == sts.Status.ObservedGeneration &&
+			(sts.Status.UpdateRevision != sts.Status.CurrentRevision || sts.Status.UpdatedReplicas < sts.Status.Replicas),
+		UpdateRevision: sts.Status.UpdateRevision,
+	}
+}
+
+// Intervention is a stuck Pod to delete.
+type Intervention struct {
+	Pod       *corev1.Pod
+	Component string
+	Group     string
+	Reason    risingwavev1alpha1.RisingWaveRemediationReason
+	Message   string
+}
+
+// Record returns the record of the intervention made at the given time.
+func (i *Intervention) Record(now metav1.Time) risingwavev1alpha1.RisingWaveRemediationIntervention {
+	return risingwavev1alpha1.RisingWaveRemediationIntervention{
+		Time:      now,
+		Component: i.Component,
+		Group:     i.Group,
+		Pod:       i.Pod.Name,
+		Reason:    i.Reason,
+		Message:   i.Message,
+	}
+}
+
+// Plan is the result of the planning.
+type Plan struct {
+	// Interventions to make within the budget.
+	Interventions []Intervention
+
+	// Withheld are the names of the stuck Pods left as is because the budget is exhausted.
+	Withheld []string
+
+	// RecheckAfter is the duration after which the next Pod would be stuck, or zero if there's none.
+	RecheckAfter time.Duration
+}
+
+// Planner finds the stuck Pods and plans the interventions.
+type Planner struct {
+	// Now is the time of the planning.
+	Now time.Time
+
+	// Policy of the remediation.
+	Policy *risingwavev1alpha1.RisingWaveRemediationPolicy
+
+	// History are the interventions made before.
+	History []risingwavev1alpha1.RisingWaveRemediationIntervention
+}
+
+func (p *Planner) stuckThreshold() time.Duration {
+	if p.Policy.StuckThreshold != nil {
+		return p.Policy.StuckThreshold.Duration
+	}
+
+	return DefaultStuckThreshold
+}
+
+func (p *Planner) budgetWindow() time.Duration {
+	if p.Policy.BudgetWindow != nil {
+		return p.Policy.BudgetWindow.Duration
+	}
+
+	return DefaultBudgetWindow
+}
+
+// IsComponentEnabled returns true if the Pods of the component are remediated.
+func IsComponentEnabled(policy *risingwavev1alpha1.RisingWaveRemediationPolicy, component string) bool {
+	if policy == nil {
+		return false
+	}
+
+	return len(policy.Components) == 0 || slices.Contains(policy.Components, component)
+}
+
+// RemainingBudget returns the number of interventions that can still be made within the budget window.
+func (p *Planner) RemainingBudget() int {
+	used := 0
+	for _, record := range p.History {
+		if p.Now.Sub(record.Time.Time) < p.budgetWindow() {
+			used++
+		}
+	}
+
+	return max(int(ptr.Deref(p.Policy.MaxInterventions, DefaultMaxInterventions))-used, 0)
+}
+
+// notReadySince returns the time since when the Pod has been not ready, or false if it's ready.
+func notReadySince(pod *corev1.Pod) (time.Time, bool) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != corev1.PodReady {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			return time.Time{}, false
+		}
+		if !cond.LastTransitionTime.IsZero() {
+			return cond.LastTransitionTime.Time, true
+		}
+	}
+
+	return pod.CreationTimestamp.Time, true
+}
+
+// isUnschedulable returns true if the Pod is pending for no node fits it, which deleting the Pod doesn't help.
+func isUnschedulable(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Plan finds the Pods of the updating workloads that have been not ready for longer than the stuck threshold, and
+// plans to delete at most one Pod per workload within the remaining budget, the longest stuck first.
+func (p *Planner) Plan(workloads []Workload, pods []corev1.Pod) Plan {
+	var plan Plan
+
+	type candidate struct {
+		intervention Intervention
+		since        time.Time
+	}
+
+	workloadOf := make(map[types.UID]*Workload, len(workloads))
+	for i := range workloads {
+		if workloads[i].Updating && IsComponentEnabled(p.Policy, workloads[i].Component) {
+			workloadOf[workloads[i].UID] = &workloads[i]
+		}
+	}
+
+	threshold := p.stuckThreshold()
+	stuckOf := make(map[types.UID]*candidate)
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || isUnschedulable(pod) {
+			continue
+		}
+
+		owner := metav1.GetControllerOfNoCopy(pod)
+		if owner == nil {
+			continue
+		}
+		workload, ok := workloadOf[owner.UID]
+		if !ok {
+			continue
+		}
+
+		since, notReady := notReadySince(pod)
+		if !notReady {
+			continue
+		}
+
+		if notReadyFor := p.Now.Sub(since); notReadyFor < threshold {
+			if plan.RecheckAfter == 0 || threshold-notReadyFor < plan.RecheckAfter {
+				plan.RecheckAfter = threshold - notReadyFor
+			}
+
+			continue
+		}
+
+		reason, revision := risingwavev1alpha1.RisingWaveRemediationReasonNotReady, pod.Labels[appsv1.StatefulSetRevisionLabel]
+		if revision != workload.UpdateRevision {
+			reason = risingwavev1alpha1.RisingWaveRemediationReasonOutdated
+		}
+
+		c := &candidate{
+			intervention: Intervention{
+				Pod:       pod,
+				Component: workload.Component,
+				Group:     workload.Group,
+				Reason:    reason,
+				Message: fmt.Sprintf("not ready for %s on revision %s, while the update revision is %s",
+					p.Now.Sub(since).Round(time.Second), revision, workload.UpdateRevision),
+			},
+			since: since,
+		}
+
+		// Only one Pod of a workload is deleted at a time, the others wait for the next round.
+		if prev, ok := stuckOf[owner.UID]; !ok || since.Before(prev.since) {
+			stuckOf[owner.UID] = c
+		}
+	}
+
+	candidates := make([]*candidate, 0, len(stuckOf))
+	for _, c := range stuckOf {
+		candidates = append(candidates, c)
+	}
+	slices.SortFunc(candidates, func(a, b *candidate) int {
+		return cmp.Or(a.since.Compare(b.since), cmp.Compare(a.intervention.Pod.Name, b.intervention.Pod.Name))
+	})
+
+	budget := p.RemainingBudget()
+	for _, c := range candidates {
+		if len(plan.Interventions) < budget {
+			plan.Interventions = append(plan.Interventions, c.intervention)
+		} else {
+			plan.Withheld = append(plan.Withheld, c.intervention.Pod.Name)
+		}
+	}
+
+	return plan
+}
+
+// AppendHistory appends the records to the history. The records within the budget window are always kept, as the
+// budget is counted from them, and only the older ones are pruned to keep at most MaxInterventionsKept of them.
+func (p *Planner) AppendHistory(records ...risingwavev1alpha1.RisingWaveRemediationIntervention) []risingwavev1alpha1.RisingWaveRemediationIntervention {
+	history := append(slices.Clone(p.History), records...)
+
+	pruned := 0
+	for pruned < len(history)-MaxInterventionsKept && p.Now.Sub(history[pruned].Time.Time) >= p.budgetWindow() {
+		pruned++
+	}
+
+	return history[pruned:]
+}
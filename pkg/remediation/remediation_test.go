@@ -0,0 +1,221 @@
+// Copyright 2024 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+)
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newWorkload(uid types.UID, component string) Workload {
+	return Workload{UID: uid, Component: component, Updating: true, UpdateRevision: "new"}
+}
+
+// newPod returns a Pod of the workload on the revision, which has been not ready since the given time, or ready if
+// it's zero.
+func newPod(name string, owner types.UID, revision string, notReadySince time.Time) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-24 * time.Hour)),
+			Labels:            map[string]string{appsv1.StatefulSetRevisionLabel: revision},
+			OwnerReferences: []metav1.OwnerReference{
+				{UID: owner, Controller: ptr.To(true)},
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	if !notReadySince.IsZero() {
+		pod.Status.Conditions[0].Status = corev1.ConditionFalse
+		pod.Status.Conditions[0].LastTransitionTime = metav1.NewTime(notReadySince)
+	}
+
+	return pod
+}
+
+func TestWorkloadOfStatefulSet(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:        "uid",
+			Generation: 2,
+			Labels: map[string]string{
+				consts.LabelRisingWaveComponent: consts.ComponentCompute,
+				consts.LabelRisingWaveGroup:     "a",
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2,
+			Replicas:           3,
+			UpdatedReplicas:    1,
+			CurrentRevision:    "old",
+			UpdateRevision:     "new",
+		},
+	}
+	assert.Equal(t, Workload{UID: "uid", Component: consts.ComponentCompute, Group: "a", Updating: true, UpdateRevision: "new"},
+		WorkloadOfStatefulSet(sts))
+
+	// Not observed yet.
+	sts.Generation = 3
+	assert.False(t, WorkloadOfStatefulSet(sts).Updating)
+
+	// Rolled out.
+	sts.Generation = 2
+	sts.Status.UpdatedReplicas, sts.Status.CurrentRevision = 3, "new"
+	assert.False(t, WorkloadOfStatefulSet(sts).Updating)
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	workloads := []Workload{
+		newWorkload("meta", consts.ComponentMeta),
+		newWorkload("compute", consts.ComponentCompute),
+		newWorkload("frontend", consts.ComponentFrontend),
+		{UID: "standalone", Component: consts.ComponentStandalone, UpdateRevision: "new"},
+	}
+
+	unschedulable := newPod("rw-frontend-0", "frontend", "new", now.Add(-time.Hour))
+	unschedulable.Status.Conditions = append(unschedulable.Status.Conditions, corev1.PodCondition{
+		Type:   corev1.PodScheduled,
+		Status: corev1.ConditionFalse,
+		Reason: corev1.PodReasonUnschedulable,
+	})
+
+	pods := []corev1.Pod{
+		// Stuck on the old revision.
+		newPod("rw-meta-1", "meta", "old", now.Add(-20*time.Minute)),
+		// Stuck on the update revision, but not the longest stuck one of the workload.
+		newPod("rw-compute-1", "compute", "new", now.Add(-15*time.Minute)),
+		newPod("rw-compute-2", "compute", "old", now.Add(-30*time.Minute)),
+		// Not stuck yet.
+		newPod("rw-meta-0", "meta", "new", now.Add(-7*time.Minute)),
+		// Ready.
+		newPod("rw-compute-0", "compute", "old", time.Time{}),
+		// Deleting pods, unschedulable pods and pods of the workloads not updating are left alone.
+		unschedulable,
+		newPod("rw-standalone-0", "standalone", "old", now.Add(-time.Hour)),
+	}
+	deleting := newPod("rw-meta-2", "meta", "old", now.Add(-time.Hour))
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+	pods = append(pods, deleting)
+
+	planner := &Planner{Now: now, Policy: &risingwavev1alpha1.RisingWaveRemediationPolicy{}}
+	plan := planner.Plan(workloads, pods)
+
+	if assert.Len(t, plan.Interventions, 2) {
+		assert.Equal(t, "rw-compute-2", plan.Interventions[0].Pod.Name)
+		assert.Equal(t, risingwavev1alpha1.RisingWaveRemediationReasonOutdated, plan.Interventions[0].Reason)
+		assert.Equal(t, "not ready for 30m0s on revision old, while the update revision is new", plan.Interventions[0].Message)
+		assert.Equal(t, "rw-meta-1", plan.Interventions[1].Pod.Name)
+	}
+	assert.Empty(t, plan.Withheld)
+	assert.Equal(t, 3*time.Minute, plan.RecheckAfter)
+
+	// Only the components in the policy.
+	planner.Policy.Components = []string{consts.ComponentMeta}
+	plan = planner.Plan(workloads, pods)
+	if assert.Len(t, plan.Interventions, 1) {
+		assert.Equal(t, "rw-meta-1", plan.Interventions[0].Pod.Name)
+	}
+
+	// On the update revision.
+	planner.Policy.Components = []string{consts.ComponentCompute}
+	plan = planner.Plan(workloads, []corev1.Pod{newPod("rw-compute-1", "compute", "new", now.Add(-15*time.Minute))})
+	if assert.Len(t, plan.Interventions, 1) {
+		assert.Equal(t, risingwavev1alpha1.RisingWaveRemediationReasonNotReady, plan.Interventions[0].Reason)
+	}
+}
+
+func TestPlanner_Budget(t *testing.T) {
+	var workloads []Workload
+	var pods []corev1.Pod
+	for i := range 3 {
+		uid := types.UID(fmt.Sprintf("compute-%d", i))
+		workloads = append(workloads, newWorkload(uid, consts.ComponentCompute))
+		pods = append(pods, newPod(fmt.Sprintf("rw-compute-%d-0", i), uid, "old", now.Add(-time.Duration(30-i)*time.Minute)))
+	}
+
+	planner := &Planner{
+		Now: now,
+		Policy: &risingwavev1alpha1.RisingWaveRemediationPolicy{
+			MaxInterventions: ptr.To[int32](2),
+			BudgetWindow:     &metav1.Duration{Duration: time.Hour},
+		},
+		History: []risingwavev1alpha1.RisingWaveRemediationIntervention{
+			{Time: metav1.NewTime(now.Add(-2 * time.Hour)), Pod: "rw-compute-0-0"},
+			{Time: metav1.NewTime(now.Add(-30 * time.Minute)), Pod: "rw-compute-0-0"},
+		},
+	}
+	assert.Equal(t, 1, planner.RemainingBudget())
+
+	plan := planner.Plan(workloads, pods)
+	if assert.Len(t, plan.Interventions, 1) {
+		assert.Equal(t, "rw-compute-0-0", plan.Interventions[0].Pod.Name)
+	}
+	assert.Equal(t, []string{"rw-compute-1-0", "rw-compute-2-0"}, plan.Withheld)
+}
+
+func TestAppendHistory(t *testing.T) {
+	now := time.Now()
+	planner := &Planner{Now: now, Policy: &risingwavev1alpha1.RisingWaveRemediationPolicy{}}
+	for i := range MaxInterventionsKept + 5 {
+		planner.History = planner.AppendHistory(risingwavev1alpha1.RisingWaveRemediationIntervention{
+			Time: metav1.NewTime(now.Add(-2 * DefaultBudgetWindow)),
+			Pod:  fmt.Sprintf("pod-%d", i),
+		})
+	}
+
+	assert.Len(t, planner.History, MaxInterventionsKept)
+	assert.Equal(t, "pod-5", planner.History[0].Pod)
+	assert.Equal(t, fmt.Sprintf("pod-%d", MaxInterventionsKept+4), planner.History[len(planner.History)-1].Pod)
+}
+
+func TestAppendHistory_WithinBudgetWindow(t *testing.T) {
+	now := time.Now()
+	planner := &Planner{
+		Now:    now,
+		Policy: &risingwavev1alpha1.RisingWaveRemediationPolicy{MaxInterventions: ptr.To[int32](MaxInterventionsKept + 10)},
+	}
+	for i := range 5 {
+		planner.History = planner.AppendHistory(risingwavev1alpha1.RisingWaveRemediationIntervention{
+			Time: metav1.NewTime(now.Add(-2 * DefaultBudgetWindow)),
+			Pod:  fmt.Sprintf("old-pod-%d", i),
+		})
+	}
+	for i := range MaxInterventionsKept + 5 {
+		planner.History = planner.AppendHistory(risingwavev1alpha1.RisingWaveRemediationIntervention{
+			Time: metav1.NewTime(now.Add(-time.Minute)),
+			Pod:  fmt.Sprintf("pod-%d", i),
+		})
+	}
+
+	// The records within the budget window are all kept, and the older ones are pruned.
+	assert.Len(t, planner.History, MaxInterventionsKept+5)
+	assert.Equal(t, "pod-0", planner.History[0].Pod)
+	assert.Equal(t, 5, planner.RemainingBudget())
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+)
+
+// ErrBucketUnsupported is returned when the state store can't be accessed as an S3 compatible bucket with the
+// credentials in a Secret.
+var ErrBucketUnsupported = errors.New("state store is not an S3 compatible bucket with credentials in a secret")
+
+// Bucket is an S3 compatible bucket of the state store.
+type Bucket struct {
+	Endpoint        string
+	Secure          bool
+	Region          string
+	Lookup          minio.BucketLookupType
+	AccessKeyID     string
+	SecretAccessKey string
+	Name            string
+}
+
+// NewClient creates a client of the bucket with the transport.
+func (b *Bucket) NewClient(transport http.RoundTripper) (*minio.Client, error) {
+	c, err := minio.New(b.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(b.AccessKeyID, b.SecretAccessKey, ""),
+		Secure:       b.Secure,
+		Region:       b.Region,
+		BucketLookup: b.Lookup,
+		Transport:    transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", b.Endpoint, err)
+	}
+
+	return c, nil
+}
+
+// EndpointURL returns the URL of the endpoint of the bucket.
+func (b *Bucket) EndpointURL() string {
+	if b.Secure {
+		return "https://" + b.Endpoint
+	}
+
+	return "http://" + b.Endpoint
+}
+
+// AddressingStyle returns the addressing style of the bucket in the terms of the AWS CLI, i.e., path, virtual or auto.
+func (b *Bucket) AddressingStyle() string {
+	switch b.Lookup {
+	case minio.BucketLookupPath:
+		return "path"
+	case minio.BucketLookupDNS:
+		return "virtual"
+	default:
+		return "auto"
+	}
+}
+
+// IsBucketSupported tells if the state store could be accessed as an S3 compatible bucket by the operator.
+func IsBucketSupported(stateStore *risingwavev1alpha1.RisingWaveStateStoreBackend) bool {
+	switch {
+	case stateStore.S3 != nil:
+		return !ptr.Deref(stateStore.S3.UseServiceAccount, false)
+	case stateStore.MinIO != nil, stateStore.ManagedMinIO != nil, stateStore.AliyunOSS != nil, stateStore.HuaweiCloudOBS != nil:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveBucket resolves the bucket of the state store of the RisingWave and reads the credentials from the secret.
+// It returns ErrBucketUnsupported if the state store isn't supported.
+func ResolveBucket(ctx context.Context, reader client.Reader, risingwave *risingwavev1alpha1.RisingWave) (*Bucket, error) {
+	namespace, stateStore := risingwave.Namespace, &risingwave.Spec.StateStore
+	if !IsBucketSupported(stateStore) {
+		return nil, ErrBucketUnsupported
+	}
+
+	switch {
+	case stateStore.MinIO != nil, stateStore.ManagedMinIO != nil:
+		return resolveMinIOBucket(ctx, reader, namespace, object.NewRisingWaveReader(risingwave).GetMinIO())
+	case stateStore.S3 != nil:
+		return resolveS3Bucket(ctx, reader, namespace, stateStore.S3)
+	case stateStore.AliyunOSS != nil:
+		return resolveAliyunOSSBucket(ctx, reader, namespace, stateStore.AliyunOSS)
+	default:
+		return resolveHuaweiCloudOBSBucket(ctx, reader, namespace, stateStore.HuaweiCloudOBS)
+	}
+}
+
+func resolveMinIOBucket(ctx context.Context, reader client.Reader, namespace string, minIO *risingwavev1alpha1.RisingWaveStateStoreBackendMinIO) (*Bucket, error) {
+	creds := &minIO.RisingWaveMinIOCredentials
+	values, err := SecretValues(ctx, reader, namespace, creds.SecretName, creds.UsernameKeyRef, creds.PasswordKeyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, secure := TrimScheme(minIO.Endpoint)
+
+	return &Bucket{
+		Endpoint:        endpoint,
+		Secure:          secure,
+		Lookup:          minio.BucketLookupPath,
+		AccessKeyID:     values[0],
+		SecretAccessKey: values[1],
+		Name:            minIO.Bucket,
+	}, nil
+}
+
+// EnsureBucket creates the bucket if it doesn't exist.
+func EnsureBucket(ctx context.Context, c *minio.Client, name string) error {
+	exists, err := c.BucketExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("unable to check bucket %q: %w", name, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := c.MakeBucket(ctx, name, minio.MakeBucketOptions{}); err != nil {
+		return fmt.Errorf("unable to create bucket %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// S3Endpoint interprets the endpoint of S3 in the same way as the RisingWave does. It returns the endpoint without the
+// scheme, whether it's https, and the bucket lookup style.
+func S3Endpoint(s3 *risingwavev1alpha1.RisingWaveStateStoreBackendS3, region string) (string, bool, minio.BucketLookupType) {
+	lookup := minio.BucketLookupAuto
+	if s3.ForcePathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	if len(s3.Endpoint) == 0 {
+		if region == "" {
+			region = "us-east-1"
+		}
+
+		return fmt.Sprintf("s3.%s.amazonaws.com", region), true, lookup
+	}
+
+	endpoint := strings.ReplaceAll(strings.TrimSpace(s3.Endpoint), "${REGION}", region)
+	secure := !strings.HasPrefix(endpoint, "http://")
+	endpoint, _ = TrimScheme(endpoint)
+
+	// The bucket is resolved by the client.
+	if strings.Contains(endpoint, "${BUCKET}.") {
+		endpoint = strings.ReplaceAll(endpoint, "${BUCKET}.", "")
+		if !s3.ForcePathStyle {
+			lookup = minio.BucketLookupDNS
+		}
+	}
+
+	return endpoint, secure, lookup
+}
+
+func resolveS3Bucket(ctx context.Context, reader client.Reader, namespace string, s3 *risingwavev1alpha1.RisingWaveStateStoreBackendS3) (*Bucket, error) {
+	creds := &s3.RisingWaveS3Credentials
+	keys := []string{creds.AccessKeyRef, creds.SecretAccessKeyRef}
+	// The region of S3 compatible services could be stored in the secret.
+	if s3.Region == "" {
+		keys = append(keys, consts.SecretKeyAWSS3Region)
+	}
+
+	values, err := SecretValues(ctx, reader, namespace, creds.SecretName, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	region := s3.Region
+	if region == "" {
+		region = values[2]
+	}
+
+	endpoint, secure, lookup := S3Endpoint(s3, region)
+
+	return &Bucket{
+		Endpoint:        endpoint,
+		Secure:          secure,
+		Region:          region,
+		Lookup:          lookup,
+		AccessKeyID:     values[0],
+		SecretAccessKey: values[1],
+		Name:            s3.Bucket,
+	}, nil
+}
+
+func resolveAliyunOSSBucket(ctx context.Context, reader client.Reader, namespace string, oss *risingwavev1alpha1.RisingWaveStateStoreBackendAliyunOSS) (*Bucket, error) {
+	creds := &oss.RisingWaveAliyunOSSCredentials
+	values, err := SecretValues(ctx, reader, namespace, creds.SecretName, creds.AccessKeyIDRef, creds.AccessKeySecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("oss-%s.aliyuncs.com", oss.Region)
+	if oss.InternalEndpoint {
+		endpoint = fmt.Sprintf("oss-%s-internal.aliyuncs.com", oss.Region)
+	}
+
+	return &Bucket{
+		Endpoint:        endpoint,
+		Secure:          true,
+		Region:          oss.Region,
+		Lookup:          minio.BucketLookupDNS,
+		AccessKeyID:     values[0],
+		SecretAccessKey: values[1],
+		Name:            oss.Bucket,
+	}, nil
+}
+
+func resolveHuaweiCloudOBSBucket(ctx context.Context, reader client.Reader, namespace string, obs *risingwavev1alpha1.RisingWaveStateStoreBackendHuaweiCloudOBS) (*Bucket, error) {
+	creds := &obs.RisingWaveHuaweiCloudOBSCredentials
+	values, err := SecretValues(ctx, reader, namespace, creds.SecretName, creds.AccessKeyIDRef, creds.AccessKeySecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bucket{
+		Endpoint:        fmt.Sprintf("obs.%s.myhuaweicloud.com", obs.Region),
+		Secure:          true,
+		Region:          obs.Region,
+		Lookup:          minio.BucketLookupDNS,
+		AccessKeyID:     values[0],
+		SecretAccessKey: values[1],
+		Name:            obs.Bucket,
+	}, nil
+}
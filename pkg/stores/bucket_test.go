@@ -0,0 +1,130 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+func TestS3Endpoint(t *testing.T) {
+	testcases := map[string]struct {
+		s3       risingwavev1alpha1.RisingWaveStateStoreBackendS3
+		endpoint string
+		secure   bool
+		lookup   minio.BucketLookupType
+		url      string
+		style    string
+	}{
+		"aws": {
+			s3:       risingwavev1alpha1.RisingWaveStateStoreBackendS3{Region: "ap-southeast-1"},
+			endpoint: "s3.ap-southeast-1.amazonaws.com",
+			secure:   true,
+			lookup:   minio.BucketLookupAuto,
+			url:      "https://s3.ap-southeast-1.amazonaws.com",
+			style:    "auto",
+		},
+		"virtual-hosted": {
+			s3:       risingwavev1alpha1.RisingWaveStateStoreBackendS3{Region: "us-west-2", Endpoint: "${BUCKET}.s3.${REGION}.amazonaws.com"},
+			endpoint: "s3.us-west-2.amazonaws.com",
+			secure:   true,
+			lookup:   minio.BucketLookupDNS,
+			url:      "https://s3.us-west-2.amazonaws.com",
+			style:    "virtual",
+		},
+		"http-path-style": {
+			s3:       risingwavev1alpha1.RisingWaveStateStoreBackendS3{Region: "us-east-1", Endpoint: "http://storage:9000", ForcePathStyle: true},
+			endpoint: "storage:9000",
+			secure:   false,
+			lookup:   minio.BucketLookupPath,
+			url:      "http://storage:9000",
+			style:    "path",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			endpoint, secure, lookup := S3Endpoint(&tc.s3, tc.s3.Region)
+			if endpoint != tc.endpoint || secure != tc.secure || lookup != tc.lookup {
+				t.Fatalf("unexpected endpoint: %s, %v, %v", endpoint, secure, lookup)
+			}
+
+			bucket := &Bucket{Endpoint: endpoint, Secure: secure, Lookup: lookup}
+			if bucket.EndpointURL() != tc.url || bucket.AddressingStyle() != tc.style {
+				t.Fatalf("unexpected endpoint for the AWS CLI: %s, %s", bucket.EndpointURL(), bucket.AddressingStyle())
+			}
+		})
+	}
+}
+
+func TestEnsureBucket(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		buckets = map[string]bool{}
+		created int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		name := strings.Trim(r.URL.Path, "/")
+		switch r.Method {
+		case http.MethodHead:
+			if !buckets[name] {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			buckets[name] = true
+			created++
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+
+	b := &Bucket{
+		Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+		Region:          "us-east-1",
+		Lookup:          minio.BucketLookupPath,
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+		Name:            "hummock",
+	}
+	c, err := b.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second call is a no-op since the bucket exists.
+	for range 2 {
+		if err := EnsureBucket(context.Background(), c, b.Name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !buckets[b.Name] || created != 1 {
+		t.Fatalf("unexpected buckets %v, created %d times", buckets, created)
+	}
+}
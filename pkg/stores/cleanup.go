@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/minio/minio-go/v7"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+// postgreSQLMaintenanceDatabase is the database to connect to when dropping the meta database.
+const postgreSQLMaintenanceDatabase = "postgres"
+
+// DeleteObjects deletes at most limit objects under the directory in the bucket. It returns the number of deleted
+// objects and whether there might be more objects left.
+func DeleteObjects(ctx context.Context, c *minio.Client, bucket, directory string, limit int) (int, bool, error) {
+	prefix := ""
+	if directory != "" {
+		prefix = directory + "/"
+	}
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := make(chan minio.ObjectInfo, limit)
+	var listErr error
+	for obj := range c.ListObjects(listCtx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			listErr = obj.Err
+			break
+		}
+		objects <- obj
+		if len(objects) == limit {
+			break
+		}
+	}
+	close(objects)
+	if listErr != nil {
+		return 0, false, fmt.Errorf("unable to list objects under %q in bucket %q: %w", prefix, bucket, listErr)
+	}
+
+	listed, deleted := len(objects), 0
+	var removeErr error
+	// Drain the results to release the goroutine behind.
+	for result := range c.RemoveObjectsWithResult(ctx, bucket, objects, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil {
+			if removeErr == nil {
+				removeErr = fmt.Errorf("unable to delete object %q in bucket %q: %w", result.ObjectName, bucket, result.Err)
+			}
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, removeErr == nil && listed == limit, removeErr
+}
+
+// DropMySQLDatabase drops the database of the MySQL meta store if it exists.
+func DropMySQLDatabase(ctx context.Context, reader client.Reader, namespace string, mysqlSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL) error {
+	cfg, err := MySQLConfig(ctx, reader, namespace, mysqlSpec)
+	if err != nil {
+		return err
+	}
+	cfg.DBName = ""
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid MySQL configuration: %w", err)
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	stmt := fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", strings.ReplaceAll(mysqlSpec.Database, "`", "``"))
+	if _, err := db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("unable to drop database %q: %w", mysqlSpec.Database, err)
+	}
+
+	return nil
+}
+
+// DropPostgreSQLDatabase drops the database of the PostgreSQL meta store if it exists. It connects to the maintenance
+// database postgres because the database to drop can't be the current one.
+func DropPostgreSQLDatabase(ctx context.Context, reader client.Reader, namespace string, pgSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL) error {
+	cfg, err := PostgreSQLConfig(ctx, reader, namespace, pgSpec)
+	if err != nil {
+		return err
+	}
+	cfg.Database = postgreSQLMaintenanceDatabase
+
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("unable to connect to PostgreSQL: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	if _, err := conn.Exec(ctx, "DROP DATABASE IF EXISTS "+pgx.Identifier{pgSpec.Database}.Sanitize()); err != nil {
+		return fmt.Errorf("unable to drop database %q: %w", pgSpec.Database, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// fakeBucket is an in-memory S3 bucket which supports listing, copying and deleting objects only.
+type fakeBucket struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && query.Get("list-type") == "2":
+		maxKeys, _ := strconv.Atoi(query.Get("max-keys"))
+		if maxKeys == 0 {
+			maxKeys = 1000
+		}
+
+		type content struct {
+			Key string `xml:"Key"`
+		}
+		result := struct {
+			XMLName     xml.Name  `xml:"ListBucketResult"`
+			Name        string    `xml:"Name"`
+			Prefix      string    `xml:"Prefix"`
+			KeyCount    int       `xml:"KeyCount"`
+			MaxKeys     int       `xml:"MaxKeys"`
+			IsTruncated bool      `xml:"IsTruncated"`
+			Contents    []content `xml:"Contents"`
+		}{Name: "bucket", Prefix: query.Get("prefix"), MaxKeys: maxKeys}
+		for _, key := range b.keys {
+			if strings.HasPrefix(key, query.Get("prefix")) && key > query.Get("start-after") {
+				result.Contents = append(result.Contents, content{Key: key})
+			}
+		}
+		if len(result.Contents) > maxKeys {
+			result.Contents, result.IsTruncated = result.Contents[:maxKeys], true
+		}
+		result.KeyCount = len(result.Contents)
+
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPost && query.Has("delete"):
+		var req struct {
+			Objects []struct {
+				Key string `xml:"Key"`
+			} `xml:"Object"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		type deleted struct {
+			Key string `xml:"Key"`
+		}
+		result := struct {
+			XMLName xml.Name  `xml:"DeleteResult"`
+			Deleted []deleted `xml:"Deleted"`
+		}{}
+		for _, obj := range req.Objects {
+			b.keys = slices.DeleteFunc(b.keys, func(key string) bool { return key == obj.Key })
+			result.Deleted = append(result.Deleted, deleted{Key: obj.Key})
+		}
+
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		source, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		if !slices.Contains(b.keys, strings.TrimPrefix(strings.TrimPrefix(source, "/"), "bucket/")) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+
+		target := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		if !slices.Contains(b.keys, target) {
+			b.keys = append(b.keys, target)
+			slices.Sort(b.keys)
+		}
+
+		_, _ = w.Write([]byte(`<CopyObjectResult><ETag>"etag"</ETag><LastModified>2024-01-01T00:00:00.000Z</LastModified></CopyObjectResult>`))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestDeleteObjects(t *testing.T) {
+	bucket := &fakeBucket{keys: []string{
+		"hummock/a", "hummock/b", "hummock/c/d", "hummock-other/e", "other/hummock/f",
+	}}
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	b := &Bucket{
+		Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+		Region:          "us-east-1",
+		Lookup:          minio.BucketLookupPath,
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+		Name:            "bucket",
+	}
+	c, err := b.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, more, err := DeleteObjects(context.Background(), c, b.Name, "hummock", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 || !more {
+		t.Fatalf("unexpected result of the first batch: deleted %d, more %t", deleted, more)
+	}
+
+	deleted, more, err = DeleteObjects(context.Background(), c, b.Name, "hummock", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 || more {
+		t.Fatalf("unexpected result of the second batch: deleted %d, more %t", deleted, more)
+	}
+
+	if want := []string{"hummock-other/e", "other/hummock/f"}; !slices.Equal(bucket.keys, want) {
+		t.Fatalf("unexpected objects left: %v, want %v", bucket.keys, want)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"path"
+	"strings"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
+)
+
+// defaultDataDirectory is the default value of the data directory in the state store.
+const defaultDataDirectory = "hummock"
+
+// DataLocation is the location of the data of a RisingWave in the state store.
+type DataLocation struct {
+	// Store identifies the storage, e.g., the bucket and the endpoint of it.
+	Store string
+
+	// Directory of the data in the storage, without the leading and trailing slashes.
+	Directory string
+}
+
+// Overlaps tells if the two locations share any object.
+func (l DataLocation) Overlaps(other DataLocation) bool {
+	if l.Store != other.Store {
+		return false
+	}
+
+	isUnder := func(dir, parent string) bool {
+		return parent == "" || dir == parent || strings.HasPrefix(dir, parent+"/")
+	}
+
+	return isUnder(l.Directory, other.Directory) || isUnder(other.Directory, l.Directory)
+}
+
+// DataLocationOf returns the location of the data of the RisingWave. The root path recorded in the status is
+// preferred. It returns false if the data isn't persisted in a shared storage, e.g., in memory or on the local disk.
+func DataLocationOf(risingwave *risingwavev1alpha1.RisingWave) (DataLocation, bool) {
+	stateStore := &risingwave.Spec.StateStore
+
+	var store string
+	switch {
+	case stateStore.S3 != nil:
+		endpoint, _, _ := S3Endpoint(stateStore.S3, stateStore.S3.Region)
+		store = "s3://" + path.Join(endpoint, stateStore.S3.Bucket)
+	case stateStore.MinIO != nil, stateStore.ManagedMinIO != nil:
+		minIO := object.NewRisingWaveReader(risingwave).GetMinIO()
+		endpoint, _ := TrimScheme(minIO.Endpoint)
+		store = "minio://" + path.Join(endpoint, minIO.Bucket)
+	case stateStore.GCS != nil:
+		store = "gcs://" + stateStore.GCS.Bucket
+	case stateStore.AliyunOSS != nil:
+		store = "oss://" + path.Join(stateStore.AliyunOSS.Region, stateStore.AliyunOSS.Bucket)
+	case stateStore.AzureBlob != nil:
+		endpoint, _ := TrimScheme(stateStore.AzureBlob.Endpoint)
+		store = "azblob://" + path.Join(endpoint, stateStore.AzureBlob.Container)
+	case stateStore.HDFS != nil:
+		store = "hdfs://" + strings.TrimPrefix(stateStore.HDFS.NameNode, "hdfs://")
+	case stateStore.WebHDFS != nil:
+		store = "webhdfs://" + strings.TrimPrefix(stateStore.WebHDFS.NameNode, "webhdfs://")
+	case stateStore.HuaweiCloudOBS != nil:
+		store = "obs://" + path.Join(stateStore.HuaweiCloudOBS.Region, stateStore.HuaweiCloudOBS.Bucket)
+	default:
+		return DataLocation{}, false
+	}
+
+	rootPath := risingwave.Status.Internal.StateStoreRootPath
+	if rootPath == "" {
+		rootPath = object.NewRisingWaveReader(risingwave).StateStoreRootPath()
+	}
+
+	dataDirectory := stateStore.DataDirectory
+	if dataDirectory == "" {
+		dataDirectory = defaultDataDirectory
+	}
+
+	return DataLocation{
+		Store:     store,
+		Directory: strings.Trim(path.Join(rootPath, dataDirectory), "/"),
+	}, true
+}
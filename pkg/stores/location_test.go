@@ -0,0 +1,107 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+func TestDataLocationOf(t *testing.T) {
+	testcases := map[string]struct {
+		stateStore risingwavev1alpha1.RisingWaveStateStoreBackend
+		rootPath   string
+		location   DataLocation
+		persistent bool
+	}{
+		"memory": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{Memory: ptr.To(true)},
+		},
+		"s3": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{
+				DataDirectory: "a/b",
+				S3:            &risingwavev1alpha1.RisingWaveStateStoreBackendS3{Bucket: "bucket", Region: "us-east-1"},
+			},
+			location:   DataLocation{Store: "s3://s3.us-east-1.amazonaws.com/bucket", Directory: "a/b"},
+			persistent: true,
+		},
+		"gcs-with-root-path-in-status": {
+			stateStore: risingwavev1alpha1.RisingWaveStateStoreBackend{
+				GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{Bucket: "bucket", Root: "new-root"},
+			},
+			rootPath:   "root",
+			location:   DataLocation{Store: "gcs://bucket", Directory: "root/hummock"},
+			persistent: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := &risingwavev1alpha1.RisingWave{
+				Spec: risingwavev1alpha1.RisingWaveSpec{StateStore: tc.stateStore},
+			}
+			risingwave.Status.Internal.StateStoreRootPath = tc.rootPath
+
+			location, persistent := DataLocationOf(risingwave)
+			if location != tc.location || persistent != tc.persistent {
+				t.Fatalf("unexpected location: %v, %v", location, persistent)
+			}
+		})
+	}
+}
+
+func TestDataLocation_Overlaps(t *testing.T) {
+	testcases := map[string]struct {
+		a, b     DataLocation
+		overlaps bool
+	}{
+		"same": {
+			a:        DataLocation{Store: "s3://bucket", Directory: "hummock"},
+			b:        DataLocation{Store: "s3://bucket", Directory: "hummock"},
+			overlaps: true,
+		},
+		"different-stores": {
+			a: DataLocation{Store: "s3://bucket", Directory: "hummock"},
+			b: DataLocation{Store: "s3://another", Directory: "hummock"},
+		},
+		"nested": {
+			a:        DataLocation{Store: "s3://bucket", Directory: "hummock"},
+			b:        DataLocation{Store: "s3://bucket", Directory: "hummock/a"},
+			overlaps: true,
+		},
+		"root": {
+			a:        DataLocation{Store: "s3://bucket", Directory: ""},
+			b:        DataLocation{Store: "s3://bucket", Directory: "hummock"},
+			overlaps: true,
+		},
+		"sibling-with-common-prefix": {
+			a: DataLocation{Store: "s3://bucket", Directory: "hummock"},
+			b: DataLocation{Store: "s3://bucket", Directory: "hummock2"},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			if tc.a.Overlaps(tc.b) != tc.overlaps || tc.b.Overlaps(tc.a) != tc.overlaps {
+				t.Fatalf("unexpected result, expected %v", tc.overlaps)
+			}
+		})
+	}
+}
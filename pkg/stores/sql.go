@@ -0,0 +1,108 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+// MySQLAddr returns the address of the MySQL meta store.
+func MySQLAddr(mysqlSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL) string {
+	return net.JoinHostPort(mysqlSpec.Host, strconv.FormatUint(uint64(mysqlSpec.Port), 10))
+}
+
+// MySQLConfig returns the config to connect to the MySQL meta store with the credentials in the secret.
+func MySQLConfig(ctx context.Context, reader client.Reader, namespace string, mysqlSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL) (*mysql.Config, error) {
+	creds := &mysqlSpec.RisingWaveDBCredentials
+	values, err := SecretValues(ctx, reader, namespace, creds.SecretName, creds.UsernameKeyRef, creds.PasswordKeyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User, cfg.Passwd = values[0], values[1]
+	cfg.Net, cfg.Addr = "tcp", MySQLAddr(mysqlSpec)
+	cfg.DBName = mysqlSpec.Database
+
+	tlsConfig, err := TLSConfig(ctx, reader, namespace, mysqlSpec.TLS, mysqlSpec.Host)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		cfg.TLS = tlsConfig
+		cfg.AllowFallbackToPlaintext = mysqlSpec.TLS.SSLMode == risingwavev1alpha1.RisingWaveDBSSLModePrefer
+	}
+
+	return cfg, nil
+}
+
+// PostgreSQLAddr returns the address of the PostgreSQL meta store.
+func PostgreSQLAddr(pgSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL) string {
+	return net.JoinHostPort(pgSpec.Host, strconv.FormatUint(uint64(pgSpec.Port), 10))
+}
+
+// PostgreSQLConfig returns the config to connect to the PostgreSQL meta store with the credentials in the secret.
+func PostgreSQLConfig(ctx context.Context, reader client.Reader, namespace string, pgSpec *risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL) (*pgx.ConnConfig, error) {
+	creds := &pgSpec.RisingWaveDBCredentials
+	values, err := SecretValues(ctx, reader, namespace, creds.SecretName, creds.UsernameKeyRef, creds.PasswordKeyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	options := url.Values{}
+	for k, v := range pgSpec.Options {
+		options.Set(k, v)
+	}
+
+	connString := (&url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(values[0], values[1]),
+		Host:     PostgreSQLAddr(pgSpec),
+		Path:     "/" + pgSpec.Database,
+		RawQuery: options.Encode(),
+	}).String()
+
+	cfg, err := pgx.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PostgreSQL options: %w", err)
+	}
+
+	if pgSpec.TLS != nil {
+		tlsConfig, err := TLSConfig(ctx, reader, namespace, pgSpec.TLS, pgSpec.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.TLSConfig, cfg.Fallbacks = tlsConfig, nil
+		if pgSpec.TLS.SSLMode == risingwavev1alpha1.RisingWaveDBSSLModePrefer {
+			cfg.Fallbacks = []*pgconn.FallbackConfig{{Host: cfg.Host, Port: cfg.Port}}
+		}
+	}
+
+	return cfg, nil
+}
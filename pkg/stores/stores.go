@@ -0,0 +1,62 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stores helps the operator access the meta store and the state store of RisingWave with the same
+// configurations and credentials as the RisingWave components.
+package stores
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SecretValues reads the values of the keys from the secret.
+func SecretValues(ctx context.Context, reader client.Reader, namespace, secretName string, keys ...string) ([]string, error) {
+	var secret corev1.Secret
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("secret %q not found", secretName)
+		}
+
+		return nil, fmt.Errorf("unable to get secret %q: %w", secretName, err)
+	}
+
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		v, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in secret %q", key, secretName)
+		}
+
+		values = append(values, string(v))
+	}
+
+	return values, nil
+}
+
+// TrimScheme removes the http or https scheme from the address and tells if it's https.
+func TrimScheme(addr string) (string, bool) {
+	if s, ok := strings.CutPrefix(addr, "https://"); ok {
+		return s, true
+	}
+
+	return strings.TrimPrefix(addr, "http://"), false
+}
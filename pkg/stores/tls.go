@@ -0,0 +1,151 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+// caBundle reads the CA bundle from the secret or the config map.
+func caBundle(ctx context.Context, reader client.Reader, namespace string, ref *risingwavev1alpha1.RisingWaveDBCABundle) ([]byte, error) {
+	key := ref.Key
+	if key == "" {
+		key = corev1.ServiceAccountRootCAKey
+	}
+
+	if ref.SecretName != "" {
+		values, err := SecretValues(ctx, reader, namespace, ref.SecretName, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(values[0]), nil
+	}
+
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.ConfigMapName}, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("config map %q not found", ref.ConfigMapName)
+		}
+
+		return nil, fmt.Errorf("unable to get config map %q: %w", ref.ConfigMapName, err)
+	}
+
+	v, ok := configMap.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in config map %q", key, ref.ConfigMapName)
+	}
+
+	return []byte(v), nil
+}
+
+// TLSConfig returns the TLS config to connect to the DB at the host in the same way as the RisingWave does, with the
+// CA bundle and the client certificate read from the secrets or config maps. It returns nil if TLS is disabled.
+func TLSConfig(ctx context.Context, reader client.Reader, namespace string, tlsSpec *risingwavev1alpha1.RisingWaveDBTLS, host string) (*tls.Config, error) {
+	if tlsSpec == nil || tlsSpec.SSLMode == risingwavev1alpha1.RisingWaveDBSSLModeDisable {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if tlsSpec.CABundle != nil {
+		pem, err := caBundle(ctx, reader, namespace, tlsSpec.CABundle)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no valid certificate found in the CA bundle")
+		}
+	}
+
+	if clientCert := tlsSpec.ClientCertificate; clientCert != nil {
+		certKey, keyKey := clientCert.CertificateKeyRef, clientCert.PrivateKeyKeyRef
+		if certKey == "" {
+			certKey = corev1.TLSCertKey
+		}
+		if keyKey == "" {
+			keyKey = corev1.TLSPrivateKeyKey
+		}
+
+		values, err := SecretValues(ctx, reader, namespace, clientCert.SecretName, certKey, keyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := tls.X509KeyPair([]byte(values[0]), []byte(values[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in secret %q: %w", clientCert.SecretName, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	switch tlsSpec.SSLMode {
+	case risingwavev1alpha1.RisingWaveDBSSLModePrefer, risingwavev1alpha1.RisingWaveDBSSLModeRequire:
+		cfg.InsecureSkipVerify = true
+	case risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA:
+		// Verify the certificate chain without the host name.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyCertificateChain(rawCerts, cfg.RootCAs)
+		}
+	case risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull:
+		cfg.ServerName = host
+	default:
+		return nil, fmt.Errorf("invalid ssl mode %q", tlsSpec.SSLMode)
+	}
+
+	return cfg, nil
+}
+
+func verifyCertificateChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no certificate from the server")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("unable to parse the certificate from the server: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(opts)
+
+	return err
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2023 RisingWave Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stores
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
+)
+
+func TestTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	reader := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ca"},
+			Data:       map[string]string{"ca.crt": string(caPEM)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ca"},
+			Data:       map[string][]byte{"root.pem": caPEM},
+		},
+	).Build()
+
+	testcases := map[string]struct {
+		tls       *risingwavev1alpha1.RisingWaveDBTLS
+		host      string
+		noTLS     bool
+		connected bool
+	}{
+		"nil": {
+			noTLS: true,
+		},
+		"disable": {
+			tls:   &risingwavev1alpha1.RisingWaveDBTLS{SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeDisable},
+			noTLS: true,
+		},
+		"require": {
+			tls:       &risingwavev1alpha1.RisingWaveDBTLS{SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeRequire},
+			host:      "unknown",
+			connected: true,
+		},
+		"verify-ca-with-another-host": {
+			tls: &risingwavev1alpha1.RisingWaveDBTLS{
+				SSLMode:  risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA,
+				CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{SecretName: "ca", Key: "root.pem"},
+			},
+			host:      "unknown",
+			connected: true,
+		},
+		"verify-full": {
+			tls: &risingwavev1alpha1.RisingWaveDBTLS{
+				SSLMode:  risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull,
+				CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{ConfigMapName: "ca"},
+			},
+			host:      "example.com",
+			connected: true,
+		},
+		"verify-full-with-another-host": {
+			tls: &risingwavev1alpha1.RisingWaveDBTLS{
+				SSLMode:  risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull,
+				CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{ConfigMapName: "ca"},
+			},
+			host:      "unknown",
+			connected: false,
+		},
+		"verify-ca-without-ca-bundle": {
+			tls:       &risingwavev1alpha1.RisingWaveDBTLS{SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA},
+			host:      "unknown",
+			connected: false,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := TLSConfig(context.Background(), reader, "default", tc.tls, tc.host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (cfg == nil) != tc.noTLS {
+				t.Fatalf("unexpected TLS config: %v", cfg)
+			}
+			if cfg == nil {
+				return
+			}
+
+			conn, err := tls.Dial("tcp", server.Listener.Addr().String(), cfg)
+			if err == nil {
+				_ = conn.Close()
+			}
+			if (err == nil) != tc.connected {
+				t.Fatalf("unexpected handshake result: %v", err)
+			}
+		})
+	}
+}
+
+func TestTLSConfig_NotFound(t *testing.T) {
+	reader := fake.NewClientBuilder().Build()
+
+	_, err := TLSConfig(context.Background(), reader, "default", &risingwavev1alpha1.RisingWaveDBTLS{
+		SSLMode:  risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull,
+		CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{ConfigMapName: "ca"},
+	}, "example.com")
+	if err == nil {
+		t.Fatal("expected an error for the missing config map")
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/uuid"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 )
@@ -44,6 +45,7 @@ func init() {
 	_ = risingwavev1alpha1.AddToScheme(Scheme)
 	_ = apiextensionsv1.AddToScheme(Scheme)
 	_ = prometheusv1.AddToScheme(Scheme)
+	_ = gatewayv1.AddToScheme(Scheme)
 	_ = kruiseappsv1alpha1.AddToScheme(Scheme)
 	_ = kruiseappsv1beta1.AddToScheme(Scheme)
 }
@@ -69,6 +71,58 @@ func FakeRisingWave() *risingwavev1alpha1.RisingWave {
 	return fakeRisingWave.DeepCopy()
 }
 
+// FakeRisingWaveStandaloneHA returns a new fake RisingWave in the standalone mode with three replicas on a PostgreSQL
+// meta store and a MinIO state store.
+func FakeRisingWaveStandaloneHA() *risingwavev1alpha1.RisingWave {
+	risingwaveCopy := fakeRisingWave.DeepCopy()
+	risingwaveCopy.Spec.EnableStandaloneMode = ptr.To(true)
+	risingwaveCopy.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+		PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+			Host:     "postgresql",
+			Port:     5432,
+			Database: "risingwave",
+			RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+				SecretName: "postgresql-creds",
+			},
+		},
+	}
+	risingwaveCopy.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+		MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+			Endpoint: "minio",
+			Bucket:   "hummock",
+			RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+				SecretName: "minio-creds",
+			},
+		},
+	}
+	risingwaveCopy.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{
+		Replicas: 3,
+	}
+
+	return risingwaveCopy
+}
+
+// FakeRisingWaveMigratingFromStandalone returns a new fake RisingWave migrating from the standalone mode, with the
+// SQLite DB file on the PVC of the standalone Pod.
+func FakeRisingWaveMigratingFromStandalone() *risingwavev1alpha1.RisingWave {
+	risingwaveCopy := fakeRisingWave.DeepCopy()
+	risingwaveCopy.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+		SQLite: &risingwavev1alpha1.RisingWaveMetaStoreBackendSQLite{Path: "/risingwave/data/meta.db"},
+	}
+	risingwaveCopy.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{
+		Replicas: 1,
+		VolumeClaimTemplates: []risingwavev1alpha1.PersistentVolumeClaim{
+			{PersistentVolumeClaimPartialObjectMeta: risingwavev1alpha1.PersistentVolumeClaimPartialObjectMeta{Name: "data"}},
+		},
+	}
+	risingwaveCopy.Spec.Components.Standalone.Template.Spec.VolumeMounts = []corev1.VolumeMount{
+		{Name: "data", MountPath: "/risingwave/data"},
+	}
+	risingwaveCopy.Spec.StandaloneMigration = &risingwavev1alpha1.RisingWaveStandaloneMigration{}
+
+	return risingwaveCopy
+}
+
 var fakeRisingWave = &risingwavev1alpha1.RisingWave{
 	TypeMeta: metav1.TypeMeta{
 		Kind:       "RisingWave",
@@ -0,0 +1,170 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// tracingClient is a client that starts a span for each call, as a child of the span in the context.
+type tracingClient struct {
+	client.Client
+}
+
+func (c *tracingClient) kindOf(obj runtime.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return ""
+	}
+	return gvk.Kind
+}
+
+func (c *tracingClient) trace(ctx context.Context, verb string, obj runtime.Object, name string, f func(ctx context.Context) error) error {
+	return c.traceSubResource(ctx, verb, obj, name, "", f)
+}
+
+func (c *tracingClient) traceSubResource(ctx context.Context, verb string, obj runtime.Object, name, subResource string, f func(ctx context.Context) error) error {
+	// Skip when the context isn't traced, e.g., the calls from the webhooks.
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return f(ctx)
+	}
+
+	attributes := []attribute.KeyValue{
+		AttributeKind.String(c.kindOf(obj)),
+		AttributeObjectName.String(name),
+	}
+	if subResource != "" {
+		verb += "/" + subResource
+		attributes = append(attributes, AttributeSubResource.String(subResource))
+	}
+
+	ctx, span := Tracer().Start(ctx, "k8s."+verb, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	defer span.End()
+
+	err := f(ctx)
+	// Not found is expected for the states, don't mark it as an error.
+	if err != nil && !apierrors.IsNotFound(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// Get implements the client.Client.
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.trace(ctx, "Get", obj, key.Name, func(ctx context.Context) error {
+		return c.Client.Get(ctx, key, obj, opts...)
+	})
+}
+
+// List implements the client.Client.
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.trace(ctx, "List", list, "", func(ctx context.Context) error {
+		return c.Client.List(ctx, list, opts...)
+	})
+}
+
+// Create implements the client.Client.
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.trace(ctx, "Create", obj, obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Create(ctx, obj, opts...)
+	})
+}
+
+// Delete implements the client.Client.
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.trace(ctx, "Delete", obj, obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
+}
+
+// Update implements the client.Client.
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.trace(ctx, "Update", obj, obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Update(ctx, obj, opts...)
+	})
+}
+
+// Patch implements the client.Client.
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.trace(ctx, "Patch", obj, obj.GetName(), func(ctx context.Context) error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+// Status implements the client.Client.
+func (c *tracingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+// SubResource implements the client.Client.
+func (c *tracingClient) SubResource(subResource string) client.SubResourceClient {
+	return &tracingSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		client:            c,
+		subResource:       subResource,
+	}
+}
+
+// tracingSubResourceClient is a client of the subresource that starts a span for each call, as a child of the span
+// in the context.
+type tracingSubResourceClient struct {
+	client.SubResourceClient
+
+	client      *tracingClient
+	subResource string
+}
+
+// Get implements the client.SubResourceClient.
+func (c *tracingSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.client.traceSubResource(ctx, "Get", obj, obj.GetName(), c.subResource, func(ctx context.Context) error {
+		return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+	})
+}
+
+// Create implements the client.SubResourceClient.
+func (c *tracingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return c.client.traceSubResource(ctx, "Create", obj, obj.GetName(), c.subResource, func(ctx context.Context) error {
+		return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+	})
+}
+
+// Update implements the client.SubResourceClient.
+func (c *tracingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.client.traceSubResource(ctx, "Update", obj, obj.GetName(), c.subResource, func(ctx context.Context) error {
+		return c.SubResourceClient.Update(ctx, obj, opts...)
+	})
+}
+
+// Patch implements the client.SubResourceClient.
+func (c *tracingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.client.traceSubResource(ctx, "Patch", obj, obj.GetName(), c.subResource, func(ctx context.Context) error {
+		return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+// NewClient wraps the client to trace the calls to the Kubernetes API.
+func NewClient(c client.Client) client.Client {
+	return &tracingClient{Client: c}
+}
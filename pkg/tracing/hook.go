@@ -0,0 +1,55 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// actionSpanKey is the key of the span of the action in the context.
+type actionSpanKey struct{}
+
+// ActionHook is a context action hook that starts a span for each action, as a child of the span in the context of
+// the action, e.g., the span of the reconciliation. The span is carried by the context of the action, so that the
+// calls made by the action are traced as its children.
+type ActionHook struct{}
+
+// NewActionHook creates a new ActionHook.
+func NewActionHook() *ActionHook {
+	return &ActionHook{}
+}
+
+// PreRun starts the span of the action and returns the context carrying it.
+func (h *ActionHook) PreRun(ctx context.Context, logger logr.Logger, action string) context.Context {
+	// Skip when the context isn't traced, e.g., not sampled.
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx
+	}
+
+	ctx, span := Tracer().Start(ctx, action, trace.WithAttributes(AttributeAction.String(action)))
+
+	return context.WithValue(ctx, actionSpanKey{}, span)
+}
+
+// PostRun ends the span of the action.
+func (h *ActionHook) PostRun(ctx context.Context, logger logr.Logger, action string, result ctrl.Result, err error) {
+	if span, ok := ctx.Value(actionSpanKey{}).(trace.Span); ok {
+		EndSpan(span, result, err)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing provides the OpenTelemetry tracing of the reconciliations. The spans are dropped unless an exporter
+// is configured with Setup.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/risingwavelabs/ctrlkit"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+)
+
+const (
+	instrumentationName = "github.com/risingwavelabs/risingwave-operator"
+	serviceName         = "risingwave-operator"
+)
+
+// Supported exporters.
+const (
+	ExporterNone     = "none"
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+)
+
+// Attribute keys of the spans.
+const (
+	AttributeNamespace    = attribute.Key("k8s.namespace.name")
+	AttributeName         = attribute.Key("risingwave.name")
+	AttributeController   = attribute.Key("risingwave.controller")
+	AttributeAction       = attribute.Key("risingwave.action")
+	AttributeResult       = attribute.Key("risingwave.result")
+	AttributeRequeue      = attribute.Key("risingwave.requeue")
+	AttributeRequeueAfter = attribute.Key("risingwave.requeue_after")
+	AttributeKind         = attribute.Key("k8s.kind")
+	AttributeObjectName   = attribute.Key("k8s.object.name")
+	AttributeSubResource  = attribute.Key("k8s.subresource")
+)
+
+// Options of the tracing.
+type Options struct {
+	// Exporter of the spans, one of none, otlp-grpc and otlp-http.
+	Exporter string
+
+	// Endpoint of the OTLP collector, e.g., otel-collector.monitoring:4317. The OTEL_EXPORTER_OTLP_ENDPOINT
+	// environment variable is respected when it's empty.
+	Endpoint string
+
+	// Insecure disables the TLS to connect to the collector.
+	Insecure bool
+
+	// SampleRatio is the ratio of the reconciliations to trace.
+	SampleRatio float64
+}
+
+// BindFlags binds the options to the flags.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Exporter, "tracing-exporter", ExporterNone, "The exporter of the traces of the reconciliations, one of none, otlp-grpc and otlp-http.")
+	fs.StringVar(&o.Endpoint, "tracing-endpoint", "", "The endpoint of the OTLP collector. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, or the default of the exporter.")
+	fs.BoolVar(&o.Insecure, "tracing-insecure", false, "Connect to the OTLP collector without TLS.")
+	fs.Float64Var(&o.SampleRatio, "tracing-sample-ratio", 1.0, "The ratio of the reconciliations to trace.")
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case ExporterOTLPGRPC:
+		var grpcOpts []otlptracegrpc.Option
+		if opts.Endpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+		}
+		if opts.Insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, grpcOpts...)
+	case ExporterOTLPHTTP:
+		var httpOpts []otlptracehttp.Option
+		if opts.Endpoint != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpoint(opts.Endpoint))
+		}
+		if opts.Insecure {
+			httpOpts = append(httpOpts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, httpOpts...)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q", opts.Exporter)
+	}
+}
+
+// Setup sets up the global tracer provider with the options. It returns a function to flush and stop the exporter,
+// which must be called before exiting. Nothing is set up with the none exporter.
+func Setup(ctx context.Context, opts Options, version string) (func(context.Context) error, error) {
+	if opts.Exporter == "" || opts.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, errors.New("tracing sample ratio must be between 0 and 1")
+	}
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the operator from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartReconcileSpan starts the span of a reconciliation of the object.
+func StartReconcileSpan(ctx context.Context, controller string, target types.NamespacedName) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, controller+".Reconcile", trace.WithAttributes(
+		AttributeController.String(controller),
+		AttributeNamespace.String(target.Namespace),
+		AttributeName.String(target.Name),
+	))
+}
+
+// EndSpan records the result and the error in the span and ends it. Exiting the workflow isn't an error.
+func EndSpan(span trace.Span, result ctrl.Result, err error) {
+	span.SetAttributes(
+		AttributeResult.String(metrics.ActionResult(result, err)),
+		AttributeRequeue.Bool(result.Requeue), //nolint:staticcheck
+		AttributeRequeueAfter.String(result.RequeueAfter.String()),
+	)
+	if err != nil && !errors.Is(err, ctrlkit.ErrExit) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 RisingWave Labs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/risingwavelabs/ctrlkit"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
+)
+
+func setupTestTracerProvider(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestReconcileTracing(t *testing.T) {
+	recorder := setupTestTracerProvider(t)
+
+	ctx, reconcileSpan := StartReconcileSpan(context.Background(), "RisingWaveController", types.NamespacedName{Namespace: "default", Name: "rw"})
+
+	hook := NewActionHook()
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rw-config"}}
+	c := NewClient(fake.NewClientBuilder().WithScheme(testutils.Scheme).WithObjects(configMap).Build())
+
+	actionCtx := hook.PreRun(ctx, logr.Discard(), "SyncConfigConfigMap")
+	err := c.Get(actionCtx, types.NamespacedName{Namespace: "default", Name: "rw-absent"}, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = c.Status().Update(actionCtx, configMap)
+	assert.Error(t, err, "config maps don't have the status")
+	hook.PostRun(actionCtx, logr.Discard(), "SyncConfigConfigMap", ctrl.Result{}, nil)
+
+	actionCtx = hook.PreRun(ctx, logr.Discard(), "BarrierFirstTimeObserved")
+	hook.PostRun(actionCtx, logr.Discard(), "BarrierFirstTimeObserved", ctrl.Result{}, ctrlkit.ErrExit)
+
+	EndSpan(reconcileSpan, ctrl.Result{}, errors.New("unable to sync"))
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 5) {
+		return
+	}
+
+	getSpan, updateStatusSpan, syncSpan, barrierSpan, rootSpan := spans[0], spans[1], spans[2], spans[3], spans[4]
+	assert.Equal(t, "RisingWaveController.Reconcile", rootSpan.Name())
+	assert.Equal(t, "rw", spanAttribute(rootSpan, AttributeName).AsString())
+	assert.Equal(t, codes.Error, rootSpan.Status().Code)
+
+	assert.Equal(t, "k8s.Get", getSpan.Name())
+	assert.Equal(t, "ConfigMap", spanAttribute(getSpan, AttributeKind).AsString())
+	assert.Equal(t, codes.Unset, getSpan.Status().Code, "not found isn't an error")
+
+	assert.Equal(t, "k8s.Update/status", updateStatusSpan.Name())
+	assert.Equal(t, "ConfigMap", spanAttribute(updateStatusSpan, AttributeKind).AsString())
+	assert.Equal(t, "rw-config", spanAttribute(updateStatusSpan, AttributeObjectName).AsString())
+	assert.Equal(t, "status", spanAttribute(updateStatusSpan, AttributeSubResource).AsString())
+
+	assert.Equal(t, "SyncConfigConfigMap", syncSpan.Name())
+	assert.Equal(t, "continue", spanAttribute(syncSpan, AttributeResult).AsString())
+	assert.Equal(t, "exit", spanAttribute(barrierSpan, AttributeResult).AsString())
+	assert.Equal(t, codes.Unset, barrierSpan.Status().Code, "exit isn't an error")
+
+	// The calls are the children of the action, and the actions are the children of the reconciliation.
+	for _, span := range []sdktrace.ReadOnlySpan{getSpan, updateStatusSpan} {
+		assert.Equal(t, syncSpan.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{syncSpan, barrierSpan} {
+		assert.Equal(t, rootSpan.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+	}
+	for _, span := range spans[:4] {
+		assert.Equal(t, rootSpan.SpanContext().TraceID(), span.SpanContext().TraceID(), span.Name())
+	}
+}
+
+func TestActionHook_NotTraced(t *testing.T) {
+	recorder := setupTestTracerProvider(t)
+
+	hook := NewActionHook()
+	ctx := hook.PreRun(context.Background(), logr.Discard(), "SyncConfigConfigMap")
+	hook.PostRun(ctx, logr.Discard(), "SyncConfigConfigMap", ctrl.Result{}, nil)
+
+	assert.Empty(t, recorder.Ended(), "actions out of the reconciliations aren't traced")
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{Exporter: ExporterNone}, "")
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), Options{Exporter: "zipkin"}, "")
+	assert.Error(t, err)
+}
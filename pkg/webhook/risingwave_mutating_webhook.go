@@ -37,6 +37,11 @@ type RisingWaveMutatingWebhook struct{}
 func (m *RisingWaveMutatingWebhook) Default(ctx context.Context, risingwave *risingwavev1alpha1.RisingWave) error {
 	risingwave.Spec.StateStore.DataDirectory = strings.TrimRight(strings.TrimSpace(risingwave.Spec.StateStore.DataDirectory), "/")
 
+	// The clone writes to a fresh data directory, which is likely in the same bucket as the source.
+	if risingwave.Spec.CloneFrom != nil && risingwave.Spec.StateStore.DataDirectory == "" {
+		risingwave.Spec.StateStore.DataDirectory = "hummock-" + risingwave.Name
+	}
+
 	if features.GetFeatureManager().IsFeatureEnabled(features.RandomSecretStorePrivateKey) {
 		// Generate a random private key if it is not set.
 		secretStorePrivateKey := &risingwave.Spec.SecretStore.PrivateKey
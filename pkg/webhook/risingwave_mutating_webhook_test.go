@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/features"
 	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
 )
@@ -34,3 +35,26 @@ func Test_RisingWaveMutatingWebhook_Default(t *testing.T) {
 		t.Errorf("Expected no error, got %v", err)
 	}
 }
+
+func Test_RisingWaveMutatingWebhook_DefaultCloneDataDirectory(t *testing.T) {
+	features.InitFeatureManager(features.SupportedFeatureList, "")
+
+	mutatingWebhook := NewRisingWaveMutatingWebhook()
+
+	risingwave := testutils.FakeRisingWave()
+	risingwave.Spec.CloneFrom = &risingwavev1alpha1.RisingWaveCloneFrom{Name: "source"}
+	if err := mutatingWebhook.Default(context.Background(), risingwave); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dataDirectory := risingwave.Spec.StateStore.DataDirectory; dataDirectory != "hummock-"+risingwave.Name {
+		t.Errorf("Expected a fresh data directory, got %q", dataDirectory)
+	}
+
+	risingwave.Spec.StateStore.DataDirectory = "clone/"
+	if err := mutatingWebhook.Default(context.Background(), risingwave); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dataDirectory := risingwave.Spec.StateStore.DataDirectory; dataDirectory != "clone" {
+		t.Errorf("Expected the data directory to be kept, got %q", dataDirectory)
+	}
+}
@@ -17,9 +17,12 @@
 package webhook
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"net"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -29,12 +32,15 @@ import (
 
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
 	"github.com/risingwavelabs/risingwave-operator/pkg/factory/envs"
+	"github.com/risingwavelabs/risingwave-operator/pkg/maintenance"
 
 	"github.com/distribution/reference"
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -42,12 +48,21 @@ import (
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/metrics"
+	"github.com/risingwavelabs/risingwave-operator/pkg/object"
 	"github.com/risingwavelabs/risingwave-operator/pkg/scaleview"
+	"github.com/risingwavelabs/risingwave-operator/pkg/stores"
 )
 
 // RisingWaveValidatingWebhook is the validating webhook for RisingWaves.
 type RisingWaveValidatingWebhook struct {
 	openKruiseAvailable bool
+
+	// client reads the RisingWaveMetaStoreMigrations that switch the meta stores.
+	client client.Reader
+
+	// operatorUsername is the username of the service account of the operator, the only user allowed to switch the
+	// meta stores. The switches are forbidden if it's empty.
+	operatorUsername string
 }
 
 func isImageValid(image string) bool {
@@ -61,6 +76,21 @@ var systemEnv = map[string]bool{
 	envs.RustBacktrace:   true,
 	envs.RWWorkerThreads: true,
 	envs.JavaOpts:        true,
+	// The resource group is set with the resourceGroup of the compute node groups.
+	envs.RWResourceGroup: true,
+}
+
+func validateSystemEnv(path *field.Path, env []corev1.EnvVar) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	for i, v := range env {
+		if systemEnv[v.Name] {
+			fieldErrs = append(fieldErrs,
+				field.Invalid(path.Index(i).Child("name"), v.Name, fmt.Sprintf("Env with the name %s is system reserved", v.Name)))
+		}
+	}
+
+	return fieldErrs
 }
 
 func (v *RisingWaveValidatingWebhook) isBypassed(obj client.Object) bool {
@@ -136,6 +166,32 @@ func (v *RisingWaveValidatingWebhook) validateNodeGroup(path *field.Path, nodeGr
 		}
 	}
 
+	// Validate the zones.
+	if nodeGroup.Zones != nil {
+		zonesPath := path.Child("zones")
+		for i, zone := range nodeGroup.Zones.Values {
+			if errs := validation.IsDNS1123Label(zone); len(errs) > 0 {
+				fieldErrs = append(fieldErrs, field.Invalid(
+					zonesPath.Child("values").Index(i),
+					zone,
+					fmt.Sprintf("invalid zone, must be a valid DNS-1123 label: %s", strings.Join(errs, "; ")),
+				))
+			}
+		}
+
+		if nodeGroup.Zones.TopologyKey != "" {
+			if errs := validation.IsQualifiedName(nodeGroup.Zones.TopologyKey); len(errs) > 0 {
+				fieldErrs = append(fieldErrs, field.Invalid(zonesPath.Child("topologyKey"), nodeGroup.Zones.TopologyKey, strings.Join(errs, "; ")))
+			}
+		}
+
+		topologyKey := lo.Ternary(nodeGroup.Zones.TopologyKey != "", nodeGroup.Zones.TopologyKey, consts.DefaultZoneTopologyKey)
+		if _, ok := nodeGroup.Template.Spec.NodeSelector[topologyKey]; ok {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("template", "spec", "nodeSelector").Key(topologyKey),
+				"not allowed when the node group is expanded into zones"))
+		}
+	}
+
 	// Validate labels of the RisingWave's Pods
 	for label := range nodeGroup.Template.ObjectMeta.Labels {
 		if strings.HasPrefix(label, "risingwave/") {
@@ -145,12 +201,7 @@ func (v *RisingWaveValidatingWebhook) validateNodeGroup(path *field.Path, nodeGr
 	}
 
 	// Validate env of the RisingWave's Pods
-	for i, v := range nodeGroup.Template.Spec.Env {
-		if systemEnv[v.Name] {
-			fieldErrs = append(fieldErrs,
-				field.Invalid(path.Child("template", "spec", "env").Index(i).Child("name"), v.Name, fmt.Sprintf("Env with the name %s is system reserved", v.Name)))
-		}
-	}
+	fieldErrs = append(fieldErrs, validateSystemEnv(path.Child("template", "spec", "env"), nodeGroup.Template.Spec.Env)...)
 
 	// Validate the resources only when limits exist
 	if nodeGroup.Template.Spec.Resources.Limits != nil {
@@ -176,6 +227,52 @@ func ptrValueNotZero[T any](ptr *T) bool {
 	return ptr != nil && !reflect.DeepEqual(ptr, &zero)
 }
 
+// Connection options that are generated from the TLS settings of the SQL meta stores.
+var (
+	mysqlSSLOptions      = []string{"ssl-mode", "ssl-ca", "ssl-cert", "ssl-key"}
+	postgreSQLSSLOptions = []string{"sslmode", "sslrootcert", "sslcert", "sslkey"}
+)
+
+func validateDBTLS(path *field.Path, tls *risingwavev1alpha1.RisingWaveDBTLS, options map[string]string, sslOptions []string) field.ErrorList {
+	if tls == nil {
+		return nil
+	}
+
+	fieldErrs := field.ErrorList{}
+
+	for k := range options {
+		if lo.Contains(sslOptions, strings.ToLower(k)) {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("options").Key(k), "must be configured with tls instead"))
+		}
+	}
+
+	tlsPath := path.Child("tls")
+	if tls.SSLMode == risingwavev1alpha1.RisingWaveDBSSLModeDisable {
+		if tls.CABundle != nil {
+			fieldErrs = append(fieldErrs, field.Forbidden(tlsPath.Child("caBundle"), "not allowed when sslMode is disable"))
+		}
+		if tls.ClientCertificate != nil {
+			fieldErrs = append(fieldErrs, field.Forbidden(tlsPath.Child("clientCertificate"), "not allowed when sslMode is disable"))
+		}
+
+		return fieldErrs
+	}
+
+	if tls.CABundle == nil {
+		if tls.SSLMode == risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA || tls.SSLMode == risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull {
+			fieldErrs = append(fieldErrs, field.Required(tlsPath.Child("caBundle"), fmt.Sprintf("caBundle is required when sslMode is %s", tls.SSLMode)))
+		}
+	} else if (tls.CABundle.SecretName == "") == (tls.CABundle.ConfigMapName == "") {
+		fieldErrs = append(fieldErrs, field.Invalid(tlsPath.Child("caBundle"), tls.CABundle, "exactly one of secretName and configMapName must be specified"))
+	}
+
+	if tls.ClientCertificate != nil && tls.ClientCertificate.SecretName == "" {
+		fieldErrs = append(fieldErrs, field.Required(tlsPath.Child("clientCertificate", "secretName"), "secretName is required"))
+	}
+
+	return fieldErrs
+}
+
 func (v *RisingWaveValidatingWebhook) validateMetaStoreAndStateStore(path *field.Path, metaStore *risingwavev1alpha1.RisingWaveMetaStoreBackend, stateStore *risingwavev1alpha1.RisingWaveStateStoreBackend) field.ErrorList {
 	fieldErrs := field.ErrorList{}
 
@@ -184,17 +281,26 @@ func (v *RisingWaveValidatingWebhook) validateMetaStoreAndStateStore(path *field
 	isMetaSQLite := ptrValueNotZero(metaStore.SQLite)
 	isMetaMySQL := ptrValueNotZero(metaStore.MySQL)
 	isMetaPG := ptrValueNotZero(metaStore.PostgreSQL)
+	isMetaManagedPG := metaStore.ManagedPostgreSQL != nil
 
 	validMetaStoreTypeCount := lo.CountBy([]bool{isMetaMemory, isMetaEtcd, isMetaSQLite,
-		isMetaMySQL, isMetaPG}, func(x bool) bool { return x })
+		isMetaMySQL, isMetaPG, isMetaManagedPG}, func(x bool) bool { return x })
 	if validMetaStoreTypeCount == 0 {
 		fieldErrs = append(fieldErrs, field.Invalid(path.Child("metaStore"), metaStore, "must configure the meta store"))
 	} else if validMetaStoreTypeCount > 1 {
 		fieldErrs = append(fieldErrs, field.Invalid(path.Child("metaStore"), metaStore, "multiple meta store types"))
 	}
 
+	if isMetaMySQL {
+		fieldErrs = append(fieldErrs, validateDBTLS(path.Child("metaStore", "mysql"), metaStore.MySQL.TLS, metaStore.MySQL.Options, mysqlSSLOptions)...)
+	}
+	if isMetaPG {
+		fieldErrs = append(fieldErrs, validateDBTLS(path.Child("metaStore", "postgresql"), metaStore.PostgreSQL.TLS, metaStore.PostgreSQL.Options, postgreSQLSSLOptions)...)
+	}
+
 	isStateMemory := ptrValueNotZero(stateStore.Memory)
 	isStateMinIO := stateStore.MinIO != nil
+	isStateManagedMinIO := stateStore.ManagedMinIO != nil
 	isStateS3 := stateStore.S3 != nil
 	isStateGCS := stateStore.GCS != nil
 	isStateAliyunOSS := stateStore.AliyunOSS != nil
@@ -231,8 +337,8 @@ func (v *RisingWaveValidatingWebhook) validateMetaStoreAndStateStore(path *field
 		}
 	}
 
-	validStateStoreTypeCount := lo.CountBy([]bool{isStateMemory, isStateMinIO, isStateS3, isStateGCS, isStateAliyunOSS,
-		isStateAzureBlob, isStateHDFS, isStateWebHDFS, isStateLocalDisk, isStateHuaweiCloudOBS}, func(x bool) bool { return x })
+	validStateStoreTypeCount := lo.CountBy([]bool{isStateMemory, isStateMinIO, isStateManagedMinIO, isStateS3, isStateGCS,
+		isStateAliyunOSS, isStateAzureBlob, isStateHDFS, isStateWebHDFS, isStateLocalDisk, isStateHuaweiCloudOBS}, func(x bool) bool { return x })
 	if validStateStoreTypeCount == 0 {
 		fieldErrs = append(fieldErrs, field.Invalid(path.Child("stateStore"), stateStore, "must configure the state store"))
 	} else if validStateStoreTypeCount > 1 {
@@ -246,6 +352,25 @@ func (v *RisingWaveValidatingWebhook) validateMetaStoreAndStateStore(path *field
 		fieldErrs = append(fieldErrs, field.Invalid(path.Child("stateStore", "dataDirectory"), stateStore.DataDirectory, "must be a valid path"))
 	}
 
+	if metaStore.DeletionPolicy == risingwavev1alpha1.RisingWaveDeletionPolicyDelete {
+		if isMetaManagedPG {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("metaStore", "deletionPolicy"),
+				"data of the managed PostgreSQL is always deleted with the RisingWave"))
+		} else if !isMetaMySQL && !isMetaPG {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("metaStore", "deletionPolicy"), "Delete is only supported by MySQL and PostgreSQL"))
+		}
+	}
+
+	if stateStore.DeletionPolicy == risingwavev1alpha1.RisingWaveDeletionPolicyDelete {
+		if isStateManagedMinIO {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("stateStore", "deletionPolicy"),
+				"data of the managed MinIO is always deleted with the RisingWave"))
+		} else if !stores.IsBucketSupported(stateStore) {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("stateStore", "deletionPolicy"),
+				"Delete is only supported by S3, MinIO, Aliyun OSS and Huawei Cloud OBS with the credentials in a secret"))
+		}
+	}
+
 	return fieldErrs
 }
 
@@ -265,6 +390,132 @@ func (v *RisingWaveValidatingWebhook) validateConfiguration(path *field.Path, co
 	return nil
 }
 
+func (v *RisingWaveValidatingWebhook) validateComputeOnlyFields(path *field.Path, components *risingwavev1alpha1.RisingWaveComponentsSpec) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	for _, c := range []struct {
+		name       string
+		nodeGroups []risingwavev1alpha1.RisingWaveNodeGroup
+	}{
+		{name: "meta", nodeGroups: components.Meta.NodeGroups},
+		{name: "frontend", nodeGroups: components.Frontend.NodeGroups},
+		{name: "compactor", nodeGroups: components.Compactor.NodeGroups},
+	} {
+		for i, ng := range c.nodeGroups {
+			if ng.ComputeRole != "" {
+				fieldErrs = append(fieldErrs, field.Forbidden(path.Child(c.name, "nodeGroups").Index(i).Child("computeRole"), "only allowed on compute node groups"))
+			}
+			if ng.ResourceGroup != "" {
+				fieldErrs = append(fieldErrs, field.Forbidden(path.Child(c.name, "nodeGroups").Index(i).Child("resourceGroup"), "only allowed on compute node groups"))
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateComputeRoles(path *field.Path, components *risingwavev1alpha1.RisingWaveComponentsSpec) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	streamingCapable := false
+	for i, ng := range components.Compute.NodeGroups {
+		switch ng.ComputeRole {
+		case "", risingwavev1alpha1.RisingWaveComputeRoleStreaming, risingwavev1alpha1.RisingWaveComputeRoleBoth:
+			streamingCapable = true
+		case risingwavev1alpha1.RisingWaveComputeRoleServing:
+		default:
+			fieldErrs = append(fieldErrs, field.NotSupported(path.Child("compute", "nodeGroups").Index(i).Child("computeRole"), ng.ComputeRole, []risingwavev1alpha1.RisingWaveComputeRole{
+				risingwavev1alpha1.RisingWaveComputeRoleStreaming,
+				risingwavev1alpha1.RisingWaveComputeRoleServing,
+				risingwavev1alpha1.RisingWaveComputeRoleBoth,
+			}))
+		}
+	}
+
+	// Streaming jobs can't be scheduled when all the compute nodes are serving only.
+	if len(components.Compute.NodeGroups) > 0 && !streamingCapable {
+		fieldErrs = append(fieldErrs, field.Forbidden(path.Child("compute", "nodeGroups"), "at least one node group must be able to run streaming jobs"))
+	}
+
+	return fieldErrs
+}
+
+// servedResourceGroups returns the resource groups that the compute nodes of the RisingWave join. The compute node in
+// the standalone Pods always joins the default resource group, and the compute node groups are ignored then.
+func servedResourceGroups(obj *risingwavev1alpha1.RisingWave) map[string]bool {
+	if ptr.Deref(obj.Spec.EnableStandaloneMode, false) {
+		return map[string]bool{consts.DefaultResourceGroup: true}
+	}
+
+	reader := object.NewRisingWaveReader(obj)
+
+	return lo.SliceToMap(obj.Spec.Components.Compute.NodeGroups, func(ng risingwavev1alpha1.RisingWaveNodeGroup) (string, bool) {
+		return reader.GetResourceGroup(&ng), true
+	})
+}
+
+func (v *RisingWaveValidatingWebhook) validateResourceGroups(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	// Only check the clusters that opt in to the resource groups explicitly, where the node groups without a name
+	// are expected to serve the default resource group.
+	if !lo.ContainsBy(obj.Spec.Components.Compute.NodeGroups, func(ng risingwavev1alpha1.RisingWaveNodeGroup) bool {
+		return ng.ResourceGroup != ""
+	}) {
+		return nil
+	}
+
+	if !servedResourceGroups(obj)[consts.DefaultResourceGroup] {
+		return field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "components", "compute", "nodeGroups"),
+				fmt.Sprintf("at least one node group must serve the %s resource group", consts.DefaultResourceGroup)),
+		}
+	}
+
+	return nil
+}
+
+// validateResourceGroupsWithJobs forbids removing all the node groups of a resource group that still has streaming jobs
+// assigned according to the last status reported by the meta service, including by switching to or from the
+// standalone mode.
+func (v *RisingWaveValidatingWebhook) validateResourceGroupsWithJobs(oldObj, newObj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	oldGroups, newGroups := servedResourceGroups(oldObj), servedResourceGroups(newObj)
+
+	fieldErrs := field.ErrorList{}
+	for _, rg := range oldObj.Status.ResourceGroups {
+		if rg.StreamingJobs > 0 && oldGroups[rg.Name] && !newGroups[rg.Name] {
+			fieldErrs = append(fieldErrs, field.Forbidden(field.NewPath("spec", "components", "compute", "nodeGroups"),
+				fmt.Sprintf("resource group %s still has %d streaming jobs assigned", rg.Name, rg.StreamingJobs)))
+		}
+	}
+
+	return fieldErrs
+}
+
+// validateZonedNodeGroupNames validates that the node groups expanded into zones don't conflict with the other
+// node groups of the same component.
+func (v *RisingWaveValidatingWebhook) validateZonedNodeGroupNames(path *field.Path, nodeGroups []risingwavev1alpha1.RisingWaveNodeGroup) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	groupNames := make(map[string]bool)
+	for _, ng := range nodeGroups {
+		groupNames[ng.Name] = true
+	}
+
+	for i, ng := range nodeGroups {
+		if ng.Zones == nil {
+			continue
+		}
+
+		for j, zone := range ng.Zones.Values {
+			if name := object.ZonedNodeGroupName(ng.Name, zone); groupNames[name] {
+				fieldErrs = append(fieldErrs, field.Invalid(path.Index(i).Child("zones", "values").Index(j), zone,
+					fmt.Sprintf("conflicts with the node group %q", name)))
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
 func (v *RisingWaveValidatingWebhook) validateComponents(path *field.Path, components *risingwavev1alpha1.RisingWaveComponentsSpec, openKruiseEnabled bool) field.ErrorList {
 	fieldErrs := field.ErrorList{}
 
@@ -288,6 +539,18 @@ func (v *RisingWaveValidatingWebhook) validateComponents(path *field.Path, compo
 		fieldErrs = append(fieldErrs, v.validateNodeGroup(computeGroupsPath.Index(i), &ng, openKruiseEnabled)...)
 	}
 
+	fieldErrs = append(fieldErrs, v.validateZonedNodeGroupNames(metaGroupsPath, components.Meta.NodeGroups)...)
+	fieldErrs = append(fieldErrs, v.validateZonedNodeGroupNames(frontendGroupsPath, components.Frontend.NodeGroups)...)
+	fieldErrs = append(fieldErrs, v.validateZonedNodeGroupNames(compactorGroupsPath, components.Compactor.NodeGroups)...)
+	fieldErrs = append(fieldErrs, v.validateZonedNodeGroupNames(computeGroupsPath, components.Compute.NodeGroups)...)
+	// The standalone Pods run the compute node as well, which joins the default resource group.
+	if components.Standalone != nil {
+		fieldErrs = append(fieldErrs, validateSystemEnv(path.Child("standalone", "template", "spec", "env"), components.Standalone.Template.Spec.Env)...)
+	}
+
+	fieldErrs = append(fieldErrs, v.validateComputeOnlyFields(path, components)...)
+	fieldErrs = append(fieldErrs, v.validateComputeRoles(path, components)...)
+
 	return fieldErrs
 }
 
@@ -320,6 +583,302 @@ func (v *RisingWaveValidatingWebhook) validateSecretStore(obj *risingwavev1alpha
 	return fieldErrs
 }
 
+func validateNetworkPolicyPeers(path *field.Path, peers []networkingv1.NetworkPolicyPeer) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	for i, peer := range peers {
+		peerPath := path.Index(i)
+		if peer.PodSelector == nil && peer.NamespaceSelector == nil && peer.IPBlock == nil {
+			fieldErrs = append(fieldErrs, field.Required(peerPath, "must specify a pod selector, a namespace selector or an IP block"))
+		}
+
+		if peer.IPBlock != nil {
+			if _, _, err := net.ParseCIDR(peer.IPBlock.CIDR); err != nil {
+				fieldErrs = append(fieldErrs, field.Invalid(peerPath.Child("ipBlock", "cidr"), peer.IPBlock.CIDR, "invalid CIDR"))
+			}
+			for j, except := range peer.IPBlock.Except {
+				if _, _, err := net.ParseCIDR(except); err != nil {
+					fieldErrs = append(fieldErrs, field.Invalid(peerPath.Child("ipBlock", "except").Index(j), except, "invalid CIDR"))
+				}
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateNetworkPolicy(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	networkPolicy := obj.Spec.NetworkPolicy
+	if networkPolicy == nil {
+		return nil
+	}
+
+	fieldErrs := field.ErrorList{}
+
+	path := field.NewPath("spec", "networkPolicy")
+	fieldErrs = append(fieldErrs, validateNetworkPolicyPeers(path.Child("frontendClients"), networkPolicy.FrontendClients)...)
+	fieldErrs = append(fieldErrs, validateNetworkPolicyPeers(path.Child("httpClients"), networkPolicy.HTTPClients)...)
+
+	// The Ingress controllers and the Gateways can't reach the exposed endpoints unless they are allowed.
+	externalAccess := obj.Spec.ExternalAccess
+	if ptr.Deref(networkPolicy.Enabled, false) && externalAccess != nil &&
+		(externalAccess.Dashboard != nil || externalAccess.Webhook != nil) && len(networkPolicy.HTTPClients) == 0 {
+		fieldErrs = append(fieldErrs, field.Required(path.Child("httpClients"),
+			"must allow the Ingress controllers or the Gateways to access the endpoints exposed in spec.externalAccess"))
+	}
+
+	for _, ns := range []struct {
+		name  string
+		value string
+	}{
+		{name: "monitoringNamespace", value: networkPolicy.MonitoringNamespace},
+		{name: "operatorNamespace", value: networkPolicy.OperatorNamespace},
+	} {
+		if ns.value == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(ns.value); len(errs) > 0 {
+			fieldErrs = append(fieldErrs, field.Invalid(path.Child(ns.name), ns.value, strings.Join(errs, "; ")))
+		}
+	}
+
+	return fieldErrs
+}
+
+func validateDNS1123Subdomain(path *field.Path, value string) field.ErrorList {
+	if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+		return field.ErrorList{field.Invalid(path, value, strings.Join(errs, "; "))}
+	}
+
+	return nil
+}
+
+func validateExternalEndpointPath(path *field.Path, value string) field.ErrorList {
+	if value != "" && !strings.HasPrefix(value, "/") {
+		return field.ErrorList{field.Invalid(path, value, "must be an absolute path")}
+	}
+
+	return nil
+}
+
+func (v *RisingWaveValidatingWebhook) validateExternalEndpoint(path *field.Path, endpoint *risingwavev1alpha1.RisingWaveExternalEndpoint) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	if endpoint.Ingress == nil && endpoint.HTTPRoute == nil {
+		fieldErrs = append(fieldErrs, field.Required(path, "must specify an ingress or an http route"))
+	}
+
+	if ingress := endpoint.Ingress; ingress != nil {
+		ingressPath := path.Child("ingress")
+		if ingress.Host != "" {
+			fieldErrs = append(fieldErrs, validateDNS1123Subdomain(ingressPath.Child("host"), ingress.Host)...)
+		}
+		fieldErrs = append(fieldErrs, validateExternalEndpointPath(ingressPath.Child("path"), ingress.Path)...)
+		if ingress.TLSSecretName != "" {
+			fieldErrs = append(fieldErrs, validateDNS1123Subdomain(ingressPath.Child("tlsSecretName"), ingress.TLSSecretName)...)
+		}
+	}
+
+	if httpRoute := endpoint.HTTPRoute; httpRoute != nil {
+		httpRoutePath := path.Child("httpRoute")
+		if len(httpRoute.ParentRefs) == 0 {
+			fieldErrs = append(fieldErrs, field.Required(httpRoutePath.Child("parentRefs"), "must specify at least one gateway"))
+		}
+		for i, ref := range httpRoute.ParentRefs {
+			refPath := httpRoutePath.Child("parentRefs").Index(i)
+			fieldErrs = append(fieldErrs, validateDNS1123Subdomain(refPath.Child("name"), ref.Name)...)
+			if ref.Namespace != "" {
+				if errs := validation.IsDNS1123Label(ref.Namespace); len(errs) > 0 {
+					fieldErrs = append(fieldErrs, field.Invalid(refPath.Child("namespace"), ref.Namespace, strings.Join(errs, "; ")))
+				}
+			}
+		}
+		if httpRoute.Hostname != "" {
+			fieldErrs = append(fieldErrs, validateDNS1123Subdomain(httpRoutePath.Child("hostname"), httpRoute.Hostname)...)
+		}
+		fieldErrs = append(fieldErrs, validateExternalEndpointPath(httpRoutePath.Child("path"), httpRoute.Path)...)
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateExternalAccess(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	externalAccess := obj.Spec.ExternalAccess
+	if externalAccess == nil {
+		return nil
+	}
+
+	fieldErrs := field.ErrorList{}
+
+	path := field.NewPath("spec", "externalAccess")
+	if externalAccess.Dashboard != nil {
+		fieldErrs = append(fieldErrs, v.validateExternalEndpoint(path.Child("dashboard"), externalAccess.Dashboard)...)
+	}
+	if externalAccess.Webhook != nil {
+		if !ptr.Deref(obj.Spec.EnableWebhookListener, false) {
+			fieldErrs = append(fieldErrs, field.Forbidden(path.Child("webhook"), "requires the webhook listener to be enabled"))
+		}
+		fieldErrs = append(fieldErrs, v.validateExternalEndpoint(path.Child("webhook"), externalAccess.Webhook)...)
+	}
+
+	return fieldErrs
+}
+
+// prometheusLabelNamePattern is the pattern of the valid label names of Prometheus.
+var prometheusLabelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+func validateReservedLabels(path *field.Path, labels map[string]string) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	for label := range labels {
+		if strings.HasPrefix(label, "risingwave/") {
+			fieldErrs = append(fieldErrs, field.Invalid(path, label, "Labels with the prefix 'risingwave/' are system reserved"))
+		}
+	}
+
+	return fieldErrs
+}
+
+func validateScrapeDurations(path *field.Path, interval, scrapeTimeout *metav1.Duration) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	if interval != nil && interval.Duration <= 0 {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("interval"), interval.Duration.String(), "must be positive"))
+	}
+	if scrapeTimeout != nil && scrapeTimeout.Duration <= 0 {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("scrapeTimeout"), scrapeTimeout.Duration.String(), "must be positive"))
+	}
+	if interval != nil && scrapeTimeout != nil && scrapeTimeout.Duration > interval.Duration {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("scrapeTimeout"), scrapeTimeout.Duration.String(), "must not be greater than the interval"))
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateMonitoring(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	monitoring := obj.Spec.Monitoring
+	if monitoring == nil {
+		return nil
+	}
+
+	fieldErrs := field.ErrorList{}
+
+	path := field.NewPath("spec", "monitoring")
+	fieldErrs = append(fieldErrs, validateReservedLabels(path.Child("labels"), monitoring.Labels)...)
+	fieldErrs = append(fieldErrs, validateScrapeDurations(path, monitoring.Interval, monitoring.ScrapeTimeout)...)
+	for i, endpoint := range monitoring.AdditionalEndpoints {
+		endpointPath := path.Child("additionalEndpoints").Index(i)
+		if endpoint.Port == "" {
+			fieldErrs = append(fieldErrs, field.Required(endpointPath.Child("port"), "port must be specified"))
+		}
+		fieldErrs = append(fieldErrs, validateScrapeDurations(endpointPath, endpoint.Interval, endpoint.ScrapeTimeout)...)
+	}
+
+	if prometheusRule := monitoring.PrometheusRule; prometheusRule != nil {
+		rulePath := path.Child("prometheusRule")
+		fieldErrs = append(fieldErrs, validateReservedLabels(rulePath.Child("labels"), prometheusRule.Labels)...)
+
+		for label := range prometheusRule.AlertLabels {
+			if !prometheusLabelNamePattern.MatchString(label) {
+				fieldErrs = append(fieldErrs, field.Invalid(rulePath.Child("alertLabels"), label, "must be a valid Prometheus label name"))
+			}
+		}
+
+		thresholdsPath := rulePath.Child("thresholds")
+		thresholds := &prometheusRule.Thresholds
+		if thresholds.BarrierLatency != nil && thresholds.BarrierLatency.Duration <= 0 {
+			fieldErrs = append(fieldErrs, field.Invalid(thresholdsPath.Child("barrierLatency"), thresholds.BarrierLatency.Duration.String(), "must be positive"))
+		}
+		if thresholds.PodNotReady != nil && thresholds.PodNotReady.Duration <= 0 {
+			fieldErrs = append(fieldErrs, field.Invalid(thresholdsPath.Child("podNotReady"), thresholds.PodNotReady.Duration.String(), "must be positive"))
+		}
+		if thresholds.CompactionPendingBytes != nil && thresholds.CompactionPendingBytes.Sign() <= 0 {
+			fieldErrs = append(fieldErrs, field.Invalid(thresholdsPath.Child("compactionPendingBytes"), thresholds.CompactionPendingBytes.String(), "must be positive"))
+		}
+	}
+
+	if grafanaDashboard := monitoring.GrafanaDashboard; grafanaDashboard != nil {
+		fieldErrs = append(fieldErrs, validateReservedLabels(path.Child("grafanaDashboard", "labels"), grafanaDashboard.Labels)...)
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateMaintenanceWindows(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	fieldErrs := field.ErrorList{}
+
+	path := field.NewPath("spec", "maintenanceWindows")
+	for i := range obj.Spec.MaintenanceWindows {
+		fieldErrs = append(fieldErrs, maintenance.ValidateWindow(path.Index(i), &obj.Spec.MaintenanceWindows[i])...)
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateStandaloneHA(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	if !object.NewRisingWaveReader(obj).IsStandaloneHAEnabled() {
+		return nil
+	}
+
+	// The standalone Pods share the meta store and the state store.
+	path := field.NewPath("spec", "components", "standalone", "replicas")
+	metaStore, stateStore := &obj.Spec.MetaStore, &obj.Spec.StateStore
+
+	fieldErrs := field.ErrorList{}
+	if metaStore.PostgreSQL == nil && metaStore.MySQL == nil && metaStore.ManagedPostgreSQL == nil {
+		fieldErrs = append(fieldErrs, field.Forbidden(path, "more than one replica requires a PostgreSQL or MySQL meta store"))
+	}
+	if ptr.Deref(stateStore.Memory, false) || stateStore.LocalDisk != nil {
+		fieldErrs = append(fieldErrs, field.Forbidden(path, "more than one replica requires an object storage state store"))
+	}
+
+	return fieldErrs
+}
+
+func (v *RisingWaveValidatingWebhook) validateStandaloneMigration(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	// The standalone component may be removed after the migration completes.
+	reader := object.NewRisingWaveReader(obj)
+	if !reader.IsStandaloneMigrationInProgress() {
+		return nil
+	}
+
+	if err := reader.CheckStandaloneMigration(); err != nil {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "standaloneMigration"), err.Error())}
+	}
+
+	return nil
+}
+
+func (v *RisingWaveValidatingWebhook) validateCloneFrom(obj *risingwavev1alpha1.RisingWave) field.ErrorList {
+	cloneFrom := obj.Spec.CloneFrom
+	if cloneFrom == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec", "cloneFrom")
+	metaStore, stateStore := &obj.Spec.MetaStore, &obj.Spec.StateStore
+
+	fieldErrs := field.ErrorList{}
+	if cloneFrom.Name == obj.Name && cmp.Or(cloneFrom.Namespace, obj.Namespace) == obj.Namespace {
+		fieldErrs = append(fieldErrs, field.Invalid(path.Child("name"), cloneFrom.Name, "can't clone from itself"))
+	}
+	// The meta snapshot is restored into the meta store by the restore tool, which supports only the SQL backends.
+	if metaStore.MySQL == nil && metaStore.PostgreSQL == nil {
+		fieldErrs = append(fieldErrs, field.Forbidden(path, "clone requires a MySQL or PostgreSQL meta store"))
+	}
+	// The data of the source is copied by the operator.
+	if !stores.IsBucketSupported(stateStore) {
+		fieldErrs = append(fieldErrs, field.Forbidden(path, "clone requires an S3 compatible state store with the credentials in a Secret"))
+	}
+	// The streaming jobs are paused by restarting the meta service alone before the other components are created,
+	// which isn't possible in the standalone mode.
+	if ptr.Deref(obj.Spec.EnableStandaloneMode, false) && !cloneFrom.AllowSinks {
+		fieldErrs = append(fieldErrs, field.Required(path.Child("allowSinks"), "clone in the standalone mode requires the sinks to be allowed"))
+	}
+
+	return fieldErrs
+}
+
 func (v *RisingWaveValidatingWebhook) validateCreate(ctx context.Context, obj *risingwavev1alpha1.RisingWave) error {
 	gvk := obj.GroupVersionKind()
 
@@ -371,6 +930,30 @@ func (v *RisingWaveValidatingWebhook) validateCreate(ctx context.Context, obj *r
 	// Validate the secret store.
 	fieldErrs = append(fieldErrs, v.validateSecretStore(obj)...)
 
+	// Validate the resource groups.
+	fieldErrs = append(fieldErrs, v.validateResourceGroups(obj)...)
+
+	// Validate the network policy.
+	fieldErrs = append(fieldErrs, v.validateNetworkPolicy(obj)...)
+
+	// Validate the external access.
+	fieldErrs = append(fieldErrs, v.validateExternalAccess(obj)...)
+
+	// Validate the monitoring.
+	fieldErrs = append(fieldErrs, v.validateMonitoring(obj)...)
+
+	// Validate the maintenance windows.
+	fieldErrs = append(fieldErrs, v.validateMaintenanceWindows(obj)...)
+
+	// Validate the migration from the standalone mode.
+	fieldErrs = append(fieldErrs, v.validateStandaloneMigration(obj)...)
+
+	// Validate the high availability mode of the standalone Pods.
+	fieldErrs = append(fieldErrs, v.validateStandaloneHA(obj)...)
+
+	// Validate the source of the clone.
+	fieldErrs = append(fieldErrs, v.validateCloneFrom(obj)...)
+
 	if len(fieldErrs) > 0 {
 		return apierrors.NewInvalid(gvk.GroupKind(), obj.Name, fieldErrs)
 	}
@@ -389,17 +972,112 @@ func (v *RisingWaveValidatingWebhook) ValidateCreate(ctx context.Context, obj *r
 	return
 }
 
+// validateDelete forbids the deletion of the RisingWave if the deletion protection is on and the deletion isn't
+// confirmed, or if the confirmation doesn't match the name. The bypass annotation doesn't apply.
+func (v *RisingWaveValidatingWebhook) validateDelete(obj *risingwavev1alpha1.RisingWave) error {
+	gvk := obj.GroupVersionKind()
+
+	confirmation, confirmed := obj.Annotations[consts.AnnotationConfirmDeletion]
+	if confirmed && confirmation != obj.Name {
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind},
+			obj.Name,
+			field.Forbidden(field.NewPath("metadata", "annotations").Key(consts.AnnotationConfirmDeletion),
+				"must be the name of the risingwave to confirm the deletion"),
+		)
+	}
+
+	if ptr.Deref(obj.Spec.DeletionProtection, false) && !confirmed {
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind},
+			obj.Name,
+			field.Forbidden(field.NewPath("spec", "deletionProtection"),
+				fmt.Sprintf("deletion protection is on, turn it off or set the annotation %s to the name of the risingwave",
+					consts.AnnotationConfirmDeletion)),
+		)
+	}
+
+	return nil
+}
+
 // ValidateDelete implements admission.Validator.
 func (v *RisingWaveValidatingWebhook) ValidateDelete(ctx context.Context, obj *risingwavev1alpha1.RisingWave) (warnings admission.Warnings, err error) {
-	return nil, nil
+	err = v.validateDelete(obj)
+
+	return
 }
 
+// isMetaStoresTheSame compares the meta stores except the deletion policies and the TLS settings, which could be
+// changed at any time, e.g., to rotate the certificates.
 func (v *RisingWaveValidatingWebhook) isMetaStoresTheSame(oldObj, newObj *risingwavev1alpha1.RisingWave) bool {
-	return equality.Semantic.DeepEqual(oldObj.Spec.MetaStore, newObj.Spec.MetaStore)
+	return isMetaStoreBackendsTheSame(&oldObj.Spec.MetaStore, &newObj.Spec.MetaStore)
+}
+
+func isMetaStoreBackendsTheSame(a, b *risingwavev1alpha1.RisingWaveMetaStoreBackend) bool {
+	oldStore, newStore := *a.DeepCopy(), *b.DeepCopy()
+	oldStore.DeletionPolicy, newStore.DeletionPolicy = "", ""
+	for _, store := range []*risingwavev1alpha1.RisingWaveMetaStoreBackend{&oldStore, &newStore} {
+		if store.MySQL != nil {
+			store.MySQL.TLS = nil
+		}
+		if store.PostgreSQL != nil {
+			store.PostgreSQL.TLS = nil
+		}
+	}
+
+	return equality.Semantic.DeepEqual(oldStore, newStore)
+}
+
+// isSwitchedByMetaStoreMigration tells if the meta store is switched by a RisingWaveMetaStoreMigration. The operator
+// pauses and annotates the RisingWave with the name of the migration before the switch. Since anyone could set the
+// annotations, the switch must also be made by the operator itself, and the new meta store must be either the target
+// of the migration while it's switching, or the rollback point while it's rolling back.
+func (v *RisingWaveValidatingWebhook) isSwitchedByMetaStoreMigration(ctx context.Context, oldObj, newObj *risingwavev1alpha1.RisingWave) bool {
+	name := oldObj.Annotations[consts.AnnotationMetaStoreMigration]
+	_, paused := oldObj.Annotations[consts.AnnotationPauseReconcile]
+	if name == "" || !paused || newObj.Annotations[consts.AnnotationMetaStoreMigration] != name {
+		return false
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || v.operatorUsername == "" || req.UserInfo.Username != v.operatorUsername || v.client == nil {
+		return false
+	}
+
+	var migration risingwavev1alpha1.RisingWaveMetaStoreMigration
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: oldObj.Namespace, Name: name}, &migration); err != nil {
+		return false
+	}
+	if migration.Spec.RisingWave != oldObj.Name {
+		return false
+	}
+
+	if migration.Spec.Rollback {
+		point := migration.Status.RollbackPoint
+
+		return point != nil && isMetaStoreBackendsTheSame(&point.MetaStore, &newObj.Spec.MetaStore)
+	}
+
+	return migration.Status.Phase == risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseSwitching &&
+		isMetaStoreBackendsTheSame(&migration.Spec.MetaStore, &newObj.Spec.MetaStore)
 }
 
+// isStateStoresTheSame compares the state stores except the deletion policies, which could be changed at any time.
 func (v *RisingWaveValidatingWebhook) isStateStoresTheSame(oldObj, newObj *risingwavev1alpha1.RisingWave) bool {
-	return equality.Semantic.DeepEqual(oldObj.Spec.StateStore, newObj.Spec.StateStore)
+	oldStore, newStore := oldObj.Spec.StateStore, newObj.Spec.StateStore
+	oldStore.DeletionPolicy, newStore.DeletionPolicy = "", ""
+
+	return equality.Semantic.DeepEqual(oldStore, newStore)
+}
+
+// isCloneSourcesTheSame compares the sources of the clones. The sinks could be allowed or disallowed at any time.
+func (v *RisingWaveValidatingWebhook) isCloneSourcesTheSame(oldObj, newObj *risingwavev1alpha1.RisingWave) bool {
+	oldSource, newSource := oldObj.Spec.CloneFrom, newObj.Spec.CloneFrom
+	if oldSource == nil || newSource == nil {
+		return oldSource == newSource
+	}
+
+	return oldSource.Name == newSource.Name && oldSource.Namespace == newSource.Namespace
 }
 
 func pathForGroupReplicas(obj *risingwavev1alpha1.RisingWave, component, group string) *field.Path {
@@ -430,7 +1108,7 @@ func (v *RisingWaveValidatingWebhook) validateUpdate(ctx context.Context, oldObj
 	gvk := oldObj.GroupVersionKind()
 
 	// The meta store and state store must be kept consistent.
-	if !v.isMetaStoresTheSame(oldObj, newObj) {
+	if !v.isMetaStoresTheSame(oldObj, newObj) && !v.isSwitchedByMetaStoreMigration(ctx, oldObj, newObj) {
 		return apierrors.NewForbidden(
 			schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind},
 			oldObj.Name,
@@ -454,6 +1132,14 @@ func (v *RisingWaveValidatingWebhook) validateUpdate(ctx context.Context, oldObj
 		)
 	}
 
+	if !v.isCloneSourcesTheSame(oldObj, newObj) {
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind},
+			oldObj.Name,
+			field.Forbidden(field.NewPath("spec", "cloneFrom"), "clone source must be kept consistent"),
+		)
+	}
+
 	fieldErrs := field.ErrorList{}
 
 	// Validate the locks from scale views.
@@ -493,6 +1179,9 @@ func (v *RisingWaveValidatingWebhook) validateUpdate(ctx context.Context, oldObj
 		}
 	}
 
+	// Validate the resource groups with streaming jobs.
+	fieldErrs = append(fieldErrs, v.validateResourceGroupsWithJobs(oldObj, newObj)...)
+
 	if len(fieldErrs) > 0 {
 		return apierrors.NewInvalid(gvk.GroupKind(), oldObj.Name, fieldErrs)
 	}
@@ -529,7 +1218,11 @@ func nodeGroupPartitionExistAndIsString(nodeGroup *risingwavev1alpha1.RisingWave
 }
 
 // NewRisingWaveValidatingWebhook returns a new validator for the RisingWave. The behavior differs on different values of the
-// openKruiseAvailable.
-func NewRisingWaveValidatingWebhook(openKruiseAvailable bool) admission.Validator[*risingwavev1alpha1.RisingWave] {
-	return metrics.NewValidatingWebhookMetricsRecorder(&RisingWaveValidatingWebhook{openKruiseAvailable: openKruiseAvailable})
+// openKruiseAvailable. Only the operatorUsername is allowed to switch the meta stores for the migrations read by the client.
+func NewRisingWaveValidatingWebhook(openKruiseAvailable bool, client client.Reader, operatorUsername string) admission.Validator[*risingwavev1alpha1.RisingWave] {
+	return metrics.NewValidatingWebhookMetricsRecorder(&RisingWaveValidatingWebhook{
+		openKruiseAvailable: openKruiseAvailable,
+		client:              client,
+		operatorUsername:    operatorUsername,
+	})
 }
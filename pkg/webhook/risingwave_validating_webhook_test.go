@@ -17,31 +17,94 @@
 package webhook
 
 import (
+	"cmp"
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	kruisepubs "github.com/openkruise/kruise-api/apps/pub"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 	"github.com/risingwavelabs/risingwave-operator/pkg/consts"
+	"github.com/risingwavelabs/risingwave-operator/pkg/factory/envs"
 	"github.com/risingwavelabs/risingwave-operator/pkg/testutils"
 	"github.com/risingwavelabs/risingwave-operator/pkg/utils"
 )
 
 func Test_RisingWaveValidatingWebhook_ValidateDelete(t *testing.T) {
-	_, err := NewRisingWaveValidatingWebhook(false).ValidateDelete(context.Background(), &risingwavev1alpha1.RisingWave{})
+	_, err := NewRisingWaveValidatingWebhook(false, nil, "").ValidateDelete(context.Background(), &risingwavev1alpha1.RisingWave{})
 	require.NoError(t, err)
 }
 
+func Test_RisingWaveValidatingWebhook_ValidateDelete_DeletionProtection(t *testing.T) {
+	testcases := map[string]struct {
+		protected    bool
+		annotations  map[string]string
+		pass         bool
+		errorMessage string
+	}{
+		"unprotected-pass": {
+			pass: true,
+		},
+		"protected-fail": {
+			protected:    true,
+			errorMessage: "deletion protection is on",
+		},
+		"protected-confirmed-pass": {
+			protected:   true,
+			annotations: map[string]string{consts.AnnotationConfirmDeletion: "fake-risingwave"},
+			pass:        true,
+		},
+		"protected-confirmation-mismatch-fail": {
+			protected:    true,
+			annotations:  map[string]string{consts.AnnotationConfirmDeletion: "another"},
+			errorMessage: "must be the name of the risingwave",
+		},
+		"unprotected-confirmation-mismatch-fail": {
+			annotations:  map[string]string{consts.AnnotationConfirmDeletion: "another"},
+			errorMessage: "must be the name of the risingwave",
+		},
+		"protected-bypassed-fail": {
+			protected:    true,
+			annotations:  map[string]string{consts.AnnotationBypassValidatingWebhook: "true"},
+			errorMessage: "deletion protection is on",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			risingwave := testutils.FakeRisingWave()
+			risingwave.Spec.DeletionProtection = ptr.To(tc.protected)
+			risingwave.Annotations = tc.annotations
+
+			_, err := NewRisingWaveValidatingWebhook(false, nil, "").ValidateDelete(context.Background(), risingwave)
+			if tc.pass {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.errorMessage)
+				require.True(t, apierrors.IsForbidden(err))
+			}
+		})
+	}
+}
+
 func Test_RisingWaveValidatingWebhook_ValidateCreate(t *testing.T) {
 	testcases := map[string]struct {
 		patch               func(r *risingwavev1alpha1.RisingWave)
@@ -385,36 +448,27 @@ func Test_RisingWaveValidatingWebhook_ValidateCreate(t *testing.T) {
 			},
 			pass: true,
 		},
-		"s3-state-store-no-credentials-fail": {
-			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
-						Bucket: "hummock",
-					},
-				}
-			},
-			pass: false,
-		},
-		"s3-compatible-state-store-pass": {
+		"minio-state-store-deletion-policy-delete-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
 				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+					DeletionPolicy: risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
+					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+						Endpoint: "minio",
 						Bucket:   "hummock",
-						Endpoint: "123",
-						RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
-							SecretName: "s3-creds",
+						RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+							SecretName: "minio-creds",
 						},
 					},
 				}
 			},
 			pass: true,
 		},
-		"s3-compatible-state-store-use-service-account-fail": {
+		"s3-state-store-use-service-account-deletion-policy-delete-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
 				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					DeletionPolicy: risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
 					S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
-						Bucket:   "hummock",
-						Endpoint: "123",
+						Bucket: "hummock",
 						RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
 							UseServiceAccount: ptr.To(true),
 						},
@@ -423,312 +477,724 @@ func Test_RisingWaveValidatingWebhook_ValidateCreate(t *testing.T) {
 			},
 			pass: false,
 		},
-		"gcs-state-store-workload-pass": {
+		"memory-state-store-deletion-policy-delete-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
-						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
-							UseWorkloadIdentity: ptr.To(true),
+				r.Spec.StateStore.DeletionPolicy = risingwavev1alpha1.RisingWaveDeletionPolicyDelete
+			},
+			pass: false,
+		},
+		"postgresql-meta-store-deletion-policy-delete-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					DeletionPolicy: risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
 						},
-						Bucket: "gcs-bucket",
-						Root:   "gcs-root",
 					},
 				}
 			},
 			pass: true,
 		},
-		"gcs-state-store-workload-pass-1": {
+		"etcd-meta-store-deletion-policy-delete-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
-						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
-							UseWorkloadIdentity: ptr.To(true),
-							SecretName:          "gcs-creds",
-						},
-						Bucket: "gcs-bucket",
-						Root:   "gcs-root",
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					DeletionPolicy: risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
 					},
 				}
 			},
-			pass: true,
+			pass: false,
 		},
-		"gcs-state-store-secret-pass": {
+		"postgresql-meta-store-tls-verify-full-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
-						Bucket: "gcs-bucket",
-						Root:   "gcs-root",
-						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
-							SecretName: "gcs-creds",
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+						TLS: &risingwavev1alpha1.RisingWaveDBTLS{
+							SSLMode:  risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull,
+							CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{ConfigMapName: "postgresql-ca"},
+							ClientCertificate: &risingwavev1alpha1.RisingWaveDBClientCertificate{
+								SecretName: "postgresql-client-cert",
+							},
 						},
 					},
 				}
 			},
 			pass: true,
 		},
-		"gcs-state-store-none-fail": {
+		"postgresql-meta-store-tls-verify-ca-without-ca-bundle-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
-						Bucket: "gcs-bucket",
-						Root:   "gcs-root",
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+						TLS: &risingwavev1alpha1.RisingWaveDBTLS{
+							SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA,
+						},
 					},
 				}
 			},
 			pass: false,
 		},
-		"gcs-state-store-edge-fail": {
+		"postgresql-meta-store-tls-and-sslmode-option-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
-						Bucket: "gcs-bucket",
-						Root:   "gcs-root",
-						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
-							SecretName: "",
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+						Options: map[string]string{"sslmode": "require"},
+						TLS: &risingwavev1alpha1.RisingWaveDBTLS{
+							SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeRequire,
 						},
 					},
 				}
 			},
 			pass: false,
 		},
-		"aliyun-oss-state-store-pass": {
+		"mysql-meta-store-tls-ca-bundle-from-both-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					AliyunOSS: &risingwavev1alpha1.RisingWaveStateStoreBackendAliyunOSS{
-						Bucket:           "hummock",
-						Root:             "AliyunOSS-root",
-						Region:           "cn-hangzhou",
-						InternalEndpoint: false,
-						RisingWaveAliyunOSSCredentials: risingwavev1alpha1.RisingWaveAliyunOSSCredentials{
-							SecretName: "AliyunOSS-creds",
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					MySQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL{
+						Host:     "mysql",
+						Port:     3306,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "mysql-creds",
+						},
+						TLS: &risingwavev1alpha1.RisingWaveDBTLS{
+							SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeVerifyCA,
+							CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{
+								SecretName:    "mysql-ca",
+								ConfigMapName: "mysql-ca",
+							},
 						},
 					},
 				}
 			},
-			pass: true,
+			pass: false,
 		},
-		"azure-blob-state-store-pass": {
+		"mysql-meta-store-tls-disable-with-client-certificate-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					AzureBlob: &risingwavev1alpha1.RisingWaveStateStoreBackendAzureBlob{
-						Container: "hummock",
-						Root:      "azure-blob-root",
-						Endpoint:  "https://accountName.blob.core.windows.net",
-						RisingWaveAzureBlobCredentials: risingwavev1alpha1.RisingWaveAzureBlobCredentials{
-							SecretName: "azure-blob-creds",
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					MySQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendMySQL{
+						Host:     "mysql",
+						Port:     3306,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "mysql-creds",
+						},
+						TLS: &risingwavev1alpha1.RisingWaveDBTLS{
+							SSLMode: risingwavev1alpha1.RisingWaveDBSSLModeDisable,
+							ClientCertificate: &risingwavev1alpha1.RisingWaveDBClientCertificate{
+								SecretName: "mysql-client-cert",
+							},
 						},
 					},
 				}
 			},
-			pass: true,
+			pass: false,
 		},
-		"hdfs-state-store-pass": {
+		"monitoring-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					HDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{
-						NameNode: "test",
-						Root:     "test",
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					PrometheusRule: &risingwavev1alpha1.RisingWavePrometheusRule{
+						Enabled:     ptr.To(true),
+						Labels:      map[string]string{"release": "prometheus"},
+						AlertLabels: map[string]string{"team": "streaming"},
+					},
+					GrafanaDashboard: &risingwavev1alpha1.RisingWaveGrafanaDashboard{
+						Enabled: ptr.To(true),
 					},
 				}
 			},
 			pass: true,
 		},
-		"webhdfs-state-store-pass": {
+		"monitoring-reserved-labels-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					WebHDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{
-						NameNode: "test",
-						Root:     "test",
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					GrafanaDashboard: &risingwavev1alpha1.RisingWaveGrafanaDashboard{
+						Enabled: ptr.To(true),
+						Labels:  map[string]string{"risingwave/name": "x"},
 					},
 				}
 			},
-			pass: true,
+			pass: false,
 		},
-		"empty-state-store-fail": {
+		"monitoring-invalid-alert-labels-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{}
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					PrometheusRule: &risingwavev1alpha1.RisingWavePrometheusRule{
+						Enabled:     ptr.To(true),
+						AlertLabels: map[string]string{"team/name": "streaming"},
+					},
+				}
 			},
 			pass: false,
 		},
-		"multiple-state-stores-fail-1": {
+		"monitoring-negative-threshold-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					Memory: ptr.To(true),
-					MinIO:  &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					PrometheusRule: &risingwavev1alpha1.RisingWavePrometheusRule{
+						Enabled: ptr.To(true),
+						Thresholds: risingwavev1alpha1.RisingWaveAlertThresholds{
+							PodNotReady: &metav1.Duration{Duration: -time.Minute},
+						},
+					},
 				}
 			},
 			pass: false,
 		},
-		"multiple-state-stores-fail-2": {
+		"monitoring-pod-monitor-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					Memory: ptr.To(true),
-					S3:     &risingwavev1alpha1.RisingWaveStateStoreBackendS3{},
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					MonitorKind:   risingwavev1alpha1.RisingWaveMonitorKindPodMonitor,
+					Interval:      &metav1.Duration{Duration: 30 * time.Second},
+					ScrapeTimeout: &metav1.Duration{Duration: 10 * time.Second},
+					Labels:        map[string]string{"release": "prometheus"},
+					AdditionalEndpoints: []risingwavev1alpha1.RisingWaveMetricsEndpoint{
+						{Port: "exporter", Path: "/stats"},
+					},
 				}
 			},
-			pass: false,
+			pass: true,
 		},
-		"multiple-state-stores-fail-3": {
+		"monitoring-scrape-timeout-greater-than-interval-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
-					S3:    &risingwavev1alpha1.RisingWaveStateStoreBackendS3{},
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					Interval:      &metav1.Duration{Duration: 10 * time.Second},
+					ScrapeTimeout: &metav1.Duration{Duration: 30 * time.Second},
 				}
 			},
 			pass: false,
 		},
-		"multiple-state-stores-fail-4": {
+		"monitoring-endpoint-without-port-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
-					HDFS:  &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{},
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					AdditionalEndpoints: []risingwavev1alpha1.RisingWaveMetricsEndpoint{
+						{Path: "/stats"},
+					},
 				}
 			},
 			pass: false,
 		},
-		"multiple-state-stores-fail-5": {
+		"monitoring-monitor-reserved-labels-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					MinIO:     &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
-					AzureBlob: &risingwavev1alpha1.RisingWaveStateStoreBackendAzureBlob{},
+				r.Spec.Monitoring = &risingwavev1alpha1.RisingWaveMonitoring{
+					Labels: map[string]string{"risingwave/component": "x"},
 				}
 			},
 			pass: false,
 		},
-		"multiple-state-stores-fail-6": {
+		"maintenance-windows-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
-					HDFS:    &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{},
-					WebHDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{},
+				r.Spec.MaintenanceWindows = []risingwavev1alpha1.RisingWaveMaintenanceWindow{
+					{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: "Asia/Shanghai"},
 				}
 			},
-			pass: false,
+			pass: true,
 		},
-		"empty-configuration-fail": {
+		"maintenance-windows-invalid-schedule-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Configuration.ConfigMap = &risingwavev1alpha1.RisingWaveNodeConfigurationConfigMapSource{}
+				r.Spec.MaintenanceWindows = []risingwavev1alpha1.RisingWaveMaintenanceWindow{
+					{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				}
 			},
 			pass: false,
 		},
-		"half-empty-configuration-fail-1": {
+		"maintenance-windows-invalid-time-zone-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Configuration.ConfigMap = &risingwavev1alpha1.RisingWaveNodeConfigurationConfigMapSource{
-					Name: "a",
+				r.Spec.MaintenanceWindows = []risingwavev1alpha1.RisingWaveMaintenanceWindow{
+					{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: "Invalid/Zone"},
 				}
 			},
 			pass: false,
 		},
-		"half-empty-configuration-fail-2": {
+		"standalone-migration-sqlite-on-pvc-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Configuration.ConfigMap = &risingwavev1alpha1.RisingWaveNodeConfigurationConfigMapSource{
-					Key: "a",
-				}
+				*r = *testutils.FakeRisingWaveMigratingFromStandalone()
 			},
-			pass: false,
+			pass: true,
 		},
-		"insufficient-resources-cpu-fail": {
+		"standalone-migration-sqlite-not-on-pvc-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						"cpu": resource.MustParse("250m"),
-					},
-					Requests: corev1.ResourceList{
-						"cpu": resource.MustParse("1000m"),
-					},
-				}
+				*r = *testutils.FakeRisingWaveMigratingFromStandalone()
+				r.Spec.MetaStore.SQLite.Path = "/tmp/meta.db"
 			},
 			pass: false,
 		},
-		"insufficient-resources-memory-fail": {
+		"standalone-migration-sqlite-pvc-deleted-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						"memory": resource.MustParse("100Mi"),
-					},
-					Requests: corev1.ResourceList{
-						"memory": resource.MustParse("1Gi"),
-					},
+				*r = *testutils.FakeRisingWaveMigratingFromStandalone()
+				r.Spec.Components.Standalone.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+					WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
 				}
 			},
 			pass: false,
 		},
-		"pods-meta-labels-pass": {
+		"standalone-migration-sqlite-multiple-meta-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.ObjectMeta = risingwavev1alpha1.PartialObjectMeta{
-					Labels: map[string]string{
-						"key1": "value1",
-						"key2": "value2",
-					},
-				}
+				*r = *testutils.FakeRisingWaveMigratingFromStandalone()
+				r.Spec.Components.Meta.NodeGroups[0].Replicas = 3
 			},
-			pass: true,
+			pass: false,
 		},
-		"pods-meta-labels-fail": {
+		"standalone-migration-memory-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.ObjectMeta = risingwavev1alpha1.PartialObjectMeta{
-					Labels: map[string]string{
-						"key1":            "value1",
-						"risingwave/key2": "value2",
-					},
-				}
+				r.Spec.StandaloneMigration = &risingwavev1alpha1.RisingWaveStandaloneMigration{}
 			},
 			pass: false,
 		},
-		"limit-not-exist-pass-1": {
+		"standalone-migration-completed-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{
-						"cpu":    resource.MustParse("1"),
-						"memory": resource.MustParse("100Mi"),
-					},
+				r.Spec.StandaloneMigration = &risingwavev1alpha1.RisingWaveStandaloneMigration{}
+				r.Status.StandaloneMigration = &risingwavev1alpha1.RisingWaveStandaloneMigrationStatus{
+					Phase: risingwavev1alpha1.RisingWaveStandaloneMigrationPhaseCompleted,
 				}
 			},
 			pass: true,
 		},
-		"limit-not-exist-pass-2": {
+		"standalone-ha-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						"cpu": resource.MustParse("1"),
-					},
-					Requests: corev1.ResourceList{
-						"memory": resource.MustParse("100Mi"),
-					},
-				}
+				*r = *testutils.FakeRisingWaveStandaloneHA()
 			},
 			pass: true,
 		},
-		"limit-zero-fail-1": {
+		"standalone-ha-memory-meta-store-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						"cpu": resource.MustParse("0"),
-					},
-					Requests: corev1.ResourceList{
-						"cpu": resource.MustParse("1"),
-					},
-				}
+				*r = *testutils.FakeRisingWaveStandaloneHA()
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{Memory: ptr.To(true)}
 			},
 			pass: false,
 		},
-		"limit-zero-fail-2": {
+		"standalone-ha-sqlite-meta-store-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						"memory": resource.MustParse("0"),
-					},
-					Requests: corev1.ResourceList{
-						"memory": resource.MustParse("100Mi"),
-					},
+				*r = *testutils.FakeRisingWaveStandaloneHA()
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					SQLite: &risingwavev1alpha1.RisingWaveMetaStoreBackendSQLite{Path: "/risingwave/data/meta.db"},
 				}
 			},
 			pass: false,
 		},
-		"limit-exist-pass": {
+		"standalone-ha-memory-state-store-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						"cpu":    resource.MustParse("1"),
+				*r = *testutils.FakeRisingWaveStandaloneHA()
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{Memory: ptr.To(true)}
+			},
+			pass: false,
+		},
+		"standalone-single-replica-memory-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+				r.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{Replicas: 1}
+			},
+			pass: true,
+		},
+		"managed-stores-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					ManagedMinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendManagedMinIO{},
+				}
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					ManagedPostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendManagedPostgreSQL{},
+				}
+			},
+			pass: true,
+		},
+		"managed-minio-and-minio-state-store-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					ManagedMinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendManagedMinIO{},
+					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+						Endpoint: "minio",
+						Bucket:   "hummock",
+						RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+							SecretName: "minio-creds",
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"managed-minio-state-store-deletion-policy-delete-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					DeletionPolicy: risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
+					ManagedMinIO:   &risingwavev1alpha1.RisingWaveStateStoreBackendManagedMinIO{},
+				}
+			},
+			pass: false,
+		},
+		"managed-postgresql-meta-store-deletion-policy-delete-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					DeletionPolicy:    risingwavev1alpha1.RisingWaveDeletionPolicyDelete,
+					ManagedPostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendManagedPostgreSQL{},
+				}
+			},
+			pass: false,
+		},
+		"s3-state-store-no-credentials-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+						Bucket: "hummock",
+					},
+				}
+			},
+			pass: false,
+		},
+		"s3-compatible-state-store-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+						Bucket:   "hummock",
+						Endpoint: "123",
+						RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
+							SecretName: "s3-creds",
+						},
+					},
+				}
+			},
+			pass: true,
+		},
+		"s3-compatible-state-store-use-service-account-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					S3: &risingwavev1alpha1.RisingWaveStateStoreBackendS3{
+						Bucket:   "hummock",
+						Endpoint: "123",
+						RisingWaveS3Credentials: risingwavev1alpha1.RisingWaveS3Credentials{
+							UseServiceAccount: ptr.To(true),
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"gcs-state-store-workload-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
+						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
+							UseWorkloadIdentity: ptr.To(true),
+						},
+						Bucket: "gcs-bucket",
+						Root:   "gcs-root",
+					},
+				}
+			},
+			pass: true,
+		},
+		"gcs-state-store-workload-pass-1": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
+						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
+							UseWorkloadIdentity: ptr.To(true),
+							SecretName:          "gcs-creds",
+						},
+						Bucket: "gcs-bucket",
+						Root:   "gcs-root",
+					},
+				}
+			},
+			pass: true,
+		},
+		"gcs-state-store-secret-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
+						Bucket: "gcs-bucket",
+						Root:   "gcs-root",
+						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
+							SecretName: "gcs-creds",
+						},
+					},
+				}
+			},
+			pass: true,
+		},
+		"gcs-state-store-none-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
+						Bucket: "gcs-bucket",
+						Root:   "gcs-root",
+					},
+				}
+			},
+			pass: false,
+		},
+		"gcs-state-store-edge-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					GCS: &risingwavev1alpha1.RisingWaveStateStoreBackendGCS{
+						Bucket: "gcs-bucket",
+						Root:   "gcs-root",
+						RisingWaveGCSCredentials: risingwavev1alpha1.RisingWaveGCSCredentials{
+							SecretName: "",
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"aliyun-oss-state-store-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					AliyunOSS: &risingwavev1alpha1.RisingWaveStateStoreBackendAliyunOSS{
+						Bucket:           "hummock",
+						Root:             "AliyunOSS-root",
+						Region:           "cn-hangzhou",
+						InternalEndpoint: false,
+						RisingWaveAliyunOSSCredentials: risingwavev1alpha1.RisingWaveAliyunOSSCredentials{
+							SecretName: "AliyunOSS-creds",
+						},
+					},
+				}
+			},
+			pass: true,
+		},
+		"azure-blob-state-store-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					AzureBlob: &risingwavev1alpha1.RisingWaveStateStoreBackendAzureBlob{
+						Container: "hummock",
+						Root:      "azure-blob-root",
+						Endpoint:  "https://accountName.blob.core.windows.net",
+						RisingWaveAzureBlobCredentials: risingwavev1alpha1.RisingWaveAzureBlobCredentials{
+							SecretName: "azure-blob-creds",
+						},
+					},
+				}
+			},
+			pass: true,
+		},
+		"hdfs-state-store-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					HDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{
+						NameNode: "test",
+						Root:     "test",
+					},
+				}
+			},
+			pass: true,
+		},
+		"webhdfs-state-store-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					WebHDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{
+						NameNode: "test",
+						Root:     "test",
+					},
+				}
+			},
+			pass: true,
+		},
+		"empty-state-store-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{}
+			},
+			pass: false,
+		},
+		"multiple-state-stores-fail-1": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					Memory: ptr.To(true),
+					MinIO:  &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
+				}
+			},
+			pass: false,
+		},
+		"multiple-state-stores-fail-2": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					Memory: ptr.To(true),
+					S3:     &risingwavev1alpha1.RisingWaveStateStoreBackendS3{},
+				}
+			},
+			pass: false,
+		},
+		"multiple-state-stores-fail-3": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
+					S3:    &risingwavev1alpha1.RisingWaveStateStoreBackendS3{},
+				}
+			},
+			pass: false,
+		},
+		"multiple-state-stores-fail-4": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
+					HDFS:  &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{},
+				}
+			},
+			pass: false,
+		},
+		"multiple-state-stores-fail-5": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					MinIO:     &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{},
+					AzureBlob: &risingwavev1alpha1.RisingWaveStateStoreBackendAzureBlob{},
+				}
+			},
+			pass: false,
+		},
+		"multiple-state-stores-fail-6": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					HDFS:    &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{},
+					WebHDFS: &risingwavev1alpha1.RisingWaveStateStoreBackendHDFS{},
+				}
+			},
+			pass: false,
+		},
+		"empty-configuration-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Configuration.ConfigMap = &risingwavev1alpha1.RisingWaveNodeConfigurationConfigMapSource{}
+			},
+			pass: false,
+		},
+		"half-empty-configuration-fail-1": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Configuration.ConfigMap = &risingwavev1alpha1.RisingWaveNodeConfigurationConfigMapSource{
+					Name: "a",
+				}
+			},
+			pass: false,
+		},
+		"half-empty-configuration-fail-2": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Configuration.ConfigMap = &risingwavev1alpha1.RisingWaveNodeConfigurationConfigMapSource{
+					Key: "a",
+				}
+			},
+			pass: false,
+		},
+		"insufficient-resources-cpu-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"cpu": resource.MustParse("250m"),
+					},
+					Requests: corev1.ResourceList{
+						"cpu": resource.MustParse("1000m"),
+					},
+				}
+			},
+			pass: false,
+		},
+		"insufficient-resources-memory-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"memory": resource.MustParse("100Mi"),
+					},
+					Requests: corev1.ResourceList{
+						"memory": resource.MustParse("1Gi"),
+					},
+				}
+			},
+			pass: false,
+		},
+		"pods-meta-labels-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.ObjectMeta = risingwavev1alpha1.PartialObjectMeta{
+					Labels: map[string]string{
+						"key1": "value1",
+						"key2": "value2",
+					},
+				}
+			},
+			pass: true,
+		},
+		"pods-meta-labels-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.ObjectMeta = risingwavev1alpha1.PartialObjectMeta{
+					Labels: map[string]string{
+						"key1":            "value1",
+						"risingwave/key2": "value2",
+					},
+				}
+			},
+			pass: false,
+		},
+		"limit-not-exist-pass-1": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						"cpu":    resource.MustParse("1"),
+						"memory": resource.MustParse("100Mi"),
+					},
+				}
+			},
+			pass: true,
+		},
+		"limit-not-exist-pass-2": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"cpu": resource.MustParse("1"),
+					},
+					Requests: corev1.ResourceList{
+						"memory": resource.MustParse("100Mi"),
+					},
+				}
+			},
+			pass: true,
+		},
+		"limit-zero-fail-1": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"cpu": resource.MustParse("0"),
+					},
+					Requests: corev1.ResourceList{
+						"cpu": resource.MustParse("1"),
+					},
+				}
+			},
+			pass: false,
+		},
+		"limit-zero-fail-2": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"memory": resource.MustParse("0"),
+					},
+					Requests: corev1.ResourceList{
+						"memory": resource.MustParse("100Mi"),
+					},
+				}
+			},
+			pass: false,
+		},
+		"limit-exist-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Template.Spec.Resources = corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						"cpu":    resource.MustParse("1"),
 						"memory": resource.MustParse("1Gi"),
 					},
 					Requests: corev1.ResourceList{
@@ -789,52 +1255,401 @@ func Test_RisingWaveValidatingWebhook_ValidateCreate(t *testing.T) {
 			},
 			pass: false,
 		},
-		"valid-data-dir-1": {
+		"valid-data-dir-1": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore.DataDirectory = "a"
+			},
+			pass: true,
+		},
+		"valid-data-dir-2": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore.DataDirectory = "a/b"
+			},
+			pass: true,
+		},
+		"empty-secret-store-private-key": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{}
+			},
+			pass: true,
+		},
+		"secret-store-private-key-value-set": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{
+					Value: ptr.To("123"),
+				}
+			},
+			pass: true,
+		},
+		"secret-store-private-key-ref-set": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{
+					SecretRef: &risingwavev1alpha1.RisingWaveSecretStorePrivateKeySecretReference{
+						Name: "test",
+						Key:  "test",
+					},
+				}
+			},
+			pass: true,
+		},
+		"secret-store-private-key-both-set": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{
+					Value: ptr.To("123"),
+					SecretRef: &risingwavev1alpha1.RisingWaveSecretStorePrivateKeySecretReference{
+						Name: "test",
+						Key:  "test",
+					},
+				}
+			},
+			pass: false,
+		},
+		"compute-role-serving-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:        "serving",
+					Replicas:    1,
+					ComputeRole: risingwavev1alpha1.RisingWaveComputeRoleServing,
+				})
+			},
+			pass: true,
+		},
+		"compute-role-invalid-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].ComputeRole = "invalid"
+			},
+			pass: false,
+		},
+		"compute-role-all-serving-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].ComputeRole = risingwavev1alpha1.RisingWaveComputeRoleServing
+			},
+			pass: false,
+		},
+		"resource-group-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:          "analytics",
+					Replicas:      1,
+					ResourceGroup: "rg-analytics",
+				})
+			},
+			pass: true,
+		},
+		"resource-group-without-default-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].ResourceGroup = "rg-analytics"
+			},
+			pass: false,
+		},
+		"resource-group-on-compactor-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compactor.NodeGroups[0].ResourceGroup = "rg-analytics"
+			},
+			pass: false,
+		},
+		"resource-group-env-on-compute-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].Template.Spec.Env = []corev1.EnvVar{
+					{Name: envs.RWResourceGroup, Value: "rg-analytics"},
+				}
+			},
+			pass: false,
+		},
+		"resource-group-env-on-standalone-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+				r.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{Replicas: 1}
+				r.Spec.Components.Standalone.Template.Spec.Env = []corev1.EnvVar{
+					{Name: envs.RWResourceGroup, Value: "rg-analytics"},
+				}
+			},
+			pass: false,
+		},
+		"resource-group-in-standalone-mode-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+				r.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{Replicas: 1}
+				r.Spec.Components.Compute.NodeGroups[0].ResourceGroup = "rg-analytics"
+			},
+			pass: true,
+		},
+		"compute-role-on-frontend-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Frontend.NodeGroups[0].ComputeRole = risingwavev1alpha1.RisingWaveComputeRoleBoth
+			},
+			pass: false,
+		},
+		"zones-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+					Values: []string{"us-east-1a", "us-east-1b"},
+				}
+			},
+			pass: true,
+		},
+		"zones-by-topology-key-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Meta.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+					TopologyKey: "example.com/rack",
+				}
+			},
+			pass: true,
+		},
+		"zones-invalid-value-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+					Values: []string{"US_EAST"},
+				}
+			},
+			pass: false,
+		},
+		"zones-invalid-topology-key-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+					TopologyKey: "invalid key",
+				}
+			},
+			pass: false,
+		},
+		"zones-with-node-selector-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+					Values: []string{"us-east-1a"},
+				}
+				r.Spec.Components.Compute.NodeGroups[0].Template.Spec.NodeSelector = map[string]string{
+					"topology.kubernetes.io/zone": "us-east-1a",
+				}
+			},
+			pass: false,
+		},
+		"network-policy-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled: ptr.To(true),
+					FrontendClients: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "analytics"}}},
+					},
+					MonitoringNamespace: "observability",
+				}
+			},
+			pass: true,
+		},
+		"network-policy-empty-peer-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled:         ptr.To(true),
+					FrontendClients: []networkingv1.NetworkPolicyPeer{{}},
+				}
+			},
+			pass: false,
+		},
+		"network-policy-invalid-cidr-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled: ptr.To(true),
+					FrontendClients: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0"}},
+					},
+				}
+			},
+			pass: false,
+		},
+		"network-policy-invalid-namespace-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled:           ptr.To(true),
+					OperatorNamespace: "Invalid_Namespace",
+				}
+			},
+			pass: false,
+		},
+		"network-policy-http-clients-with-external-access-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled: ptr.To(true),
+					HTTPClients: []networkingv1.NetworkPolicyPeer{
+						{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "ingress-nginx"}}},
+					},
+				}
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{},
+					},
+				}
+			},
+			pass: true,
+		},
+		"network-policy-no-http-clients-with-external-access-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled: ptr.To(true),
+				}
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{},
+					},
+				}
+			},
+			pass: false,
+		},
+		"network-policy-disabled-with-external-access-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled: ptr.To(false),
+				}
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{},
+					},
+				}
+			},
+			pass: true,
+		},
+		"network-policy-empty-http-client-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.NetworkPolicy = &risingwavev1alpha1.RisingWaveNetworkPolicy{
+					Enabled:     ptr.To(true),
+					HTTPClients: []networkingv1.NetworkPolicyPeer{{}},
+				}
+			},
+			pass: false,
+		},
+		"external-access-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableWebhookListener = ptr.To(true)
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{
+							Host:          "dashboard.example.com",
+							Path:          "/risingwave",
+							TLSSecretName: "dashboard-tls",
+						},
+					},
+					Webhook: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						HTTPRoute: &risingwavev1alpha1.RisingWaveHTTPRoute{
+							ParentRefs: []risingwavev1alpha1.RisingWaveGatewayReference{
+								{Name: "gateway", Namespace: "gateway-system"},
+							},
+							Hostname: "webhook.example.com",
+						},
+					},
+				}
+			},
+			pass: true,
+		},
+		"external-access-webhook-listener-disabled-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Webhook: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{},
+					},
+				}
+			},
+			pass: false,
+		},
+		"external-access-empty-endpoint-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{},
+				}
+			},
+			pass: false,
+		},
+		"external-access-invalid-host-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{
+							Host: "Invalid_Host",
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"external-access-relative-path-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						Ingress: &risingwavev1alpha1.RisingWaveIngress{
+							Path: "dashboard",
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"external-access-no-gateway-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.ExternalAccess = &risingwavev1alpha1.RisingWaveExternalAccess{
+					Dashboard: &risingwavev1alpha1.RisingWaveExternalEndpoint{
+						HTTPRoute: &risingwavev1alpha1.RisingWaveHTTPRoute{},
+					},
+				}
+			},
+			pass: false,
+		},
+		"zones-conflict-with-node-group-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[0].Zones = &risingwavev1alpha1.RisingWaveNodeGroupZones{
+					Values: []string{"a"},
+				}
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:     "a",
+					Replicas: 1,
+				})
+			},
+			pass: false,
+		},
+		"clone-from-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore.DataDirectory = "a"
+				setCloneFromForTest(r, "source")
 			},
 			pass: true,
 		},
-		"valid-data-dir-2": {
+		"clone-from-itself-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.StateStore.DataDirectory = "a/b"
+				setCloneFromForTest(r, r.Name)
 			},
-			pass: true,
+			pass: false,
 		},
-		"empty-secret-store-private-key": {
+		"clone-from-itself-in-another-namespace-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{}
+				setCloneFromForTest(r, r.Name)
+				r.Spec.CloneFrom.Namespace = "another"
 			},
 			pass: true,
 		},
-		"secret-store-private-key-value-set": {
+		"clone-from-sqlite-meta-store-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{
-					Value: ptr.To("123"),
+				setCloneFromForTest(r, "source")
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					SQLite: &risingwavev1alpha1.RisingWaveMetaStoreBackendSQLite{Path: "/risingwave/data/meta.db"},
 				}
 			},
-			pass: true,
+			pass: false,
 		},
-		"secret-store-private-key-ref-set": {
+		"clone-from-standalone-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{
-					SecretRef: &risingwavev1alpha1.RisingWaveSecretStorePrivateKeySecretReference{
-						Name: "test",
-						Key:  "test",
-					},
-				}
+				setCloneFromForTest(r, "source")
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+			},
+			pass: false,
+		},
+		"clone-from-standalone-with-sinks-allowed-pass": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				setCloneFromForTest(r, "source")
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+				r.Spec.CloneFrom.AllowSinks = true
 			},
 			pass: true,
 		},
-		"secret-store-private-key-both-set": {
+		"clone-from-memory-state-store-fail": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
-				r.Spec.SecretStore.PrivateKey = risingwavev1alpha1.RisingWaveSecretStorePrivateKey{
-					Value: ptr.To("123"),
-					SecretRef: &risingwavev1alpha1.RisingWaveSecretStorePrivateKeySecretReference{
-						Name: "test",
-						Key:  "test",
-					},
-				}
+				setCloneFromForTest(r, "source")
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{Memory: ptr.To(true)}
 			},
 			pass: false,
 		},
@@ -848,7 +1663,7 @@ func Test_RisingWaveValidatingWebhook_ValidateCreate(t *testing.T) {
 			}
 
 			// we let webhook take on open kruise availability specified in Test case.
-			webhook := NewRisingWaveValidatingWebhook(tc.openKruiseAvailable)
+			webhook := NewRisingWaveValidatingWebhook(tc.openKruiseAvailable, nil, "")
 
 			_, err := webhook.ValidateCreate(context.Background(), risingwave)
 			if tc.pass != (err == nil) {
@@ -865,6 +1680,8 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 		pass                bool
 		openKruiseAvailable bool
 		oldObjMutation      func(r *risingwavev1alpha1.RisingWave)
+		username            string
+		migrationMutation   func(m *risingwavev1alpha1.RisingWaveMetaStoreMigration)
 	}{
 		"storages-unchanged-pass": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
@@ -911,6 +1728,198 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 			},
 			pass: false,
 		},
+		"meta-store-switched-by-migration-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+					},
+				}
+			},
+			pass: true,
+		},
+		"meta-store-switched-with-migration-annotation-added-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"meta-store-switched-by-migration-not-paused-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+					},
+				}
+			},
+			pass: false,
+		},
+		"meta-store-switched-by-migration-not-operator-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = *newMetaStoreOfMigrationForTest()
+			},
+			username: "system:serviceaccount:default:someone",
+			pass:     false,
+		},
+		"meta-store-switched-by-migration-not-switching-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = *newMetaStoreOfMigrationForTest()
+			},
+			migrationMutation: func(m *risingwavev1alpha1.RisingWaveMetaStoreMigration) {
+				m.Status.Phase = risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseMigrating
+			},
+			pass: false,
+		},
+		"meta-store-switched-by-migration-of-another-risingwave-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = *newMetaStoreOfMigrationForTest()
+			},
+			migrationMutation: func(m *risingwavev1alpha1.RisingWaveMetaStoreMigration) {
+				m.Spec.RisingWave = "another"
+			},
+			pass: false,
+		},
+		"meta-store-switched-by-migration-not-found-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "another-migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = *newMetaStoreOfMigrationForTest()
+			},
+			pass: false,
+		},
+		"meta-store-switched-by-migration-to-other-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = *newMetaStoreOfMigrationForTest()
+				r.Spec.MetaStore.PostgreSQL.Host = "elsewhere"
+			},
+			pass: false,
+		},
+		"meta-store-rolled-back-by-migration-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = *newMetaStoreOfMigrationForTest()
+				r.Annotations = map[string]string{
+					consts.AnnotationPauseReconcile:     "true",
+					consts.AnnotationMetaStoreMigration: "migration",
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{
+						Endpoint: "etcd",
+					},
+				}
+			},
+			migrationMutation: func(m *risingwavev1alpha1.RisingWaveMetaStoreMigration) {
+				m.Spec.Rollback = true
+				m.Status.Phase = risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseFailed
+				m.Status.Switched = true
+			},
+			pass: true,
+		},
 		"state-store-changed-fail-1": {
 			patch: func(r *risingwavev1alpha1.RisingWave) {
 				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
@@ -927,6 +1936,44 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 			},
 			pass: false,
 		},
+		"state-store-deletion-policy-changed-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore = risingwavev1alpha1.RisingWaveStateStoreBackend{
+					MinIO: &risingwavev1alpha1.RisingWaveStateStoreBackendMinIO{
+						Endpoint: "minio",
+						Bucket:   "hummock",
+						RisingWaveMinIOCredentials: risingwavev1alpha1.RisingWaveMinIOCredentials{
+							SecretName: "minio-creds",
+						},
+					},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.StateStore.DeletionPolicy = risingwavev1alpha1.RisingWaveDeletionPolicyDelete
+			},
+			pass: true,
+		},
+		"meta-store-tls-changed-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore = risingwavev1alpha1.RisingWaveMetaStoreBackend{
+					PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+						Host:     "postgresql",
+						Port:     5432,
+						Database: "risingwave",
+						RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+							SecretName: "postgresql-creds",
+						},
+					},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.MetaStore.PostgreSQL.TLS = &risingwavev1alpha1.RisingWaveDBTLS{
+					SSLMode:  risingwavev1alpha1.RisingWaveDBSSLModeVerifyFull,
+					CABundle: &risingwavev1alpha1.RisingWaveDBCABundle{SecretName: "postgresql-ca"},
+				}
+			},
+			pass: true,
+		},
 		"secret-store-nil-unchanged-success": { // nil secret store
 			patch: func(r *risingwavev1alpha1.RisingWave) {},
 			pass:  true,
@@ -1024,6 +2071,119 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 			},
 			pass: false,
 		},
+		"resource-group-with-jobs-removed-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:          "analytics",
+					Replicas:      1,
+					ResourceGroup: "rg-analytics",
+				})
+				r.Status.ResourceGroups = []risingwavev1alpha1.RisingWaveResourceGroupStatus{
+					{Name: "default", Workers: 1, StreamingJobs: 1},
+					{Name: "rg-analytics", Workers: 1, StreamingJobs: 2},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = r.Spec.Components.Compute.NodeGroups[:1]
+			},
+			pass: false,
+		},
+		"resource-group-with-jobs-moved-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:          "analytics",
+					Replicas:      1,
+					ResourceGroup: "rg-analytics",
+				})
+				r.Status.ResourceGroups = []risingwavev1alpha1.RisingWaveResourceGroupStatus{
+					{Name: "rg-analytics", Workers: 1, StreamingJobs: 2},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups[1].Name = "analytics-v2"
+			},
+			pass: true,
+		},
+		"resource-group-with-jobs-standalone-mode-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:          "analytics",
+					Replicas:      1,
+					ResourceGroup: "rg-analytics",
+				})
+				r.Status.ResourceGroups = []risingwavev1alpha1.RisingWaveResourceGroupStatus{
+					{Name: "default", Workers: 1, StreamingJobs: 1},
+					{Name: "rg-analytics", Workers: 1, StreamingJobs: 2},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+				r.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{Replicas: 1}
+			},
+			pass: false,
+		},
+		"resource-group-with-jobs-from-standalone-mode-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableStandaloneMode = ptr.To(true)
+				r.Spec.Components.Standalone = &risingwavev1alpha1.RisingWaveStandaloneComponent{Replicas: 1}
+				r.Status.ResourceGroups = []risingwavev1alpha1.RisingWaveResourceGroupStatus{
+					{Name: "default", Workers: 1, StreamingJobs: 1},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.EnableStandaloneMode = ptr.To(false)
+				r.Spec.Components.Compute.NodeGroups[0].Name = "analytics"
+			},
+			pass: false,
+		},
+		"resource-group-without-jobs-removed-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = append(r.Spec.Components.Compute.NodeGroups, risingwavev1alpha1.RisingWaveNodeGroup{
+					Name:     "analytics",
+					Replicas: 1,
+				})
+				r.Status.ResourceGroups = []risingwavev1alpha1.RisingWaveResourceGroupStatus{
+					{Name: "analytics", Workers: 1, StreamingJobs: 0},
+				}
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.Components.Compute.NodeGroups = r.Spec.Components.Compute.NodeGroups[:1]
+			},
+			pass: true,
+		},
+		"clone-from-add-fail": {
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.CloneFrom = &risingwavev1alpha1.RisingWaveCloneFrom{Name: "source"}
+			},
+			pass: false,
+		},
+		"clone-from-remove-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				setCloneFromForTest(r, "source")
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.CloneFrom = nil
+			},
+			pass: false,
+		},
+		"clone-from-change-source-fail": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				setCloneFromForTest(r, "source")
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.CloneFrom.Namespace = "another"
+			},
+			pass: false,
+		},
+		"clone-from-allow-sinks-pass": {
+			init: func(r *risingwavev1alpha1.RisingWave) {
+				setCloneFromForTest(r, "source")
+			},
+			patch: func(r *risingwavev1alpha1.RisingWave) {
+				r.Spec.CloneFrom.AllowSinks = true
+			},
+			pass: true,
+		},
 	}
 
 	for name, tc := range testcases {
@@ -1042,7 +2202,33 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 
 			tc.patch(risingwave)
 
-			webhook := NewRisingWaveValidatingWebhook(tc.openKruiseAvailable)
+			migration := &risingwavev1alpha1.RisingWaveMetaStoreMigration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: risingwave.Namespace, Name: "migration"},
+				Spec: risingwavev1alpha1.RisingWaveMetaStoreMigrationSpec{
+					RisingWave: risingwave.Name,
+					MetaStore:  *newMetaStoreOfMigrationForTest(),
+				},
+				Status: risingwavev1alpha1.RisingWaveMetaStoreMigrationStatus{
+					Phase: risingwavev1alpha1.RisingWaveMetaStoreMigrationPhaseSwitching,
+					RollbackPoint: &risingwavev1alpha1.RisingWaveMetaStoreMigrationRollbackPoint{
+						MetaStore: risingwavev1alpha1.RisingWaveMetaStoreBackend{
+							Etcd: &risingwavev1alpha1.RisingWaveMetaStoreBackendEtcd{Endpoint: "etcd"},
+						},
+					},
+				},
+			}
+			if tc.migrationMutation != nil {
+				tc.migrationMutation(migration)
+			}
+			webhook := NewRisingWaveValidatingWebhook(tc.openKruiseAvailable,
+				fake.NewClientBuilder().WithScheme(testutils.Scheme).WithObjects(migration).Build(), operatorUsernameForTest)
+
+			// The updates are made by the operator unless specified.
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: cmp.Or(tc.username, operatorUsernameForTest)},
+				},
+			})
 
 			// test when operator is disabled and openKruise enabled -> disabled, risingwave should be set to default.
 			if name == "disabled-openKruise-when-not-available" {
@@ -1051,7 +2237,7 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 				}
 			}
 
-			_, err := webhook.ValidateUpdate(context.Background(), oldObj, risingwave)
+			_, err := webhook.ValidateUpdate(ctx, oldObj, risingwave)
 			if tc.pass != (err == nil) {
 				t.Fatal(tc.pass, err)
 			}
@@ -1059,6 +2245,21 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate(t *testing.T) {
 	}
 }
 
+const operatorUsernameForTest = "system:serviceaccount:risingwave-operator-system:risingwave-operator-controller-manager"
+
+func newMetaStoreOfMigrationForTest() *risingwavev1alpha1.RisingWaveMetaStoreBackend {
+	return &risingwavev1alpha1.RisingWaveMetaStoreBackend{
+		PostgreSQL: &risingwavev1alpha1.RisingWaveMetaStoreBackendPostgreSQL{
+			Host:     "postgresql",
+			Port:     5432,
+			Database: "risingwave",
+			RisingWaveDBCredentials: risingwavev1alpha1.RisingWaveDBCredentials{
+				SecretName: "postgresql-creds",
+			},
+		},
+	}
+}
+
 func Test_RisingWaveValidatingWebhook_ValidateUpdate_ScaleViews(t *testing.T) {
 	testcases := map[string]struct {
 		origin      *risingwavev1alpha1.RisingWave
@@ -1181,7 +2382,7 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate_ScaleViews(t *testing.T) {
 			newObj := obj.DeepCopy()
 			tc.mutate(newObj)
 
-			_, err := NewRisingWaveValidatingWebhook(false).ValidateUpdate(context.Background(), obj, newObj)
+			_, err := NewRisingWaveValidatingWebhook(false, nil, "").ValidateUpdate(context.Background(), obj, newObj)
 			if tc.pass {
 				require.NoError(t, err, "unexpected error")
 			} else {
@@ -1190,3 +2391,9 @@ func Test_RisingWaveValidatingWebhook_ValidateUpdate_ScaleViews(t *testing.T) {
 		})
 	}
 }
+
+func setCloneFromForTest(r *risingwavev1alpha1.RisingWave, source string) {
+	ha := testutils.FakeRisingWaveStandaloneHA()
+	r.Spec.MetaStore, r.Spec.StateStore = ha.Spec.MetaStore, ha.Spec.StateStore
+	r.Spec.CloneFrom = &risingwavev1alpha1.RisingWaveCloneFrom{Name: source}
+}
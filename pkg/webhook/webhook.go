@@ -24,11 +24,12 @@ import (
 	risingwavev1alpha1 "github.com/risingwavelabs/risingwave-operator/apis/risingwave/v1alpha1"
 )
 
-// SetupWebhooksWithManager set up the webhooks.
-func SetupWebhooksWithManager(mgr ctrl.Manager, openKruiseAvailable bool) error {
+// SetupWebhooksWithManager set up the webhooks. The operatorUsername is the username of the service account of the
+// operator.
+func SetupWebhooksWithManager(mgr ctrl.Manager, openKruiseAvailable bool, operatorUsername string) error {
 	if err := ctrl.NewWebhookManagedBy(mgr, &risingwavev1alpha1.RisingWave{}).
 		WithDefaulter(NewRisingWaveMutatingWebhook()).
-		WithValidator(NewRisingWaveValidatingWebhook(openKruiseAvailable)).
+		WithValidator(NewRisingWaveValidatingWebhook(openKruiseAvailable, mgr.GetAPIReader(), operatorUsername)).
 		Complete(); err != nil {
 		return fmt.Errorf("unable to setup webhooks for risingwave: %w", err)
 	}